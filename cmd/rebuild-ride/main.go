@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"ridehail/internal/ride/adapter/out/repo"
+	"ridehail/internal/ride/application/usecase"
+	"ridehail/internal/shared/config"
+	db_conn "ridehail/internal/shared/db"
+	"ridehail/internal/shared/logger"
+)
+
+func main() {
+	rideID := flag.String("ride_id", "", "ride UUID to rebuild from ride_events")
+	dryRun := flag.Bool("dry-run", false, "print projected ride without writing to rides")
+	flag.Parse()
+
+	if *rideID == "" {
+		fmt.Fprintln(os.Stderr, "Error: -ride_id flag is required")
+		fmt.Fprintln(os.Stderr, "Usage: go run cmd/rebuild-ride/main.go -ride_id=<RIDE_ID> [-dry-run]")
+		os.Exit(1)
+	}
+
+	// Загружаем конфигурацию (тот же способ, что и в сервисе)
+	cfg := config.Load()
+	log := logger.NewLogger("rebuild-ride")
+	ctx := context.Background()
+
+	pool, err := db_conn.NewPool(ctx, cfg.Database, log)
+	if err != nil {
+		fmt.Printf("❌ Database connection FAILED: %v\n", err)
+		os.Exit(1)
+	}
+	defer db_conn.Close(pool, log)
+
+	projector := usecase.NewRideProjector(
+		repo.NewPgTxManager(pool, log),
+		repo.NewRidePgRepository(pool, log),
+		repo.NewRideEventPgStore(pool, log),
+		log,
+	)
+
+	if *dryRun {
+		ride, err := projector.Project(ctx, *rideID)
+		if err != nil {
+			fmt.Printf("❌ Projection FAILED: %v\n", err)
+			os.Exit(1)
+		}
+		printRide("🔍 Projected ride (dry run)", ride)
+		return
+	}
+
+	ride, err := projector.Rebuild(ctx, *rideID)
+	if err != nil {
+		fmt.Printf("❌ Rebuild FAILED: %v\n", err)
+		os.Exit(1)
+	}
+	printRide("✅ Ride rebuilt from events", ride)
+}
+
+func printRide(title string, ride any) {
+	out, _ := json.MarshalIndent(ride, "", "  ")
+	fmt.Printf("%s:\n%s\n", title, out)
+}
@@ -0,0 +1,112 @@
+package in_amqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DriverStatusConsumer передает дашборду смены статусов водителей (driver.status.*)
+type DriverStatusConsumer struct {
+	mqConn    *mq.RabbitMQ
+	dashboard in.LiveDashboardUseCase
+	log       *logger.Logger
+}
+
+// NewDriverStatusConsumer создает новый consumer
+func NewDriverStatusConsumer(mqConn *mq.RabbitMQ, dashboard in.LiveDashboardUseCase, log *logger.Logger) *DriverStatusConsumer {
+	return &DriverStatusConsumer{
+		mqConn:    mqConn,
+		dashboard: dashboard,
+		log:       log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *DriverStatusConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	// Временная очередь реплики, как у ride events: старые статусы дашборду не нужны,
+	// а общая очередь делила бы их между экземплярами
+	queue, err := ch.QueueDeclare(
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := ch.QueueBind(queue.Name, contract.RoutingPatternDriverStatus, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queue.Name,
+		"admin-service-driver-status", // consumer tag
+		false,                         // auto-ack
+		true,                          // exclusive
+		false,                         // no-local
+		false,                         // no-wait
+		nil,                           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "driver_status_consumer_started",
+		Message: fmt.Sprintf("listening on driver_topic (queue: %s, pattern: driver.status.*)", queue.Name),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{Action: "driver_status_consumer_stopping", Message: "context cancelled"})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{Action: "driver_status_consumer_channel_closed", Message: "message channel closed"})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handle(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "driver_status_processing_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				_ = msg.Nack(false, false)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handle обновляет статус водителя на карте дашборда
+func (c *DriverStatusConsumer) handle(ctx context.Context, msg amqp.Delivery) error {
+	var status contract.DriverStatusChanged
+	if err := contract.Decode(msg.Body, &status); err != nil {
+		return fmt.Errorf("failed to parse driver status: %w", err)
+	}
+
+	c.dashboard.RecordDriverStatus(ctx, in.DriverStatusInput{
+		DriverID: status.DriverID,
+		Status:   status.Status,
+		RideID:   status.RideID,
+	})
+	return nil
+}
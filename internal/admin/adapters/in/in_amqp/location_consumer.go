@@ -0,0 +1,121 @@
+package in_amqp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// LocationConsumer передает дашборду локации водителей из location_fanout
+type LocationConsumer struct {
+	mqConn    *mq.RabbitMQ
+	dashboard in.LiveDashboardUseCase
+	log       *logger.Logger
+}
+
+// NewLocationConsumer создает новый consumer для location updates
+func NewLocationConsumer(mqConn *mq.RabbitMQ, dashboard in.LiveDashboardUseCase, log *logger.Logger) *LocationConsumer {
+	return &LocationConsumer{
+		mqConn:    mqConn,
+		dashboard: dashboard,
+		log:       log,
+	}
+}
+
+// Start запускает consumer для location_fanout exchange
+func (c *LocationConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	// Временная очередь реплики: fanout копирует каждую локацию всем сервисам,
+	// а экземпляры Admin Service не должны делить поток между собой
+	queue, err := ch.QueueDeclare(
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := ch.QueueBind(queue.Name, "", contract.ExchangeLocation, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queue.Name,
+		"admin-service-locations", // consumer tag
+		false,                     // auto-ack
+		true,                      // exclusive
+		false,                     // no-local
+		false,                     // no-wait
+		nil,                       // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "location_consumer_started",
+		Message: fmt.Sprintf("listening on location_fanout (queue: %s)", queue.Name),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{Action: "location_consumer_stopping", Message: "context cancelled"})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{Action: "location_consumer_channel_closed", Message: "message channel closed"})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handle(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "handle_location_update_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				// Не возвращаем в очередь: через несколько секунд придет более свежая локация
+				_ = msg.Nack(false, false)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handle запоминает локацию до ближайшей рассылки дашборду
+func (c *LocationConsumer) handle(ctx context.Context, msg amqp.Delivery) error {
+	var update contract.LocationUpdate
+	if err := contract.Decode(msg.Body, &update); err != nil {
+		return fmt.Errorf("failed to parse location update: %w", err)
+	}
+
+	recordedAt, _ := time.Parse(time.RFC3339, update.Timestamp)
+	c.dashboard.RecordDriverLocation(ctx, in.DriverLocationInput{
+		DriverID: update.DriverID,
+		RideID:   update.RideID,
+		Location: in.GeoPoint{
+			Lat: update.Location.Lat,
+			Lng: update.Location.Lng,
+		},
+		SpeedKmh:       update.SpeedKmh,
+		HeadingDegrees: update.HeadingDegrees,
+		RecordedAt:     recordedAt,
+	})
+	return nil
+}
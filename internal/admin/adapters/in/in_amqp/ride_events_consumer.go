@@ -0,0 +1,144 @@
+package in_amqp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RideEventsConsumer передает дашборду все события поездок из ride_topic (ride.*).
+// Очередь временная: после перезапуска дашборду нужны свежие события, а не накопленные.
+type RideEventsConsumer struct {
+	mqConn    *mq.RabbitMQ
+	dashboard in.LiveDashboardUseCase
+	log       *logger.Logger
+}
+
+// NewRideEventsConsumer создает новый consumer
+func NewRideEventsConsumer(mqConn *mq.RabbitMQ, dashboard in.LiveDashboardUseCase, log *logger.Logger) *RideEventsConsumer {
+	return &RideEventsConsumer{
+		mqConn:    mqConn,
+		dashboard: dashboard,
+		log:       log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *RideEventsConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	// Очередь своя у каждой реплики: дашборд живет в памяти экземпляра, и каждому
+	// нужны все события. Имя генерирует RabbitMQ, очередь удаляется с соединением.
+	queue, err := ch.QueueDeclare(
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := ch.QueueBind(queue.Name, contract.RoutingPatternRideAll, contract.ExchangeRide, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queue.Name,
+		"admin-service-ride-events", // consumer tag
+		false,                       // auto-ack
+		true,                        // exclusive
+		false,                       // no-local
+		false,                       // no-wait
+		nil,                         // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "ride_events_consumer_started",
+		Message: fmt.Sprintf("listening on ride_topic (queue: %s, pattern: ride.*)", queue.Name),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{Action: "ride_events_consumer_stopping", Message: "context cancelled"})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{Action: "ride_events_consumer_channel_closed", Message: "message channel closed"})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handle(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "ride_event_processing_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				_ = msg.Nack(false, false)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handle разбирает сообщение по routing key: ride.requested несет RideRequested,
+// остальные ключи — RideStatusChanged
+func (c *RideEventsConsumer) handle(ctx context.Context, msg amqp.Delivery) error {
+	if msg.RoutingKey == contract.RoutingKeyRideRequested {
+		var requested contract.RideRequested
+		if err := contract.Decode(msg.Body, &requested); err != nil {
+			return fmt.Errorf("failed to parse ride requested: %w", err)
+		}
+		requestedAt, _ := time.Parse(time.RFC3339, requested.RequestedAt)
+		c.dashboard.RecordRideEvent(ctx, in.RideEventInput{
+			RideID:      requested.RideID,
+			EventType:   constants.EventRideRequested,
+			Status:      constants.RideStatusRequested,
+			PassengerID: requested.PassengerID,
+			VehicleType: requested.RideType,
+			Pickup: &in.GeoPoint{
+				Lat: requested.PickupLocation.Lat,
+				Lng: requested.PickupLocation.Lng,
+			},
+			OccurredAt: requestedAt,
+		})
+		return nil
+	}
+
+	var event contract.RideStatusChanged
+	if err := contract.Decode(msg.Body, &event); err != nil {
+		return fmt.Errorf("failed to parse ride status changed: %w", err)
+	}
+
+	input := in.RideEventInput{
+		RideID:      event.RideID,
+		EventType:   event.EventType,
+		Status:      event.Status,
+		PassengerID: event.PassengerID,
+		VehicleType: event.VehicleType,
+		OccurredAt:  msg.Timestamp,
+	}
+	if event.DriverID != nil {
+		input.DriverID = *event.DriverID
+	}
+	c.dashboard.RecordRideEvent(ctx, input)
+	return nil
+}
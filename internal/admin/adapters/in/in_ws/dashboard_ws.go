@@ -0,0 +1,401 @@
+package in_ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/shared/auth"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/user"
+	"ridehail/internal/shared/ws"
+
+	"github.com/google/uuid"
+)
+
+// BoundingBox — прямоугольная область карты (через антимеридиан не переходит)
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// Contains проверяет, попадает ли точка в область (границы включительно)
+func (b BoundingBox) Contains(p in.GeoPoint) bool {
+	return p.Lat >= b.MinLat && p.Lat <= b.MaxLat && p.Lng >= b.MinLng && p.Lng <= b.MaxLng
+}
+
+// validate проверяет координаты и порядок границ
+func (b BoundingBox) validate() error {
+	if b.MinLat < -90 || b.MaxLat > 90 || b.MinLng < -180 || b.MaxLng > 180 {
+		return errors.New("bbox coordinates out of range")
+	}
+	if b.MinLat > b.MaxLat || b.MinLng > b.MaxLng {
+		return errors.New("bbox min must not exceed max")
+	}
+	return nil
+}
+
+// Subscription — что клиент хочет получать. Пустая подписка — все события.
+// Метрики уходят всем клиентам независимо от подписки.
+type Subscription struct {
+	RideID string       `json:"ride_id,omitempty"`
+	BBox   *BoundingBox `json:"bbox,omitempty"`
+}
+
+// matchesRide решает, нужно ли клиенту событие поездки
+func (s Subscription) matchesRide(rideID string, location *in.GeoPoint) bool {
+	switch {
+	case s.RideID != "":
+		return s.RideID == rideID
+	case s.BBox != nil:
+		return location != nil && s.BBox.Contains(*location)
+	default:
+		return true
+	}
+}
+
+// matchesDriver решает, нужна ли клиенту локация водителя
+func (s Subscription) matchesDriver(d in.DriverLocationDTO) bool {
+	switch {
+	case s.RideID != "":
+		return s.RideID == d.RideID
+	case s.BBox != nil:
+		return s.BBox.Contains(d.Location)
+	default:
+		return true
+	}
+}
+
+// DashboardWSHandler обслуживает живой дашборд администраторов (GET /ws).
+// Он же реализует out.DashboardPublisher: фильтрует рассылку по подпискам клиентов.
+//
+// СООБЩЕНИЯ СЕРВЕРА: metrics_snapshot, metrics_delta, ride_status, driver_locations,
+// subscribed, error, pong.
+// СООБЩЕНИЯ КЛИЕНТА: subscribe {ride_id | bbox}, unsubscribe, ping.
+type DashboardWSHandler struct {
+	hub       *ws.Hub
+	dashboard in.LiveDashboardUseCase
+	log       *logger.Logger
+
+	mu   sync.RWMutex
+	subs map[string]Subscription // client.ID → подписка
+}
+
+// NewDashboardWSHandler создает handler дашборда; подключиться может только ADMIN
+func NewDashboardWSHandler(jwtSvc *auth.JWTService, userRepo user.Repository, log *logger.Logger) *DashboardWSHandler {
+	authFunc := func(token string) (userID, role string, err error) {
+		claims, err := jwtSvc.ValidateToken(token)
+		if err != nil {
+			return "", "", err
+		}
+
+		// Роль и статус — из БД, как в AdminAuthMiddleware
+		u, err := userRepo.FindByID(context.Background(), claims.UserID)
+		if err != nil {
+			return "", "", err
+		}
+		if !u.IsActive() {
+			return "", "", fmt.Errorf("user is %s", strings.ToLower(u.Status))
+		}
+		if u.Role != constants.RoleAdmin {
+			return "", "", fmt.Errorf("invalid role: %s (expected ADMIN)", u.Role)
+		}
+
+		return u.ID, u.Role, nil
+	}
+
+	hub := ws.NewHub(authFunc, log)
+
+	handler := &DashboardWSHandler{
+		hub:  hub,
+		log:  log,
+		subs: make(map[string]Subscription),
+	}
+
+	hub.SetMessageHandler(handler.handleMessage)
+	hub.SetConnectionHandlers(handler.onConnect, handler.onDisconnect)
+
+	return handler
+}
+
+// GetHub возвращает WebSocket hub
+func (h *DashboardWSHandler) GetHub() *ws.Hub {
+	return h.hub
+}
+
+// SetLiveDashboardUseCase подключает use case дашборда.
+// Use case рассылает обновления через этот handler, поэтому зависимость
+// передается после конструирования.
+func (h *DashboardWSHandler) SetLiveDashboardUseCase(uc in.LiveDashboardUseCase) {
+	h.dashboard = uc
+}
+
+// ServeWS обрабатывает WebSocket соединение администратора
+func (h *DashboardWSHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
+	h.hub.ServeWS(w, r)
+}
+
+// onConnect подписывает клиента на все события и отправляет текущее состояние
+func (h *DashboardWSHandler) onConnect(client *ws.Client) {
+	h.mu.Lock()
+	h.subs[client.ID] = Subscription{}
+	h.mu.Unlock()
+
+	// Снимок метрик может потребовать запроса в БД — не держим цикл хаба
+	go h.sendInitialState(client.ID)
+}
+
+// onDisconnect забывает подписку клиента
+func (h *DashboardWSHandler) onDisconnect(client *ws.Client) {
+	h.mu.Lock()
+	delete(h.subs, client.ID)
+	h.mu.Unlock()
+}
+
+// sendInitialState отправляет новому клиенту метрики целиком и карту водителей
+func (h *DashboardWSHandler) sendInitialState(clientID string) {
+	if h.dashboard == nil {
+		return
+	}
+
+	metrics, err := h.dashboard.MetricsSnapshot(context.Background())
+	if err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "dashboard_metrics_snapshot_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	} else {
+		h.sendToClient(clientID, "metrics_snapshot", map[string]interface{}{
+			"metrics":   metrics,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
+	h.sendDriverSnapshot(clientID, Subscription{})
+}
+
+// sendDriverSnapshot отправляет клиенту всех водителей, подходящих под подписку
+func (h *DashboardWSHandler) sendDriverSnapshot(clientID string, sub Subscription) {
+	if h.dashboard == nil {
+		return
+	}
+
+	drivers := make([]in.DriverLocationDTO, 0)
+	for _, d := range h.dashboard.DriverLocations() {
+		if sub.matchesDriver(d) {
+			drivers = append(drivers, d)
+		}
+	}
+	h.sendToClient(clientID, "driver_locations", map[string]interface{}{
+		"drivers":   drivers,
+		"removed":   []string{},
+		"snapshot":  true,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// handleMessage обрабатывает входящие сообщения администратора
+func (h *DashboardWSHandler) handleMessage(client *ws.Client, msgType string, data json.RawMessage) error {
+	h.log.Debug(logger.Entry{
+		Action:  "dashboard_ws_message",
+		Message: msgType,
+		Additional: map[string]any{
+			"admin_id":  client.UserID,
+			"client_id": client.ID,
+		},
+	})
+
+	switch msgType {
+	case "ping":
+		h.sendToClient(client.ID, "pong", map[string]string{"status": "ok"})
+
+	case "subscribe":
+		var sub Subscription
+		if err := json.Unmarshal(data, &sub); err != nil {
+			h.sendToClient(client.ID, "error", map[string]string{"message": "invalid subscribe format"})
+			return fmt.Errorf("invalid subscribe format: %w", err)
+		}
+		if err := validateSubscription(sub); err != nil {
+			h.sendToClient(client.ID, "error", map[string]string{"message": err.Error()})
+			return nil
+		}
+		h.subscribe(client, sub)
+
+	case "unsubscribe":
+		h.subscribe(client, Subscription{})
+
+	default:
+		h.log.Warn(logger.Entry{
+			Action:  "dashboard_ws_unknown_message_type",
+			Message: msgType,
+			Additional: map[string]any{
+				"admin_id": client.UserID,
+			},
+		})
+	}
+
+	return nil
+}
+
+// validateSubscription допускает либо поездку, либо область, но не обе сразу
+func validateSubscription(sub Subscription) error {
+	if sub.RideID != "" && sub.BBox != nil {
+		return errors.New("subscribe to either ride_id or bbox, not both")
+	}
+	if sub.RideID != "" {
+		if _, err := uuid.Parse(sub.RideID); err != nil {
+			return errors.New("invalid ride_id")
+		}
+	}
+	if sub.BBox != nil {
+		return sub.BBox.validate()
+	}
+	return nil
+}
+
+// subscribe меняет подписку клиента, подтверждает ее и досылает подходящих водителей
+func (h *DashboardWSHandler) subscribe(client *ws.Client, sub Subscription) {
+	h.mu.Lock()
+	if _, ok := h.subs[client.ID]; !ok {
+		h.mu.Unlock()
+		return
+	}
+	h.subs[client.ID] = sub
+	h.mu.Unlock()
+
+	h.log.Info(logger.Entry{
+		Action:  "dashboard_subscription_changed",
+		Message: client.ID,
+		Additional: map[string]any{
+			"admin_id": client.UserID,
+			"ride_id":  sub.RideID,
+			"bbox":     sub.BBox,
+		},
+	})
+
+	h.sendToClient(client.ID, "subscribed", sub)
+	h.sendDriverSnapshot(client.ID, sub)
+}
+
+// PublishMetricsDelta рассылает изменившиеся метрики всем клиентам
+func (h *DashboardWSHandler) PublishMetricsDelta(changes map[string]interface{}, at time.Time) {
+	h.publish("metrics_delta", map[string]interface{}{
+		"metrics":   changes,
+		"timestamp": at.Format(time.RFC3339),
+	}, nil)
+}
+
+// PublishRideStatus рассылает статус поездки клиентам, подписанным на нее или ее область
+func (h *DashboardWSHandler) PublishRideStatus(update in.RideStatusUpdateDTO) {
+	h.publish("ride_status", update, func(sub Subscription) interface{} {
+		if !sub.matchesRide(update.RideID, update.Location) {
+			return nil
+		}
+		return update
+	})
+}
+
+// PublishDriverLocations рассылает каждому клиенту водителей из его подписки.
+// Ушедших с карты получают все, кроме подписанных на одну поездку: список короткий,
+// а проверить, был ли водитель в области, уже не по чему.
+func (h *DashboardWSHandler) PublishDriverLocations(drivers []in.DriverLocationDTO, removed []string, at time.Time) {
+	timestamp := at.Format(time.RFC3339)
+	h.publish("driver_locations", nil, func(sub Subscription) interface{} {
+		matched := make([]in.DriverLocationDTO, 0, len(drivers))
+		for _, d := range drivers {
+			if sub.matchesDriver(d) {
+				matched = append(matched, d)
+			}
+		}
+		gone := removed
+		if sub.RideID != "" {
+			gone = []string{}
+		}
+		if len(matched) == 0 && len(gone) == 0 {
+			return nil
+		}
+		return map[string]interface{}{
+			"drivers":   matched,
+			"removed":   gone,
+			"timestamp": timestamp,
+		}
+	})
+}
+
+// publish отправляет сообщение подключенным клиентам. filter получает подписку
+// клиента и возвращает данные для него (nil — не отправлять).
+// Без filter всем уходит data, сериализованная один раз.
+func (h *DashboardWSHandler) publish(msgType string, data interface{}, filter func(Subscription) interface{}) {
+	h.mu.RLock()
+	subs := make(map[string]Subscription, len(h.subs))
+	for id, sub := range h.subs {
+		subs[id] = sub
+	}
+	h.mu.RUnlock()
+
+	var shared []byte
+	for clientID, sub := range subs {
+		if filter == nil {
+			if shared == nil {
+				var err error
+				if shared, err = marshalTyped(msgType, data); err != nil {
+					h.logMarshalError(msgType, err)
+					return
+				}
+			}
+			h.hub.SendToClient(clientID, shared)
+			continue
+		}
+
+		payload := filter(sub)
+		if payload == nil {
+			continue
+		}
+		message, err := marshalTyped(msgType, payload)
+		if err != nil {
+			h.logMarshalError(msgType, err)
+			continue
+		}
+		h.hub.SendToClient(clientID, message)
+	}
+}
+
+// sendToClient отправляет типизированное сообщение одному соединению
+func (h *DashboardWSHandler) sendToClient(clientID, msgType string, data interface{}) {
+	message, err := marshalTyped(msgType, data)
+	if err != nil {
+		h.logMarshalError(msgType, err)
+		return
+	}
+	h.hub.SendToClient(clientID, message)
+}
+
+// logMarshalError логирует ошибку сериализации исходящего сообщения
+func (h *DashboardWSHandler) logMarshalError(msgType string, err error) {
+	h.log.Error(logger.Entry{
+		Action:  "dashboard_ws_marshal_failed",
+		Message: err.Error(),
+		Error:   &logger.ErrObj{Msg: err.Error()},
+		Additional: map[string]any{
+			"msg_type": msgType,
+		},
+	})
+}
+
+// marshalTyped сериализует сообщение в формате {"type": ..., "data": ...}, как SendTypedMessage
+func marshalTyped(msgType string, data interface{}) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type": msgType,
+		"data": data,
+	})
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"net/http"
+
+	"ridehail/internal/admin/application/ports/in"
+)
+
+// handleGetAnalytics обрабатывает GET /admin/analytics?from=&to=&granularity=&group_by=
+// from/to — RFC3339; остальное проверяет use case
+func (h *HTTPHandler) handleGetAnalytics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := in.GetAnalyticsInput{
+		Granularity: query.Get("granularity"),
+		GroupBy:     query.Get("group_by"),
+	}
+
+	var err error
+	if input.From, err = parseTimeParam(query.Get("from")); err != nil {
+		h.respondError(w, http.StatusBadRequest, "from must be RFC3339")
+		return
+	}
+	if input.To, err = parseTimeParam(query.Get("to")); err != nil {
+		h.respondError(w, http.StatusBadRequest, "to must be RFC3339")
+		return
+	}
+
+	output, err := h.getAnalyticsUC.Execute(r.Context(), input)
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+
+	"github.com/google/uuid"
+)
+
+// handleListAudit обрабатывает GET /admin/audit?actor=&target=&from=&to=&limit=&offset=
+// from/to — RFC3339, интервал [from, to)
+func (h *HTTPHandler) handleListAudit(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := in.ListAuditInput{
+		ActorID:  query.Get("actor"),
+		TargetID: query.Get("target"),
+		Limit:    50,
+	}
+
+	if input.ActorID != "" {
+		if _, err := uuid.Parse(input.ActorID); err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid actor id")
+			return
+		}
+	}
+
+	var err error
+	if input.From, err = parseTimeParam(query.Get("from")); err != nil {
+		h.respondError(w, http.StatusBadRequest, "from must be RFC3339")
+		return
+	}
+	if input.To, err = parseTimeParam(query.Get("to")); err != nil {
+		h.respondError(w, http.StatusBadRequest, "to must be RFC3339")
+		return
+	}
+	if input.From != nil && input.To != nil && !input.From.Before(*input.To) {
+		h.respondError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			input.Limit = l
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			input.Offset = o
+		}
+	}
+
+	output, err := h.listAuditUC.Execute(r.Context(), input)
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// parseTimeParam разбирает необязательный RFC3339 параметр; пустая строка — nil
+func parseTimeParam(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
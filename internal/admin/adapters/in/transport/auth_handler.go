@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/shared/logger"
+)
+
+// LoginHTTPRequest — HTTP DTO для входа
+type LoginHTTPRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RefreshTokenHTTPRequest — HTTP DTO для /auth/refresh и /auth/logout
+type RefreshTokenHTTPRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// handleLogin обрабатывает POST /auth/login
+func (h *HTTPHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req LoginHTTPRequest
+	if !h.decodeJSON(w, r, &req, "parse_login_request_failed") {
+		return
+	}
+
+	if req.Email == "" {
+		h.respondError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+	if req.Password == "" {
+		h.respondError(w, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	output, err := h.authUC.Login(r.Context(), in.LoginInput{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleRefresh обрабатывает POST /auth/refresh
+func (h *HTTPHandler) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenHTTPRequest
+	if !h.decodeJSON(w, r, &req, "parse_refresh_request_failed") {
+		return
+	}
+
+	if req.RefreshToken == "" {
+		h.respondError(w, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+
+	output, err := h.authUC.Refresh(r.Context(), in.RefreshInput{RefreshToken: req.RefreshToken})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleLogout обрабатывает POST /auth/logout
+func (h *HTTPHandler) handleLogout(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenHTTPRequest
+	if !h.decodeJSON(w, r, &req, "parse_logout_request_failed") {
+		return
+	}
+
+	if req.RefreshToken == "" {
+		h.respondError(w, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+
+	if err := h.authUC.Logout(r.Context(), in.LogoutInput{RefreshToken: req.RefreshToken}); err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeJSON читает тело запроса в dst; при ошибке сам отвечает 400 и возвращает false
+func (h *HTTPHandler) decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}, action string) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			h.respondError(w, http.StatusBadRequest, "empty request body")
+			return false
+		}
+		h.log.Warn(logger.Entry{
+			Action:  action,
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		h.respondError(w, http.StatusBadRequest, "invalid request format")
+		return false
+	}
+
+	return true
+}
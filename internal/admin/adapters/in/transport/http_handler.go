@@ -20,6 +20,11 @@ type HTTPHandler struct {
 	listUsersUC      in.ListUsersUseCase
 	getOverviewUC    in.GetOverviewUseCase
 	getActiveRidesUC in.GetActiveRidesUseCase
+	authUC           in.AuthUseCase
+	verificationUC   in.DriverVerificationUseCase
+	manageUserUC     in.ManageUserUseCase
+	listAuditUC      in.ListAuditUseCase
+	getAnalyticsUC   in.GetAnalyticsUseCase
 	log              *logger.Logger
 }
 
@@ -29,6 +34,11 @@ func NewHTTPHandler(
 	listUsersUC in.ListUsersUseCase,
 	getOverviewUC in.GetOverviewUseCase,
 	getActiveRidesUC in.GetActiveRidesUseCase,
+	authUC in.AuthUseCase,
+	verificationUC in.DriverVerificationUseCase,
+	manageUserUC in.ManageUserUseCase,
+	listAuditUC in.ListAuditUseCase,
+	getAnalyticsUC in.GetAnalyticsUseCase,
 	log *logger.Logger,
 ) *HTTPHandler {
 	return &HTTPHandler{
@@ -36,6 +46,11 @@ func NewHTTPHandler(
 		listUsersUC:      listUsersUC,
 		getOverviewUC:    getOverviewUC,
 		getActiveRidesUC: getActiveRidesUC,
+		authUC:           authUC,
+		verificationUC:   verificationUC,
+		manageUserUC:     manageUserUC,
+		listAuditUC:      listAuditUC,
+		getAnalyticsUC:   getAnalyticsUC,
 		log:              log,
 	}
 }
@@ -45,11 +60,24 @@ func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux, adminAuthMiddleware fun
 	// liveness probe (без аутентификации)
 	mux.HandleFunc("GET /health", h.handleHealth)
 
+	// auth endpoints (публичные: токен выдается здесь)
+	mux.HandleFunc("POST /auth/login", h.handleLogin)
+	mux.HandleFunc("POST /auth/refresh", h.handleRefresh)
+	mux.HandleFunc("POST /auth/logout", h.handleLogout)
+
 	// admin endpoints (требуют ADMIN роль)
 	mux.HandleFunc("POST /admin/users", adminAuthMiddleware(h.handleCreateUser))
 	mux.HandleFunc("GET /admin/users", adminAuthMiddleware(h.handleListUsers))
+	mux.HandleFunc("GET /admin/users/{id}", adminAuthMiddleware(h.handleGetUser))
+	mux.HandleFunc("PATCH /admin/users/{id}", adminAuthMiddleware(h.handleUpdateUser))
+	mux.HandleFunc("DELETE /admin/users/{id}", adminAuthMiddleware(h.handleDeleteUser))
 	mux.HandleFunc("GET /admin/overview", adminAuthMiddleware(h.handleGetOverview))
+	mux.HandleFunc("GET /admin/analytics", adminAuthMiddleware(h.handleGetAnalytics))
 	mux.HandleFunc("GET /admin/rides/active", adminAuthMiddleware(h.handleGetActiveRides))
+	mux.HandleFunc("GET /admin/drivers/pending", adminAuthMiddleware(h.handleListPendingDrivers))
+	mux.HandleFunc("POST /admin/drivers/{id}/approve", adminAuthMiddleware(h.handleApproveDriver))
+	mux.HandleFunc("POST /admin/drivers/{id}/reject", adminAuthMiddleware(h.handleRejectDriver))
+	mux.HandleFunc("GET /admin/audit", adminAuthMiddleware(h.handleListAudit))
 }
 
 // handleHealth обрабатывает health check
@@ -169,6 +197,14 @@ func (h *HTTPHandler) handleListUsers(w http.ResponseWriter, r *http.Request) {
 // handleUseCaseError обрабатывает ошибки use case
 func (h *HTTPHandler) handleUseCaseError(w http.ResponseWriter, err error) {
 	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		h.respondError(w, http.StatusNotFound, "user not found")
+	case errors.Is(err, domain.ErrNothingToUpdate):
+		h.respondError(w, http.StatusBadRequest, "nothing to update")
+	case errors.Is(err, domain.ErrCannotModifySelf):
+		h.respondError(w, http.StatusForbidden, "cannot change status or role of own account or delete it")
+	case errors.Is(err, domain.ErrUserHasActiveRides):
+		h.respondError(w, http.StatusConflict, "user has active rides")
 	case errors.Is(err, domain.ErrUserAlreadyExists):
 		h.respondError(w, http.StatusConflict, "user already exists")
 	case errors.Is(err, domain.ErrInvalidEmail):
@@ -181,6 +217,27 @@ func (h *HTTPHandler) handleUseCaseError(w http.ResponseWriter, err error) {
 		h.respondError(w, http.StatusBadRequest, "password too short (minimum 8 characters)")
 	case errors.Is(err, domain.ErrUnauthorized):
 		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		h.respondError(w, http.StatusUnauthorized, "invalid email or password")
+	case errors.Is(err, domain.ErrInvalidRefreshToken):
+		h.respondError(w, http.StatusUnauthorized, "invalid refresh token")
+	case errors.Is(err, domain.ErrUserBanned):
+		h.respondError(w, http.StatusForbidden, "user is banned")
+	case errors.Is(err, domain.ErrUserInactive):
+		h.respondError(w, http.StatusForbidden, "user is inactive")
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		h.respondError(w, http.StatusNotFound, "no pending application for this driver")
+	case errors.Is(err, domain.ErrReasonRequired):
+		h.respondError(w, http.StatusBadRequest, "reason is required")
+	case errors.Is(err, domain.ErrDocumentExpired):
+		h.respondError(w, http.StatusConflict, "application has expired documents")
+	case errors.Is(err, domain.ErrLicenseNumberTaken):
+		h.respondError(w, http.StatusConflict, "license number belongs to another driver")
+	case errors.Is(err, domain.ErrInvalidGranularity),
+		errors.Is(err, domain.ErrInvalidGroupBy),
+		errors.Is(err, domain.ErrInvalidTimeRange),
+		errors.Is(err, domain.ErrTimeRangeTooLarge):
+		h.respondError(w, http.StatusBadRequest, err.Error())
 	default:
 		h.log.Error(logger.Entry{
 			Action:  "admin_usecase_error",
@@ -260,3 +317,103 @@ func (h *HTTPHandler) handleGetActiveRides(w http.ResponseWriter, r *http.Reques
 
 	h.respondJSON(w, http.StatusOK, output)
 }
+
+// ReviewDriverHTTPRequest — HTTP DTO решения по заявке водителя
+type ReviewDriverHTTPRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleListPendingDrivers обрабатывает GET /admin/drivers/pending
+func (h *HTTPHandler) handleListPendingDrivers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	limit := 50
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	output, err := h.verificationUC.ListPending(ctx, in.ListPendingDriversInput{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleApproveDriver обрабатывает POST /admin/drivers/{id}/approve (reason необязателен)
+func (h *HTTPHandler) handleApproveDriver(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.parseReviewRequest(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.verificationUC.Approve(r.Context(), input)
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleRejectDriver обрабатывает POST /admin/drivers/{id}/reject (reason обязателен)
+func (h *HTTPHandler) handleRejectDriver(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.parseReviewRequest(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.verificationUC.Reject(r.Context(), input)
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// parseReviewRequest читает driver_id из пути и reason из тела (пустое тело допустимо)
+func (h *HTTPHandler) parseReviewRequest(w http.ResponseWriter, r *http.Request) (in.ReviewDriverInput, bool) {
+	driverID := r.PathValue("id")
+	if driverID == "" {
+		h.respondError(w, http.StatusBadRequest, "driver id is required")
+		return in.ReviewDriverInput{}, false
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	var req ReviewDriverHTTPRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.log.Error(logger.Entry{
+			Action:  "parse_review_driver_request_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		h.respondError(w, http.StatusBadRequest, "invalid request format")
+		return in.ReviewDriverInput{}, false
+	}
+
+	return in.ReviewDriverInput{
+		DriverID: driverID,
+		AdminID:  GetAdminID(r.Context()),
+		Reason:   req.Reason,
+	}, true
+}
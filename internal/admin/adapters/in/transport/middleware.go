@@ -2,11 +2,17 @@ package transport
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"strings"
 
+	"ridehail/internal/admin/application/ports/in"
 	"ridehail/internal/shared/auth"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/user"
+
+	"github.com/google/uuid"
 )
 
 type contextKey string
@@ -17,8 +23,10 @@ const (
 	ContextKeyUserRole  contextKey = "user_role"
 )
 
-// AdminAuthMiddleware создает middleware для проверки JWT + роль ADMIN
-func AdminAuthMiddleware(jwtService *auth.JWTService, log *logger.Logger) func(http.HandlerFunc) http.HandlerFunc {
+// AdminAuthMiddleware создает middleware для проверки JWT + роль ADMIN.
+// Статус берется из БД: токен заблокированного или удаленного администратора
+// перестает действовать сразу, не дожидаясь истечения.
+func AdminAuthMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *logger.Logger) func(http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
 			// Извлекаем токен из заголовка Authorization
@@ -71,6 +79,44 @@ func AdminAuthMiddleware(jwtService *auth.JWTService, log *logger.Logger) func(h
 				return
 			}
 
+			admin, err := userRepo.FindByID(r.Context(), claims.UserID)
+			if err != nil {
+				if errors.Is(err, user.ErrUserNotFound) {
+					respondUnauthorized(w, "user not found")
+					return
+				}
+				log.Error(logger.Entry{
+					Action:  "admin_lookup_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+					Additional: map[string]interface{}{
+						"user_id": claims.UserID,
+					},
+				})
+				respondInternalError(w, "internal server error")
+				return
+			}
+			if !admin.IsActive() || !admin.HasRole("ADMIN") {
+				log.Warn(logger.Entry{
+					Action:  "admin_auth_rejected",
+					Message: "admin account is not active",
+					Additional: map[string]interface{}{
+						"user_id": claims.UserID,
+						"status":  admin.Status,
+						"role":    admin.Role,
+					},
+				})
+				switch {
+				case admin.Status == "BANNED":
+					respondForbidden(w, "user is banned")
+				case !admin.IsActive():
+					respondForbidden(w, "user is inactive")
+				default:
+					respondForbidden(w, "admin role required")
+				}
+				return
+			}
+
 			log.Info(logger.Entry{
 				Action:  "admin_auth_success",
 				Message: "admin authenticated",
@@ -85,6 +131,13 @@ func AdminAuthMiddleware(jwtService *auth.JWTService, log *logger.Logger) func(h
 			ctx = context.WithValue(ctx, ContextKeyUserEmail, claims.Email)
 			ctx = context.WithValue(ctx, ContextKeyUserRole, claims.Role)
 
+			// Данные для журнала действий: кто, с какого адреса, в каком запросе
+			ctx = in.WithRequestMeta(ctx, in.RequestMeta{
+				ActorID:   claims.UserID,
+				IP:        clientIP(r),
+				RequestID: requestID(w, r),
+			})
+
 			// Передаем управление следующему обработчику
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
@@ -104,3 +157,41 @@ func respondForbidden(w http.ResponseWriter, message string) {
 	w.WriteHeader(http.StatusForbidden)
 	_, _ = w.Write([]byte(`{"error":"` + message + `"}`))
 }
+
+// respondInternalError отправляет 500 ответ
+func respondInternalError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write([]byte(`{"error":"` + message + `"}`))
+}
+
+// clientIP возвращает адрес клиента: первый из X-Forwarded-For (за прокси), иначе RemoteAddr
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		first, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(first)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// requestID берет X-Request-ID клиента или создает новый и возвращает его в ответе
+func requestID(w http.ResponseWriter, r *http.Request) string {
+	id := r.Header.Get("X-Request-ID")
+	if id == "" {
+		id = uuid.New().String()
+	}
+	w.Header().Set("X-Request-ID", id)
+	return id
+}
+
+// GetAdminID возвращает ID администратора, установленный AdminAuthMiddleware
+func GetAdminID(ctx context.Context) string {
+	if id, ok := ctx.Value(ContextKeyUserID).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"net/http"
+
+	"ridehail/internal/admin/application/ports/in"
+
+	"github.com/google/uuid"
+)
+
+// UpdateUserHTTPRequest — HTTP DTO для PATCH /admin/users/{id}; отсутствующие поля не меняются
+type UpdateUserHTTPRequest struct {
+	Status *string                `json:"status,omitempty"`
+	Role   *string                `json:"role,omitempty"`
+	Attrs  map[string]interface{} `json:"attrs,omitempty"` // ключ со значением null удаляется
+}
+
+// handleGetUser обрабатывает GET /admin/users/{id}
+func (h *HTTPHandler) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.manageUserUC.Get(r.Context(), userID)
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleUpdateUser обрабатывает PATCH /admin/users/{id}
+func (h *HTTPHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateUserHTTPRequest
+	if !h.decodeJSON(w, r, &req, "parse_update_user_request_failed") {
+		return
+	}
+
+	output, err := h.manageUserUC.Update(r.Context(), in.UpdateUserInput{
+		AdminID: GetAdminID(r.Context()),
+		UserID:  userID,
+		Status:  req.Status,
+		Role:    req.Role,
+		Attrs:   req.Attrs,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleDeleteUser обрабатывает DELETE /admin/users/{id} (soft delete)
+func (h *HTTPHandler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.manageUserUC.Delete(r.Context(), in.DeleteUserInput{
+		AdminID: GetAdminID(r.Context()),
+		UserID:  userID,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// userIDFromPath читает {id} из пути; некорректный UUID — 400, а не ошибка БД
+func (h *HTTPHandler) userIDFromPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := r.PathValue("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid user id")
+		return "", false
+	}
+	return userID, true
+}
@@ -0,0 +1,45 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+)
+
+// DriverNotifier публикует решения по заявкам водителей в driver_topic;
+// до водителя по WebSocket их доносит Driver Service
+type DriverNotifier struct {
+	mq  *mq.RabbitMQ
+	log *logger.Logger
+}
+
+// NewDriverNotifier создает publisher решений по заявкам
+func NewDriverNotifier(mq *mq.RabbitMQ, log *logger.Logger) *DriverNotifier {
+	return &DriverNotifier{
+		mq:  mq,
+		log: log,
+	}
+}
+
+// PublishVerification публикует решение по заявке водителя.
+// Routing key: driver.verification.{driver_id}. С подтверждением брокера:
+// повторно решение не отправляется, потерянное сообщение водитель не увидит до запроса статуса.
+func (n *DriverNotifier) PublishVerification(ctx context.Context, msg *contract.DriverVerification) error {
+	body, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("encode driver verification: %w", err)
+	}
+
+	if err := n.mq.PublishConfirmed(ctx, contract.ExchangeDriver, contract.VerificationKey(msg.DriverID), body); err != nil {
+		return fmt.Errorf("publish to driver_topic: %w", err)
+	}
+
+	n.log.Debug(logger.Entry{
+		Action:  "driver_verification_published",
+		Message: fmt.Sprintf("driver_id=%s, status=%s", msg.DriverID, msg.Status),
+	})
+	return nil
+}
@@ -0,0 +1,45 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+)
+
+// SessionNotifier публикует отзыв сессий пользователя в user_topic;
+// Ride и Driver Service закрывают его WebSocket-соединения
+type SessionNotifier struct {
+	mq  *mq.RabbitMQ
+	log *logger.Logger
+}
+
+// NewSessionNotifier создает publisher отзыва сессий
+func NewSessionNotifier(mq *mq.RabbitMQ, log *logger.Logger) *SessionNotifier {
+	return &SessionNotifier{
+		mq:  mq,
+		log: log,
+	}
+}
+
+// PublishSessionsRevoked публикует отзыв сессий.
+// Routing key: user.sessions_revoked.{user_id}. С подтверждением брокера: если сообщение
+// потеряно, открытые соединения доживут до переподключения — новые отсечет проверка статуса.
+func (n *SessionNotifier) PublishSessionsRevoked(ctx context.Context, msg *contract.UserSessionsRevoked) error {
+	body, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("encode sessions revoked: %w", err)
+	}
+
+	if err := n.mq.PublishConfirmed(ctx, contract.ExchangeUser, contract.SessionsRevokedKey(msg.UserID), body); err != nil {
+		return fmt.Errorf("publish to %s: %w", contract.ExchangeUser, err)
+	}
+
+	n.log.Debug(logger.Entry{
+		Action:  "sessions_revoked_published",
+		Message: fmt.Sprintf("user_id=%s, reason=%s", msg.UserID, msg.Reason),
+	})
+	return nil
+}
@@ -0,0 +1,296 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rollupName — строка analytics_rollup_state для роллапов поездок
+const rollupName = "ride_stats"
+
+// groupColumns — колонка роллапа для каждого разреза (только из этого списка попадает в SQL)
+var groupColumns = map[string]string{
+	"":                        "''::text",
+	domain.GroupByVehicleType: "vehicle_type",
+	domain.GroupByStatus:      "status",
+	domain.GroupByCity:        "city",
+}
+
+// AnalyticsPgRepository — Postgres реализация AnalyticsRepository
+type AnalyticsPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewAnalyticsPgRepository создает репозиторий роллапов аналитики
+func NewAnalyticsPgRepository(pool *pgxpool.Pool, log *logger.Logger) *AnalyticsPgRepository {
+	return &AnalyticsPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// RefreshRollups пересчитывает часовые корзины поездок, измененных в [since - overlap, until),
+// и дневные корзины, в которые они входят. Все в одной транзакции: читатели видят
+// либо старые, либо новые роллапы. Параллельные прогоны (несколько экземпляров
+// Admin Service) выстраиваются в очередь на advisory lock.
+func (r *AnalyticsPgRepository) RefreshRollups(ctx context.Context, until time.Time, overlap time.Duration) (*out.RollupRefresh, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Откатываем если не закоммитили
+	}()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('analytics_rollup_' || $1))`, rollupName); err != nil {
+		return nil, fmt.Errorf("lock rollup: %w", err)
+	}
+
+	result := &out.RollupRefresh{Until: until}
+
+	var refreshedUntil time.Time
+	err = tx.QueryRow(ctx, `SELECT refreshed_until FROM analytics_rollup_state WHERE name = $1`, rollupName).Scan(&refreshedUntil)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// Первый прогон — вся история
+	case err != nil:
+		return nil, fmt.Errorf("read rollup state: %w", err)
+	default:
+		since := refreshedUntil.Add(-overlap)
+		result.Since = &since
+	}
+
+	hours, err := r.changedHours(ctx, tx, result.Since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hours) > 0 {
+		days, err := r.rebuildHourly(ctx, tx, hours)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.rebuildDaily(ctx, tx, days); err != nil {
+			return nil, err
+		}
+		result.Hours = len(hours)
+		result.Days = len(days)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO analytics_rollup_state (name, refreshed_until, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			refreshed_until = EXCLUDED.refreshed_until,
+			updated_at      = EXCLUDED.updated_at
+	`, rollupName, until)
+	if err != nil {
+		return nil, fmt.Errorf("save rollup state: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// changedHours возвращает часовые корзины (по времени заказа) поездок, измененных в [since, until)
+func (r *AnalyticsPgRepository) changedHours(ctx context.Context, tx pgx.Tx, since *time.Time, until time.Time) ([]time.Time, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT date_trunc('hour', coalesce(requested_at, created_at), 'UTC')
+		FROM rides
+		WHERE ($1::timestamptz IS NULL OR updated_at >= $1)
+		  AND updated_at < $2
+	`, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("query changed ride hours: %w", err)
+	}
+
+	hours, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return nil, fmt.Errorf("scan changed ride hours: %w", err)
+	}
+	return hours, nil
+}
+
+// rebuildHourly пересчитывает часовые корзины целиком и возвращает дни, в которые они входят
+func (r *AnalyticsPgRepository) rebuildHourly(ctx context.Context, tx pgx.Tx, hours []time.Time) ([]time.Time, error) {
+	first, last := hours[0], hours[0]
+	seenDays := make(map[time.Time]struct{})
+	days := make([]time.Time, 0)
+	for _, h := range hours {
+		h = h.UTC()
+		if h.Before(first) {
+			first = h
+		}
+		if h.After(last) {
+			last = h
+		}
+		day := time.Date(h.Year(), h.Month(), h.Day(), 0, 0, 0, 0, time.UTC)
+		if _, ok := seenDays[day]; !ok {
+			seenDays[day] = struct{}{}
+			days = append(days, day)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM ride_stats_hourly WHERE bucket = ANY($1)`, hours); err != nil {
+		return nil, fmt.Errorf("delete hourly stats: %w", err)
+	}
+
+	// Диапазон по requested_at — для индекса; точный набор корзин — через ANY
+	_, err := tx.Exec(ctx, `
+		INSERT INTO ride_stats_hourly (
+			bucket, vehicle_type, status, city,
+			rides, completed, cancelled, revenue,
+			wait_seconds_sum, wait_count, duration_seconds_sum, duration_count
+		)
+		SELECT
+			date_trunc('hour', coalesce(requested_at, created_at), 'UTC'),
+			coalesce(vehicle_type, 'UNKNOWN'),
+			coalesce(status, 'UNKNOWN'),
+			city,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'COMPLETED'),
+			COUNT(*) FILTER (WHERE status = 'CANCELLED'),
+			COALESCE(SUM(final_fare) FILTER (WHERE status = 'COMPLETED'), 0),
+			COALESCE(SUM(EXTRACT(EPOCH FROM (matched_at - requested_at))) FILTER (WHERE matched_at IS NOT NULL AND requested_at IS NOT NULL), 0),
+			COUNT(*) FILTER (WHERE matched_at IS NOT NULL AND requested_at IS NOT NULL),
+			COALESCE(SUM(EXTRACT(EPOCH FROM (completed_at - started_at))) FILTER (WHERE completed_at IS NOT NULL AND started_at IS NOT NULL), 0),
+			COUNT(*) FILTER (WHERE completed_at IS NOT NULL AND started_at IS NOT NULL)
+		FROM rides
+		WHERE coalesce(requested_at, created_at) >= $2
+		  AND coalesce(requested_at, created_at) < $3
+		  AND date_trunc('hour', coalesce(requested_at, created_at), 'UTC') = ANY($1)
+		GROUP BY 1, 2, 3, 4
+	`, hours, first, last.Add(time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("insert hourly stats: %w", err)
+	}
+
+	return days, nil
+}
+
+// rebuildDaily пересчитывает дневные корзины из часовых
+func (r *AnalyticsPgRepository) rebuildDaily(ctx context.Context, tx pgx.Tx, days []time.Time) error {
+	first, last := days[0], days[0]
+	for _, d := range days {
+		if d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM ride_stats_daily WHERE bucket = ANY($1)`, days); err != nil {
+		return fmt.Errorf("delete daily stats: %w", err)
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO ride_stats_daily (
+			bucket, vehicle_type, status, city,
+			rides, completed, cancelled, revenue,
+			wait_seconds_sum, wait_count, duration_seconds_sum, duration_count
+		)
+		SELECT
+			date_trunc('day', bucket, 'UTC'), vehicle_type, status, city,
+			SUM(rides), SUM(completed), SUM(cancelled), SUM(revenue),
+			SUM(wait_seconds_sum), SUM(wait_count), SUM(duration_seconds_sum), SUM(duration_count)
+		FROM ride_stats_hourly
+		WHERE bucket >= $2
+		  AND bucket < $3
+		  AND date_trunc('day', bucket, 'UTC') = ANY($1)
+		GROUP BY 1, 2, 3, 4
+	`, days, first, last.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("insert daily stats: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRideStats складывает строки роллапа в корзины шага и группы разреза.
+// Часовой шаг читает ride_stats_hourly, дневной и недельный — ride_stats_daily.
+func (r *AnalyticsPgRepository) QueryRideStats(ctx context.Context, query out.AnalyticsQuery) ([]domain.RideStatsRow, error) {
+	groupColumn, ok := groupColumns[query.GroupBy]
+	if !ok {
+		return nil, domain.ErrInvalidGroupBy
+	}
+	if !domain.ValidGranularity(query.Granularity) {
+		return nil, domain.ErrInvalidGranularity
+	}
+
+	table := "ride_stats_daily"
+	if query.Granularity == domain.GranularityHour {
+		table = "ride_stats_hourly"
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT
+			date_trunc($3, bucket, 'UTC') AS b,
+			%s AS grp,
+			SUM(rides), SUM(completed), SUM(cancelled), SUM(revenue)::float8,
+			SUM(wait_seconds_sum), SUM(wait_count), SUM(duration_seconds_sum), SUM(duration_count)
+		FROM %s
+		WHERE bucket >= $1
+		  AND bucket < $2
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`, groupColumn, table)
+
+	rows, err := r.pool.Query(ctx, sql, query.From, query.To, query.Granularity)
+	if err != nil {
+		return nil, fmt.Errorf("query ride stats: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.RideStatsRow, 0)
+	for rows.Next() {
+		var row domain.RideStatsRow
+		if err := rows.Scan(
+			&row.Bucket,
+			&row.Group,
+			&row.Rides,
+			&row.Completed,
+			&row.Cancelled,
+			&row.Revenue,
+			&row.WaitSecondsSum,
+			&row.WaitCount,
+			&row.DurationSecondsSum,
+			&row.DurationCount,
+		); err != nil {
+			return nil, fmt.Errorf("scan ride stats: %w", err)
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate ride stats: %w", err)
+	}
+
+	return result, nil
+}
+
+// RefreshedUntil возвращает момент последнего пересчета роллапов
+func (r *AnalyticsPgRepository) RefreshedUntil(ctx context.Context) (*time.Time, error) {
+	var refreshedUntil time.Time
+	err := r.pool.QueryRow(ctx, `SELECT refreshed_until FROM analytics_rollup_state WHERE name = $1`, rollupName).Scan(&refreshedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read rollup state: %w", err)
+	}
+	return &refreshedUntil, nil
+}
@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditLogPgRepository — Postgres реализация AuditLogRepository.
+// Только INSERT и SELECT: UPDATE/DELETE запрещены триггерами admin_audit_log.
+type AuditLogPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewAuditLogPgRepository создает репозиторий журнала действий
+func NewAuditLogPgRepository(pool *pgxpool.Pool, log *logger.Logger) *AuditLogPgRepository {
+	return &AuditLogPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Append добавляет запись журнала
+func (r *AuditLogPgRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	beforeJSON, err := marshalNullable(entry.Before)
+	if err != nil {
+		return fmt.Errorf("marshal before: %w", err)
+	}
+	afterJSON, err := marshalNullable(entry.After)
+	if err != nil {
+		return fmt.Errorf("marshal after: %w", err)
+	}
+	diffJSON, err := json.Marshal(entry.Diff)
+	if err != nil {
+		return fmt.Errorf("marshal diff: %w", err)
+	}
+
+	query := `
+		INSERT INTO admin_audit_log (actor_id, action, target_type, target_id, before, after, diff, ip, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''))
+		RETURNING id, created_at
+	`
+
+	err = conn(ctx, r.pool).QueryRow(ctx, query,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		beforeJSON,
+		afterJSON,
+		diffJSON,
+		entry.IP,
+		entry.RequestID,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// List возвращает записи журнала по фильтрам, новые первыми
+func (r *AuditLogPgRepository) List(ctx context.Context, filters out.AuditFilters) ([]*domain.AuditEntry, int, error) {
+	whereClause := ""
+	args := []interface{}{}
+	argIndex := 1
+
+	if filters.ActorID != "" {
+		whereClause += fmt.Sprintf(" AND actor_id = $%d", argIndex)
+		args = append(args, filters.ActorID)
+		argIndex++
+	}
+
+	if filters.TargetID != "" {
+		whereClause += fmt.Sprintf(" AND target_id = $%d", argIndex)
+		args = append(args, filters.TargetID)
+		argIndex++
+	}
+
+	if filters.From != nil {
+		whereClause += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, *filters.From)
+		argIndex++
+	}
+
+	if filters.To != nil {
+		whereClause += fmt.Sprintf(" AND created_at < $%d", argIndex)
+		args = append(args, *filters.To)
+		argIndex++
+	}
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM admin_audit_log WHERE 1=1 %s`, whereClause)
+
+	var totalCount int
+	if err := conn(ctx, r.pool).QueryRow(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("count audit entries: %w", err)
+	}
+
+	args = append(args, filters.Limit, filters.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, action, target_type, target_id, before, after, diff,
+		       COALESCE(ip, ''), COALESCE(request_id, ''), created_at
+		FROM admin_audit_log
+		WHERE 1=1 %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argIndex, argIndex+1)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var beforeJSON, afterJSON, diffJSON []byte
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&beforeJSON,
+			&afterJSON,
+			&diffJSON,
+			&entry.IP,
+			&entry.RequestID,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan audit entry: %w", err)
+		}
+
+		if len(beforeJSON) > 0 {
+			if err := json.Unmarshal(beforeJSON, &entry.Before); err != nil {
+				return nil, 0, fmt.Errorf("unmarshal audit before: %w", err)
+			}
+		}
+		if len(afterJSON) > 0 {
+			if err := json.Unmarshal(afterJSON, &entry.After); err != nil {
+				return nil, 0, fmt.Errorf("unmarshal audit after: %w", err)
+			}
+		}
+		if err := json.Unmarshal(diffJSON, &entry.Diff); err != nil {
+			return nil, 0, fmt.Errorf("unmarshal audit diff: %w", err)
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate audit entries: %w", err)
+	}
+
+	return entries, totalCount, nil
+}
+
+// marshalNullable кодирует снимок в JSON; nil — SQL NULL, а не 'null'::jsonb
+func marshalNullable(v map[string]interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,228 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DriverApplicationPgRepository — Postgres реализация DriverApplicationRepository
+type DriverApplicationPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewDriverApplicationPgRepository создает репозиторий заявок водителей
+func NewDriverApplicationPgRepository(pool *pgxpool.Pool, log *logger.Logger) *DriverApplicationPgRepository {
+	return &DriverApplicationPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// documentRow — документ в jsonb_agg выборки заявок
+type documentRow struct {
+	Kind      string `json:"kind"`
+	Number    string `json:"number"`
+	ExpiresOn string `json:"expires_on"`
+}
+
+// ListPending возвращает заявки в PENDING вместе с документами, старые первыми
+func (r *DriverApplicationPgRepository) ListPending(ctx context.Context, limit, offset int) ([]*domain.DriverApplication, int, error) {
+	var totalCount int
+	if err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM driver_applications WHERE status = 'PENDING'`).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("count pending applications: %w", err)
+	}
+
+	query := `
+		SELECT a.id, a.driver_id, u.email, a.status, a.license_number, a.vehicle_type, a.vehicle_attrs, a.created_at,
+		       COALESCE((
+		           SELECT jsonb_agg(jsonb_build_object(
+		                      'kind', d.kind,
+		                      'number', d.number,
+		                      'expires_on', to_char(d.expires_on, 'YYYY-MM-DD')
+		                  ) ORDER BY d.kind)
+		           FROM driver_documents d
+		           WHERE d.application_id = a.id
+		       ), '[]'::jsonb)
+		FROM driver_applications a
+		JOIN users u ON u.id = a.driver_id
+		WHERE a.status = 'PENDING'
+		ORDER BY a.created_at
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query pending applications: %w", err)
+	}
+	defer rows.Close()
+
+	apps := make([]*domain.DriverApplication, 0)
+	for rows.Next() {
+		var app domain.DriverApplication
+		var vehicleAttrsJSON, documentsJSON []byte
+
+		if err := rows.Scan(
+			&app.ID,
+			&app.DriverID,
+			&app.Email,
+			&app.Status,
+			&app.LicenseNumber,
+			&app.VehicleType,
+			&vehicleAttrsJSON,
+			&app.CreatedAt,
+			&documentsJSON,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan pending application: %w", err)
+		}
+
+		if len(vehicleAttrsJSON) > 0 {
+			if err := json.Unmarshal(vehicleAttrsJSON, &app.VehicleAttrs); err != nil {
+				r.log.Debug(logger.Entry{
+					Action:  "unmarshal_application_vehicle_attrs_failed",
+					Message: err.Error(),
+				})
+			}
+		}
+
+		var docs []documentRow
+		if err := json.Unmarshal(documentsJSON, &docs); err != nil {
+			return nil, 0, fmt.Errorf("unmarshal application documents: %w", err)
+		}
+		for _, d := range docs {
+			expiresOn, err := time.Parse(domain.DateLayout, d.ExpiresOn)
+			if err != nil {
+				return nil, 0, fmt.Errorf("parse document expiry: %w", err)
+			}
+			app.Documents = append(app.Documents, domain.DriverDocument{
+				Kind:      d.Kind,
+				Number:    d.Number,
+				ExpiresOn: expiresOn,
+			})
+		}
+
+		apps = append(apps, &app)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate pending applications: %w", err)
+	}
+
+	return apps, totalCount, nil
+}
+
+// Approve одобряет заявку и создает/обновляет строку drivers в одной транзакции.
+// Заявка блокируется FOR UPDATE: параллельное отклонение дождется и не найдет PENDING.
+func (r *DriverApplicationPgRepository) Approve(ctx context.Context, driverID, adminID, reason string, today, at time.Time) (*domain.ApplicationDecision, error) {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // Откатываем если не закоммитили
+	}()
+
+	var applicationID string
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM driver_applications
+		WHERE driver_id = $1 AND status = 'PENDING'
+		FOR UPDATE
+	`, driverID).Scan(&applicationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("lock pending application: %w", err)
+	}
+
+	var verifiedUntil *time.Time
+	if err := tx.QueryRow(ctx, `
+		SELECT MIN(expires_on) FROM driver_documents WHERE application_id = $1
+	`, applicationID).Scan(&verifiedUntil); err != nil {
+		return nil, fmt.Errorf("query document expiry: %w", err)
+	}
+	if verifiedUntil != nil && verifiedUntil.Before(today) {
+		return nil, domain.ErrDocumentExpired
+	}
+
+	// Строку drivers мог создать администратор при заведении пользователя — тогда обновляем ее
+	_, err = tx.Exec(ctx, `
+		INSERT INTO drivers (id, license_number, vehicle_type, vehicle_attrs, status, is_verified, verified_until, created_at, updated_at)
+		SELECT driver_id, license_number, vehicle_type, vehicle_attrs, 'OFFLINE', true, $2::date, $3, $3
+		FROM driver_applications
+		WHERE id = $1
+		ON CONFLICT (id) DO UPDATE SET
+			license_number = EXCLUDED.license_number,
+			vehicle_type   = EXCLUDED.vehicle_type,
+			vehicle_attrs  = EXCLUDED.vehicle_attrs,
+			is_verified    = true,
+			verified_until = EXCLUDED.verified_until,
+			updated_at     = EXCLUDED.updated_at
+	`, applicationID, verifiedUntil, at)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrLicenseNumberTaken
+		}
+		return nil, fmt.Errorf("upsert driver: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE driver_applications
+		SET status = 'APPROVED', reviewed_by = $2, reviewed_at = $3, review_reason = NULLIF($4, ''), updated_at = $3
+		WHERE id = $1
+	`, applicationID, adminID, at, reason)
+	if err != nil {
+		return nil, fmt.Errorf("approve application: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return &domain.ApplicationDecision{
+		ApplicationID: applicationID,
+		DriverID:      driverID,
+		Status:        domain.ApplicationApproved,
+		Reason:        reason,
+		VerifiedUntil: verifiedUntil,
+		ReviewedBy:    adminID,
+		ReviewedAt:    at,
+	}, nil
+}
+
+// Reject отклоняет заявку водителя, если она еще ждет решения
+func (r *DriverApplicationPgRepository) Reject(ctx context.Context, driverID, adminID, reason string, at time.Time) (*domain.ApplicationDecision, error) {
+	var applicationID string
+	err := conn(ctx, r.pool).QueryRow(ctx, `
+		UPDATE driver_applications
+		SET status = 'REJECTED', reviewed_by = $2, reviewed_at = $3, review_reason = $4, updated_at = $3
+		WHERE driver_id = $1 AND status = 'PENDING'
+		RETURNING id
+	`, driverID, adminID, at, reason).Scan(&applicationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("reject application: %w", err)
+	}
+
+	return &domain.ApplicationDecision{
+		ApplicationID: applicationID,
+		DriverID:      driverID,
+		Status:        domain.ApplicationRejected,
+		Reason:        reason,
+		ReviewedBy:    adminID,
+		ReviewedAt:    at,
+	}, nil
+}
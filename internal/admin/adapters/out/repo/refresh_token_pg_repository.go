@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RefreshTokenPgRepository — Postgres реализация RefreshTokenRepository
+type RefreshTokenPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewRefreshTokenPgRepository создает новый репозиторий refresh-токенов
+func NewRefreshTokenPgRepository(pool *pgxpool.Pool, log *logger.Logger) *RefreshTokenPgRepository {
+	return &RefreshTokenPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Create сохраняет новый refresh-токен
+func (r *RefreshTokenPgRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByHash находит токен по хешу
+func (r *RefreshTokenPgRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var token domain.RefreshToken
+	err := conn(ctx, r.pool).QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("query refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Revoke отзывает токен, если он еще не отозван
+func (r *RefreshTokenPgRepository) Revoke(ctx context.Context, tokenID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, tokenID)
+	if err != nil {
+		return fmt.Errorf("revoke refresh token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrRefreshTokenRevoked
+	}
+
+	return nil
+}
+
+// RevokeAllForUser отзывает все активные токены пользователя
+func (r *RefreshTokenPgRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("revoke user refresh tokens: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"ridehail/internal/admin/application/ports/in"
 	"ridehail/internal/admin/application/ports/out"
@@ -39,7 +40,7 @@ func (r *UserPgRepository) Create(ctx context.Context, user *domain.User) error
 	}
 
 	// Начинаем транзакцию
-	tx, err := r.pool.Begin(ctx)
+	tx, err := begin(ctx, r.pool)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -129,26 +130,33 @@ func (r *UserPgRepository) Create(ctx context.Context, user *domain.User) error
 	return nil
 }
 
-// FindByID находит пользователя по ID
+// FindByID находит пользователя по ID (в том числе удаленного)
 func (r *UserPgRepository) FindByID(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-		SELECT id, email, role, status, password_hash, attrs, created_at, updated_at
-		FROM users
-		WHERE id = $1
+		SELECT u.id, u.email, u.role, u.status, u.password_hash, u.attrs,
+		       CASE WHEN d.id IS NOT NULL THEN d.rating ELSE u.rating END,
+		       COALESCE(d.rating_count, u.rating_count),
+		       u.created_at, u.updated_at, u.deleted_at
+		FROM users u
+		LEFT JOIN drivers d ON d.id = u.id
+		WHERE u.id = $1
 	`
 
 	var user domain.User
 	var attrsJSON []byte
 
-	err := r.pool.QueryRow(ctx, query, userID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Role,
 		&user.Status,
 		&user.PasswordHash,
 		&attrsJSON,
+		&user.Rating,
+		&user.RatingCount,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -173,18 +181,19 @@ func (r *UserPgRepository) FindByID(ctx context.Context, userID string) (*domain
 	return &user, nil
 }
 
-// FindByEmail находит пользователя по email
+// FindByEmail находит пользователя по email. Удаленные не находятся:
+// вход под ними невозможен
 func (r *UserPgRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
 		SELECT id, email, role, status, password_hash, attrs, created_at, updated_at
 		FROM users
-		WHERE email = $1
+		WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	var user domain.User
 	var attrsJSON []byte
 
-	err := r.pool.QueryRow(ctx, query, email).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, email).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Role,
@@ -217,21 +226,21 @@ func (r *UserPgRepository) FindByEmail(ctx context.Context, email string) (*doma
 	return &user, nil
 }
 
-// List возвращает список пользователей с фильтрами
+// List возвращает список пользователей с фильтрами (без удаленных)
 func (r *UserPgRepository) List(ctx context.Context, filters out.ListUsersFilters) ([]*domain.User, int, error) {
 	// Строим динамический WHERE clause
-	whereClause := ""
+	whereClause := " AND u.deleted_at IS NULL"
 	args := []interface{}{}
 	argIndex := 1
 
 	if filters.Role != "" {
-		whereClause += fmt.Sprintf(" AND role = $%d", argIndex)
+		whereClause += fmt.Sprintf(" AND u.role = $%d", argIndex)
 		args = append(args, filters.Role)
 		argIndex++
 	}
 
 	if filters.Status != "" {
-		whereClause += fmt.Sprintf(" AND status = $%d", argIndex)
+		whereClause += fmt.Sprintf(" AND u.status = $%d", argIndex)
 		args = append(args, filters.Status)
 		argIndex++
 	}
@@ -239,12 +248,12 @@ func (r *UserPgRepository) List(ctx context.Context, filters out.ListUsersFilter
 	// Запрос для подсчета общего количества
 	countQuery := fmt.Sprintf(`
 		SELECT COUNT(*)
-		FROM users
+		FROM users u
 		WHERE 1=1 %s
 	`, whereClause)
 
 	var totalCount int
-	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
+	err := conn(ctx, r.pool).QueryRow(ctx, countQuery, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("count users: %w", err)
 	}
@@ -252,14 +261,18 @@ func (r *UserPgRepository) List(ctx context.Context, filters out.ListUsersFilter
 	// Запрос для получения пользователей
 	args = append(args, filters.Limit, filters.Offset)
 	query := fmt.Sprintf(`
-		SELECT id, email, role, status, password_hash, attrs, created_at, updated_at
-		FROM users
+		SELECT u.id, u.email, u.role, u.status, u.password_hash, u.attrs,
+		       CASE WHEN d.id IS NOT NULL THEN d.rating ELSE u.rating END,
+		       COALESCE(d.rating_count, u.rating_count),
+		       u.created_at, u.updated_at
+		FROM users u
+		LEFT JOIN drivers d ON d.id = u.id
 		WHERE 1=1 %s
-		ORDER BY created_at DESC
+		ORDER BY u.created_at DESC
 		LIMIT $%d OFFSET $%d
 	`, whereClause, argIndex, argIndex+1)
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("query users: %w", err)
 	}
@@ -277,6 +290,8 @@ func (r *UserPgRepository) List(ctx context.Context, filters out.ListUsersFilter
 			&user.Status,
 			&user.PasswordHash,
 			&attrsJSON,
+			&user.Rating,
+			&user.RatingCount,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -303,7 +318,7 @@ func (r *UserPgRepository) List(ctx context.Context, filters out.ListUsersFilter
 	return users, totalCount, nil
 }
 
-// Update обновляет пользователя
+// Update обновляет пользователя (удаленного — ErrUserNotFound)
 func (r *UserPgRepository) Update(ctx context.Context, user *domain.User) error {
 	attrsJSON, err := json.Marshal(user.Attrs)
 	if err != nil {
@@ -313,10 +328,10 @@ func (r *UserPgRepository) Update(ctx context.Context, user *domain.User) error
 	query := `
 		UPDATE users
 		SET email = $2, role = $3, status = $4, password_hash = $5, attrs = $6, updated_at = $7
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		user.ID,
 		user.Email,
 		user.Role,
@@ -336,11 +351,16 @@ func (r *UserPgRepository) Update(ctx context.Context, user *domain.User) error
 	return nil
 }
 
-// Delete удаляет пользователя (hard delete)
-func (r *UserPgRepository) Delete(ctx context.Context, userID string) error {
-	query := `DELETE FROM users WHERE id = $1`
+// Delete удаляет пользователя (soft delete). Строка остается: на нее ссылаются
+// поездки, оценки и заявки
+func (r *UserPgRepository) Delete(ctx context.Context, userID string, at time.Time) error {
+	query := `
+		UPDATE users
+		SET status = 'INACTIVE', deleted_at = $2, updated_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
 
-	result, err := r.pool.Exec(ctx, query, userID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID, at)
 	if err != nil {
 		return fmt.Errorf("delete user: %w", err)
 	}
@@ -352,12 +372,30 @@ func (r *UserPgRepository) Delete(ctx context.Context, userID string) error {
 	return nil
 }
 
+// HasActiveRides проверяет незавершенные поездки пользователя — пассажиром или водителем
+func (r *UserPgRepository) HasActiveRides(ctx context.Context, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM rides
+			WHERE (passenger_id = $1 OR driver_id = $1)
+			  AND status IN ('SCHEDULED', 'REQUESTED', 'MATCHED', 'EN_ROUTE', 'ARRIVED', 'IN_PROGRESS')
+		)
+	`
+
+	var exists bool
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check active rides: %w", err)
+	}
+
+	return exists, nil
+}
+
 // Exists проверяет существование пользователя
 func (r *UserPgRepository) Exists(ctx context.Context, userID string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
 
 	var exists bool
-	err := r.pool.QueryRow(ctx, query, userID).Scan(&exists)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check user exists: %w", err)
 	}
@@ -398,7 +436,7 @@ func (r *UserPgRepository) GetSystemMetrics(ctx context.Context) (*in.SystemMetr
 	`
 
 	var metrics in.SystemMetrics
-	err := r.pool.QueryRow(ctx, query).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query).Scan(
 		&metrics.ActiveRides,
 		&metrics.AvailableDrivers,
 		&metrics.BusyDrivers,
@@ -426,7 +464,7 @@ func (r *UserPgRepository) GetDriverDistribution(ctx context.Context) (map[strin
 		GROUP BY vehicle_type
 	`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("query driver distribution: %w", err)
 	}
@@ -472,7 +510,7 @@ func (r *UserPgRepository) GetHotspots(ctx context.Context) ([]in.Hotspot, error
 		FROM pickup_addresses pa
 	`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("query hotspots: %w", err)
 	}
@@ -506,7 +544,7 @@ func (r *UserPgRepository) GetActiveRides(ctx context.Context, page, pageSize in
 	`
 
 	var totalCount int
-	if err := r.pool.QueryRow(ctx, countQuery).Scan(&totalCount); err != nil {
+	if err := conn(ctx, r.pool).QueryRow(ctx, countQuery).Scan(&totalCount); err != nil {
 		return nil, 0, fmt.Errorf("count active rides: %w", err)
 	}
 
@@ -538,7 +576,7 @@ func (r *UserPgRepository) GetActiveRides(ctx context.Context, page, pageSize in
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.pool.Query(ctx, query, pageSize, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, pageSize, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("query active rides: %w", err)
 	}
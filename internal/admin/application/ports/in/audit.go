@@ -0,0 +1,71 @@
+package in
+
+import (
+	"context"
+	"time"
+)
+
+// RequestMeta — кто и откуда выполняет запрос; кладется в контекст
+// AdminAuthMiddleware и читается аудитом use cases
+type RequestMeta struct {
+	ActorID   string
+	IP        string
+	RequestID string
+}
+
+type requestMetaKey struct{}
+
+// WithRequestMeta возвращает контекст с данными запроса
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFrom достает данные запроса из контекста (пустые, если их нет)
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
+
+// ListAuditInput — фильтры журнала действий
+type ListAuditInput struct {
+	ActorID  string
+	TargetID string
+	From     *time.Time
+	To       *time.Time
+	Limit    int // по умолчанию 50, максимум 200
+	Offset   int
+}
+
+// AuditChangeDTO — изменение одного поля
+type AuditChangeDTO struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AuditEntryDTO — запись журнала
+type AuditEntryDTO struct {
+	ID         int64                     `json:"id"`
+	ActorID    string                    `json:"actor_id"`
+	Action     string                    `json:"action"`
+	TargetType string                    `json:"target_type"`
+	TargetID   string                    `json:"target_id"`
+	Before     map[string]interface{}    `json:"before,omitempty"`
+	After      map[string]interface{}    `json:"after,omitempty"`
+	Diff       map[string]AuditChangeDTO `json:"diff"`
+	IP         string                    `json:"ip,omitempty"`
+	RequestID  string                    `json:"request_id,omitempty"`
+	CreatedAt  string                    `json:"created_at"`
+}
+
+// ListAuditOutput — страница журнала
+type ListAuditOutput struct {
+	Entries    []AuditEntryDTO `json:"entries"`
+	TotalCount int             `json:"total_count"`
+	Limit      int             `json:"limit"`
+	Offset     int             `json:"offset"`
+}
+
+// ListAuditUseCase — интерфейс use case чтения журнала действий
+type ListAuditUseCase interface {
+	Execute(ctx context.Context, input ListAuditInput) (*ListAuditOutput, error)
+}
@@ -0,0 +1,51 @@
+package in
+
+import "context"
+
+// LoginInput — входные данные для входа по email и паролю
+type LoginInput struct {
+	Email    string
+	Password string // plain text, сверяется с bcrypt хешем
+}
+
+// RefreshInput — входные данные для обновления пары токенов
+type RefreshInput struct {
+	RefreshToken string
+}
+
+// LogoutInput — входные данные для выхода
+type LogoutInput struct {
+	RefreshToken string
+}
+
+// AuthTokensOutput — пара токенов (формат ответа /auth/login и /auth/refresh)
+type AuthTokensOutput struct {
+	AccessToken           string `json:"access_token"`
+	TokenType             string `json:"token_type"` // Bearer
+	ExpiresIn             int    `json:"expires_in"` // TTL access-токена в секундах
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at"` // ISO8601
+	UserID                string `json:"user_id"`
+	Role                  string `json:"role"`
+}
+
+// AuthUseCase — интерфейс use case аутентификации
+type AuthUseCase interface {
+	// Login проверяет email и пароль и выдает пару токенов.
+	//
+	// Возвращает:
+	//   - domain.ErrInvalidCredentials — неверный email или пароль
+	//   - domain.ErrUserInactive / domain.ErrUserBanned — вход запрещен
+	Login(ctx context.Context, input LoginInput) (*AuthTokensOutput, error)
+
+	// Refresh обменивает refresh-токен на новую пару (старый токен отзывается).
+	//
+	// Возвращает:
+	//   - domain.ErrInvalidRefreshToken — токен не найден, истек или уже использован
+	//   - domain.ErrUserInactive / domain.ErrUserBanned — пользователь заблокирован после входа
+	Refresh(ctx context.Context, input RefreshInput) (*AuthTokensOutput, error)
+
+	// Logout отзывает refresh-токен. Идемпотентен: неизвестный или уже
+	// отозванный токен не считается ошибкой.
+	Logout(ctx context.Context, input LogoutInput) error
+}
@@ -0,0 +1,72 @@
+package in
+
+import (
+	"context"
+)
+
+// ListPendingDriversInput — пагинация очереди заявок
+type ListPendingDriversInput struct {
+	Limit  int // по умолчанию 50
+	Offset int
+}
+
+// DriverDocumentDTO — документ водителя в заявке
+type DriverDocumentDTO struct {
+	Kind      string `json:"kind"`
+	Number    string `json:"number"`
+	ExpiresOn string `json:"expires_on"` // YYYY-MM-DD
+}
+
+// PendingDriverDTO — заявка водителя, ожидающая решения
+type PendingDriverDTO struct {
+	ApplicationID string                 `json:"application_id"`
+	DriverID      string                 `json:"driver_id"`
+	Email         string                 `json:"email"`
+	LicenseNumber string                 `json:"license_number"`
+	VehicleType   string                 `json:"vehicle_type"`
+	VehicleAttrs  map[string]interface{} `json:"vehicle_attrs,omitempty"`
+	Documents     []DriverDocumentDTO    `json:"documents"`
+	SubmittedAt   string                 `json:"submitted_at"`
+}
+
+// ListPendingDriversOutput — очередь заявок, старые первыми
+type ListPendingDriversOutput struct {
+	Applications []PendingDriverDTO `json:"applications"`
+	TotalCount   int                `json:"total_count"`
+	Limit        int                `json:"limit"`
+	Offset       int                `json:"offset"`
+}
+
+// ReviewDriverInput — решение администратора по заявке водителя
+type ReviewDriverInput struct {
+	DriverID string
+	AdminID  string // Из JWT администратора
+	Reason   string // Обязательна при отклонении
+}
+
+// ReviewDriverOutput — результат рассмотрения заявки
+type ReviewDriverOutput struct {
+	ApplicationID string `json:"application_id"`
+	DriverID      string `json:"driver_id"`
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+	VerifiedUntil string `json:"verified_until,omitempty"` // YYYY-MM-DD
+	ReviewedAt    string `json:"reviewed_at"`
+}
+
+// DriverVerificationUseCase — проверка водителей администратором
+type DriverVerificationUseCase interface {
+	// ListPending возвращает заявки, ожидающие решения
+	ListPending(ctx context.Context, input ListPendingDriversInput) (*ListPendingDriversOutput, error)
+
+	// Approve одобряет заявку: водитель получает строку drivers с данными заявки и is_verified.
+	//
+	// Возвращает:
+	//   - domain.ErrApplicationNotFound — нет заявки в PENDING
+	//   - domain.ErrDocumentExpired — документ истек, пока заявка ждала решения
+	//   - domain.ErrLicenseNumberTaken — номер прав уже у другого водителя
+	Approve(ctx context.Context, input ReviewDriverInput) (*ReviewDriverOutput, error)
+
+	// Reject отклоняет заявку с обязательной причиной (domain.ErrReasonRequired)
+	Reject(ctx context.Context, input ReviewDriverInput) (*ReviewDriverOutput, error)
+}
@@ -0,0 +1,47 @@
+package in
+
+import (
+	"context"
+	"time"
+)
+
+// GetAnalyticsInput — параметры временных рядов аналитики
+type GetAnalyticsInput struct {
+	From        *time.Time // по умолчанию — To минус окно шага (сутки, 30 дней, 12 недель)
+	To          *time.Time // по умолчанию — сейчас
+	Granularity string     // hour | day | week, по умолчанию day
+	GroupBy     string     // vehicle_type | status | city, пусто — без разбивки
+}
+
+// AnalyticsPointDTO — показатели одной корзины ряда
+type AnalyticsPointDTO struct {
+	Bucket                     string  `json:"bucket"`
+	Rides                      int     `json:"rides"`
+	Completed                  int     `json:"completed"`
+	Cancelled                  int     `json:"cancelled"`
+	Revenue                    float64 `json:"revenue"`
+	AverageWaitTimeMinutes     float64 `json:"average_wait_time_minutes"`
+	AverageRideDurationMinutes float64 `json:"average_ride_duration_minutes"`
+	CancellationRate           float64 `json:"cancellation_rate"`
+}
+
+// AnalyticsSeriesDTO — ряд одной группы; пустые корзины заполнены нулями
+type AnalyticsSeriesDTO struct {
+	Group  string              `json:"group"` // значение разреза или "all"
+	Points []AnalyticsPointDTO `json:"points"`
+}
+
+// GetAnalyticsOutput — временные ряды аналитики
+type GetAnalyticsOutput struct {
+	From           string               `json:"from"` // начало первой корзины
+	To             string               `json:"to"`
+	Granularity    string               `json:"granularity"`
+	GroupBy        string               `json:"group_by,omitempty"`
+	RefreshedUntil *string              `json:"refreshed_until"` // данные роллапов актуальны до этого момента
+	Series         []AnalyticsSeriesDTO `json:"series"`
+}
+
+// GetAnalyticsUseCase — use case для временных рядов аналитики
+type GetAnalyticsUseCase interface {
+	Execute(ctx context.Context, input GetAnalyticsInput) (*GetAnalyticsOutput, error)
+}
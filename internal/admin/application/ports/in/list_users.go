@@ -14,13 +14,15 @@ type ListUsersInput struct {
 
 // UserDTO — DTO пользователя для списка
 type UserDTO struct {
-	UserID    string                 `json:"user_id"`
-	Email     string                 `json:"email"`
-	Role      string                 `json:"role"`
-	Status    string                 `json:"status"`
-	Attrs     map[string]interface{} `json:"attrs,omitempty"`
-	CreatedAt string                 `json:"created_at"`
-	UpdatedAt string                 `json:"updated_at"`
+	UserID      string                 `json:"user_id"`
+	Email       string                 `json:"email"`
+	Role        string                 `json:"role"`
+	Status      string                 `json:"status"`
+	Attrs       map[string]interface{} `json:"attrs,omitempty"`
+	Rating      *float64               `json:"rating,omitempty"` // Нет оценок у пассажира — поле отсутствует
+	RatingCount int                    `json:"rating_count"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
 }
 
 // ListUsersOutput — результат получения списка
@@ -0,0 +1,85 @@
+package in
+
+import (
+	"context"
+	"time"
+)
+
+// GeoPoint — точка на карте дашборда
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// RideEventInput — событие поездки из ride_topic
+type RideEventInput struct {
+	RideID      string
+	EventType   string // RIDE_REQUESTED, DRIVER_MATCHED, RIDE_STARTED, RIDE_COMPLETED, RIDE_CANCELLED, ...
+	Status      string
+	PassengerID string
+	DriverID    string
+	VehicleType string
+	Pickup      *GeoPoint // Есть только у RIDE_REQUESTED
+	OccurredAt  time.Time
+}
+
+// DriverStatusInput — смена статуса водителя из driver_topic
+type DriverStatusInput struct {
+	DriverID string
+	Status   string
+	RideID   string
+}
+
+// DriverLocationInput — локация водителя из location_fanout
+type DriverLocationInput struct {
+	DriverID       string
+	RideID         string
+	Location       GeoPoint
+	SpeedKmh       float64
+	HeadingDegrees float64
+	RecordedAt     time.Time
+}
+
+// RideStatusUpdateDTO — изменение статуса поездки для дашборда
+type RideStatusUpdateDTO struct {
+	RideID      string    `json:"ride_id"`
+	EventType   string    `json:"event_type"`
+	Status      string    `json:"status"`
+	PassengerID string    `json:"passenger_id"`
+	DriverID    string    `json:"driver_id,omitempty"`
+	VehicleType string    `json:"vehicle_type,omitempty"`
+	Location    *GeoPoint `json:"location,omitempty"` // Последняя известная точка поездки (подача или водитель)
+	Timestamp   string    `json:"timestamp"`
+}
+
+// DriverLocationDTO — последняя локация водителя на карте дашборда
+type DriverLocationDTO struct {
+	DriverID       string   `json:"driver_id"`
+	Status         string   `json:"status,omitempty"`
+	RideID         string   `json:"ride_id,omitempty"`
+	Location       GeoPoint `json:"location"`
+	SpeedKmh       float64  `json:"speed_kmh,omitempty"`
+	HeadingDegrees float64  `json:"heading_degrees,omitempty"`
+	UpdatedAt      string   `json:"updated_at"`
+}
+
+// LiveDashboardUseCase — поток событий для живого дашборда администратора
+type LiveDashboardUseCase interface {
+	// Run пересчитывает метрики и рассылает накопленные локации до отмены ctx
+	Run(ctx context.Context)
+
+	// RecordRideEvent сразу рассылает изменение статуса поездки
+	RecordRideEvent(ctx context.Context, input RideEventInput)
+
+	// RecordDriverStatus обновляет статус водителя на карте (OFFLINE убирает его)
+	RecordDriverStatus(ctx context.Context, input DriverStatusInput)
+
+	// RecordDriverLocation запоминает локацию до следующей рассылки
+	RecordDriverLocation(ctx context.Context, input DriverLocationInput)
+
+	// MetricsSnapshot возвращает последние метрики целиком — для только что подключившихся
+	MetricsSnapshot(ctx context.Context) (map[string]interface{}, error)
+
+	// DriverLocations возвращает текущую карту водителей целиком
+	DriverLocations() []DriverLocationDTO
+}
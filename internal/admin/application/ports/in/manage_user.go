@@ -0,0 +1,42 @@
+package in
+
+import (
+	"context"
+)
+
+// UserDetailsOutput — карточка пользователя для администратора
+type UserDetailsOutput struct {
+	UserDTO
+	DeletedAt string `json:"deleted_at,omitempty"` // ISO8601, только для удаленных
+}
+
+// UpdateUserInput — частичное изменение пользователя (PATCH).
+// Поля nil не меняются.
+type UpdateUserInput struct {
+	AdminID string
+	UserID  string
+	Status  *string                // ACTIVE | INACTIVE | BANNED
+	Role    *string                // PASSENGER | DRIVER
+	Attrs   map[string]interface{} // сливается с текущими attrs; ключ со значением null удаляется
+}
+
+// DeleteUserInput — удаление пользователя администратором
+type DeleteUserInput struct {
+	AdminID string
+	UserID  string
+}
+
+// DeleteUserOutput — результат удаления
+type DeleteUserOutput struct {
+	UserID    string `json:"user_id"`
+	Status    string `json:"status"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+// ManageUserUseCase — просмотр, изменение и удаление пользователя.
+// Блокировка, деактивация, смена роли и удаление сразу отзывают сессии пользователя.
+type ManageUserUseCase interface {
+	Get(ctx context.Context, userID string) (*UserDetailsOutput, error)
+	Update(ctx context.Context, input UpdateUserInput) (*UserDetailsOutput, error)
+	Delete(ctx context.Context, input DeleteUserInput) (*DeleteUserOutput, error)
+}
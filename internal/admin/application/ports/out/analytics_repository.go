@@ -0,0 +1,37 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/admin/domain"
+)
+
+// AnalyticsRepository — роллапы поездок (ride_stats_hourly, ride_stats_daily)
+type AnalyticsRepository interface {
+	// RefreshRollups пересчитывает корзины поездок, измененных с прошлого прогона
+	// (минус overlap) до until. Город корзины — rides.city, зафиксированный при заказе.
+	RefreshRollups(ctx context.Context, until time.Time, overlap time.Duration) (*RollupRefresh, error)
+
+	// QueryRideStats возвращает агрегаты по корзинам шага и группам разреза, упорядоченные по корзине
+	QueryRideStats(ctx context.Context, query AnalyticsQuery) ([]domain.RideStatsRow, error)
+
+	// RefreshedUntil возвращает момент, до которого роллапы актуальны (nil — еще не считались)
+	RefreshedUntil(ctx context.Context) (*time.Time, error)
+}
+
+// RollupRefresh — итог одного пересчета роллапов
+type RollupRefresh struct {
+	Since *time.Time // nil — первый прогон, пересчитана вся история
+	Until time.Time
+	Hours int // Пересчитано часовых корзин
+	Days  int // Пересчитано дневных корзин
+}
+
+// AnalyticsQuery — выборка из роллапов: корзины с началом в [From, To)
+type AnalyticsQuery struct {
+	From        time.Time // Уже выровнено по началу корзины
+	To          time.Time
+	Granularity string // domain.GranularityHour | GranularityDay | GranularityWeek
+	GroupBy     string // "" | domain.GroupByVehicleType | GroupByStatus | GroupByCity
+}
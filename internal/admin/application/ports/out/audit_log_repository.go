@@ -0,0 +1,27 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/admin/domain"
+)
+
+// AuditLogRepository — журнал действий администраторов (только добавление)
+type AuditLogRepository interface {
+	// Append добавляет запись; ID и CreatedAt заполняются из БД
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+
+	// List возвращает записи по фильтрам (новые первыми) и их общее число
+	List(ctx context.Context, filters AuditFilters) ([]*domain.AuditEntry, int, error)
+}
+
+// AuditFilters — фильтры журнала; пустые поля не ограничивают выборку
+type AuditFilters struct {
+	ActorID  string
+	TargetID string
+	From     *time.Time // включительно
+	To       *time.Time // не включительно
+	Limit    int
+	Offset   int
+}
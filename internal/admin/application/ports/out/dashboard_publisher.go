@@ -0,0 +1,20 @@
+package out
+
+import (
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+)
+
+// DashboardPublisher — рассылка обновлений подключенным к дашборду администраторам.
+// Реализация сама решает, кому из клиентов что отправить (подписка на область или поездку).
+type DashboardPublisher interface {
+	// PublishMetricsDelta рассылает только изменившиеся метрики
+	PublishMetricsDelta(changes map[string]interface{}, at time.Time)
+
+	// PublishRideStatus рассылает изменение статуса поездки
+	PublishRideStatus(update in.RideStatusUpdateDTO)
+
+	// PublishDriverLocations рассылает обновившиеся локации и водителей, ушедших с карты
+	PublishDriverLocations(drivers []in.DriverLocationDTO, removed []string, at time.Time)
+}
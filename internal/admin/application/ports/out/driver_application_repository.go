@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/admin/domain"
+)
+
+// DriverApplicationRepository — заявки водителей на проверку
+type DriverApplicationRepository interface {
+	// ListPending возвращает заявки в PENDING (старые первыми) и их общее число
+	ListPending(ctx context.Context, limit, offset int) ([]*domain.DriverApplication, int, error)
+
+	// Approve в одной транзакции одобряет заявку водителя и переносит ее данные в drivers
+	// (is_verified = true, verified_until = самый ранний срок документов).
+	// Документ, истекший раньше today, — domain.ErrDocumentExpired.
+	Approve(ctx context.Context, driverID, adminID, reason string, today, at time.Time) (*domain.ApplicationDecision, error)
+
+	// Reject отклоняет заявку водителя (conditional UPDATE по status = PENDING)
+	Reject(ctx context.Context, driverID, adminID, reason string, at time.Time) (*domain.ApplicationDecision, error)
+}
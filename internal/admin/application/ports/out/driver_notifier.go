@@ -0,0 +1,13 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/shared/contract"
+)
+
+// DriverNotifier — доставка водителю решения по заявке (через Driver Service)
+type DriverNotifier interface {
+	// PublishVerification публикует решение в driver_topic
+	PublishVerification(ctx context.Context, msg *contract.DriverVerification) error
+}
@@ -0,0 +1,24 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/admin/domain"
+)
+
+// RefreshTokenRepository — хранилище refresh-токенов
+type RefreshTokenRepository interface {
+	// Create сохраняет новый refresh-токен
+	Create(ctx context.Context, token *domain.RefreshToken) error
+
+	// FindByHash находит токен по хешу (в том числе отозванный и истекший).
+	// Возвращает domain.ErrRefreshTokenNotFound, если токена нет.
+	FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+
+	// Revoke отзывает токен. Conditional UPDATE: возвращает
+	// domain.ErrRefreshTokenRevoked, если токен уже отозван (конкурентная ротация).
+	Revoke(ctx context.Context, tokenID string) error
+
+	// RevokeAllForUser отзывает все активные токены пользователя
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
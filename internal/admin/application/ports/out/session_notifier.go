@@ -0,0 +1,13 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/shared/contract"
+)
+
+// SessionNotifier — рассылка отзыва сессий сервисам с WebSocket (Ride, Driver)
+type SessionNotifier interface {
+	// PublishSessionsRevoked публикует отзыв сессий в user_topic
+	PublishSessionsRevoked(ctx context.Context, msg *contract.UserSessionsRevoked) error
+}
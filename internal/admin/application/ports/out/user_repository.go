@@ -2,6 +2,7 @@ package out
 
 import (
 	"context"
+	"time"
 
 	"ridehail/internal/admin/application/ports/in"
 	"ridehail/internal/admin/domain"
@@ -12,20 +13,24 @@ type UserRepository interface {
 	// Create создает нового пользователя
 	Create(ctx context.Context, user *domain.User) error
 
-	// FindByID находит пользователя по ID
+	// FindByID находит пользователя по ID (в том числе удаленного — см. User.DeletedAt)
 	FindByID(ctx context.Context, userID string) (*domain.User, error)
 
-	// FindByEmail находит пользователя по email
+	// FindByEmail находит пользователя по email (удаленные не находятся)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 
 	// List возвращает список пользователей с фильтрами
 	List(ctx context.Context, filters ListUsersFilters) ([]*domain.User, int, error)
 
-	// Update обновляет пользователя
+	// Update обновляет пользователя; удаленного — ErrUserNotFound
 	Update(ctx context.Context, user *domain.User) error
 
-	// Delete удаляет пользователя (soft delete через status=BANNED или hard delete)
-	Delete(ctx context.Context, userID string) error
+	// Delete удаляет пользователя (soft delete: status=INACTIVE, deleted_at=at)
+	Delete(ctx context.Context, userID string, at time.Time) error
+
+	// HasActiveRides проверяет, есть ли у пользователя незавершенные поездки
+	// (пассажиром или водителем, включая запланированные)
+	HasActiveRides(ctx context.Context, userID string) (bool, error)
 
 	// GetSystemMetrics получает метрики системы для admin dashboard
 	GetSystemMetrics(ctx context.Context) (*in.SystemMetrics, error)
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/shared/config"
+	"ridehail/internal/shared/logger"
+)
+
+// AnalyticsRollupWorker поддерживает роллапы аналитики (ride_stats_hourly, ride_stats_daily).
+//
+// ЦИКЛ (раз в interval):
+//  1. Находит поездки, у которых updated_at сдвинулся с прошлого прогона.
+//     Окно начинается на overlap раньше: транзакция, начатая до прошлого прогона,
+//     могла закоммититься после него
+//  2. Пересчитывает целиком часовые корзины этих поездок (по requested_at),
+//     затем дневные корзины из часовых
+//  3. Запоминает момент прогона в analytics_rollup_state
+//
+// Первый прогон пересчитывает всю историю.
+type AnalyticsRollupWorker struct {
+	analyticsRepo out.AnalyticsRepository
+	interval      time.Duration
+	overlap       time.Duration
+	log           *logger.Logger
+}
+
+// NewAnalyticsRollupWorker создает воркер роллапов
+func NewAnalyticsRollupWorker(analyticsRepo out.AnalyticsRepository, cfg config.AnalyticsConfig, log *logger.Logger) *AnalyticsRollupWorker {
+	return &AnalyticsRollupWorker{
+		analyticsRepo: analyticsRepo,
+		interval:      time.Duration(cfg.RollupIntervalSeconds) * time.Second,
+		overlap:       time.Duration(cfg.RollupOverlapSeconds) * time.Second,
+		log:           log,
+	}
+}
+
+// Run запускает цикл до отмены контекста (блокирующий).
+// Первый прогон — сразу при старте, чтобы аналитика не ждала целый интервал.
+func (w *AnalyticsRollupWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		w.log.Info(logger.Entry{Action: "analytics_rollup_worker_disabled", Message: "rollup_interval_seconds = 0"})
+		return
+	}
+
+	w.log.Info(logger.Entry{
+		Action:  "analytics_rollup_worker_started",
+		Message: fmt.Sprintf("interval %s, overlap %s", w.interval, w.overlap),
+	})
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			w.log.Info(logger.Entry{Action: "analytics_rollup_worker_stopped", Message: "context cancelled"})
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick пересчитывает роллапы до now
+func (w *AnalyticsRollupWorker) tick(ctx context.Context, now time.Time) {
+	started := time.Now()
+	result, err := w.analyticsRepo.RefreshRollups(ctx, now, w.overlap)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		w.log.Error(logger.Entry{
+			Action:  "analytics_rollup_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return
+	}
+
+	additional := map[string]interface{}{
+		"hours":       result.Hours,
+		"days":        result.Days,
+		"until":       result.Until.Format(time.RFC3339),
+		"duration_ms": time.Since(started).Milliseconds(),
+	}
+	if result.Since != nil {
+		additional["since"] = result.Since.Format(time.RFC3339)
+	}
+
+	entry := logger.Entry{
+		Action:     "analytics_rollup_refreshed",
+		Message:    fmt.Sprintf("rebuilt %d hourly and %d daily buckets", result.Hours, result.Days),
+		Additional: additional,
+	}
+	if result.Hours == 0 {
+		w.log.Debug(entry)
+		return
+	}
+	w.log.Info(entry)
+}
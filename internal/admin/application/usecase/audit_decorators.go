@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/logger"
+)
+
+// ============================================================================
+// Аудит действий администратора
+// ============================================================================
+// Декораторы оборачивают use cases, меняющие данные: действие и запись
+// в admin_audit_log (кто — RequestMeta из контекста, что, над кем, состояние
+// до/после и разница) выполняются в одной транзакции. Не записался журнал —
+// откатывается и действие: изменений без записи в журнале не бывает.
+// Уведомления use cases уходят после коммита (TxManager.AfterCommit).
+// Неудачные попытки не пишутся — они есть в логах. Чтение (списки, карточки,
+// метрики) не аудируется.
+// ============================================================================
+
+// AuditRecorder пишет записи журнала для декораторов
+type AuditRecorder struct {
+	repo      out.AuditLogRepository
+	txManager out.TxManager
+	log       *logger.Logger
+}
+
+// NewAuditRecorder создает writer журнала действий
+func NewAuditRecorder(repo out.AuditLogRepository, txManager out.TxManager, log *logger.Logger) *AuditRecorder {
+	return &AuditRecorder{
+		repo:      repo,
+		txManager: txManager,
+		log:       log,
+	}
+}
+
+// withinTx выполняет действие декоратора и запись журнала в одной транзакции
+func (r *AuditRecorder) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.txManager.WithinTx(ctx, fn)
+}
+
+// record сохраняет запись в транзакции действия. Ошибка возвращается: декоратор
+// откатывает действие, клиент получает ошибку вместо неаудированного изменения.
+func (r *AuditRecorder) record(ctx context.Context, action, targetType, targetID string, before, after interface{}) error {
+	meta := in.RequestMetaFrom(ctx)
+	entry := &domain.AuditEntry{
+		ActorID:    meta.ActorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     snapshot(before),
+		After:      snapshot(after),
+		IP:         meta.IP,
+		RequestID:  meta.RequestID,
+	}
+	entry.Diff = domain.AuditDiff(entry.Before, entry.After)
+
+	if err := r.repo.Append(ctx, entry); err != nil {
+		r.log.Error(logger.Entry{
+			Action:  "admin_audit_append_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]interface{}{
+				"actor_id":   entry.ActorID,
+				"action":     entry.Action,
+				"target_id":  entry.TargetID,
+				"diff":       entry.Diff,
+				"request_id": entry.RequestID,
+			},
+		})
+		return fmt.Errorf("append audit entry: %w", err)
+	}
+	return nil
+}
+
+// snapshot переводит DTO в JSON-объект в том виде, в каком его видит клиент API
+func snapshot(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// AuditedCreateUser — CreateUserUseCase с записью в журнал
+type AuditedCreateUser struct {
+	next  in.CreateUserUseCase
+	audit *AuditRecorder
+}
+
+// NewAuditedCreateUser оборачивает создание пользователя аудитом
+func NewAuditedCreateUser(next in.CreateUserUseCase, audit *AuditRecorder) *AuditedCreateUser {
+	return &AuditedCreateUser{next: next, audit: audit}
+}
+
+// Execute создает пользователя и пишет user.create
+func (d *AuditedCreateUser) Execute(ctx context.Context, input in.CreateUserInput) (*in.CreateUserOutput, error) {
+	var output *in.CreateUserOutput
+	err := d.audit.withinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if output, err = d.next.Execute(ctx, input); err != nil {
+			return err
+		}
+		return d.audit.record(ctx, domain.AuditUserCreate, domain.AuditTargetUser, output.UserID, nil, output)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// AuditedManageUser — ManageUserUseCase с записью изменений и удалений в журнал
+type AuditedManageUser struct {
+	next  in.ManageUserUseCase
+	audit *AuditRecorder
+}
+
+// NewAuditedManageUser оборачивает управление пользователями аудитом
+func NewAuditedManageUser(next in.ManageUserUseCase, audit *AuditRecorder) *AuditedManageUser {
+	return &AuditedManageUser{next: next, audit: audit}
+}
+
+// Get не аудируется
+func (d *AuditedManageUser) Get(ctx context.Context, userID string) (*in.UserDetailsOutput, error) {
+	return d.next.Get(ctx, userID)
+}
+
+// Update меняет пользователя и пишет user.update с карточками до и после
+func (d *AuditedManageUser) Update(ctx context.Context, input in.UpdateUserInput) (*in.UserDetailsOutput, error) {
+	var output *in.UserDetailsOutput
+	err := d.audit.withinTx(ctx, func(ctx context.Context) error {
+		before, err := d.next.Get(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		if output, err = d.next.Update(ctx, input); err != nil {
+			return err
+		}
+
+		return d.audit.record(ctx, domain.AuditUserUpdate, domain.AuditTargetUser, input.UserID, before, output)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Delete удаляет пользователя и пишет user.delete с карточками до и после
+func (d *AuditedManageUser) Delete(ctx context.Context, input in.DeleteUserInput) (*in.DeleteUserOutput, error) {
+	var output *in.DeleteUserOutput
+	err := d.audit.withinTx(ctx, func(ctx context.Context) error {
+		before, err := d.next.Get(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		if output, err = d.next.Delete(ctx, input); err != nil {
+			return err
+		}
+
+		// Карточка после удаления (статус, deleted_at) — из той же транзакции
+		after, err := d.next.Get(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		return d.audit.record(ctx, domain.AuditUserDelete, domain.AuditTargetUser, input.UserID, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// AuditedDriverVerification — DriverVerificationUseCase с записью решений в журнал
+type AuditedDriverVerification struct {
+	next  in.DriverVerificationUseCase
+	audit *AuditRecorder
+}
+
+// NewAuditedDriverVerification оборачивает проверку водителей аудитом
+func NewAuditedDriverVerification(next in.DriverVerificationUseCase, audit *AuditRecorder) *AuditedDriverVerification {
+	return &AuditedDriverVerification{next: next, audit: audit}
+}
+
+// ListPending не аудируется
+func (d *AuditedDriverVerification) ListPending(ctx context.Context, input in.ListPendingDriversInput) (*in.ListPendingDriversOutput, error) {
+	return d.next.ListPending(ctx, input)
+}
+
+// Approve одобряет заявку и пишет driver.approve
+func (d *AuditedDriverVerification) Approve(ctx context.Context, input in.ReviewDriverInput) (*in.ReviewDriverOutput, error) {
+	var output *in.ReviewDriverOutput
+	err := d.audit.withinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if output, err = d.next.Approve(ctx, input); err != nil {
+			return err
+		}
+		return d.audit.record(ctx, domain.AuditDriverApprove, domain.AuditTargetDriver, input.DriverID, pendingApplication(output), output)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Reject отклоняет заявку и пишет driver.reject
+func (d *AuditedDriverVerification) Reject(ctx context.Context, input in.ReviewDriverInput) (*in.ReviewDriverOutput, error) {
+	var output *in.ReviewDriverOutput
+	err := d.audit.withinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if output, err = d.next.Reject(ctx, input); err != nil {
+			return err
+		}
+		return d.audit.record(ctx, domain.AuditDriverReject, domain.AuditTargetDriver, input.DriverID, pendingApplication(output), output)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// pendingApplication — состояние заявки до решения: решение принимается только по PENDING
+func pendingApplication(decision *in.ReviewDriverOutput) map[string]interface{} {
+	return map[string]interface{}{
+		"application_id": decision.ApplicationID,
+		"driver_id":      decision.DriverID,
+		"status":         domain.ApplicationPending,
+	}
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/auth"
+	"ridehail/internal/shared/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash сверяется, когда email не найден, чтобы время ответа
+// не выдавало, зарегистрирован ли email
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// AuthService реализует AuthUseCase.
+//
+// Access-токен — короткоживущий JWT, refresh-токен — непрозрачная строка,
+// хеш которой хранится в refresh_tokens. Refresh ротируется при каждом
+// использовании; повторное предъявление уже отозванного токена считается
+// утечкой и отзывает все токены пользователя.
+type AuthService struct {
+	userRepo    out.UserRepository
+	refreshRepo out.RefreshTokenRepository
+	jwtService  *auth.JWTService
+	log         *logger.Logger
+}
+
+// NewAuthService создает новый сервис аутентификации
+func NewAuthService(
+	userRepo out.UserRepository,
+	refreshRepo out.RefreshTokenRepository,
+	jwtService *auth.JWTService,
+	log *logger.Logger,
+) *AuthService {
+	return &AuthService{
+		userRepo:    userRepo,
+		refreshRepo: refreshRepo,
+		jwtService:  jwtService,
+		log:         log,
+	}
+}
+
+// Login проверяет email и пароль и выдает пару токенов
+func (s *AuthService) Login(ctx context.Context, input in.LoginInput) (*in.AuthTokensOutput, error) {
+	email := strings.TrimSpace(input.Email)
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(input.Password))
+		s.log.Warn(logger.Entry{
+			Action:  "login_failed",
+			Message: "unknown email",
+		})
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "login_failed",
+			Message: "wrong password",
+			Additional: map[string]interface{}{
+				"user_id": user.ID,
+			},
+		})
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err := checkUserStatus(user); err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "login_rejected",
+			Message: err.Error(),
+			Additional: map[string]interface{}{
+				"user_id": user.ID,
+				"status":  user.Status,
+			},
+		})
+		return nil, err
+	}
+
+	output, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "user_logged_in",
+		Message: user.Email,
+		Additional: map[string]interface{}{
+			"user_id": user.ID,
+			"role":    user.Role,
+		},
+	})
+
+	return output, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару
+func (s *AuthService) Refresh(ctx context.Context, input in.RefreshInput) (*in.AuthTokensOutput, error) {
+	token, err := s.refreshRepo.FindByHash(ctx, auth.HashRefreshToken(input.RefreshToken))
+	if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find refresh token: %w", err)
+	}
+
+	if token.IsRevoked() {
+		s.revokeAll(ctx, token.UserID, "refresh_token_reused")
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if token.IsExpired(time.Now().UTC()) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// Ротация: старый токен одноразовый. Проигравший гонку запрос получает отказ.
+	if err := s.refreshRepo.Revoke(ctx, token.ID); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenRevoked) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("revoke refresh token: %w", err)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	// Пользователя могли заблокировать после входа
+	if err := checkUserStatus(user); err != nil {
+		s.revokeAll(ctx, user.ID, "refresh_rejected_user_status")
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// Logout отзывает refresh-токен
+func (s *AuthService) Logout(ctx context.Context, input in.LogoutInput) error {
+	token, err := s.refreshRepo.FindByHash(ctx, auth.HashRefreshToken(input.RefreshToken))
+	if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find refresh token: %w", err)
+	}
+
+	if err := s.refreshRepo.Revoke(ctx, token.ID); err != nil && !errors.Is(err, domain.ErrRefreshTokenRevoked) {
+		return fmt.Errorf("revoke refresh token: %w", err)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "user_logged_out",
+		Message: token.UserID,
+		Additional: map[string]interface{}{
+			"user_id": token.UserID,
+		},
+	})
+
+	return nil
+}
+
+// issueTokens выпускает access JWT и сохраняет новый refresh-токен
+func (s *AuthService) issueTokens(ctx context.Context, user *domain.User) (*in.AuthTokensOutput, error) {
+	accessToken, err := s.jwtService.GenerateToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("generate access token: %w", err)
+	}
+
+	refreshToken, refreshHash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	record := &domain.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TokenHash: refreshHash,
+		ExpiresAt: now.Add(s.jwtService.RefreshTokenTTL()),
+		CreatedAt: now,
+	}
+	if err := s.refreshRepo.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("save refresh token: %w", err)
+	}
+
+	return &in.AuthTokensOutput{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(s.jwtService.AccessTokenTTL().Seconds()),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: record.ExpiresAt.Format(time.RFC3339),
+		UserID:                user.ID,
+		Role:                  user.Role,
+	}, nil
+}
+
+// revokeAll отзывает все refresh-токены пользователя (ошибка только логируется)
+func (s *AuthService) revokeAll(ctx context.Context, userID, reason string) {
+	s.log.Warn(logger.Entry{
+		Action:  "refresh_tokens_revoked",
+		Message: reason,
+		Additional: map[string]interface{}{
+			"user_id": userID,
+		},
+	})
+
+	if err := s.refreshRepo.RevokeAllForUser(ctx, userID); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "revoke_refresh_tokens_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]interface{}{
+				"user_id": userID,
+			},
+		})
+	}
+}
+
+// checkUserStatus запрещает вход заблокированным и деактивированным пользователям
+func checkUserStatus(user *domain.User) error {
+	switch user.Status {
+	case domain.StatusBanned:
+		return domain.ErrUserBanned
+	case domain.StatusInactive:
+		return domain.ErrUserInactive
+	default:
+		return nil
+	}
+}
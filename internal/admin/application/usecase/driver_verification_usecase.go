@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+)
+
+// DriverVerificationService реализует DriverVerificationUseCase
+type DriverVerificationService struct {
+	appRepo   out.DriverApplicationRepository
+	notifier  out.DriverNotifier
+	txManager out.TxManager
+	log       *logger.Logger
+}
+
+// NewDriverVerificationService создает сервис проверки водителей
+func NewDriverVerificationService(appRepo out.DriverApplicationRepository, notifier out.DriverNotifier, txManager out.TxManager, log *logger.Logger) *DriverVerificationService {
+	return &DriverVerificationService{
+		appRepo:   appRepo,
+		notifier:  notifier,
+		txManager: txManager,
+		log:       log,
+	}
+}
+
+// ListPending возвращает очередь заявок на проверку
+func (s *DriverVerificationService) ListPending(ctx context.Context, input in.ListPendingDriversInput) (*in.ListPendingDriversOutput, error) {
+	limit := input.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	apps, totalCount, err := s.appRepo.ListPending(ctx, limit, input.Offset)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "list_pending_drivers_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, err
+	}
+
+	dtos := make([]in.PendingDriverDTO, 0, len(apps))
+	for _, app := range apps {
+		documents := make([]in.DriverDocumentDTO, 0, len(app.Documents))
+		for _, doc := range app.Documents {
+			documents = append(documents, in.DriverDocumentDTO{
+				Kind:      doc.Kind,
+				Number:    doc.Number,
+				ExpiresOn: doc.ExpiresOn.Format(domain.DateLayout),
+			})
+		}
+		dtos = append(dtos, in.PendingDriverDTO{
+			ApplicationID: app.ID,
+			DriverID:      app.DriverID,
+			Email:         app.Email,
+			LicenseNumber: app.LicenseNumber,
+			VehicleType:   app.VehicleType,
+			VehicleAttrs:  app.VehicleAttrs,
+			Documents:     documents,
+			SubmittedAt:   app.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &in.ListPendingDriversOutput{
+		Applications: dtos,
+		TotalCount:   totalCount,
+		Limit:        limit,
+		Offset:       input.Offset,
+	}, nil
+}
+
+// Approve одобряет заявку водителя и уведомляет его
+func (s *DriverVerificationService) Approve(ctx context.Context, input in.ReviewDriverInput) (*in.ReviewDriverOutput, error) {
+	now := time.Now().UTC()
+	reason := strings.TrimSpace(input.Reason)
+
+	decision, err := s.appRepo.Approve(ctx, input.DriverID, input.AdminID, reason, now.Truncate(24*time.Hour), now)
+	if err != nil {
+		return nil, fmt.Errorf("approve driver application: %w", err)
+	}
+
+	return s.finish(ctx, decision), nil
+}
+
+// Reject отклоняет заявку водителя с причиной и уведомляет его
+func (s *DriverVerificationService) Reject(ctx context.Context, input in.ReviewDriverInput) (*in.ReviewDriverOutput, error) {
+	// Водитель должен понимать, что исправить в следующей заявке
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, domain.ErrReasonRequired
+	}
+
+	decision, err := s.appRepo.Reject(ctx, input.DriverID, input.AdminID, reason, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("reject driver application: %w", err)
+	}
+
+	return s.finish(ctx, decision), nil
+}
+
+// finish логирует решение, публикует его водителю и формирует ответ.
+// Ошибка публикации не откатывает решение: водитель увидит его в GET /drivers/{id}/application.
+func (s *DriverVerificationService) finish(ctx context.Context, decision *domain.ApplicationDecision) *in.ReviewDriverOutput {
+	output := &in.ReviewDriverOutput{
+		ApplicationID: decision.ApplicationID,
+		DriverID:      decision.DriverID,
+		Status:        decision.Status,
+		Reason:        decision.Reason,
+		ReviewedAt:    decision.ReviewedAt.Format(time.RFC3339),
+	}
+	if decision.VerifiedUntil != nil {
+		output.VerifiedUntil = decision.VerifiedUntil.Format(domain.DateLayout)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "driver_application_reviewed",
+		Message: fmt.Sprintf("driver %s %s", decision.DriverID, decision.Status),
+		Additional: map[string]interface{}{
+			"application_id": decision.ApplicationID,
+			"admin_id":       decision.ReviewedBy,
+			"reason":         decision.Reason,
+		},
+	})
+
+	// Driver Service читает drivers.verified_until — решение уходит после коммита
+	verification := &contract.DriverVerification{
+		DriverID:      decision.DriverID,
+		ApplicationID: decision.ApplicationID,
+		Status:        decision.Status,
+		Reason:        decision.Reason,
+		VerifiedUntil: output.VerifiedUntil,
+		DecidedAt:     output.ReviewedAt,
+	}
+	s.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.notifier.PublishVerification(ctx, verification); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "publish_driver_verification_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]interface{}{
+					"driver_id": verification.DriverID,
+				},
+			})
+		}
+	})
+
+	return output
+}
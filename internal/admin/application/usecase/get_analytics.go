@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/logger"
+)
+
+// allGroup — имя единственного ряда, когда разрез не задан
+const allGroup = "all"
+
+// defaultAnalyticsWindow — окно по умолчанию, если from не передан
+var defaultAnalyticsWindow = map[string]time.Duration{
+	domain.GranularityHour: 24 * time.Hour,
+	domain.GranularityDay:  30 * 24 * time.Hour,
+	domain.GranularityWeek: 12 * 7 * 24 * time.Hour,
+}
+
+// GetAnalyticsService реализует GetAnalyticsUseCase.
+// Читает только роллапы: свежесть данных — refreshed_until в ответе.
+type GetAnalyticsService struct {
+	analyticsRepo out.AnalyticsRepository
+	log           *logger.Logger
+}
+
+// NewGetAnalyticsService создает сервис аналитики
+func NewGetAnalyticsService(analyticsRepo out.AnalyticsRepository, log *logger.Logger) *GetAnalyticsService {
+	return &GetAnalyticsService{
+		analyticsRepo: analyticsRepo,
+		log:           log,
+	}
+}
+
+// Execute возвращает временные ряды по корзинам, начинающимся в [from, to)
+func (s *GetAnalyticsService) Execute(ctx context.Context, input in.GetAnalyticsInput) (*in.GetAnalyticsOutput, error) {
+	granularity := input.Granularity
+	if granularity == "" {
+		granularity = domain.GranularityDay
+	}
+	if !domain.ValidGranularity(granularity) {
+		return nil, domain.ErrInvalidGranularity
+	}
+	if !domain.ValidGroupBy(input.GroupBy) {
+		return nil, domain.ErrInvalidGroupBy
+	}
+
+	to := time.Now().UTC()
+	if input.To != nil {
+		to = input.To.UTC()
+	}
+	from := to.Add(-defaultAnalyticsWindow[granularity])
+	if input.From != nil {
+		from = input.From.UTC()
+	}
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	// Корзины ряда: от начала корзины с from до последней, начавшейся раньше to
+	start := domain.TruncateBucket(from, granularity)
+	var buckets []time.Time
+	for b := start; b.Before(to); b = domain.NextBucket(b, granularity) {
+		if len(buckets) == domain.MaxBuckets(granularity) {
+			return nil, domain.ErrTimeRangeTooLarge
+		}
+		buckets = append(buckets, b)
+	}
+
+	rows, err := s.analyticsRepo.QueryRideStats(ctx, out.AnalyticsQuery{
+		From:        start,
+		To:          to,
+		Granularity: granularity,
+		GroupBy:     input.GroupBy,
+	})
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "get_analytics_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, fmt.Errorf("query ride stats: %w", err)
+	}
+
+	refreshedUntil, err := s.analyticsRepo.RefreshedUntil(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get rollup freshness: %w", err)
+	}
+
+	output := &in.GetAnalyticsOutput{
+		From:        start.Format(time.RFC3339),
+		To:          to.Format(time.RFC3339),
+		Granularity: granularity,
+		GroupBy:     input.GroupBy,
+		Series:      buildSeries(rows, buckets, input.GroupBy),
+	}
+	if refreshedUntil != nil {
+		formatted := refreshedUntil.UTC().Format(time.RFC3339)
+		output.RefreshedUntil = &formatted
+	}
+
+	return output, nil
+}
+
+// buildSeries раскладывает строки роллапа по рядам групп и заполняет пустые корзины нулями.
+// Без разреза ряд "all" есть всегда; с разрезом — только группы, у которых были поездки.
+func buildSeries(rows []domain.RideStatsRow, buckets []time.Time, groupBy string) []in.AnalyticsSeriesDTO {
+	byGroup := make(map[string]map[time.Time]domain.RideStatsRow)
+	if groupBy == "" {
+		byGroup[allGroup] = make(map[time.Time]domain.RideStatsRow)
+	}
+	for _, row := range rows {
+		group := row.Group
+		if groupBy == "" {
+			group = allGroup
+		}
+		if byGroup[group] == nil {
+			byGroup[group] = make(map[time.Time]domain.RideStatsRow)
+		}
+		byGroup[group][row.Bucket.UTC()] = row
+	}
+
+	groups := make([]string, 0, len(byGroup))
+	for group := range byGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	series := make([]in.AnalyticsSeriesDTO, 0, len(groups))
+	for _, group := range groups {
+		points := make([]in.AnalyticsPointDTO, 0, len(buckets))
+		for _, bucket := range buckets {
+			row := byGroup[group][bucket]
+			points = append(points, in.AnalyticsPointDTO{
+				Bucket:                     bucket.Format(time.RFC3339),
+				Rides:                      row.Rides,
+				Completed:                  row.Completed,
+				Cancelled:                  row.Cancelled,
+				Revenue:                    row.Revenue,
+				AverageWaitTimeMinutes:     row.AverageWaitMinutes(),
+				AverageRideDurationMinutes: row.AverageDurationMinutes(),
+				CancellationRate:           row.CancellationRate(),
+			})
+		}
+		series = append(series, in.AnalyticsSeriesDTO{Group: group, Points: points})
+	}
+	return series
+}
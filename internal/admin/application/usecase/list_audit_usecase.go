@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/shared/logger"
+)
+
+// ListAuditService реализует ListAuditUseCase
+type ListAuditService struct {
+	auditRepo out.AuditLogRepository
+	log       *logger.Logger
+}
+
+// NewListAuditService создает сервис чтения журнала действий
+func NewListAuditService(auditRepo out.AuditLogRepository, log *logger.Logger) *ListAuditService {
+	return &ListAuditService{
+		auditRepo: auditRepo,
+		log:       log,
+	}
+}
+
+// Execute возвращает страницу журнала, новые записи первыми
+func (s *ListAuditService) Execute(ctx context.Context, input in.ListAuditInput) (*in.ListAuditOutput, error) {
+	limit := input.Limit
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	entries, totalCount, err := s.auditRepo.List(ctx, out.AuditFilters{
+		ActorID:  input.ActorID,
+		TargetID: input.TargetID,
+		From:     input.From,
+		To:       input.To,
+		Limit:    limit,
+		Offset:   input.Offset,
+	})
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "list_audit_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, err
+	}
+
+	dtos := make([]in.AuditEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		diff := make(map[string]in.AuditChangeDTO, len(e.Diff))
+		for k, c := range e.Diff {
+			diff[k] = in.AuditChangeDTO{From: c.From, To: c.To}
+		}
+		dtos = append(dtos, in.AuditEntryDTO{
+			ID:         e.ID,
+			ActorID:    e.ActorID,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetID:   e.TargetID,
+			Before:     e.Before,
+			After:      e.After,
+			Diff:       diff,
+			IP:         e.IP,
+			RequestID:  e.RequestID,
+			CreatedAt:  e.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return &in.ListAuditOutput{
+		Entries:    dtos,
+		TotalCount: totalCount,
+		Limit:      limit,
+		Offset:     input.Offset,
+	}, nil
+}
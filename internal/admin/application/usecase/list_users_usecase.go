@@ -53,13 +53,15 @@ func (s *ListUsersService) Execute(ctx context.Context, input in.ListUsersInput)
 	userDTOs := make([]in.UserDTO, 0, len(users))
 	for _, user := range users {
 		userDTOs = append(userDTOs, in.UserDTO{
-			UserID:    user.ID,
-			Email:     user.Email,
-			Role:      user.Role,
-			Status:    user.Status,
-			Attrs:     user.Attrs,
-			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UserID:      user.ID,
+			Email:       user.Email,
+			Role:        user.Role,
+			Status:      user.Status,
+			Attrs:       user.Attrs,
+			Rating:      user.Rating,
+			RatingCount: user.RatingCount,
+			CreatedAt:   user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:   user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
 
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+const (
+	// driverStatusOffline — статус из driver_topic, после которого водитель пропадает с карты
+	driverStatusOffline = "OFFLINE"
+
+	// rideStaleAfter — точка поездки без событий дольше этого срока забывается
+	// (поездка могла завершиться, пока Admin Service не слушал ride_topic)
+	rideStaleAfter = 12 * time.Hour
+)
+
+// trackedDriver — водитель на карте дашборда
+type trackedDriver struct {
+	dto     in.DriverLocationDTO
+	located bool // Локация уже приходила; до этого водителя нечего показывать
+	seenAt  time.Time
+}
+
+// trackedRide — последняя известная точка поездки: подача, затем локация водителя
+type trackedRide struct {
+	location in.GeoPoint
+	seenAt   time.Time
+}
+
+// LiveDashboardService реализует LiveDashboardUseCase.
+//
+// ПОТОКИ ДАННЫХ:
+//   - ride_topic → RecordRideEvent → сразу PublishRideStatus (событий немного)
+//   - location_fanout → RecordDriverLocation → копится, раз в flushInterval
+//     PublishDriverLocations только с изменившимися водителями
+//   - раз в metricsInterval GetSystemMetrics → PublishMetricsDelta с изменившимися полями
+type LiveDashboardService struct {
+	userRepo        out.UserRepository
+	publisher       out.DashboardPublisher
+	metricsInterval time.Duration
+	flushInterval   time.Duration
+	driverStale     time.Duration
+	log             *logger.Logger
+
+	mu      sync.Mutex
+	metrics map[string]interface{} // Последние разосланные метрики
+	drivers map[string]*trackedDriver
+	dirty   map[string]struct{} // Водители, изменившиеся с прошлой рассылки
+	removed map[string]struct{} // Водители, ушедшие с карты с прошлой рассылки
+	rides   map[string]trackedRide
+}
+
+// NewLiveDashboardService создает сервис живого дашборда
+func NewLiveDashboardService(userRepo out.UserRepository, publisher out.DashboardPublisher, cfg config.DashboardConfig, log *logger.Logger) *LiveDashboardService {
+	flushInterval := time.Duration(cfg.LocationFlushMillis) * time.Millisecond
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	return &LiveDashboardService{
+		userRepo:        userRepo,
+		publisher:       publisher,
+		metricsInterval: time.Duration(cfg.MetricsIntervalSeconds) * time.Second,
+		flushInterval:   flushInterval,
+		driverStale:     time.Duration(cfg.DriverStaleSeconds) * time.Second,
+		log:             log,
+		drivers:         make(map[string]*trackedDriver),
+		dirty:           make(map[string]struct{}),
+		removed:         make(map[string]struct{}),
+		rides:           make(map[string]trackedRide),
+	}
+}
+
+// Run запускает циклы метрик и рассылки локаций до отмены контекста (блокирующий).
+// metrics_interval_seconds = 0 выключает дельты метрик; снимок при подключении остается.
+func (s *LiveDashboardService) Run(ctx context.Context) {
+	var metricsTick <-chan time.Time
+	if s.metricsInterval > 0 {
+		ticker := time.NewTicker(s.metricsInterval)
+		defer ticker.Stop()
+		metricsTick = ticker.C
+	}
+
+	flushTicker := time.NewTicker(s.flushInterval)
+	defer flushTicker.Stop()
+
+	s.log.Info(logger.Entry{
+		Action:  "live_dashboard_started",
+		Message: fmt.Sprintf("metrics every %s, locations every %s", s.metricsInterval, s.flushInterval),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info(logger.Entry{Action: "live_dashboard_stopped", Message: "context cancelled"})
+			return
+		case now := <-metricsTick:
+			s.refreshMetrics(ctx, now.UTC())
+		case now := <-flushTicker.C:
+			s.flushLocations(now.UTC())
+		}
+	}
+}
+
+// RecordRideEvent рассылает изменение статуса поездки вместе с ее последней точкой
+func (s *LiveDashboardService) RecordRideEvent(ctx context.Context, input in.RideEventInput) {
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	if input.Pickup != nil {
+		s.rides[input.RideID] = trackedRide{location: *input.Pickup, seenAt: now}
+	}
+	var location *in.GeoPoint
+	if ride, ok := s.rides[input.RideID]; ok {
+		loc := ride.location
+		location = &loc
+	}
+	if input.Status == constants.RideStatusCompleted || input.Status == constants.RideStatusCancelled {
+		delete(s.rides, input.RideID)
+	}
+	s.mu.Unlock()
+
+	occurredAt := input.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = now
+	}
+
+	s.publisher.PublishRideStatus(in.RideStatusUpdateDTO{
+		RideID:      input.RideID,
+		EventType:   input.EventType,
+		Status:      input.Status,
+		PassengerID: input.PassengerID,
+		DriverID:    input.DriverID,
+		VehicleType: input.VehicleType,
+		Location:    location,
+		Timestamp:   occurredAt.Format(time.RFC3339),
+	})
+}
+
+// RecordDriverStatus обновляет статус водителя; OFFLINE убирает его с карты
+func (s *LiveDashboardService) RecordDriverStatus(ctx context.Context, input in.DriverStatusInput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if input.Status == driverStatusOffline {
+		if d, ok := s.drivers[input.DriverID]; ok {
+			delete(s.drivers, input.DriverID)
+			delete(s.dirty, input.DriverID)
+			if d.located {
+				s.removed[input.DriverID] = struct{}{}
+			}
+		}
+		return
+	}
+
+	d := s.driver(input.DriverID)
+	d.dto.Status = input.Status
+	d.dto.RideID = input.RideID
+	d.seenAt = time.Now().UTC()
+	if d.located {
+		s.dirty[input.DriverID] = struct{}{}
+	}
+}
+
+// RecordDriverLocation запоминает локацию водителя до ближайшей рассылки.
+// Локация с ride_id заодно двигает точку поездки для подписчиков области.
+func (s *LiveDashboardService) RecordDriverLocation(ctx context.Context, input in.DriverLocationInput) {
+	now := time.Now().UTC()
+	recordedAt := input.RecordedAt
+	if recordedAt.IsZero() {
+		recordedAt = now
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.driver(input.DriverID)
+	d.dto.RideID = input.RideID
+	d.dto.Location = input.Location
+	d.dto.SpeedKmh = input.SpeedKmh
+	d.dto.HeadingDegrees = input.HeadingDegrees
+	d.dto.UpdatedAt = recordedAt.Format(time.RFC3339)
+	d.located = true
+	d.seenAt = now
+	s.dirty[input.DriverID] = struct{}{}
+	delete(s.removed, input.DriverID)
+
+	if input.RideID != "" {
+		s.rides[input.RideID] = trackedRide{location: input.Location, seenAt: now}
+	}
+}
+
+// MetricsSnapshot возвращает последние метрики; до первого пересчета читает их из БД
+func (s *LiveDashboardService) MetricsSnapshot(ctx context.Context) (map[string]interface{}, error) {
+	s.mu.Lock()
+	current := s.metrics
+	s.mu.Unlock()
+
+	if current == nil {
+		metrics, err := s.userRepo.GetSystemMetrics(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get system metrics: %w", err)
+		}
+		current = snapshot(metrics)
+
+		s.mu.Lock()
+		if s.metrics == nil {
+			s.metrics = current
+		}
+		s.mu.Unlock()
+	}
+
+	result := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// DriverLocations возвращает всех водителей на карте, упорядоченных по ID
+func (s *LiveDashboardService) DriverLocations() []in.DriverLocationDTO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]in.DriverLocationDTO, 0, len(s.drivers))
+	for _, d := range s.drivers {
+		if d.located {
+			result = append(result, d.dto)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DriverID < result[j].DriverID })
+	return result
+}
+
+// driver возвращает водителя из карты, создавая запись при первом упоминании.
+// Вызывать под s.mu.
+func (s *LiveDashboardService) driver(driverID string) *trackedDriver {
+	d, ok := s.drivers[driverID]
+	if !ok {
+		d = &trackedDriver{dto: in.DriverLocationDTO{DriverID: driverID}}
+		s.drivers[driverID] = d
+	}
+	return d
+}
+
+// refreshMetrics пересчитывает метрики и рассылает поля, изменившиеся с прошлого раза
+func (s *LiveDashboardService) refreshMetrics(ctx context.Context, now time.Time) {
+	metrics, err := s.userRepo.GetSystemMetrics(ctx)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "live_dashboard_metrics_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return
+	}
+	current := snapshot(metrics)
+
+	s.mu.Lock()
+	changes := make(map[string]interface{})
+	for k, v := range current {
+		if prev, ok := s.metrics[k]; !ok || !reflect.DeepEqual(prev, v) {
+			changes[k] = v
+		}
+	}
+	s.metrics = current
+	s.mu.Unlock()
+
+	if len(changes) > 0 {
+		s.publisher.PublishMetricsDelta(changes, now)
+	}
+}
+
+// flushLocations рассылает накопленные локации и чистит устаревших водителей и поездки
+func (s *LiveDashboardService) flushLocations(now time.Time) {
+	s.mu.Lock()
+	if s.driverStale > 0 {
+		for id, d := range s.drivers {
+			if now.Sub(d.seenAt) > s.driverStale {
+				delete(s.drivers, id)
+				delete(s.dirty, id)
+				if d.located {
+					s.removed[id] = struct{}{}
+				}
+			}
+		}
+	}
+	for id, ride := range s.rides {
+		if now.Sub(ride.seenAt) > rideStaleAfter {
+			delete(s.rides, id)
+		}
+	}
+
+	updated := make([]in.DriverLocationDTO, 0, len(s.dirty))
+	for id := range s.dirty {
+		updated = append(updated, s.drivers[id].dto)
+	}
+	removed := make([]string, 0, len(s.removed))
+	for id := range s.removed {
+		removed = append(removed, id)
+	}
+	s.dirty = make(map[string]struct{})
+	s.removed = make(map[string]struct{})
+	s.mu.Unlock()
+
+	if len(updated) == 0 && len(removed) == 0 {
+		return
+	}
+	s.publisher.PublishDriverLocations(updated, removed, now)
+}
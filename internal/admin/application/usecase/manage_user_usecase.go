@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/admin/application/ports/in"
+	"ridehail/internal/admin/application/ports/out"
+	"ridehail/internal/admin/domain"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+)
+
+// Причины отзыва сессий (contract.UserSessionsRevoked.Reason)
+const (
+	revokeReasonBanned      = "banned"
+	revokeReasonSuspended   = "suspended"
+	revokeReasonDeleted     = "deleted"
+	revokeReasonRoleChanged = "role_changed"
+)
+
+// ManageUserService реализует ManageUserUseCase
+type ManageUserService struct {
+	userRepo    out.UserRepository
+	refreshRepo out.RefreshTokenRepository
+	notifier    out.SessionNotifier
+	txManager   out.TxManager
+	log         *logger.Logger
+}
+
+// NewManageUserService создает сервис управления пользователями
+func NewManageUserService(
+	userRepo out.UserRepository,
+	refreshRepo out.RefreshTokenRepository,
+	notifier out.SessionNotifier,
+	txManager out.TxManager,
+	log *logger.Logger,
+) *ManageUserService {
+	return &ManageUserService{
+		userRepo:    userRepo,
+		refreshRepo: refreshRepo,
+		notifier:    notifier,
+		txManager:   txManager,
+		log:         log,
+	}
+}
+
+// Get возвращает карточку пользователя, в том числе удаленного
+func (s *ManageUserService) Get(ctx context.Context, userID string) (*in.UserDetailsOutput, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	return toUserDetails(user), nil
+}
+
+// Update меняет статус, роль и attrs пользователя.
+//
+// ПРАВИЛА:
+//   - Свои статус и роль администратор не меняет (не заблокирует сам себя)
+//   - Роль — только PASSENGER или DRIVER, роль администратора не меняется.
+//     Новый водитель выходит на линию после одобрения заявки
+//   - Роль не меняется посреди поездки
+//   - Переход в INACTIVE/BANNED и смена роли отзывают сессии
+func (s *ManageUserService) Update(ctx context.Context, input in.UpdateUserInput) (*in.UserDetailsOutput, error) {
+	if input.Status == nil && input.Role == nil && input.Attrs == nil {
+		return nil, domain.ErrNothingToUpdate
+	}
+
+	user, err := s.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user.IsDeleted() {
+		return nil, domain.ErrUserNotFound
+	}
+
+	prevStatus, prevRole := user.Status, user.Role
+
+	if input.Status != nil && *input.Status != user.Status {
+		if !domain.IsValidStatus(*input.Status) {
+			return nil, domain.ErrInvalidStatus
+		}
+		if user.ID == input.AdminID {
+			return nil, domain.ErrCannotModifySelf
+		}
+		user.Status = *input.Status
+	}
+
+	if input.Role != nil && *input.Role != user.Role {
+		if *input.Role != domain.RolePassenger && *input.Role != domain.RoleDriver {
+			return nil, domain.ErrInvalidRole
+		}
+		if user.ID == input.AdminID {
+			return nil, domain.ErrCannotModifySelf
+		}
+		if user.Role == domain.RoleAdmin {
+			return nil, domain.ErrInvalidRole
+		}
+		busy, err := s.userRepo.HasActiveRides(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("check active rides: %w", err)
+		}
+		if busy {
+			return nil, domain.ErrUserHasActiveRides
+		}
+		user.Role = *input.Role
+	}
+
+	if input.Attrs != nil {
+		if user.Attrs == nil {
+			user.Attrs = make(map[string]interface{})
+		}
+		for k, v := range input.Attrs {
+			if v == nil {
+				delete(user.Attrs, k)
+				continue
+			}
+			user.Attrs[k] = v
+		}
+	}
+
+	user.UpdatedAt = time.Now().UTC()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "update_user_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]interface{}{
+				"user_id": user.ID,
+			},
+		})
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "user_updated",
+		Message: fmt.Sprintf("user %s updated", user.Email),
+		Additional: map[string]interface{}{
+			"user_id":     user.ID,
+			"admin_id":    input.AdminID,
+			"status_from": prevStatus,
+			"status_to":   user.Status,
+			"role_from":   prevRole,
+			"role_to":     user.Role,
+		},
+	})
+
+	switch {
+	case user.Status != prevStatus && user.Status == domain.StatusBanned:
+		s.revokeSessions(ctx, user, prevRole, revokeReasonBanned)
+	case user.Status != prevStatus && user.Status == domain.StatusInactive:
+		s.revokeSessions(ctx, user, prevRole, revokeReasonSuspended)
+	case user.Role != prevRole:
+		s.revokeSessions(ctx, user, prevRole, revokeReasonRoleChanged)
+	}
+
+	return toUserDetails(user), nil
+}
+
+// Delete мягко удаляет пользователя и отзывает его сессии.
+// Пользователь с незавершенными поездками не удаляется: их сначала нужно завершить или отменить.
+func (s *ManageUserService) Delete(ctx context.Context, input in.DeleteUserInput) (*in.DeleteUserOutput, error) {
+	if input.UserID == input.AdminID {
+		return nil, domain.ErrCannotModifySelf
+	}
+
+	user, err := s.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user.IsDeleted() {
+		return nil, domain.ErrUserNotFound
+	}
+
+	busy, err := s.userRepo.HasActiveRides(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check active rides: %w", err)
+	}
+	if busy {
+		return nil, domain.ErrUserHasActiveRides
+	}
+
+	now := time.Now().UTC()
+	if err := s.userRepo.Delete(ctx, user.ID, now); err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+	user.Status = domain.StatusInactive
+
+	s.log.Info(logger.Entry{
+		Action:  "user_deleted",
+		Message: fmt.Sprintf("user %s deleted", user.Email),
+		Additional: map[string]interface{}{
+			"user_id":  user.ID,
+			"admin_id": input.AdminID,
+			"role":     user.Role,
+		},
+	})
+
+	s.revokeSessions(ctx, user, user.Role, revokeReasonDeleted)
+
+	return &in.DeleteUserOutput{
+		UserID:    user.ID,
+		Status:    user.Status,
+		DeletedAt: now.Format(time.RFC3339),
+	}, nil
+}
+
+// revokeSessions отзывает refresh-токены и просит сервисы закрыть WebSocket пользователя.
+// Изменение уже сохранено, поэтому ошибки только логируются: access-токены
+// все равно отсекаются middleware по статусу в БД, refresh — проверкой статуса при ротации.
+func (s *ManageUserService) revokeSessions(ctx context.Context, user *domain.User, sessionRole, reason string) {
+	if err := s.refreshRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "revoke_refresh_tokens_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]interface{}{
+				"user_id": user.ID,
+			},
+		})
+	}
+
+	// Ride/Driver Service закрывают сокеты и перечитывают статус пользователя —
+	// событие уходит только после коммита изменения
+	revoked := &contract.UserSessionsRevoked{
+		UserID:    user.ID,
+		Role:      sessionRole,
+		Status:    user.Status,
+		Reason:    reason,
+		RevokedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	s.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.notifier.PublishSessionsRevoked(ctx, revoked); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "publish_sessions_revoked_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]interface{}{
+					"user_id": revoked.UserID,
+				},
+			})
+		}
+	})
+
+	s.log.Info(logger.Entry{
+		Action:  "user_sessions_revoked",
+		Message: reason,
+		Additional: map[string]interface{}{
+			"user_id": user.ID,
+		},
+	})
+}
+
+// toUserDetails переводит пользователя в карточку ответа
+func toUserDetails(user *domain.User) *in.UserDetailsOutput {
+	output := &in.UserDetailsOutput{
+		UserDTO: in.UserDTO{
+			UserID:      user.ID,
+			Email:       user.Email,
+			Role:        user.Role,
+			Status:      user.Status,
+			Attrs:       user.Attrs,
+			Rating:      user.Rating,
+			RatingCount: user.RatingCount,
+			CreatedAt:   user.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   user.UpdatedAt.Format(time.RFC3339),
+		},
+	}
+	if user.DeletedAt != nil {
+		output.DeletedAt = user.DeletedAt.Format(time.RFC3339)
+	}
+	return output
+}
@@ -6,13 +6,18 @@ import (
 	"net/http"
 	"time"
 
+	"ridehail/internal/admin/adapters/in/in_amqp"
+	"ridehail/internal/admin/adapters/in/in_ws"
 	"ridehail/internal/admin/adapters/in/transport"
+	messaging "ridehail/internal/admin/adapters/out/amqp"
 	"ridehail/internal/admin/adapters/out/repo"
 	"ridehail/internal/admin/application/usecase"
 	"ridehail/internal/shared/auth"
 	"ridehail/internal/shared/config"
 	db_conn "ridehail/internal/shared/db"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+	"ridehail/internal/shared/user"
 )
 
 // Run запускает Admin Service
@@ -40,30 +45,116 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 		// Не падаем если миграции уже применены
 	}
 
-	// 2. Инициализация JWT сервиса
+	// 2. Инициализация RabbitMQ: решения по заявкам водителей уходят в driver_topic,
+	// отзыв сессий — в user_topic; живой дашборд слушает ride_topic, driver_topic и location_fanout
+	mqConn, err := mq.NewRabbitMQ(ctx, cfg.RabbitMQ, log)
+	if err != nil {
+		log.Fatal(logger.Entry{
+			Action:  "rabbitmq_connection_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+	defer mqConn.Close()
+
+	if err := mq.SetupTopology(ctx, mqConn, log); err != nil {
+		log.Error(logger.Entry{
+			Action:  "rabbitmq_topology_setup_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		// Не падаем если топология уже создана
+	}
+
+	// 2.1. Инициализация JWT сервиса
 	jwtService := auth.NewJWTService(cfg.JWT)
 
 	// 3. Создаем репозитории (Adapter OUT)
 	userRepo := repo.NewUserPgRepository(dbPool, log)
-
-	// 4. Создаем use cases (Application)
-	createUserUC := usecase.NewCreateUserService(userRepo, log)
+	refreshTokenRepo := repo.NewRefreshTokenPgRepository(dbPool, log)
+	driverAppRepo := repo.NewDriverApplicationPgRepository(dbPool, log)
+	driverNotifier := messaging.NewDriverNotifier(mqConn, log)
+	sessionNotifier := messaging.NewSessionNotifier(mqConn, log)
+	authUserRepo := user.NewPgRepository(dbPool, log) // статус администратора для middleware
+	auditRepo := repo.NewAuditLogPgRepository(dbPool, log)
+	analyticsRepo := repo.NewAnalyticsPgRepository(dbPool, log)
+	txManager := repo.NewPgTxManager(dbPool, log) // действие и запись аудита — одна транзакция
+
+	// 4. Создаем use cases (Application).
+	// Меняющие данные use cases обернуты аудитом: действие и его запись в admin_audit_log
+	// коммитятся вместе, уведомления уходят после коммита
+	audit := usecase.NewAuditRecorder(auditRepo, txManager, log)
+	createUserUC := usecase.NewAuditedCreateUser(usecase.NewCreateUserService(userRepo, log), audit)
 	listUsersUC := usecase.NewListUsersService(userRepo, log)
 	getOverviewUC := usecase.NewGetOverviewService(userRepo, log)
 	getActiveRidesUC := usecase.NewGetActiveRidesService(userRepo, log)
+	authUC := usecase.NewAuthService(userRepo, refreshTokenRepo, jwtService, log)
+	verificationUC := usecase.NewAuditedDriverVerification(usecase.NewDriverVerificationService(driverAppRepo, driverNotifier, txManager, log), audit)
+	manageUserUC := usecase.NewAuditedManageUser(usecase.NewManageUserService(userRepo, refreshTokenRepo, sessionNotifier, txManager, log), audit)
+	listAuditUC := usecase.NewListAuditService(auditRepo, log)
+	getAnalyticsUC := usecase.NewGetAnalyticsService(analyticsRepo, log)
+
+	// 4.1. Роллапы аналитики: GET /admin/analytics читает только их, не rides
+	rollupWorker := usecase.NewAnalyticsRollupWorker(analyticsRepo, cfg.Analytics, log)
+	go rollupWorker.Run(ctx)
 
 	// 5. Создаем HTTP handler (Adapter IN)
-	httpHandler := transport.NewHTTPHandler(createUserUC, listUsersUC, getOverviewUC, getActiveRidesUC, log)
+	httpHandler := transport.NewHTTPHandler(createUserUC, listUsersUC, getOverviewUC, getActiveRidesUC, authUC, verificationUC, manageUserUC, listAuditUC, getAnalyticsUC, log)
+
+	// 5.1. Живой дашборд: WebSocket hub (только ADMIN) и сервис, рассылающий через него
+	// метрики, статусы поездок и локации водителей
+	dashboardWS := in_ws.NewDashboardWSHandler(jwtService, authUserRepo, log)
+	go dashboardWS.GetHub().Run(ctx)
+
+	liveDashboardUC := usecase.NewLiveDashboardService(userRepo, dashboardWS, cfg.Dashboard, log)
+	dashboardWS.SetLiveDashboardUseCase(liveDashboardUC)
+	go liveDashboardUC.Run(ctx)
+
+	rideEventsConsumer := in_amqp.NewRideEventsConsumer(mqConn, liveDashboardUC, log)
+	go func() {
+		if err := rideEventsConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "ride_events_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	driverStatusConsumer := in_amqp.NewDriverStatusConsumer(mqConn, liveDashboardUC, log)
+	go func() {
+		if err := driverStatusConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "driver_status_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	locationConsumer := in_amqp.NewLocationConsumer(mqConn, liveDashboardUC, log)
+	go func() {
+		if err := locationConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "location_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
 
 	// 6. Настраиваем HTTP сервер
 	mux := http.NewServeMux()
 
 	// Middleware для ADMIN аутентификации
-	adminAuthMiddleware := transport.AdminAuthMiddleware(jwtService, log)
+	adminAuthMiddleware := transport.AdminAuthMiddleware(jwtService, authUserRepo, log)
 
 	// Регистрируем маршруты
 	httpHandler.RegisterRoutes(mux, adminAuthMiddleware)
 
+	// WebSocket дашборда: токен проверяется первым сообщением, как в Ride/Driver Service
+	mux.HandleFunc("GET /ws", dashboardWS.ServeWS)
+
 	// HTTP сервер
 	addr := fmt.Sprintf(":%d", cfg.Services.AdminServicePort)
 	server := &http.Server{
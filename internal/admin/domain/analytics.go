@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// Шаг временного ряда аналитики
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+	GranularityWeek = "week" // ISO-неделя, с понедельника
+)
+
+// Разрез аналитики
+const (
+	GroupByVehicleType = "vehicle_type"
+	GroupByStatus      = "status"
+	GroupByCity        = "city"
+)
+
+// maxBuckets — сколько точек ряда можно запросить за раз при каждом шаге
+var maxBuckets = map[string]int{
+	GranularityHour: 24 * 31, // месяц по часам
+	GranularityDay:  366,     // год по дням
+	GranularityWeek: 157,     // три года по неделям
+}
+
+// ValidGranularity проверяет шаг ряда
+func ValidGranularity(granularity string) bool {
+	_, ok := maxBuckets[granularity]
+	return ok
+}
+
+// ValidGroupBy проверяет разрез; пустой — без разбивки
+func ValidGroupBy(groupBy string) bool {
+	switch groupBy {
+	case "", GroupByVehicleType, GroupByStatus, GroupByCity:
+		return true
+	}
+	return false
+}
+
+// MaxBuckets возвращает предел точек ряда для шага
+func MaxBuckets(granularity string) int {
+	return maxBuckets[granularity]
+}
+
+// TruncateBucket возвращает начало корзины (UTC), в которую попадает момент t
+func TruncateBucket(t time.Time, granularity string) time.Time {
+	t = t.UTC()
+	switch granularity {
+	case GranularityHour:
+		return t.Truncate(time.Hour)
+	case GranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		// Weekday: воскресенье = 0, неделя начинается с понедельника
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// NextBucket возвращает начало следующей корзины
+func NextBucket(bucket time.Time, granularity string) time.Time {
+	switch granularity {
+	case GranularityHour:
+		return bucket.Add(time.Hour)
+	case GranularityWeek:
+		return bucket.AddDate(0, 0, 7)
+	default:
+		return bucket.AddDate(0, 0, 1)
+	}
+}
+
+// RideStatsRow — строка роллапа: агрегаты поездок одной корзины и одной группы.
+// Средние хранятся как сумма и количество, чтобы корзины складывались точно.
+type RideStatsRow struct {
+	Bucket             time.Time
+	Group              string // "" — без разбивки
+	Rides              int
+	Completed          int
+	Cancelled          int
+	Revenue            float64
+	WaitSecondsSum     float64
+	WaitCount          int
+	DurationSecondsSum float64
+	DurationCount      int
+}
+
+// AverageWaitMinutes — среднее ожидание водителя (от заказа до назначения)
+func (r RideStatsRow) AverageWaitMinutes() float64 {
+	if r.WaitCount == 0 {
+		return 0
+	}
+	return r.WaitSecondsSum / float64(r.WaitCount) / 60
+}
+
+// AverageDurationMinutes — средняя длительность поездки (от начала до завершения)
+func (r RideStatsRow) AverageDurationMinutes() float64 {
+	if r.DurationCount == 0 {
+		return 0
+	}
+	return r.DurationSecondsSum / float64(r.DurationCount) / 60
+}
+
+// CancellationRate — доля отмененных среди всех поездок корзины
+func (r RideStatsRow) CancellationRate() float64 {
+	if r.Rides == 0 {
+		return 0
+	}
+	return float64(r.Cancelled) / float64(r.Rides)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+)
+
+// AuditEntry — запись журнала действий администратора (таблица admin_audit_log).
+// Журнал только дополняется: изменить или удалить запись нельзя.
+type AuditEntry struct {
+	ID         int64
+	ActorID    string // ID администратора из JWT
+	Action     string // см. Audit* константы
+	TargetType string // user | driver
+	TargetID   string
+	Before     map[string]interface{} // состояние цели до действия (nil — цели не было или оно неизвестно)
+	After      map[string]interface{} // состояние цели после действия
+	Diff       map[string]AuditChange // только изменившиеся поля верхнего уровня
+	IP         string
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+// AuditChange — изменение одного поля
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Действия журнала
+const (
+	AuditUserCreate    = "user.create"
+	AuditUserUpdate    = "user.update"
+	AuditUserDelete    = "user.delete"
+	AuditDriverApprove = "driver.approve"
+	AuditDriverReject  = "driver.reject"
+)
+
+// Типы целей журнала
+const (
+	AuditTargetUser   = "user"
+	AuditTargetDriver = "driver"
+)
+
+// AuditDiff возвращает поля, которые отличаются в before и after.
+// Поле, которого нет с одной стороны, попадает в diff с nil на этой стороне.
+func AuditDiff(before, after map[string]interface{}) map[string]AuditChange {
+	diff := make(map[string]AuditChange)
+	for k, to := range after {
+		from := before[k]
+		if !reflect.DeepEqual(from, to) {
+			diff[k] = AuditChange{From: from, To: to}
+		}
+	}
+	for k, from := range before {
+		if _, ok := after[k]; !ok {
+			diff[k] = AuditChange{From: from, To: nil}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// DriverApplication — заявка водителя на проверку (таблица driver_applications)
+type DriverApplication struct {
+	ID            string
+	DriverID      string
+	Email         string // Email пользователя-водителя, для экрана модерации
+	Status        string // PENDING | APPROVED | REJECTED | EXPIRED
+	LicenseNumber string
+	VehicleType   string
+	VehicleAttrs  map[string]interface{}
+	Documents     []DriverDocument
+	CreatedAt     time.Time
+}
+
+// DriverDocument — метаданные документа водителя
+type DriverDocument struct {
+	Kind      string // DRIVER_LICENSE | VEHICLE_REGISTRATION | INSURANCE
+	Number    string
+	ExpiresOn time.Time
+}
+
+// ApplicationDecision — решение администратора по заявке
+type ApplicationDecision struct {
+	ApplicationID string
+	DriverID      string
+	Status        string
+	Reason        string
+	VerifiedUntil *time.Time // Только для APPROVED: самый ранний срок документов
+	ReviewedBy    string
+	ReviewedAt    time.Time
+}
+
+// ApplicationStatus — статусы заявки
+const (
+	ApplicationPending  = "PENDING"
+	ApplicationApproved = "APPROVED"
+	ApplicationRejected = "REJECTED"
+	ApplicationExpired  = "EXPIRED"
+)
+
+// DateLayout — формат сроков действия документов
+const DateLayout = "2006-01-02"
@@ -23,4 +23,56 @@ var (
 
 	// ErrUnauthorized недостаточно прав
 	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrInvalidCredentials неверный email или пароль
+	// (намеренно не различаем, чтобы не раскрывать существование email)
+	ErrInvalidCredentials = errors.New("invalid email or password")
+
+	// ErrUserInactive пользователь деактивирован
+	ErrUserInactive = errors.New("user is inactive")
+
+	// ErrUserBanned пользователь заблокирован
+	ErrUserBanned = errors.New("user is banned")
+
+	// ErrInvalidRefreshToken refresh-токен не найден, истек или отозван
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+	// ErrRefreshTokenNotFound refresh-токен с таким хешем не найден
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+	// ErrRefreshTokenRevoked refresh-токен уже отозван (conditional UPDATE не затронул строк)
+	ErrRefreshTokenRevoked = errors.New("refresh token already revoked")
+
+	// ErrApplicationNotFound у водителя нет заявки, ожидающей решения
+	ErrApplicationNotFound = errors.New("no pending driver application")
+
+	// ErrReasonRequired причина обязательна при отклонении заявки
+	ErrReasonRequired = errors.New("reason is required")
+
+	// ErrDocumentExpired срок документа в заявке истек до решения по ней
+	ErrDocumentExpired = errors.New("application has expired documents")
+
+	// ErrLicenseNumberTaken номер прав уже принадлежит другому водителю
+	ErrLicenseNumberTaken = errors.New("license number belongs to another driver")
+
+	// ErrUserHasActiveRides у пользователя есть незавершенные поездки
+	ErrUserHasActiveRides = errors.New("user has active rides")
+
+	// ErrCannotModifySelf администратор не может менять свой статус и роль или удалять себя
+	ErrCannotModifySelf = errors.New("administrators cannot change status or role of their own account or delete it")
+
+	// ErrNothingToUpdate в запросе на изменение нет ни одного поля
+	ErrNothingToUpdate = errors.New("nothing to update")
+
+	// ErrInvalidGranularity шаг аналитики не hour, day или week
+	ErrInvalidGranularity = errors.New("granularity must be hour, day or week")
+
+	// ErrInvalidGroupBy разрез аналитики не vehicle_type, status или city
+	ErrInvalidGroupBy = errors.New("group_by must be vehicle_type, status or city")
+
+	// ErrInvalidTimeRange начало интервала не раньше конца
+	ErrInvalidTimeRange = errors.New("from must be before to")
+
+	// ErrTimeRangeTooLarge интервал дает больше точек ряда, чем допустимо для шага
+	ErrTimeRangeTooLarge = errors.New("time range too large for granularity")
 )
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// RefreshToken — серверная запись refresh-токена.
+// Сам токен клиенту отдается один раз, в БД хранится только его хеш.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsRevoked проверяет, отозван ли токен (logout, ротация, бан)
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired проверяет, истек ли срок действия токена
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -12,8 +12,11 @@ type User struct {
 	Status       string // ACTIVE | INACTIVE | BANNED
 	PasswordHash string
 	Attrs        map[string]interface{} // дополнительные атрибуты (JSONB)
+	Rating       *float64               // Средняя оценка: водителя — drivers.rating, пассажира — users.rating
+	RatingCount  int                    // Сколько оценок получено
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	DeletedAt    *time.Time // soft delete: строка остается, пользователь скрыт и не может войти
 }
 
 // UserRole — допустимые роли
@@ -49,3 +52,8 @@ func IsValidStatus(status string) bool {
 		return false
 	}
 }
+
+// IsDeleted проверяет, удален ли пользователь администратором
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
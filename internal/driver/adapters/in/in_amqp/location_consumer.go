@@ -2,28 +2,16 @@ package in_amqp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"ridehail/internal/driver/adapters/in/in_ws"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
 	"ridehail/internal/shared/mq"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-// LocationUpdateMessage структура обновления локации водителя
-type LocationUpdateMessage struct {
-	DriverID  string  `json:"driver_id"`
-	RideID    string  `json:"ride_id,omitempty"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Heading   float64 `json:"heading,omitempty"`
-	Speed     float64 `json:"speed,omitempty"`
-	Accuracy  float64 `json:"accuracy,omitempty"`
-	Timestamp string  `json:"timestamp"`
-}
-
 // LocationUpdateConsumer обрабатывает обновления локации водителей
 type LocationUpdateConsumer struct {
 	mqConn      *mq.RabbitMQ
@@ -66,11 +54,11 @@ func (c *LocationUpdateConsumer) Start(ctx context.Context) error {
 
 	// Привязываем очередь к location_fanout exchange
 	err = ch.QueueBind(
-		queue.Name,        // queue name
-		"",                // routing key (игнорируется для fanout)
-		"location_fanout", // exchange
-		false,             // no-wait
-		nil,               // arguments
+		queue.Name,                // queue name
+		"",                        // routing key (игнорируется для fanout)
+		contract.ExchangeLocation, // exchange
+		false,                     // no-wait
+		nil,                       // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to bind queue: %w", err)
@@ -126,8 +114,8 @@ func (c *LocationUpdateConsumer) Start(ctx context.Context) error {
 
 // handleLocationUpdate обрабатывает одно сообщение location update
 func (c *LocationUpdateConsumer) handleLocationUpdate(ctx context.Context, msg amqp.Delivery) error {
-	var locationUpdate LocationUpdateMessage
-	if err := json.Unmarshal(msg.Body, &locationUpdate); err != nil {
+	var locationUpdate contract.LocationUpdate
+	if err := contract.Decode(msg.Body, &locationUpdate); err != nil {
 		return fmt.Errorf("failed to parse location update: %w", err)
 	}
 
@@ -135,7 +123,7 @@ func (c *LocationUpdateConsumer) handleLocationUpdate(ctx context.Context, msg a
 		Action: "location_update_received",
 		Message: fmt.Sprintf("driver=%s, ride=%s, lat=%f, lng=%f",
 			locationUpdate.DriverID, locationUpdate.RideID,
-			locationUpdate.Latitude, locationUpdate.Longitude),
+			locationUpdate.Location.Lat, locationUpdate.Location.Lng),
 	})
 
 	// Если есть ride_id, отправляем обновление пассажиру через WebSocket
@@ -0,0 +1,214 @@
+package in_amqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/driver/adapters/in/in_ws"
+	"ridehail/internal/driver/application/ports/in"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// rideStatusKeys — события поездки, которые касаются назначенного водителя
+var rideStatusKeys = []string{
+	contract.RoutingKeyRideMatched,
+	contract.RoutingKeyRideCancelled,
+}
+
+// RideStatusConsumer доставляет водителям изменения статуса их поездок,
+// переводит водителя в EN_ROUTE после назначения и останавливает подбор
+// водителя для отмененных поездок
+type RideStatusConsumer struct {
+	mqConn   *mq.RabbitMQ
+	driverWS *in_ws.DriverWSHandler
+	matching in.RideMatchingUseCase
+	drivers  in.DriverUseCase
+	log      *logger.Logger
+}
+
+// NewRideStatusConsumer создает новый consumer
+func NewRideStatusConsumer(
+	mqConn *mq.RabbitMQ,
+	driverWS *in_ws.DriverWSHandler,
+	matching in.RideMatchingUseCase,
+	drivers in.DriverUseCase,
+	log *logger.Logger,
+) *RideStatusConsumer {
+	return &RideStatusConsumer{
+		mqConn:   mqConn,
+		driverWS: driverWS,
+		matching: matching,
+		drivers:  drivers,
+		log:      log,
+	}
+}
+
+// Start запускает consumer
+func (c *RideStatusConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get channel from RabbitMQ")
+	}
+
+	// Объявляем очередь и привязываем к ride_topic
+	queueName := "driver_service_ride_status"
+	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	for _, key := range rideStatusKeys {
+		if err := ch.QueueBind(queueName, key, contract.ExchangeRide, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue to %s: %w", key, err)
+		}
+	}
+
+	msgs, err := ch.Consume(
+		queueName,
+		"driver-service-ride-status", // consumer tag
+		false,                        // auto-ack
+		false,                        // exclusive
+		false,                        // no-local
+		false,                        // no-wait
+		nil,                          // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register consumer: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "ride_status_consumer_started",
+		Message: fmt.Sprintf("listening on queue: %s", queueName),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "ride_status_consumer_stopped",
+				Message: "context cancelled",
+			})
+			return nil
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "ride_status_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleRideStatus(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "ride_status_processing_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				// Временный сбой (БД) — вернем сообщение в очередь: иначе водитель
+				// отмененной поездки не освободится и не получит долю штрафа
+				_ = msg.Nack(false, true)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleRideStatus уведомляет назначенного водителя об изменении статуса
+func (c *RideStatusConsumer) handleRideStatus(ctx context.Context, msg amqp.Delivery) error {
+	var event contract.RideStatusChanged
+	if err := contract.Decode(msg.Body, &event); err != nil {
+		// Невалидное сообщение (или чужая версия контракта) не станет валидным при повторе
+		c.log.Error(logger.Entry{
+			Action:  "ride_status_parse_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	// Назначение подтверждено — водитель едет к точке подачи
+	if event.Status == constants.RideStatusEnRoute && event.DriverID != nil && *event.DriverID != "" {
+		if err := c.drivers.MarkEnRoute(ctx, in.MarkEnRouteInput{
+			DriverID:    *event.DriverID,
+			RideID:      event.RideID,
+			VehicleType: event.VehicleType,
+		}); err != nil {
+			// Статус водителя вторичен: поездка уже EN_ROUTE, водитель ее выполняет
+			c.log.Error(logger.Entry{
+				Action:  "mark_driver_en_route_failed",
+				Message: err.Error(),
+				RideID:  event.RideID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}
+
+	// Поездка больше не ждет водителя — новые офферы не нужны
+	if err := c.matching.StopMatching(ctx, event.RideID); err != nil {
+		return fmt.Errorf("stop matching: %w", err)
+	}
+
+	// Отмена пассажиром: водитель свободен, доля штрафа (если есть) — ему
+	if event.Status == constants.RideStatusCancelled && event.DriverID != nil && *event.DriverID != "" {
+		// Начисление и освобождение идемпотентны — повторная доставка безопасна
+		if err := c.drivers.ReleaseCancelledRide(ctx, in.ReleaseCancelledRideInput{
+			DriverID: *event.DriverID,
+			RideID:   event.RideID,
+		}); err != nil {
+			return fmt.Errorf("release cancelled ride: %w", err)
+		}
+	}
+
+	// Водитель еще не назначен — уведомлять некого
+	if event.DriverID == nil || *event.DriverID == "" {
+		c.log.Debug(logger.Entry{
+			Action:  "ride_status_no_driver",
+			Message: event.Status,
+			RideID:  event.RideID,
+		})
+		return nil
+	}
+
+	message := fmt.Sprintf("Ride status changed to %s", event.Status)
+	if reason, ok := event.AdditionalData["reason"].(string); ok && reason != "" {
+		message = fmt.Sprintf("Ride cancelled: %s", reason)
+	}
+
+	// Водитель может быть оффлайн — это не ошибка обработки сообщения
+	if !c.driverWS.IsDriverConnected(*event.DriverID) {
+		c.log.Debug(logger.Entry{
+			Action:  "driver_not_connected",
+			Message: *event.DriverID,
+			RideID:  event.RideID,
+		})
+		return nil
+	}
+
+	if err := c.driverWS.SendRideStatusUpdate(*event.DriverID, event.RideID, event.Status, message); err != nil {
+		c.log.Error(logger.Entry{
+			Action:  "send_ride_status_update_failed",
+			Message: err.Error(),
+			RideID:  event.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "driver_notified_ride_status",
+		Message: event.Status,
+		RideID:  event.RideID,
+		Additional: map[string]interface{}{
+			"driver_id":   *event.DriverID,
+			"routing_key": msg.RoutingKey,
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package in_amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/driver/adapters/in/in_ws"
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/domain"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// SessionsRevokedConsumer закрывает сессии водителя, которого администратор
+// заблокировал, деактивировал, удалил или сменил ему роль (user.sessions_revoked.{user_id}).
+// HTTP-запросы с прежним токеном уже отсекает AuthMiddleware по статусу в БД.
+type SessionsRevokedConsumer struct {
+	mqConn   *mq.RabbitMQ
+	driverWS *in_ws.DriverWSHandler
+	driverUC in.DriverUseCase
+	log      *logger.Logger
+}
+
+// NewSessionsRevokedConsumer создает новый consumer
+func NewSessionsRevokedConsumer(mqConn *mq.RabbitMQ, driverWS *in_ws.DriverWSHandler, driverUC in.DriverUseCase, log *logger.Logger) *SessionsRevokedConsumer {
+	return &SessionsRevokedConsumer{
+		mqConn:   mqConn,
+		driverWS: driverWS,
+		driverUC: driverUC,
+		log:      log,
+	}
+}
+
+// Start запускает consumer
+func (c *SessionsRevokedConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get channel from RabbitMQ")
+	}
+
+	// Очередь на реплику (exclusive, auto-delete): водитель подключен по WebSocket
+	// к одной из реплик, и отзыв должен дойти до каждой
+	queue, err := ch.QueueDeclare(
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := ch.QueueBind(queue.Name, contract.RoutingPatternSessionsRevoked, contract.ExchangeUser, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queue.Name,
+		"driver-service-sessions-revoked", // consumer tag
+		false,                             // auto-ack
+		true,                              // exclusive
+		false,                             // no-local
+		false,                             // no-wait
+		nil,                               // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register consumer: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "sessions_revoked_consumer_started",
+		Message: fmt.Sprintf("listening on queue: %s", queue.Name),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "sessions_revoked_consumer_stopped",
+				Message: "context cancelled",
+			})
+			return nil
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "sessions_revoked_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleSessionsRevoked(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "sessions_revoked_processing_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				_ = msg.Nack(false, false)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleSessionsRevoked уводит водителя с линии и закрывает его WebSocket.
+// Водитель в поездке (BUSY/EN_ROUTE) с линии не снимается: поездку завершит
+// поддержка, а новых заказов он не получит — соединение закрыто, вход запрещен.
+func (c *SessionsRevokedConsumer) handleSessionsRevoked(ctx context.Context, msg amqp.Delivery) error {
+	var revoked contract.UserSessionsRevoked
+	if err := contract.Decode(msg.Body, &revoked); err != nil {
+		return fmt.Errorf("failed to parse sessions revoked: %w", err)
+	}
+
+	if revoked.Role == "DRIVER" {
+		_, err := c.driverUC.GoOffline(ctx, in.GoOfflineInput{DriverID: revoked.UserID})
+		if err != nil && !errors.Is(err, domain.ErrDriverCannotGoOffline) && !errors.Is(err, domain.ErrDriverNotFound) {
+			c.log.Error(logger.Entry{
+				Action:  "sessions_revoked_go_offline_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]interface{}{
+					"driver_id": revoked.UserID,
+				},
+			})
+		}
+	}
+
+	closed := c.driverWS.GetHub().DisconnectUser(revoked.UserID, revoked.Reason)
+
+	c.log.Info(logger.Entry{
+		Action:  "driver_sessions_revoked",
+		Message: revoked.Reason,
+		Additional: map[string]interface{}{
+			"user_id":     revoked.UserID,
+			"connections": closed,
+		},
+	})
+	return nil
+}
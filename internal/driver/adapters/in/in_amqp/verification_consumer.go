@@ -0,0 +1,141 @@
+package in_amqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/driver/adapters/in/in_ws"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// VerificationConsumer доставляет водителям решения администратора по их заявкам
+// (driver.verification.{driver_id}). Статус уже записан Admin Service — здесь только уведомление.
+type VerificationConsumer struct {
+	mqConn   *mq.RabbitMQ
+	driverWS *in_ws.DriverWSHandler
+	log      *logger.Logger
+}
+
+// NewVerificationConsumer создает новый consumer
+func NewVerificationConsumer(mqConn *mq.RabbitMQ, driverWS *in_ws.DriverWSHandler, log *logger.Logger) *VerificationConsumer {
+	return &VerificationConsumer{
+		mqConn:   mqConn,
+		driverWS: driverWS,
+		log:      log,
+	}
+}
+
+// Start запускает consumer
+func (c *VerificationConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get channel from RabbitMQ")
+	}
+
+	queueName := "driver_service_verification"
+	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := ch.QueueBind(queueName, contract.RoutingPatternVerification, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,
+		"driver-service-verification", // consumer tag
+		false,                         // auto-ack
+		false,                         // exclusive
+		false,                         // no-local
+		false,                         // no-wait
+		nil,                           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register consumer: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "verification_consumer_started",
+		Message: fmt.Sprintf("listening on queue: %s", queueName),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "verification_consumer_stopped",
+				Message: "context cancelled",
+			})
+			return nil
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "verification_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleVerification(msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "verification_processing_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				_ = msg.Nack(false, false)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleVerification пересылает решение водителю, если он подключен.
+// Неподключенный водитель увидит решение в GET /drivers/{driver_id}/application.
+func (c *VerificationConsumer) handleVerification(msg amqp.Delivery) error {
+	var decision contract.DriverVerification
+	if err := contract.Decode(msg.Body, &decision); err != nil {
+		return fmt.Errorf("failed to parse driver verification: %w", err)
+	}
+
+	if !c.driverWS.IsDriverConnected(decision.DriverID) {
+		c.log.Debug(logger.Entry{
+			Action:  "driver_not_connected",
+			Message: decision.DriverID,
+		})
+		return nil
+	}
+
+	update := map[string]interface{}{
+		"application_id": decision.ApplicationID,
+		"status":         decision.Status,
+		"reason":         decision.Reason,
+		"decided_at":     decision.DecidedAt,
+	}
+	if decision.VerifiedUntil != "" {
+		update["verified_until"] = decision.VerifiedUntil
+	}
+
+	if err := c.driverWS.SendVerificationUpdate(decision.DriverID, update); err != nil {
+		c.log.Error(logger.Entry{
+			Action:  "send_verification_update_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "driver_notified_verification",
+		Message: decision.Status,
+		Additional: map[string]interface{}{
+			"driver_id":      decision.DriverID,
+			"application_id": decision.ApplicationID,
+		},
+	})
+	return nil
+}
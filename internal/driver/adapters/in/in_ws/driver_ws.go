@@ -3,13 +3,18 @@ package in_ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	messaging "ridehail/internal/driver/adapters/out/amqp"
-	"ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/domain"
 	"ridehail/internal/shared/auth"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/user"
 	"ridehail/internal/shared/ws"
 )
 
@@ -18,13 +23,17 @@ type DriverWSHandler struct {
 	hub          *ws.Hub
 	jwtSvc       *auth.JWTService
 	msgPublisher *messaging.MessagePublisher
+	driverUC     in.DriverUseCase
+	matching     in.RideMatchingUseCase
 	log          *logger.Logger
 }
 
 // NewDriverWSHandler создает новый handler для водителей
 func NewDriverWSHandler(
 	jwtSvc *auth.JWTService,
+	userRepo user.Repository,
 	msgPublisher *messaging.MessagePublisher,
+	driverUC in.DriverUseCase,
 	log *logger.Logger,
 ) *DriverWSHandler {
 	// Создаем auth функцию для валидации токенов
@@ -34,12 +43,21 @@ func NewDriverWSHandler(
 			return "", "", err
 		}
 
+		// Роль и статус — из БД: заблокированный водитель не переподключится со старым токеном
+		u, err := userRepo.FindByID(context.Background(), claims.UserID)
+		if err != nil {
+			return "", "", err
+		}
+		if !u.IsActive() {
+			return "", "", fmt.Errorf("user is %s", strings.ToLower(u.Status))
+		}
+
 		// Проверяем, что пользователь - DRIVER
-		if claims.Role != "DRIVER" {
-			return "", "", fmt.Errorf("invalid role: %s (expected DRIVER)", claims.Role)
+		if u.Role != "DRIVER" {
+			return "", "", fmt.Errorf("invalid role: %s (expected DRIVER)", u.Role)
 		}
 
-		return claims.UserID, claims.Role, nil
+		return u.ID, u.Role, nil
 	}
 
 	hub := ws.NewHub(authFunc, log)
@@ -48,6 +66,7 @@ func NewDriverWSHandler(
 		hub:          hub,
 		jwtSvc:       jwtSvc,
 		msgPublisher: msgPublisher,
+		driverUC:     driverUC,
 		log:          log,
 	}
 
@@ -62,6 +81,12 @@ func (h *DriverWSHandler) GetHub() *ws.Hub {
 	return h.hub
 }
 
+// SetRideMatchingUseCase подключает координатор подбора для обработки ride_response.
+// Устанавливается после создания, т.к. координатор отправляет офферы через этот handler.
+func (h *DriverWSHandler) SetRideMatchingUseCase(uc in.RideMatchingUseCase) {
+	h.matching = uc
+}
+
 // ServeWS обрабатывает WebSocket соединение для водителя
 func (h *DriverWSHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
 	h.hub.ServeWS(w, r)
@@ -125,8 +150,28 @@ func (h *DriverWSHandler) handleMessage(client *ws.Client, msgType string, data
 			},
 		})
 
+		// Координатор проверяет, что оффер отправлялся водителю и еще не истек
+		if h.matching != nil {
+			err := h.matching.HandleOfferResponse(context.Background(), in.OfferResponseInput{
+				RideID:   resp.RideID,
+				DriverID: client.UserID,
+				Accepted: resp.Accepted,
+			})
+			if errors.Is(err, domain.ErrOfferNotFound) || errors.Is(err, domain.ErrOfferExpired) {
+				return h.hub.SendTypedMessage(client.UserID, "ride_response_result", map[string]interface{}{
+					"ride_id":  resp.RideID,
+					"offer_id": resp.OfferID,
+					"success":  false,
+					"message":  err.Error(),
+				})
+			}
+			if err != nil {
+				return fmt.Errorf("handle offer response: %w", err)
+			}
+		}
+
 		// Публикуем ответ в RabbitMQ driver.response.{ride_id}
-		dto := &out.DriverResponseDTO{
+		dto := &contract.DriverResponse{
 			RideID:   resp.RideID,
 			DriverID: client.UserID,
 			Accepted: resp.Accepted,
@@ -134,7 +179,7 @@ func (h *DriverWSHandler) handleMessage(client *ws.Client, msgType string, data
 
 		// Добавляем текущую локацию, если она была передана
 		if resp.CurrentLocation != nil {
-			dto.DriverLocation = out.LocationDTO{
+			dto.DriverLocation = &contract.LatLng{
 				Lat: resp.CurrentLocation.Latitude,
 				Lng: resp.CurrentLocation.Longitude,
 			}
@@ -170,18 +215,7 @@ func (h *DriverWSHandler) handleMessage(client *ws.Client, msgType string, data
 			return fmt.Errorf("invalid location_update format: %w", err)
 		}
 
-		h.log.Debug(logger.Entry{
-			Action:  "driver_location_update",
-			Message: client.UserID,
-			Additional: map[string]any{
-				"driver_id": client.UserID,
-				"latitude":  loc.Latitude,
-				"longitude": loc.Longitude,
-			},
-		})
-
-		// TODO: Здесь должна быть логика обновления локации в БД и публикации в RabbitMQ
-		// Пока просто логируем
+		return h.handleLocationUpdate(client, loc)
 
 	default:
 		h.log.Warn(logger.Entry{
@@ -196,6 +230,49 @@ func (h *DriverWSHandler) handleMessage(client *ws.Client, msgType string, data
 	return nil
 }
 
+// handleLocationUpdate сохраняет GPS-точку из сокета тем же use case, что и
+// POST /drivers/{id}/location (rate limit, location_history, location_fanout),
+// и отвечает водителю location_update_ack или location_update_error
+func (h *DriverWSHandler) handleLocationUpdate(client *ws.Client, loc LocationUpdateMessage) error {
+	output, err := h.driverUC.UpdateLocation(context.Background(), in.UpdateLocationInput{
+		DriverID:       client.UserID,
+		Latitude:       loc.Latitude,
+		Longitude:      loc.Longitude,
+		AccuracyMeters: loc.AccuracyMeters,
+		SpeedKmh:       loc.SpeedKmh,
+		HeadingDegrees: loc.HeadingDegrees,
+	})
+	if err != nil {
+		code := "internal_error"
+		switch {
+		case errors.Is(err, domain.ErrRateLimitExceeded):
+			code = "rate_limited"
+		case errors.Is(err, domain.ErrInvalidCoordinates):
+			code = "invalid_coordinates"
+		default:
+			h.log.Error(logger.Entry{
+				Action:  "driver_ws_location_update_failed",
+				Message: err.Error(),
+				Additional: map[string]any{
+					"driver_id": client.UserID,
+				},
+				Error: &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+
+		return h.hub.SendTypedMessage(client.UserID, "location_update_error", map[string]interface{}{
+			"code":    code,
+			"message": err.Error(),
+		})
+	}
+
+	return h.hub.SendTypedMessage(client.UserID, "location_update_ack", map[string]interface{}{
+		"coordinate_id": output.CoordinateID,
+		"ride_ids":      output.RideIDs,
+		"updated_at":    output.UpdatedAt,
+	})
+}
+
 // SendRideOffer отправляет оффер поездки водителю
 func (h *DriverWSHandler) SendRideOffer(driverID string, offer map[string]interface{}) error {
 	return h.hub.SendTypedMessage(driverID, "ride_offer", offer)
@@ -217,6 +294,11 @@ func (h *DriverWSHandler) SendRideStatusUpdate(driverID, rideID, status, message
 	return h.hub.SendTypedMessage(driverID, "ride_status_update", data)
 }
 
+// SendVerificationUpdate отправляет водителю решение по заявке или снятие с проверки
+func (h *DriverWSHandler) SendVerificationUpdate(driverID string, update map[string]interface{}) error {
+	return h.hub.SendTypedMessage(driverID, "verification_update", update)
+}
+
 // IsDriverConnected проверяет, подключен ли водитель
 func (h *DriverWSHandler) IsDriverConnected(driverID string) bool {
 	return h.hub.IsUserConnected(driverID)
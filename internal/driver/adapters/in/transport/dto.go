@@ -1,5 +1,10 @@
 package transport
 
+import (
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/shared/pricing"
+)
+
 // GoOnlineRequest — запрос на переход в онлайн
 type GoOnlineRequest struct {
 	Latitude  float64 `json:"latitude"`
@@ -43,6 +48,42 @@ type UpdateLocationResponse struct {
 	UpdatedAt    string `json:"updated_at"`
 }
 
+// ArrivedRequest — отметка прибытия на точку подачи
+type ArrivedRequest struct {
+	RideID    string  `json:"ride_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ArrivedResponse — ответ на отметку прибытия
+type ArrivedResponse struct {
+	RideID                 string  `json:"ride_id"`
+	Status                 string  `json:"status"`
+	ArrivedAt              string  `json:"arrived_at"`
+	DistanceToPickupMeters float64 `json:"distance_to_pickup_meters"`
+	FreeWaitingMinutes     int     `json:"free_waiting_minutes"`
+	Message                string  `json:"message"`
+}
+
+// StopArrivedRequest — отметка прибытия на промежуточную остановку
+type StopArrivedRequest struct {
+	RideID    string  `json:"ride_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// StopArrivedResponse — ответ на отметку прибытия на остановку
+type StopArrivedResponse struct {
+	RideID               string  `json:"ride_id"`
+	StopNumber           int     `json:"stop_number"`
+	TotalStops           int     `json:"total_stops"`
+	RemainingStops       int     `json:"remaining_stops"`
+	Address              string  `json:"address"`
+	ArrivedAt            string  `json:"arrived_at"`
+	DistanceToStopMeters float64 `json:"distance_to_stop_meters"`
+	Message              string  `json:"message"`
+}
+
 // StartRideRequest — запрос на начало поездки
 type StartRideRequest struct {
 	RideID    string  `json:"ride_id"`
@@ -52,10 +93,12 @@ type StartRideRequest struct {
 
 // StartRideResponse — ответ на начало поездки
 type StartRideResponse struct {
-	RideID    string `json:"ride_id"`
-	Status    string `json:"status"`
-	StartedAt string `json:"started_at"`
-	Message   string `json:"message"`
+	RideID         string             `json:"ride_id"`
+	Status         string             `json:"status"`
+	StartedAt      string             `json:"started_at"`
+	WaitingMinutes int                `json:"waiting_minutes"`
+	Message        string             `json:"message"`
+	Route          []in.RouteWaypoint `json:"route,omitempty"` // POOL: оставшийся план маршрута
 }
 
 // CompleteRideRequest — запрос на завершение поездки
@@ -69,11 +112,30 @@ type CompleteRideRequest struct {
 
 // CompleteRideResponse — ответ на завершение поездки
 type CompleteRideResponse struct {
-	RideID         string  `json:"ride_id"`
-	Status         string  `json:"status"`
-	CompletedAt    string  `json:"completed_at"`
-	DriverEarnings float64 `json:"driver_earnings"`
-	Message        string  `json:"message"`
+	RideID         string             `json:"ride_id"`
+	Status         string             `json:"status"`
+	CompletedAt    string             `json:"completed_at"`
+	FinalFare      float64            `json:"final_fare"`
+	FareBreakdown  pricing.Breakdown  `json:"fare_breakdown"`
+	DriverEarnings float64            `json:"driver_earnings"`
+	Message        string             `json:"message"`
+	SharedFraction float64            `json:"shared_fraction,omitempty"` // POOL: доля поездки с попутчиками
+	Route          []in.RouteWaypoint `json:"route,omitempty"`           // POOL: оставшийся план маршрута
+}
+
+// NoShowRequest — запрос на отмену поездки за неявку пассажира
+type NoShowRequest struct {
+	RideID    string  `json:"ride_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ApplicationRequest — заявка водителя на проверку: права, машина, документы
+type ApplicationRequest struct {
+	LicenseNumber string             `json:"license_number"`
+	VehicleType   string             `json:"vehicle_type"`
+	Vehicle       in.VehicleInput    `json:"vehicle"`
+	Documents     []in.DocumentInput `json:"documents"`
 }
 
 // ErrorResponse — стандартный ответ об ошибке
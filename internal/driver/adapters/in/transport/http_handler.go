@@ -2,12 +2,15 @@ package transport
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/domain"
 	"ridehail/internal/shared/logger"
 )
 
@@ -248,7 +251,7 @@ func (h *DriverHandler) HandleUpdateLocation(w http.ResponseWriter, r *http.Requ
 				Msg: err.Error(),
 			},
 		})
-		writeJSONError(w, err.Error(), http.StatusInternalServerError)
+		writeJSONError(w, err.Error(), locationErrorStatus(err))
 		return
 	}
 
@@ -259,6 +262,190 @@ func (h *DriverHandler) HandleUpdateLocation(w http.ResponseWriter, r *http.Requ
 	}, http.StatusOK)
 }
 
+// HandleArrived обрабатывает POST /drivers/{driver_id}/arrived
+func (h *DriverHandler) HandleArrived(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Извлекаем driver_id из URL path
+	driverIDFromURL := extractDriverID(r.URL.Path)
+	if driverIDFromURL == "" {
+		h.log.Error(logger.Entry{
+			Action:  "arrive_missing_driver_id",
+			Message: "driver_id not found in URL",
+		})
+		writeJSONError(w, "driver_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// Извлекаем user_id из JWT токена
+	userIDFromToken := GetUserID(ctx)
+	role := GetRole(ctx)
+
+	// Проверяем роль
+	if role != "DRIVER" {
+		h.log.Error(logger.Entry{
+			Action:  "arrive_invalid_role",
+			Message: fmt.Sprintf("expected role DRIVER, got %s", role),
+		})
+		writeJSONError(w, "only drivers can mark arrival", http.StatusForbidden)
+		return
+	}
+
+	// Проверяем, что driver_id из URL совпадает с user_id из токена
+	if driverIDFromURL != userIDFromToken {
+		h.log.Error(logger.Entry{
+			Action:  "arrive_id_mismatch",
+			Message: fmt.Sprintf("driver_id from URL (%s) != user_id from token (%s)", driverIDFromURL, userIDFromToken),
+		})
+		writeJSONError(w, "driver_id mismatch", http.StatusForbidden)
+		return
+	}
+
+	// Декодируем тело запроса
+	var req ArrivedRequest
+	body := http.MaxBytesReader(w, r.Body, 1<<20) // 1 MB limit
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "arrive_decode_failed",
+			Message: err.Error(),
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем use-case
+	output, err := h.driverUseCase.ArriveAtPickup(ctx, in.ArriveAtPickupInput{
+		DriverID:  driverIDFromURL,
+		RideID:    req.RideID,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "arrive_usecase_failed",
+			Message: err.Error(),
+			RideID:  req.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, err.Error(), rideErrorStatus(err))
+		return
+	}
+
+	// Возвращаем успешный ответ
+	writeJSON(w, ArrivedResponse{
+		RideID:                 output.RideID,
+		Status:                 output.Status,
+		ArrivedAt:              output.ArrivedAt,
+		DistanceToPickupMeters: output.DistanceToPickupMeters,
+		FreeWaitingMinutes:     output.FreeWaitingMinutes,
+		Message:                output.Message,
+	}, http.StatusOK)
+}
+
+// HandleStopArrived обрабатывает POST /drivers/{driver_id}/stops/{n}/arrived
+func (h *DriverHandler) HandleStopArrived(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Извлекаем driver_id из URL path
+	driverIDFromURL := extractDriverID(r.URL.Path)
+	if driverIDFromURL == "" {
+		h.log.Error(logger.Entry{
+			Action:  "stop_arrive_missing_driver_id",
+			Message: "driver_id not found in URL",
+		})
+		writeJSONError(w, "driver_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// Номер остановки (1..N) из URL
+	stopNumber, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || stopNumber < 1 {
+		writeJSONError(w, "stop number must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	// Извлекаем user_id из JWT токена
+	userIDFromToken := GetUserID(ctx)
+	role := GetRole(ctx)
+
+	// Проверяем роль
+	if role != "DRIVER" {
+		h.log.Error(logger.Entry{
+			Action:  "stop_arrive_invalid_role",
+			Message: fmt.Sprintf("expected role DRIVER, got %s", role),
+		})
+		writeJSONError(w, "only drivers can mark arrival", http.StatusForbidden)
+		return
+	}
+
+	// Проверяем, что driver_id из URL совпадает с user_id из токена
+	if driverIDFromURL != userIDFromToken {
+		h.log.Error(logger.Entry{
+			Action:  "stop_arrive_id_mismatch",
+			Message: fmt.Sprintf("driver_id from URL (%s) != user_id from token (%s)", driverIDFromURL, userIDFromToken),
+		})
+		writeJSONError(w, "driver_id mismatch", http.StatusForbidden)
+		return
+	}
+
+	// Декодируем тело запроса
+	var req StopArrivedRequest
+	body := http.MaxBytesReader(w, r.Body, 1<<20) // 1 MB limit
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "stop_arrive_decode_failed",
+			Message: err.Error(),
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем use-case
+	output, err := h.driverUseCase.ArriveAtStop(ctx, in.ArriveAtStopInput{
+		DriverID:   driverIDFromURL,
+		RideID:     req.RideID,
+		StopNumber: stopNumber,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+	})
+	if err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "stop_arrive_usecase_failed",
+			Message: err.Error(),
+			RideID:  req.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, err.Error(), rideErrorStatus(err))
+		return
+	}
+
+	// Возвращаем успешный ответ
+	writeJSON(w, StopArrivedResponse{
+		RideID:               output.RideID,
+		StopNumber:           output.StopNumber,
+		TotalStops:           output.TotalStops,
+		RemainingStops:       output.RemainingStops,
+		Address:              output.Address,
+		ArrivedAt:            output.ArrivedAt,
+		DistanceToStopMeters: output.DistanceToStopMeters,
+		Message:              output.Message,
+	}, http.StatusOK)
+}
+
 // HandleStartRide обрабатывает POST /drivers/{driver_id}/start
 func (h *DriverHandler) HandleStartRide(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -331,16 +518,18 @@ func (h *DriverHandler) HandleStartRide(w http.ResponseWriter, r *http.Request)
 				Msg: err.Error(),
 			},
 		})
-		writeJSONError(w, err.Error(), http.StatusInternalServerError)
+		writeJSONError(w, err.Error(), rideErrorStatus(err))
 		return
 	}
 
 	// Возвращаем успешный ответ
 	writeJSON(w, StartRideResponse{
-		RideID:    output.RideID,
-		Status:    output.Status,
-		StartedAt: output.StartedAt,
-		Message:   output.Message,
+		RideID:         output.RideID,
+		Status:         output.Status,
+		StartedAt:      output.StartedAt,
+		WaitingMinutes: output.WaitingMinutes,
+		Message:        output.Message,
+		Route:          output.Route,
 	}, http.StatusOK)
 }
 
@@ -418,7 +607,7 @@ func (h *DriverHandler) HandleCompleteRide(w http.ResponseWriter, r *http.Reques
 				Msg: err.Error(),
 			},
 		})
-		writeJSONError(w, err.Error(), http.StatusInternalServerError)
+		writeJSONError(w, err.Error(), rideErrorStatus(err))
 		return
 	}
 
@@ -427,11 +616,212 @@ func (h *DriverHandler) HandleCompleteRide(w http.ResponseWriter, r *http.Reques
 		RideID:         output.RideID,
 		Status:         output.Status,
 		CompletedAt:    output.CompletedAt,
+		FinalFare:      output.FinalFare,
+		FareBreakdown:  output.FareBreakdown,
 		DriverEarnings: output.DriverEarnings,
 		Message:        output.Message,
+		SharedFraction: output.SharedFraction,
+		Route:          output.Route,
 	}, http.StatusOK)
 }
 
+// HandleNoShow обрабатывает POST /drivers/{driver_id}/no-show — отмена за неявку пассажира
+func (h *DriverHandler) HandleNoShow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	driverIDFromURL := extractDriverID(r.URL.Path)
+	if driverIDFromURL == "" {
+		writeJSONError(w, "driver_id is required", http.StatusBadRequest)
+		return
+	}
+
+	role := GetRole(ctx)
+	if role != "DRIVER" {
+		h.log.Error(logger.Entry{
+			Action:  "no_show_invalid_role",
+			Message: fmt.Sprintf("expected role DRIVER, got %s", role),
+		})
+		writeJSONError(w, "only drivers can report a no-show", http.StatusForbidden)
+		return
+	}
+
+	if driverIDFromURL != GetUserID(ctx) {
+		writeJSONError(w, "driver_id mismatch", http.StatusForbidden)
+		return
+	}
+
+	var req NoShowRequest
+	body := http.MaxBytesReader(w, r.Body, 1<<20) // 1 MB limit
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeJSONError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.driverUseCase.ReportNoShow(ctx, in.NoShowInput{
+		DriverID:  driverIDFromURL,
+		RideID:    req.RideID,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "no_show_usecase_failed",
+			Message: err.Error(),
+			RideID:  req.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, err.Error(), rideErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, output, http.StatusOK)
+}
+
+// HandleGetRoute обрабатывает GET /drivers/{driver_id}/route — план POOL-маршрута
+func (h *DriverHandler) HandleGetRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	driverIDFromURL := extractDriverID(r.URL.Path)
+	if driverIDFromURL == "" {
+		writeJSONError(w, "driver_id is required", http.StatusBadRequest)
+		return
+	}
+
+	role := GetRole(ctx)
+	if role != "DRIVER" {
+		h.log.Error(logger.Entry{
+			Action:  "get_route_invalid_role",
+			Message: fmt.Sprintf("expected role DRIVER, got %s", role),
+		})
+		writeJSONError(w, "only drivers can view their route", http.StatusForbidden)
+		return
+	}
+
+	if driverIDFromURL != GetUserID(ctx) {
+		writeJSONError(w, "driver_id mismatch", http.StatusForbidden)
+		return
+	}
+
+	output, err := h.driverUseCase.GetRoute(ctx, driverIDFromURL)
+	if err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "get_route_usecase_failed",
+			Message: err.Error(),
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, "failed to load route", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, output, http.StatusOK)
+}
+
+// HandleSubmitApplication обрабатывает POST /drivers/{driver_id}/application — заявка на проверку
+func (h *DriverHandler) HandleSubmitApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	driverIDFromURL := extractDriverID(r.URL.Path)
+	if driverIDFromURL == "" {
+		writeJSONError(w, "driver_id is required", http.StatusBadRequest)
+		return
+	}
+
+	role := GetRole(ctx)
+	if role != "DRIVER" {
+		h.log.Error(logger.Entry{
+			Action:  "submit_application_invalid_role",
+			Message: fmt.Sprintf("expected role DRIVER, got %s", role),
+		})
+		writeJSONError(w, "only drivers can submit an application", http.StatusForbidden)
+		return
+	}
+
+	if driverIDFromURL != GetUserID(ctx) {
+		writeJSONError(w, "driver_id mismatch", http.StatusForbidden)
+		return
+	}
+
+	var req ApplicationRequest
+	body := http.MaxBytesReader(w, r.Body, 1<<20) // 1 MB limit
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeJSONError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.driverUseCase.SubmitApplication(ctx, in.SubmitApplicationInput{
+		DriverID:      driverIDFromURL,
+		LicenseNumber: req.LicenseNumber,
+		VehicleType:   req.VehicleType,
+		Vehicle:       req.Vehicle,
+		Documents:     req.Documents,
+	})
+	if err != nil {
+		h.log.Error(logger.Entry{
+			Action:  "submit_application_usecase_failed",
+			Message: err.Error(),
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		writeJSONError(w, err.Error(), applicationErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, output, http.StatusCreated)
+}
+
+// HandleGetApplication обрабатывает GET /drivers/{driver_id}/application — статус заявки
+func (h *DriverHandler) HandleGetApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	driverIDFromURL := extractDriverID(r.URL.Path)
+	if driverIDFromURL == "" {
+		writeJSONError(w, "driver_id is required", http.StatusBadRequest)
+		return
+	}
+
+	role := GetRole(ctx)
+	if role != "DRIVER" {
+		h.log.Error(logger.Entry{
+			Action:  "get_application_invalid_role",
+			Message: fmt.Sprintf("expected role DRIVER, got %s", role),
+		})
+		writeJSONError(w, "only drivers can view their application", http.StatusForbidden)
+		return
+	}
+
+	if driverIDFromURL != GetUserID(ctx) {
+		writeJSONError(w, "driver_id mismatch", http.StatusForbidden)
+		return
+	}
+
+	output, err := h.driverUseCase.GetApplication(ctx, driverIDFromURL)
+	if err != nil {
+		status := applicationErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			h.log.Error(logger.Entry{
+				Action:  "get_application_usecase_failed",
+				Message: err.Error(),
+				Error: &logger.ErrObj{
+					Msg: err.Error(),
+				},
+			})
+		}
+		writeJSONError(w, err.Error(), status)
+		return
+	}
+
+	writeJSON(w, output, http.StatusOK)
+}
+
 // extractDriverID извлекает driver_id из пути /drivers/{driver_id}/online
 func extractDriverID(path string) string {
 	// Ожидаем формат: /drivers/{driver_id}/online
@@ -462,3 +852,47 @@ func writeJSONError(w http.ResponseWriter, message string, statusCode int) {
 		Code:    statusCode,
 	})
 }
+
+// rideErrorStatus сопоставляет ошибки переходов статуса поездки с HTTP кодом
+func rideErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRideTransition), errors.Is(err, domain.ErrRideStatusConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrStopOutOfOrder), errors.Is(err, domain.ErrStopAlreadyArrived):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrStopNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrTooFarFromPickup), errors.Is(err, domain.ErrTooFarFromStop):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrNoShowTooEarly):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// applicationErrorStatus сопоставляет ошибки заявки на проверку с HTTP кодом
+func applicationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrApplicationInvalid), errors.Is(err, domain.ErrDocumentExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrApplicationPending):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// locationErrorStatus сопоставляет ошибку обновления локации с HTTP статусом
+func locationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCoordinates):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrRateLimitExceeded):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
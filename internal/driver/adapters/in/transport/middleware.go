@@ -2,11 +2,13 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"ridehail/internal/shared/auth"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/user"
 	"ridehail/internal/shared/utils"
 )
 
@@ -18,8 +20,9 @@ const (
 	contextKeyRequestID contextKey = "request_id"
 )
 
-// AuthMiddleware проверяет JWT токен и извлекает user_id
-func AuthMiddleware(jwtService *auth.JWTService, log *logger.Logger) func(http.Handler) http.Handler {
+// AuthMiddleware проверяет JWT токен и статус пользователя в БД, извлекает user_id.
+// Роль берется из БД, а не из токена: смена роли и блокировка действуют сразу.
+func AuthMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -57,9 +60,43 @@ func AuthMiddleware(jwtService *auth.JWTService, log *logger.Logger) func(http.H
 				return
 			}
 
+			userEntity, err := userRepo.FindByID(r.Context(), claims.UserID)
+			if err != nil {
+				if errors.Is(err, user.ErrUserNotFound) {
+					writeJSONError(w, "user not found", http.StatusUnauthorized)
+					return
+				}
+				log.Error(logger.Entry{
+					Action:  "auth_user_lookup_failed",
+					Message: err.Error(),
+					Error: &logger.ErrObj{
+						Msg: err.Error(),
+					},
+				})
+				writeJSONError(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			if !userEntity.IsActive() {
+				log.Warn(logger.Entry{
+					Action:  "auth_user_inactive",
+					Message: "user is not active",
+					Additional: map[string]interface{}{
+						"user_id": claims.UserID,
+						"status":  userEntity.Status,
+					},
+				})
+				if userEntity.Status == "BANNED" {
+					writeJSONError(w, "user is banned", http.StatusForbidden)
+				} else {
+					writeJSONError(w, "user is inactive", http.StatusForbidden)
+				}
+				return
+			}
+
 			// Добавляем user_id и role в контекст
 			ctx := context.WithValue(r.Context(), contextKeyUserID, claims.UserID)
-			ctx = context.WithValue(ctx, contextKeyRole, claims.Role)
+			ctx = context.WithValue(ctx, contextKeyRole, userEntity.Role)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -2,10 +2,9 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
 	"ridehail/internal/shared/mq"
 )
@@ -26,19 +25,19 @@ func NewMessagePublisher(mq *mq.RabbitMQ, log *logger.Logger) *MessagePublisher
 
 // PublishDriverResponse публикует ответ водителя на запрос поездки
 // Routing key: driver.response.{ride_id}
-func (p *MessagePublisher) PublishDriverResponse(ctx context.Context, dto *out.DriverResponseDTO) error {
-	body, err := json.Marshal(dto)
+func (p *MessagePublisher) PublishDriverResponse(ctx context.Context, msg *contract.DriverResponse) error {
+	body, err := contract.Encode(msg)
 	if err != nil {
-		return fmt.Errorf("marshal driver response: %w", err)
+		return fmt.Errorf("encode driver response: %w", err)
 	}
 
-	routingKey := fmt.Sprintf("driver.response.%s", dto.RideID)
+	routingKey := contract.DriverResponseKey(msg.RideID)
 
-	if err := p.mq.Publish(ctx, "driver_topic", routingKey, body); err != nil {
+	if err := p.mq.Publish(ctx, contract.ExchangeDriver, routingKey, body); err != nil {
 		p.log.Error(logger.Entry{
 			Action:  "publish_driver_response_failed",
 			Message: err.Error(),
-			RideID:  dto.RideID,
+			RideID:  msg.RideID,
 			Error: &logger.ErrObj{
 				Msg: err.Error(),
 			},
@@ -48,8 +47,8 @@ func (p *MessagePublisher) PublishDriverResponse(ctx context.Context, dto *out.D
 
 	p.log.Debug(logger.Entry{
 		Action:  "driver_response_published",
-		Message: fmt.Sprintf("ride_id=%s, driver_id=%s, accepted=%t", dto.RideID, dto.DriverID, dto.Accepted),
-		RideID:  dto.RideID,
+		Message: fmt.Sprintf("ride_id=%s, driver_id=%s, accepted=%t", msg.RideID, msg.DriverID, msg.Accepted),
+		RideID:  msg.RideID,
 	})
 
 	return nil
@@ -57,15 +56,15 @@ func (p *MessagePublisher) PublishDriverResponse(ctx context.Context, dto *out.D
 
 // PublishDriverStatus публикует изменение статуса водителя
 // Routing key: driver.status.{driver_id}
-func (p *MessagePublisher) PublishDriverStatus(ctx context.Context, dto *out.DriverStatusDTO) error {
-	body, err := json.Marshal(dto)
+func (p *MessagePublisher) PublishDriverStatus(ctx context.Context, msg *contract.DriverStatusChanged) error {
+	body, err := contract.Encode(msg)
 	if err != nil {
-		return fmt.Errorf("marshal driver status: %w", err)
+		return fmt.Errorf("encode driver status: %w", err)
 	}
 
-	routingKey := fmt.Sprintf("driver.status.%s", dto.DriverID)
+	routingKey := contract.DriverStatusKey(msg.DriverID)
 
-	if err := p.mq.Publish(ctx, "driver_topic", routingKey, body); err != nil {
+	if err := p.mq.Publish(ctx, contract.ExchangeDriver, routingKey, body); err != nil {
 		p.log.Error(logger.Entry{
 			Action:  "publish_driver_status_failed",
 			Message: err.Error(),
@@ -78,7 +77,7 @@ func (p *MessagePublisher) PublishDriverStatus(ctx context.Context, dto *out.Dri
 
 	p.log.Debug(logger.Entry{
 		Action:  "driver_status_published",
-		Message: fmt.Sprintf("driver_id=%s, status=%s", dto.DriverID, dto.Status),
+		Message: fmt.Sprintf("driver_id=%s, status=%s", msg.DriverID, msg.Status),
 	})
 
 	return nil
@@ -86,14 +85,14 @@ func (p *MessagePublisher) PublishDriverStatus(ctx context.Context, dto *out.Dri
 
 // PublishLocationUpdate публикует обновление локации водителя
 // Exchange: location_fanout (fanout type)
-func (p *MessagePublisher) PublishLocationUpdate(ctx context.Context, dto *out.LocationUpdateDTO) error {
-	body, err := json.Marshal(dto)
+func (p *MessagePublisher) PublishLocationUpdate(ctx context.Context, msg *contract.LocationUpdate) error {
+	body, err := contract.Encode(msg)
 	if err != nil {
-		return fmt.Errorf("marshal location update: %w", err)
+		return fmt.Errorf("encode location update: %w", err)
 	}
 
 	// Fanout exchange не использует routing key, но передаем пустую строку
-	if err := p.mq.Publish(ctx, "location_fanout", "", body); err != nil {
+	if err := p.mq.Publish(ctx, contract.ExchangeLocation, "", body); err != nil {
 		p.log.Error(logger.Entry{
 			Action:  "publish_location_update_failed",
 			Message: err.Error(),
@@ -106,7 +105,131 @@ func (p *MessagePublisher) PublishLocationUpdate(ctx context.Context, dto *out.L
 
 	p.log.Debug(logger.Entry{
 		Action:  "location_update_published",
-		Message: fmt.Sprintf("driver_id=%s, lat=%.6f, lng=%.6f", dto.DriverID, dto.Location.Lat, dto.Location.Lng),
+		Message: fmt.Sprintf("driver_id=%s, lat=%.6f, lng=%.6f", msg.DriverID, msg.Location.Lat, msg.Location.Lng),
+	})
+
+	return nil
+}
+
+// PublishMatchingStatus публикует этап подбора водителя
+// Routing key: driver.matching.{ride_id}
+func (p *MessagePublisher) PublishMatchingStatus(ctx context.Context, msg *contract.MatchingStatus) error {
+	body, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("encode matching status: %w", err)
+	}
+
+	routingKey := contract.MatchingStatusKey(msg.RideID)
+
+	if err := p.mq.Publish(ctx, contract.ExchangeDriver, routingKey, body); err != nil {
+		p.log.Error(logger.Entry{
+			Action:  "publish_matching_status_failed",
+			Message: err.Error(),
+			RideID:  msg.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return fmt.Errorf("publish to driver_topic: %w", err)
+	}
+
+	p.log.Debug(logger.Entry{
+		Action:  "matching_status_published",
+		Message: fmt.Sprintf("ride_id=%s, status=%s, round=%d", msg.RideID, msg.Status, msg.Round),
+		RideID:  msg.RideID,
+	})
+
+	return nil
+}
+
+// PublishDriverArrived публикует прибытие водителя на точку подачи
+// Routing key: driver.arrived.{ride_id}
+func (p *MessagePublisher) PublishDriverArrived(ctx context.Context, msg *contract.DriverArrived) error {
+	body, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("encode driver arrived: %w", err)
+	}
+
+	routingKey := contract.DriverArrivedKey(msg.RideID)
+
+	if err := p.mq.Publish(ctx, contract.ExchangeDriver, routingKey, body); err != nil {
+		p.log.Error(logger.Entry{
+			Action:  "publish_driver_arrived_failed",
+			Message: err.Error(),
+			RideID:  msg.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return fmt.Errorf("publish to driver_topic: %w", err)
+	}
+
+	p.log.Debug(logger.Entry{
+		Action:  "driver_arrived_published",
+		Message: fmt.Sprintf("ride_id=%s, driver_id=%s, distance=%.0fm", msg.RideID, msg.DriverID, msg.DistanceToPickupMeters),
+		RideID:  msg.RideID,
+	})
+
+	return nil
+}
+
+// PublishStopArrived публикует прибытие водителя на промежуточную остановку
+// Routing key: driver.stop_arrived.{ride_id}
+func (p *MessagePublisher) PublishStopArrived(ctx context.Context, msg *contract.StopArrived) error {
+	body, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("encode stop arrived: %w", err)
+	}
+
+	routingKey := contract.StopArrivedKey(msg.RideID)
+
+	if err := p.mq.Publish(ctx, contract.ExchangeDriver, routingKey, body); err != nil {
+		p.log.Error(logger.Entry{
+			Action:  "publish_stop_arrived_failed",
+			Message: err.Error(),
+			RideID:  msg.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return fmt.Errorf("publish to driver_topic: %w", err)
+	}
+
+	p.log.Debug(logger.Entry{
+		Action:  "stop_arrived_published",
+		Message: fmt.Sprintf("ride_id=%s, stop=%d/%d", msg.RideID, msg.StopNumber, msg.TotalStops),
+		RideID:  msg.RideID,
+	})
+
+	return nil
+}
+
+// PublishPassengerNoShow публикует отмену поездки водителем за неявку пассажира
+// Routing key: driver.no_show.{ride_id}
+func (p *MessagePublisher) PublishPassengerNoShow(ctx context.Context, msg *contract.PassengerNoShow) error {
+	body, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("encode passenger no-show: %w", err)
+	}
+
+	routingKey := contract.NoShowKey(msg.RideID)
+
+	if err := p.mq.Publish(ctx, contract.ExchangeDriver, routingKey, body); err != nil {
+		p.log.Error(logger.Entry{
+			Action:  "publish_passenger_no_show_failed",
+			Message: err.Error(),
+			RideID:  msg.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return fmt.Errorf("publish to driver_topic: %w", err)
+	}
+
+	p.log.Debug(logger.Entry{
+		Action:  "passenger_no_show_published",
+		Message: fmt.Sprintf("ride_id=%s, fee=%.2f", msg.RideID, msg.CancellationFee),
+		RideID:  msg.RideID,
 	})
 
 	return nil
@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	out "ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type applicationPgRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewApplicationPgRepository(pool *pgxpool.Pool) out.ApplicationRepository {
+	return &applicationPgRepository{pool: pool}
+}
+
+// CreatePending сохраняет заявку и документы в одной транзакции.
+// Вторую PENDING заявку не пускает частичный уникальный индекс idx_driver_applications_pending.
+func (r *applicationPgRepository) CreatePending(ctx context.Context, app *domain.DriverApplication) error {
+	vehicleAttrsJSON, err := json.Marshal(app.VehicleAttrs)
+	if err != nil {
+		return fmt.Errorf("marshal vehicle_attrs: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO driver_applications (driver_id, status, license_number, vehicle_type, vehicle_attrs)
+		VALUES ($1, 'PENDING', $2, $3, $4)
+		RETURNING id, created_at
+	`, app.DriverID, app.LicenseNumber, string(app.VehicleType), vehicleAttrsJSON).Scan(&app.ID, &app.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrApplicationPending
+		}
+		return fmt.Errorf("insert driver application: %w", err)
+	}
+
+	for _, doc := range app.Documents {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO driver_documents (application_id, kind, number, expires_on)
+			VALUES ($1, $2, $3, $4::date)
+		`, app.ID, string(doc.Kind), doc.Number, doc.ExpiresOn)
+		if err != nil {
+			return fmt.Errorf("insert driver document: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	app.Status = domain.ApplicationPending
+	return nil
+}
+
+func (r *applicationPgRepository) FindLatest(ctx context.Context, driverID string) (*domain.DriverApplication, error) {
+	var app domain.DriverApplication
+	var vehicleAttrsJSON []byte
+	var reviewReason *string
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, driver_id, status, license_number, vehicle_type, vehicle_attrs, review_reason, reviewed_at, created_at
+		FROM driver_applications
+		WHERE driver_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, driverID).Scan(
+		&app.ID,
+		&app.DriverID,
+		&app.Status,
+		&app.LicenseNumber,
+		&app.VehicleType,
+		&vehicleAttrsJSON,
+		&reviewReason,
+		&app.ReviewedAt,
+		&app.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("query driver application: %w", err)
+	}
+	if reviewReason != nil {
+		app.ReviewReason = *reviewReason
+	}
+	if len(vehicleAttrsJSON) > 0 {
+		if err := json.Unmarshal(vehicleAttrsJSON, &app.VehicleAttrs); err != nil {
+			return nil, fmt.Errorf("unmarshal vehicle_attrs: %w", err)
+		}
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT kind, number, expires_on
+		FROM driver_documents
+		WHERE application_id = $1
+		ORDER BY kind
+	`, app.ID)
+	if err != nil {
+		return nil, fmt.Errorf("query driver documents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var doc domain.DriverDocument
+		if err := rows.Scan(&doc.Kind, &doc.Number, &doc.ExpiresOn); err != nil {
+			return nil, fmt.Errorf("scan driver document: %w", err)
+		}
+		app.Documents = append(app.Documents, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate driver documents: %w", err)
+	}
+
+	return &app, nil
+}
+
+// ExpireLapsed снимает проверку пачкой. SKIP LOCKED — реплики делят водителей между собой,
+// а conditional UPDATE (is_verified) не дает снять одного водителя дважды.
+func (r *applicationPgRepository) ExpireLapsed(ctx context.Context, today time.Time, limit int) ([]domain.LapsedVerification, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH lapsed AS (
+			SELECT id
+			FROM drivers
+			WHERE is_verified AND verified_until < $1::date
+			ORDER BY verified_until
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		), unverified AS (
+			UPDATE drivers d
+			SET is_verified = false, updated_at = NOW()
+			FROM lapsed
+			WHERE d.id = lapsed.id
+			RETURNING d.id, d.status, d.verified_until
+		), expired AS (
+			UPDATE driver_applications a
+			SET status = 'EXPIRED', updated_at = NOW()
+			FROM unverified u
+			WHERE a.driver_id = u.id AND a.status = 'APPROVED'
+		)
+		SELECT id, status, verified_until FROM unverified
+	`, today, limit)
+	if err != nil {
+		return nil, fmt.Errorf("expire lapsed verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var lapsed []domain.LapsedVerification
+	for rows.Next() {
+		var l domain.LapsedVerification
+		if err := rows.Scan(&l.DriverID, &l.Status, &l.VerifiedUntil); err != nil {
+			return nil, fmt.Errorf("scan lapsed verification: %w", err)
+		}
+		lapsed = append(lapsed, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lapsed verifications: %w", err)
+	}
+
+	return lapsed, nil
+}
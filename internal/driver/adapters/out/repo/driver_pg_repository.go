@@ -30,7 +30,7 @@ func (r *driverPgRepository) FindByID(ctx context.Context, driverID string) (*do
 	var d domain.Driver
 	var vehicleAttrsJSON []byte
 
-	err := r.pool.QueryRow(ctx, query, driverID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, driverID).Scan(
 		&d.ID,
 		&d.LicenseNumber,
 		&d.VehicleType,
@@ -66,7 +66,7 @@ func (r *driverPgRepository) UpdateStatus(ctx context.Context, driverID string,
 		WHERE id = $2
 	`
 
-	result, err := r.pool.Exec(ctx, query, status, driverID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, status, driverID)
 	if err != nil {
 		return fmt.Errorf("update driver status: %w", err)
 	}
@@ -87,7 +87,7 @@ func (r *driverPgRepository) UpdateRideStats(ctx context.Context, driverID strin
 		WHERE id = $3
 	`
 
-	result, err := r.pool.Exec(ctx, query, ridesIncrement, earningsIncrement, driverID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, ridesIncrement, earningsIncrement, driverID)
 	if err != nil {
 		return fmt.Errorf("update driver stats: %w", err)
 	}
@@ -106,7 +106,7 @@ func (r *driverPgRepository) CreateSession(ctx context.Context, session *domain.
 		VALUES ($1, $2, $3, $4, $5)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		session.ID,
 		session.DriverID,
 		session.StartedAt,
@@ -132,7 +132,7 @@ func (r *driverPgRepository) GetActiveSession(ctx context.Context, driverID stri
 
 	var s domain.DriverSession
 
-	err := r.pool.QueryRow(ctx, query, driverID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, driverID).Scan(
 		&s.ID,
 		&s.DriverID,
 		&s.StartedAt,
@@ -160,7 +160,7 @@ func (r *driverPgRepository) EndSession(ctx context.Context, sessionID string, t
 		WHERE id = $3 AND ended_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query, totalRides, totalEarnings, sessionID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, totalRides, totalEarnings, sessionID)
 	if err != nil {
 		return fmt.Errorf("end session: %w", err)
 	}
@@ -201,7 +201,7 @@ func (r *driverPgRepository) FindNearbyAvailable(ctx context.Context, lat, lng f
 
 	radiusMeters := maxDistanceKm * 1000
 
-	rows, err := r.pool.Query(ctx, query, lng, lat, vehicleType, radiusMeters)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, lng, lat, vehicleType, radiusMeters)
 	if err != nil {
 		return nil, fmt.Errorf("query nearby drivers: %w", err)
 	}
@@ -161,30 +161,104 @@ func (r *LocationRepository) CheckRateLimit(ctx context.Context, driverID string
 	return elapsed >= 3*time.Second, nil
 }
 
-// NearbyDriverInfo информация о ближайшем водителе
-type NearbyDriverInfo struct {
-	DriverID string
-	Distance float64 // в метрах
+// FindNearbyOnlineDrivers находит ближайших водителей, подходящих для поездки.
+//
+// idle_seconds считается от самого позднего из: завершения последней поездки,
+// начала текущей смены; если нет ни того, ни другого — от updated_at водителя.
+func (r *LocationRepository) FindNearbyOnlineDrivers(
+	ctx context.Context,
+	pickupLat, pickupLng float64,
+	radiusKm float64,
+	vehicleType string,
+	limit int,
+) ([]out.NearbyDriverInfo, error) {
+	query := `
+		SELECT
+			d.id AS driver_id,
+			ST_Distance(
+				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
+				ST_SetSRID(ST_MakePoint(c.longitude, c.latitude), 4326)::geography
+			) AS distance,
+			d.vehicle_type,
+			COALESCE(d.rating, 5.0)::float8 AS rating,
+			EXTRACT(EPOCH FROM (NOW() - COALESCE(
+				GREATEST(
+					(SELECT MAX(r.completed_at) FROM rides r WHERE r.driver_id = d.id),
+					(SELECT MAX(s.started_at) FROM driver_sessions s WHERE s.driver_id = d.id AND s.ended_at IS NULL)
+				),
+				d.updated_at
+			)))::float8 AS idle_seconds,
+			c.latitude, c.longitude,
+			COALESCE((d.vehicle_attrs->>'vehicle_seats')::int, 0) AS seats
+		FROM drivers d
+		INNER JOIN coordinates c ON c.entity_id = d.id AND c.entity_type = 'driver' AND c.is_current = true
+		WHERE d.status = 'AVAILABLE'
+		  AND d.is_verified = true
+		  AND d.vehicle_type = $4
+		  AND ST_DWithin(
+				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
+				ST_SetSRID(ST_MakePoint(c.longitude, c.latitude), 4326)::geography,
+				$3
+			)
+		ORDER BY distance ASC, d.rating DESC
+		LIMIT $5
+	`
+
+	rows, err := r.db.Query(ctx, query, pickupLng, pickupLat, radiusKm*1000, vehicleType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query nearby drivers: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []out.NearbyDriverInfo
+	for rows.Next() {
+		var driver out.NearbyDriverInfo
+		if err := rows.Scan(
+			&driver.DriverID,
+			&driver.Distance,
+			&driver.VehicleType,
+			&driver.Rating,
+			&driver.IdleSeconds,
+			&driver.Lat,
+			&driver.Lng,
+			&driver.Seats,
+		); err != nil {
+			return nil, fmt.Errorf("scan driver: %w", err)
+		}
+		drivers = append(drivers, driver)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	return drivers, nil
 }
 
-// FindNearbyOnlineDrivers находит ближайших онлайн водителей в радиусе
-func (r *LocationRepository) FindNearbyOnlineDrivers(
+// FindPoolDriversOnTrip находит POOL-водителей в поездке рядом с точкой подачи.
+// Время простоя для них не считается (idle_seconds = 0): водитель уже занят.
+func (r *LocationRepository) FindPoolDriversOnTrip(
 	ctx context.Context,
 	pickupLat, pickupLng float64,
 	radiusKm float64,
 	limit int,
-) ([]NearbyDriverInfo, error) {
+) ([]out.NearbyDriverInfo, error) {
 	query := `
-		SELECT 
-			d.id as driver_id,
+		SELECT
+			d.id AS driver_id,
 			ST_Distance(
 				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
 				ST_SetSRID(ST_MakePoint(c.longitude, c.latitude), 4326)::geography
-			) as distance
+			) AS distance,
+			d.vehicle_type,
+			COALESCE(d.rating, 5.0)::float8 AS rating,
+			c.latitude, c.longitude,
+			COALESCE((d.vehicle_attrs->>'vehicle_seats')::int, 0) AS seats
 		FROM drivers d
 		INNER JOIN coordinates c ON c.entity_id = d.id AND c.entity_type = 'driver' AND c.is_current = true
-		WHERE d.is_online = true
-		  AND d.current_status = 'available'
+		WHERE d.status IN ('EN_ROUTE', 'BUSY')
+		  AND d.is_verified = true
+		  AND d.vehicle_type = 'POOL'
 		  AND ST_DWithin(
 				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
 				ST_SetSRID(ST_MakePoint(c.longitude, c.latitude), 4326)::geography,
@@ -196,14 +270,22 @@ func (r *LocationRepository) FindNearbyOnlineDrivers(
 
 	rows, err := r.db.Query(ctx, query, pickupLng, pickupLat, radiusKm*1000, limit)
 	if err != nil {
-		return nil, fmt.Errorf("query nearby drivers: %w", err)
+		return nil, fmt.Errorf("query pool drivers on trip: %w", err)
 	}
 	defer rows.Close()
 
-	var drivers []NearbyDriverInfo
+	var drivers []out.NearbyDriverInfo
 	for rows.Next() {
-		var driver NearbyDriverInfo
-		if err := rows.Scan(&driver.DriverID, &driver.Distance); err != nil {
+		var driver out.NearbyDriverInfo
+		if err := rows.Scan(
+			&driver.DriverID,
+			&driver.Distance,
+			&driver.VehicleType,
+			&driver.Rating,
+			&driver.Lat,
+			&driver.Lng,
+			&driver.Seats,
+		); err != nil {
 			return nil, fmt.Errorf("scan driver: %w", err)
 		}
 		drivers = append(drivers, driver)
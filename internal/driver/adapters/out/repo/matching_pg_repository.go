@@ -0,0 +1,331 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	out "ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type matchingPgRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMatchingPgRepository(pool *pgxpool.Pool) out.MatchingRepository {
+	return &matchingPgRepository{pool: pool}
+}
+
+// matchingRequest — неизменяемая часть сессии (matching_sessions.request)
+type matchingRequest struct {
+	RideNumber               string                    `json:"ride_number"`
+	VehicleType              string                    `json:"vehicle_type"`
+	Pickup                   domain.MatchingLocation   `json:"pickup"`
+	Destination              domain.MatchingLocation   `json:"destination"`
+	Stops                    []domain.MatchingLocation `json:"stops,omitempty"`
+	EstimatedFare            float64                   `json:"estimated_fare"`
+	EstimatedDurationMinutes int                       `json:"estimated_duration_minutes,omitempty"`
+}
+
+const selectSessionColumns = `
+	SELECT ride_id, state, request, offer_timeout_seconds, radius_km::float8, round,
+	       started_at, deadline, next_search_at
+	FROM matching_sessions
+`
+
+func (r *matchingPgRepository) CreateSession(ctx context.Context, session *domain.MatchingSession) (bool, error) {
+	request, err := json.Marshal(matchingRequest{
+		RideNumber:               session.RideNumber,
+		VehicleType:              session.VehicleType,
+		Pickup:                   session.Pickup,
+		Destination:              session.Destination,
+		Stops:                    session.Stops,
+		EstimatedFare:            session.EstimatedFare,
+		EstimatedDurationMinutes: session.EstimatedDurationMinutes,
+	})
+	if err != nil {
+		return false, fmt.Errorf("marshal matching request: %w", err)
+	}
+
+	query := `
+		INSERT INTO matching_sessions (
+			ride_id, state, request, offer_timeout_seconds, radius_km, round,
+			started_at, deadline, next_search_at, due_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (ride_id) DO NOTHING
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		session.RideID,
+		session.State,
+		request,
+		int(session.OfferTimeout/time.Second),
+		session.RadiusKm,
+		session.Round,
+		session.StartedAt,
+		session.Deadline,
+		nullTime(session.NextSearchAt),
+		session.DueAt(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert matching session: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *matchingPgRepository) LockSession(ctx context.Context, rideID string) (*domain.MatchingSession, error) {
+	query := selectSessionColumns + `
+		WHERE ride_id = $1 AND state = 'ACTIVE'
+		FOR UPDATE
+	`
+
+	session, err := r.loadSession(ctx, query, rideID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrMatchingSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *matchingPgRepository) ClaimDueSession(ctx context.Context, now time.Time) (*domain.MatchingSession, error) {
+	query := selectSessionColumns + `
+		WHERE state = 'ACTIVE' AND due_at <= $1
+		ORDER BY due_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	session, err := r.loadSession(ctx, query, now)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// loadSession читает строку сессии и ее офферы
+func (r *matchingPgRepository) loadSession(ctx context.Context, query string, arg any) (*domain.MatchingSession, error) {
+	var (
+		session        domain.MatchingSession
+		request        []byte
+		offerTimeout   int
+		nextSearchAt   *time.Time
+		requestPayload matchingRequest
+	)
+
+	err := conn(ctx, r.pool).QueryRow(ctx, query, arg).Scan(
+		&session.RideID,
+		&session.State,
+		&request,
+		&offerTimeout,
+		&session.RadiusKm,
+		&session.Round,
+		&session.StartedAt,
+		&session.Deadline,
+		&nextSearchAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("select matching session: %w", err)
+	}
+
+	if err := json.Unmarshal(request, &requestPayload); err != nil {
+		return nil, fmt.Errorf("unmarshal matching request: %w", err)
+	}
+	session.RideNumber = requestPayload.RideNumber
+	session.VehicleType = requestPayload.VehicleType
+	session.Pickup = requestPayload.Pickup
+	session.Destination = requestPayload.Destination
+	session.Stops = requestPayload.Stops
+	session.EstimatedFare = requestPayload.EstimatedFare
+	session.EstimatedDurationMinutes = requestPayload.EstimatedDurationMinutes
+	session.OfferTimeout = time.Duration(offerTimeout) * time.Second
+	if nextSearchAt != nil {
+		session.NextSearchAt = *nextSearchAt
+	}
+
+	session.Offers, err = r.listOffers(ctx, session.RideID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *matchingPgRepository) listOffers(ctx context.Context, rideID string) (map[string]*domain.RideOffer, error) {
+	query := `
+		SELECT offer_id, driver_id, distance_km::float8, sent_at, expires_at, state, pool
+		FROM matching_offers
+		WHERE ride_id = $1
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, rideID)
+	if err != nil {
+		return nil, fmt.Errorf("query matching offers: %w", err)
+	}
+	defer rows.Close()
+
+	offers := make(map[string]*domain.RideOffer)
+	for rows.Next() {
+		var (
+			offer domain.RideOffer
+			pool  []byte
+		)
+		if err := rows.Scan(&offer.OfferID, &offer.DriverID, &offer.DistanceKm, &offer.SentAt, &offer.ExpiresAt, &offer.State, &pool); err != nil {
+			return nil, fmt.Errorf("scan matching offer: %w", err)
+		}
+		if pool != nil {
+			offer.Pool = &domain.PoolInsertion{}
+			if err := json.Unmarshal(pool, offer.Pool); err != nil {
+				return nil, fmt.Errorf("unmarshal pool insertion: %w", err)
+			}
+		}
+		offers[offer.DriverID] = &offer
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate matching offers: %w", err)
+	}
+
+	return offers, nil
+}
+
+func (r *matchingPgRepository) SaveSession(ctx context.Context, session *domain.MatchingSession) error {
+	db := conn(ctx, r.pool)
+
+	query := `
+		UPDATE matching_sessions
+		SET state = $2,
+		    radius_km = $3,
+		    round = $4,
+		    next_search_at = $5,
+		    due_at = $6,
+		    updated_at = NOW()
+		WHERE ride_id = $1
+	`
+
+	if _, err := db.Exec(ctx, query,
+		session.RideID,
+		session.State,
+		session.RadiusKm,
+		session.Round,
+		nullTime(session.NextSearchAt),
+		session.DueAt(),
+	); err != nil {
+		return fmt.Errorf("update matching session: %w", err)
+	}
+
+	offerQuery := `
+		INSERT INTO matching_offers (offer_id, ride_id, driver_id, distance_km, sent_at, expires_at, state, pool)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (ride_id, driver_id) DO UPDATE SET state = EXCLUDED.state
+	`
+
+	for _, offer := range session.Offers {
+		var pool []byte
+		if offer.Pool != nil {
+			var err error
+			if pool, err = json.Marshal(offer.Pool); err != nil {
+				return fmt.Errorf("marshal pool insertion: %w", err)
+			}
+		}
+
+		if _, err := db.Exec(ctx, offerQuery,
+			offer.OfferID,
+			session.RideID,
+			offer.DriverID,
+			offer.DistanceKm,
+			offer.SentAt,
+			offer.ExpiresAt,
+			offer.State,
+			pool,
+		); err != nil {
+			return fmt.Errorf("upsert matching offer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *matchingPgRepository) CloseSession(ctx context.Context, rideID string) (bool, error) {
+	query := `
+		UPDATE matching_sessions
+		SET state = 'CLOSED', updated_at = NOW()
+		WHERE ride_id = $1 AND state = 'ACTIVE'
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, rideID)
+	if err != nil {
+		return false, fmt.Errorf("close matching session: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *matchingPgRepository) ClaimStaleRequestedRides(ctx context.Context, olderThan time.Time, limit int) ([]string, error) {
+	query := `
+		SELECT r.id
+		FROM rides r
+		WHERE r.status = 'REQUESTED'
+		  AND r.updated_at < $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM matching_sessions m
+			WHERE m.ride_id = r.id AND m.state IN ('ACTIVE', 'EXPIRED')
+		  )
+		ORDER BY r.updated_at
+		LIMIT $2
+		FOR UPDATE OF r SKIP LOCKED
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, olderThan, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale requested rides: %w", err)
+	}
+
+	rideIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan stale requested rides: %w", err)
+	}
+	return rideIDs, nil
+}
+
+func (r *matchingPgRepository) MarkSessionExpired(ctx context.Context, rideID string) error {
+	query := `
+		INSERT INTO matching_sessions (
+			ride_id, state, request, offer_timeout_seconds, radius_km,
+			started_at, deadline, due_at
+		)
+		VALUES ($1, 'EXPIRED', '{}'::jsonb, 0, 0, NOW(), NOW(), NOW())
+		ON CONFLICT (ride_id) DO UPDATE SET
+			state = 'EXPIRED',
+			updated_at = NOW()
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, rideID); err != nil {
+		return fmt.Errorf("mark matching session expired: %w", err)
+	}
+
+	return nil
+}
+
+// nullTime — NULL для нулевого времени
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	out "ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type poolPgRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPoolPgRepository(pool *pgxpool.Pool) out.PoolRepository {
+	return &poolPgRepository{pool: pool}
+}
+
+// openWaypointsQuery — открытые точки плана; поездки, которые уже не активны
+// (отменены или завершены без высадки), в план не попадают
+const openWaypointsQuery = `
+	SELECT w.ride_id, w.kind, w.coordinate_id, c.address, c.latitude, c.longitude
+	FROM pool_waypoints w
+	JOIN coordinates c ON c.id = w.coordinate_id
+	JOIN rides r ON r.id = w.ride_id
+	WHERE w.driver_id = $1
+	  AND w.done_at IS NULL
+	  AND r.status IN ('MATCHED', 'EN_ROUTE', 'ARRIVED', 'IN_PROGRESS')
+	ORDER BY w.sequence
+`
+
+func (r *poolPgRepository) ListOpenWaypoints(ctx context.Context, driverID string) ([]domain.PoolWaypoint, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, openWaypointsQuery, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("query pool waypoints: %w", err)
+	}
+	return scanWaypoints(rows)
+}
+
+// UpdatePlan перестраивает план водителя под блокировкой строки drivers
+func (r *poolPgRepository) UpdatePlan(
+	ctx context.Context,
+	driverID string,
+	fn func(plan []domain.PoolWaypoint) ([]domain.PoolWaypoint, error),
+) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM drivers WHERE id = $1 FOR UPDATE`, driverID); err != nil {
+		return fmt.Errorf("lock driver: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, openWaypointsQuery, driverID)
+	if err != nil {
+		return fmt.Errorf("query pool waypoints: %w", err)
+	}
+	plan, err := scanWaypoints(rows)
+	if err != nil {
+		return err
+	}
+
+	updated, err := fn(plan)
+	if err != nil {
+		return err
+	}
+
+	// Открытые точки пересоздаются в новом порядке; пройденные остаются как история
+	if _, err := tx.Exec(ctx, `DELETE FROM pool_waypoints WHERE driver_id = $1 AND done_at IS NULL`, driverID); err != nil {
+		return fmt.Errorf("delete open waypoints: %w", err)
+	}
+	for i, wp := range updated {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO pool_waypoints (driver_id, ride_id, kind, sequence, coordinate_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, driverID, wp.RideID, string(wp.Kind), i+1, wp.CoordinateID)
+		if err != nil {
+			return fmt.Errorf("insert waypoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// MarkWaypointDone отмечает точку пройденной (только первая отметка проходит)
+func (r *poolPgRepository) MarkWaypointDone(ctx context.Context, rideID string, kind domain.WaypointKind, at time.Time) (bool, error) {
+	result, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE pool_waypoints
+		SET done_at = $3
+		WHERE ride_id = $1 AND kind = $2 AND done_at IS NULL
+	`, rideID, string(kind), at)
+	if err != nil {
+		return false, fmt.Errorf("mark waypoint done: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListCoRiderIntervals — посадка/высадка других пассажиров того же водителя.
+// Пассажир, которого еще не высадили, возвращается с End = nil.
+func (r *poolPgRepository) ListCoRiderIntervals(ctx context.Context, driverID, rideID string, from, to time.Time) ([]domain.TimeInterval, error) {
+	query := `
+		SELECT p.done_at, d.done_at
+		FROM pool_waypoints p
+		LEFT JOIN pool_waypoints d ON d.ride_id = p.ride_id AND d.kind = 'DROPOFF'
+		WHERE p.driver_id = $1
+		  AND p.ride_id <> $2
+		  AND p.kind = 'PICKUP'
+		  AND p.done_at IS NOT NULL
+		  AND p.done_at < $4
+		  AND (d.done_at IS NULL OR d.done_at > $3)
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, driverID, rideID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query co-rider intervals: %w", err)
+	}
+	defer rows.Close()
+
+	var intervals []domain.TimeInterval
+	for rows.Next() {
+		var iv domain.TimeInterval
+		if err := rows.Scan(&iv.Start, &iv.End); err != nil {
+			return nil, fmt.Errorf("scan co-rider interval: %w", err)
+		}
+		intervals = append(intervals, iv)
+	}
+
+	return intervals, rows.Err()
+}
+
+func scanWaypoints(rows pgx.Rows) ([]domain.PoolWaypoint, error) {
+	defer rows.Close()
+
+	var plan []domain.PoolWaypoint
+	for rows.Next() {
+		var wp domain.PoolWaypoint
+		var kind string
+		if err := rows.Scan(&wp.RideID, &kind, &wp.CoordinateID, &wp.Address, &wp.Lat, &wp.Lng); err != nil {
+			return nil, fmt.Errorf("scan waypoint: %w", err)
+		}
+		wp.Kind = domain.WaypointKind(kind)
+		plan = append(plan, wp)
+	}
+
+	return plan, rows.Err()
+}
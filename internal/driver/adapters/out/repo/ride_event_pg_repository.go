@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	out "ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/shared/ridestate"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type rideEventPgRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRideEventPgRepository(pool *pgxpool.Pool) out.RideEventRepository {
+	return &rideEventPgRepository{pool: pool}
+}
+
+func (r *rideEventPgRepository) Append(ctx context.Context, rideID, eventType string, payload ridestate.EventPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal ride event: %w", err)
+	}
+
+	query := `
+		INSERT INTO ride_events (ride_id, event_type, event_data, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, rideID, eventType, data, payload.OccurredAt); err != nil {
+		return fmt.Errorf("insert ride event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	out "ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/shared/contract"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type rideOutboxPgRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRideOutboxPgRepository(pool *pgxpool.Pool) out.RideOutbox {
+	return &rideOutboxPgRepository{pool: pool}
+}
+
+// EnqueueRideStatus пишет в ту же таблицу outbox, что и Ride Service:
+// его relay доставит сообщение вместе с остальными событиями поездки
+func (r *rideOutboxPgRepository) EnqueueRideStatus(ctx context.Context, msg *contract.RideStatusChanged) error {
+	payload, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("marshal ride status: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (event_type, routing_key, payload, status)
+		VALUES ($1, $2, $3, 'PENDING')
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, msg.EventType, contract.RideRoutingKey(msg.EventType), payload); err != nil {
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+
+	return nil
+}
@@ -2,12 +2,16 @@ package repo
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	out "ridehail/internal/driver/application/ports/out"
 	"ridehail/internal/driver/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/pricing"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,27 +25,42 @@ func NewRidePgRepository(pool *pgxpool.Pool) out.RideRepository {
 
 func (r *ridePgRepository) FindByID(ctx context.Context, rideID string) (*out.Ride, error) {
 	query := `
-		SELECT id, ride_number, passenger_id, driver_id, vehicle_type, status, pickup_coordinate_id, destination_coordinate_id, estimated_fare, final_fare
-		FROM rides
-		WHERE id = $1
+		SELECT r.id, r.ride_number, r.passenger_id, r.driver_id, r.vehicle_type, r.city, r.status,
+		       r.pickup_coordinate_id, r.destination_coordinate_id, r.estimated_fare, r.final_fare,
+		       r.surge_multiplier, dc.distance_km, dc.duration_minutes,
+		       pc.latitude, pc.longitude, dc.latitude, dc.longitude, r.arrived_at, r.started_at
+		FROM rides r
+		LEFT JOIN coordinates dc ON dc.id = r.destination_coordinate_id
+		LEFT JOIN coordinates pc ON pc.id = r.pickup_coordinate_id
+		WHERE r.id = $1
 	`
 
 	var ride out.Ride
 
-	err := r.pool.QueryRow(ctx, query, rideID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rideID).Scan(
 		&ride.ID,
 		&ride.RideNumber,
 		&ride.PassengerID,
 		&ride.DriverID,
 		&ride.VehicleType,
+		&ride.City,
 		&ride.Status,
 		&ride.PickupCoordinateID,
 		&ride.DestinationCoordinateID,
 		&ride.EstimatedFare,
 		&ride.FinalFare,
+		&ride.SurgeMultiplier,
+		&ride.EstimatedDistanceKm,
+		&ride.EstimatedDurationMinutes,
+		&ride.PickupLat,
+		&ride.PickupLng,
+		&ride.DestLat,
+		&ride.DestLng,
+		&ride.ArrivedAt,
+		&ride.StartedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrRideNotFound
 		}
 		return nil, fmt.Errorf("query ride: %w", err)
@@ -50,52 +69,189 @@ func (r *ridePgRepository) FindByID(ctx context.Context, rideID string) (*out.Ri
 	return &ride, nil
 }
 
-func (r *ridePgRepository) UpdateRideDriver(ctx context.Context, rideID, driverID string) error {
+// FindActiveRideIDs возвращает ID всех поездок, которые водитель сейчас выполняет
+// (у POOL их несколько), в порядке назначения; пустой список — таких нет
+func (r *ridePgRepository) FindActiveRideIDs(ctx context.Context, driverID string) ([]string, error) {
 	query := `
-		UPDATE rides
-		SET driver_id = $1, status = 'MATCHED', matched_at = NOW(), updated_at = NOW()
-		WHERE id = $2 AND status = 'REQUESTED'
+		SELECT id
+		FROM rides
+		WHERE driver_id = $1
+		  AND status IN ('MATCHED', 'EN_ROUTE', 'ARRIVED', 'IN_PROGRESS')
+		ORDER BY matched_at NULLS LAST, id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("query active rides: %w", err)
+	}
+
+	rideIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan active rides: %w", err)
+	}
+
+	return rideIDs, nil
+}
+
+// ListStops возвращает промежуточные остановки поездки по порядку
+func (r *ridePgRepository) ListStops(ctx context.Context, rideID string) ([]out.RideStop, error) {
+	query := `
+		SELECT s.stop_number, c.address, c.latitude, c.longitude, s.arrived_at
+		FROM ride_stops s
+		JOIN coordinates c ON c.id = s.coordinate_id
+		WHERE s.ride_id = $1
+		ORDER BY s.stop_number
 	`
 
-	result, err := r.pool.Exec(ctx, query, driverID, rideID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, rideID)
 	if err != nil {
-		return fmt.Errorf("update ride driver: %w", err)
+		return nil, fmt.Errorf("query ride stops: %w", err)
+	}
+	defer rows.Close()
+
+	var stops []out.RideStop
+	for rows.Next() {
+		var stop out.RideStop
+		if err := rows.Scan(&stop.StopNumber, &stop.Address, &stop.Lat, &stop.Lng, &stop.ArrivedAt); err != nil {
+			return nil, fmt.Errorf("scan ride stop: %w", err)
+		}
+		stops = append(stops, stop)
+	}
+
+	return stops, rows.Err()
+}
+
+// MarkStopArrived отмечает прибытие на остановку (только первая отметка проходит)
+func (r *ridePgRepository) MarkStopArrived(ctx context.Context, rideID string, stopNumber int) (time.Time, error) {
+	query := `
+		UPDATE ride_stops
+		SET arrived_at = NOW()
+		WHERE ride_id = $1 AND stop_number = $2 AND arrived_at IS NULL
+		RETURNING arrived_at
+	`
+
+	var arrivedAt time.Time
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rideID, stopNumber).Scan(&arrivedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, domain.ErrStopAlreadyArrived
+		}
+		return time.Time{}, fmt.Errorf("mark stop arrived: %w", err)
+	}
+
+	return arrivedAt.UTC(), nil
+}
+
+// rideTransitionQueries — UPDATE для каждого статуса, в который Driver Service
+// переводит поездку через TransitionStatus; timestamp статуса — в том же запросе.
+// MATCHED ставит Ride Service при назначении, CANCELLED — отдельный метод CancelNoShow.
+var rideTransitionQueries = map[string]string{
+	constants.RideStatusArrived: `
+		UPDATE rides
+		SET status = $1, arrived_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status = $3
+	`,
+	constants.RideStatusInProgress: `
+		UPDATE rides
+		SET status = $1, started_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status = $3
+	`,
+	constants.RideStatusCompleted: `
+		UPDATE rides
+		SET status = $1, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status = $3
+	`,
+}
+
+func (r *ridePgRepository) TransitionStatus(ctx context.Context, rideID, from, to string) error {
+	if err := domain.CheckRideTransition(from, to); err != nil {
+		return err
+	}
+
+	query, ok := rideTransitionQueries[to]
+	if !ok {
+		return fmt.Errorf("%w: %s → %s is not a driver transition", domain.ErrInvalidRideTransition, from, to)
+	}
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, to, rideID, from)
+	if err != nil {
+		return fmt.Errorf("transition ride status: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return domain.ErrRideAlreadyMatched
+		return fmt.Errorf("%w: expected %s", domain.ErrRideStatusConflict, from)
 	}
 
 	return nil
 }
 
-func (r *ridePgRepository) UpdateRideStatus(ctx context.Context, rideID, status string) error {
+func (r *ridePgRepository) CancelNoShow(ctx context.Context, rideID, reason string, fee float64) (time.Time, error) {
 	query := `
 		UPDATE rides
-		SET status = $1, updated_at = NOW()
-		WHERE id = $2
+		SET status = 'CANCELLED',
+		    cancelled_at = NOW(),
+		    cancellation_reason = $2,
+		    cancellation_fee = $3,
+		    updated_at = NOW()
+		WHERE id = $1 AND status = 'ARRIVED'
+		RETURNING cancelled_at
 	`
 
-	result, err := r.pool.Exec(ctx, query, status, rideID)
+	var cancelledAt time.Time
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rideID, reason, fee).Scan(&cancelledAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, fmt.Errorf("%w: expected ARRIVED", domain.ErrRideStatusConflict)
+	}
 	if err != nil {
-		return fmt.Errorf("update ride status: %w", err)
+		return time.Time{}, fmt.Errorf("cancel ride as no-show: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return domain.ErrRideNotFound
+	return cancelledAt, nil
+}
+
+// SettleCancellationFee: начисление и отметка — один statement, повторная доставка
+// ride.cancelled не начислит долю второй раз
+func (r *ridePgRepository) SettleCancellationFee(ctx context.Context, rideID, driverID string, share float64) (float64, bool, error) {
+	query := `
+		WITH settled AS (
+			UPDATE rides
+			SET driver_compensation = round(cancellation_fee * $3::numeric, 2),
+			    compensation_paid_at = NOW()
+			WHERE id = $1
+			  AND driver_id = $2
+			  AND status = 'CANCELLED'
+			  AND cancellation_fee > 0
+			  AND compensation_paid_at IS NULL
+			RETURNING driver_id, driver_compensation
+		)
+		UPDATE drivers d
+		SET total_earnings = d.total_earnings + s.driver_compensation,
+		    updated_at = NOW()
+		FROM settled s
+		WHERE d.id = s.driver_id
+		RETURNING s.driver_compensation
+	`
+
+	var compensation float64
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rideID, driverID, share).Scan(&compensation)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("settle cancellation fee: %w", err)
 	}
 
-	return nil
+	return compensation, true, nil
 }
 
-func (r *ridePgRepository) UpdateFinalFare(ctx context.Context, rideID string, finalFare float64) error {
+func (r *ridePgRepository) UpdateFinalFare(ctx context.Context, rideID string, breakdown pricing.Breakdown) error {
 	query := `
 		UPDATE rides
-		SET final_fare = $1, updated_at = NOW()
-		WHERE id = $2
+		SET final_fare = $1, fare_breakdown = $2, updated_at = NOW()
+		WHERE id = $3
 	`
 
-	result, err := r.pool.Exec(ctx, query, finalFare, rideID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, breakdown.Total, breakdown, rideID)
 	if err != nil {
 		return fmt.Errorf("update final fare: %w", err)
 	}
@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// txKey — ключ контекста, под которым хранится открытая транзакция
+type txKey struct{}
+
+// openTx — транзакция и отложенные до ее коммита действия
+type openTx struct {
+	tx          pgx.Tx
+	afterCommit []func(ctx context.Context)
+}
+
+// dbtx — общее подмножество методов pgxpool.Pool и pgx.Tx
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// conn возвращает транзакцию из контекста, если она есть, иначе пул
+func conn(ctx context.Context, pool *pgxpool.Pool) dbtx {
+	if open, ok := ctx.Value(txKey{}).(*openTx); ok {
+		return open.tx
+	}
+	return pool
+}
+
+// PgTxManager — реализация out.TxManager поверх pgxpool
+type PgTxManager struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewPgTxManager создает новый менеджер транзакций
+func NewPgTxManager(pool *pgxpool.Pool, log *logger.Logger) *PgTxManager {
+	return &PgTxManager{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// WithinTx выполняет fn в транзакции.
+// Вложенный вызов переиспользует уже открытую транзакцию.
+func (m *PgTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*openTx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				m.log.Error(logger.Entry{
+					Action:  "db_tx_rollback_failed",
+					Message: rbErr.Error(),
+					Error:   &logger.ErrObj{Msg: rbErr.Error()},
+				})
+			}
+		}
+	}()
+
+	open := &openTx{tx: tx}
+	if err = fn(context.WithValue(ctx, txKey{}, open)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	for _, after := range open.afterCommit {
+		after(ctx)
+	}
+
+	return nil
+}
+
+// AfterCommit откладывает fn до коммита транзакции из ctx или выполняет сразу
+func (m *PgTxManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if open, ok := ctx.Value(txKey{}).(*openTx); ok {
+		open.afterCommit = append(open.afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
@@ -2,6 +2,8 @@ package in
 
 import (
 	"context"
+
+	"ridehail/internal/shared/pricing"
 )
 
 // DriverUseCase определяет бизнес-логику управления водителем
@@ -9,8 +11,28 @@ type DriverUseCase interface {
 	GoOnline(ctx context.Context, input GoOnlineInput) (GoOnlineOutput, error)
 	GoOffline(ctx context.Context, input GoOfflineInput) (GoOfflineOutput, error)
 	UpdateLocation(ctx context.Context, input UpdateLocationInput) (UpdateLocationOutput, error)
+	ArriveAtPickup(ctx context.Context, input ArriveAtPickupInput) (ArriveAtPickupOutput, error)
+	ArriveAtStop(ctx context.Context, input ArriveAtStopInput) (ArriveAtStopOutput, error)
 	StartRide(ctx context.Context, input StartRideInput) (StartRideOutput, error)
 	CompleteRide(ctx context.Context, input CompleteRideInput) (CompleteRideOutput, error)
+
+	// GetRoute возвращает план POOL-маршрута водителя: посадки и высадки по порядку
+	GetRoute(ctx context.Context, driverID string) (RouteOutput, error)
+
+	// MarkEnRoute переводит водителя в EN_ROUTE после подтверждения назначения на поездку
+	MarkEnRoute(ctx context.Context, input MarkEnRouteInput) error
+
+	// ReportNoShow отменяет поездку за неявку пассажира после ожидания на точке подачи
+	ReportNoShow(ctx context.Context, input NoShowInput) (NoShowOutput, error)
+
+	// ReleaseCancelledRide освобождает водителя отмененной поездки и начисляет ему долю штрафа
+	ReleaseCancelledRide(ctx context.Context, input ReleaseCancelledRideInput) error
+
+	// SubmitApplication отправляет права, машину и документы водителя на проверку администратору
+	SubmitApplication(ctx context.Context, input SubmitApplicationInput) (ApplicationOutput, error)
+
+	// GetApplication возвращает последнюю заявку водителя и решение по ней
+	GetApplication(ctx context.Context, driverID string) (ApplicationOutput, error)
 }
 
 // GoOnlineInput — входные данные для перехода в онлайн
@@ -59,8 +81,136 @@ type UpdateLocationInput struct {
 
 // UpdateLocationOutput — результат обновления локации
 type UpdateLocationOutput struct {
-	CoordinateID string `json:"coordinate_id"`
-	UpdatedAt    string `json:"updated_at"`
+	CoordinateID string   `json:"coordinate_id"`
+	RideIDs      []string `json:"ride_ids,omitempty"` // Активные поездки водителя (у POOL — несколько)
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+// MarkEnRouteInput — водитель назначен на поездку и едет к точке подачи
+type MarkEnRouteInput struct {
+	DriverID    string `json:"driver_id"`
+	RideID      string `json:"ride_id"`
+	VehicleType string `json:"vehicle_type"`
+}
+
+// NoShowInput — водитель ждал на точке подачи, пассажир не вышел
+type NoShowInput struct {
+	DriverID  string  `json:"driver_id"`
+	RideID    string  `json:"ride_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NoShowOutput — результат отмены за неявку
+type NoShowOutput struct {
+	RideID             string  `json:"ride_id"`
+	Status             string  `json:"status"`
+	CancelledAt        string  `json:"cancelled_at"`
+	WaitedMinutes      int     `json:"waited_minutes"`
+	CancellationFee    float64 `json:"cancellation_fee"`    // Штраф пассажиру
+	DriverCompensation float64 `json:"driver_compensation"` // Доля штрафа водителю
+	Message            string  `json:"message"`
+}
+
+// ReleaseCancelledRideInput — поездка водителя отменена (событие ride.cancelled)
+type ReleaseCancelledRideInput struct {
+	DriverID string `json:"driver_id"`
+	RideID   string `json:"ride_id"`
+}
+
+// DocumentInput — документ водителя в заявке
+type DocumentInput struct {
+	Kind      string `json:"kind"`       // DRIVER_LICENSE | VEHICLE_REGISTRATION | INSURANCE
+	Number    string `json:"number"`     // Номер документа
+	ExpiresOn string `json:"expires_on"` // YYYY-MM-DD
+}
+
+// SubmitApplicationInput — заявка водителя на проверку
+type SubmitApplicationInput struct {
+	DriverID      string          `json:"driver_id"`
+	LicenseNumber string          `json:"license_number"`
+	VehicleType   string          `json:"vehicle_type"`
+	Vehicle       VehicleInput    `json:"vehicle"`
+	Documents     []DocumentInput `json:"documents"`
+}
+
+// VehicleInput — данные машины водителя
+type VehicleInput struct {
+	Make  string `json:"make"`
+	Model string `json:"model"`
+	Color string `json:"color"`
+	Plate string `json:"plate"`
+	Year  int    `json:"year"`
+	Seats int    `json:"seats,omitempty"`
+}
+
+// ApplicationOutput — заявка водителя и ее статус
+type ApplicationOutput struct {
+	ApplicationID string          `json:"application_id"`
+	DriverID      string          `json:"driver_id"`
+	Status        string          `json:"status"` // PENDING | APPROVED | REJECTED | EXPIRED
+	LicenseNumber string          `json:"license_number"`
+	VehicleType   string          `json:"vehicle_type"`
+	Vehicle       VehicleInput    `json:"vehicle"`
+	Documents     []DocumentInput `json:"documents"`
+	Reason        string          `json:"reason,omitempty"` // Причина решения администратора
+	ReviewedAt    string          `json:"reviewed_at,omitempty"`
+	SubmittedAt   string          `json:"submitted_at"`
+}
+
+// RouteWaypoint — точка POOL-маршрута
+type RouteWaypoint struct {
+	RideID    string  `json:"ride_id"`
+	Kind      string  `json:"kind"` // PICKUP | DROPOFF
+	Address   string  `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// RouteOutput — текущий план POOL-маршрута водителя
+type RouteOutput struct {
+	DriverID  string          `json:"driver_id"`
+	Onboard   int             `json:"onboard"` // Пассажиров в машине
+	Waypoints []RouteWaypoint `json:"waypoints"`
+}
+
+// ArriveAtPickupInput — входные данные для отметки прибытия на точку подачи
+type ArriveAtPickupInput struct {
+	DriverID  string  `json:"driver_id"`
+	RideID    string  `json:"ride_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ArriveAtPickupOutput — результат отметки прибытия
+type ArriveAtPickupOutput struct {
+	RideID                 string  `json:"ride_id"`
+	Status                 string  `json:"status"`
+	ArrivedAt              string  `json:"arrived_at"`
+	DistanceToPickupMeters float64 `json:"distance_to_pickup_meters"`
+	FreeWaitingMinutes     int     `json:"free_waiting_minutes"`
+	Message                string  `json:"message"`
+}
+
+// ArriveAtStopInput — входные данные для отметки прибытия на промежуточную остановку
+type ArriveAtStopInput struct {
+	DriverID   string  `json:"driver_id"`
+	RideID     string  `json:"ride_id"`
+	StopNumber int     `json:"stop_number"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+}
+
+// ArriveAtStopOutput — результат отметки прибытия на остановку
+type ArriveAtStopOutput struct {
+	RideID               string  `json:"ride_id"`
+	StopNumber           int     `json:"stop_number"`
+	TotalStops           int     `json:"total_stops"`
+	RemainingStops       int     `json:"remaining_stops"`
+	Address              string  `json:"address"`
+	ArrivedAt            string  `json:"arrived_at"`
+	DistanceToStopMeters float64 `json:"distance_to_stop_meters"`
+	Message              string  `json:"message"`
 }
 
 // StartRideInput — входные данные для старта поездки
@@ -73,10 +223,13 @@ type StartRideInput struct {
 
 // StartRideOutput — результат старта поездки
 type StartRideOutput struct {
-	RideID    string `json:"ride_id"`
-	Status    string `json:"status"`
-	StartedAt string `json:"started_at"`
-	Message   string `json:"message"`
+	RideID         string `json:"ride_id"`
+	Status         string `json:"status"`
+	StartedAt      string `json:"started_at"`
+	WaitingMinutes int    `json:"waiting_minutes"` // Ожидание пассажира после прибытия водителя
+	Message        string `json:"message"`
+
+	Route []RouteWaypoint `json:"route,omitempty"` // POOL: оставшийся план маршрута
 }
 
 // CompleteRideInput — входные данные для завершения поездки
@@ -91,9 +244,14 @@ type CompleteRideInput struct {
 
 // CompleteRideOutput — результат завершения поездки
 type CompleteRideOutput struct {
-	RideID         string  `json:"ride_id"`
-	Status         string  `json:"status"`
-	CompletedAt    string  `json:"completed_at"`
-	DriverEarnings float64 `json:"driver_earnings"`
-	Message        string  `json:"message"`
+	RideID         string            `json:"ride_id"`
+	Status         string            `json:"status"`
+	CompletedAt    string            `json:"completed_at"`
+	FinalFare      float64           `json:"final_fare"`
+	FareBreakdown  pricing.Breakdown `json:"fare_breakdown"`
+	DriverEarnings float64           `json:"driver_earnings"`
+	Message        string            `json:"message"`
+
+	SharedFraction float64         `json:"shared_fraction,omitempty"` // POOL: доля поездки с попутчиками
+	Route          []RouteWaypoint `json:"route,omitempty"`           // POOL: оставшийся план маршрута
 }
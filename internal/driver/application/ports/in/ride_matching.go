@@ -0,0 +1,50 @@
+package in
+
+import "context"
+
+// RideMatchingUseCase определяет подбор водителя для поездки:
+// раунды офферов, таймауты, расширение радиуса и отказ по крайнему сроку
+type RideMatchingUseCase interface {
+	// StartMatching начинает подбор водителя (повторный запрос по той же поездке игнорируется)
+	StartMatching(ctx context.Context, input StartMatchingInput) error
+
+	// HandleOfferResponse фиксирует ответ водителя на оффер.
+	// Возвращает domain.ErrOfferNotFound / domain.ErrOfferExpired для неактуальных офферов.
+	HandleOfferResponse(ctx context.Context, input OfferResponseInput) error
+
+	// StopMatching прекращает подбор (поездка отменена или назначена)
+	StopMatching(ctx context.Context, rideID string) error
+}
+
+// StartMatchingInput — входные данные для подбора водителя
+type StartMatchingInput struct {
+	RideID         string         `json:"ride_id"`
+	RideNumber     string         `json:"ride_number"`
+	VehicleType    string         `json:"vehicle_type"`
+	PickupLat      float64        `json:"pickup_lat"`
+	PickupLng      float64        `json:"pickup_lng"`
+	PickupAddress  string         `json:"pickup_address"`
+	DestLat        float64        `json:"dest_lat"`
+	DestLng        float64        `json:"dest_lng"`
+	DestAddress    string         `json:"dest_address"`
+	Stops          []StopLocation `json:"stops,omitempty"` // промежуточные остановки по порядку
+	EstimatedFare  float64        `json:"estimated_fare"`
+	MaxDistanceKm  float64        `json:"max_distance_km,omitempty"` // 0 — радиус из конфигурации
+	TimeoutSeconds int            `json:"timeout_seconds,omitempty"` // 0 — таймаут из конфигурации
+
+	EstimatedDurationMinutes int `json:"estimated_duration_minutes,omitempty"` // 0 — оценка неизвестна
+}
+
+// StopLocation — промежуточная остановка маршрута
+type StopLocation struct {
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+	Address string  `json:"address"`
+}
+
+// OfferResponseInput — ответ водителя на оффер
+type OfferResponseInput struct {
+	RideID   string `json:"ride_id"`
+	DriverID string `json:"driver_id"`
+	Accepted bool   `json:"accepted"`
+}
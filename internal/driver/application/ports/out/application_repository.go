@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/driver/domain"
+)
+
+// ApplicationRepository — заявки водителей на проверку и их документы
+type ApplicationRepository interface {
+	// CreatePending сохраняет заявку со статусом PENDING вместе с документами.
+	// ErrApplicationPending — у водителя уже есть нерассмотренная заявка.
+	CreatePending(ctx context.Context, app *domain.DriverApplication) error
+
+	// FindLatest возвращает последнюю заявку водителя (ErrApplicationNotFound, если заявок нет)
+	FindLatest(ctx context.Context, driverID string) (*domain.DriverApplication, error)
+
+	// ExpireLapsed снимает с проверки водителей, у которых verified_until раньше today,
+	// и переводит их одобренные заявки в EXPIRED. Возвращает снятых водителей.
+	ExpireLapsed(ctx context.Context, today time.Time, limit int) ([]domain.LapsedVerification, error)
+}
@@ -0,0 +1,10 @@
+package out
+
+// DriverNotifier доставляет подключенному водителю уведомления о его допуске к заказам
+type DriverNotifier interface {
+	// SendVerificationUpdate сообщает водителю решение по заявке или снятие с проверки
+	SendVerificationUpdate(driverID string, update map[string]interface{}) error
+
+	// IsDriverConnected проверяет, подключен ли водитель
+	IsDriverConnected(driverID string) bool
+}
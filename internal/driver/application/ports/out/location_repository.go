@@ -22,6 +22,26 @@ type LocationRepository interface {
 
 	// CheckRateLimit проверяет, можно ли обновить локацию (макс 1 раз в 3 сек)
 	CheckRateLimit(ctx context.Context, driverID string) (bool, error)
+
+	// FindNearbyOnlineDrivers находит ближайших водителей в радиусе, которые могут взять поездку:
+	// status = AVAILABLE, is_verified = true и совпадает vehicle_type
+	FindNearbyOnlineDrivers(ctx context.Context, pickupLat, pickupLng, radiusKm float64, vehicleType string, limit int) ([]NearbyDriverInfo, error)
+
+	// FindPoolDriversOnTrip находит POOL-водителей в радиусе, которые уже везут
+	// или едут за пассажирами (status EN_ROUTE/BUSY) — кандидатов на подсадку
+	FindPoolDriversOnTrip(ctx context.Context, pickupLat, pickupLng, radiusKm float64, limit int) ([]NearbyDriverInfo, error)
+}
+
+// NearbyDriverInfo информация о ближайшем водителе
+type NearbyDriverInfo struct {
+	DriverID    string
+	Distance    float64 // в метрах
+	VehicleType string
+	Rating      float64
+	IdleSeconds float64 // сколько водитель ждет заказа (с последней поездки или начала смены)
+	Lat         float64 // текущая точка водителя
+	Lng         float64
+	Seats       int // vehicle_attrs.vehicle_seats, 0 — не указано
 }
 
 // CreateCoordinateDTO — DTO для создания координат
@@ -0,0 +1,40 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/driver/domain"
+)
+
+// MatchingRepository определяет хранение сессий подбора и отправленных офферов.
+//
+// Сессия не привязана к реплике: ответ водителя, остановка подбора и таймауты
+// обрабатываются любой репликой Driver Service. Методы блокировки вызываются
+// внутри TxManager.WithinTx — блокировка держится до конца транзакции.
+type MatchingRepository interface {
+	// CreateSession сохраняет новую сессию подбора.
+	// false — сессия по этой поездке уже есть (повторная доставка ride.requested).
+	CreateSession(ctx context.Context, session *domain.MatchingSession) (bool, error)
+
+	// LockSession блокирует активную сессию поездки (FOR UPDATE) и возвращает ее с офферами.
+	// Возвращает domain.ErrMatchingSessionNotFound, если активной сессии нет.
+	LockSession(ctx context.Context, rideID string) (*domain.MatchingSession, error)
+
+	// ClaimDueSession блокирует одну активную сессию с due_at <= now, пропуская
+	// заблокированные другими репликами (SKIP LOCKED). nil — таких сессий нет.
+	ClaimDueSession(ctx context.Context, now time.Time) (*domain.MatchingSession, error)
+
+	// SaveSession сохраняет состояние сессии, due_at и офферы
+	SaveSession(ctx context.Context, session *domain.MatchingSession) error
+
+	// CloseSession завершает активную сессию. false — активной сессии не было.
+	CloseSession(ctx context.Context, rideID string) (bool, error)
+
+	// ClaimStaleRequestedRides блокирует поездки REQUESTED, не менявшиеся с olderThan,
+	// у которых нет активной сессии и которые еще не помечены EXPIRED (SKIP LOCKED)
+	ClaimStaleRequestedRides(ctx context.Context, olderThan time.Time, limit int) ([]string, error)
+
+	// MarkSessionExpired помечает подбор поездки истекшим (создает сессию, если ее нет)
+	MarkSessionExpired(ctx context.Context, rideID string) error
+}
@@ -0,0 +1,28 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/driver/domain"
+)
+
+// PoolRepository — план маршрута POOL-водителя (pool_waypoints)
+type PoolRepository interface {
+	// ListOpenWaypoints возвращает непройденные точки плана водителя по порядку.
+	// Точки отмененных и завершенных поездок не возвращаются.
+	ListOpenWaypoints(ctx context.Context, driverID string) ([]domain.PoolWaypoint, error)
+
+	// UpdatePlan перестраивает план в одной транзакции: блокирует строку водителя,
+	// читает открытые точки, передает их в fn и сохраняет результат в новом порядке.
+	// Конкурентные подсадки к одному водителю выполняются по очереди.
+	UpdatePlan(ctx context.Context, driverID string, fn func(plan []domain.PoolWaypoint) ([]domain.PoolWaypoint, error)) error
+
+	// MarkWaypointDone отмечает посадку или высадку пассажира.
+	// false — точки нет в плане или она уже пройдена.
+	MarkWaypointDone(ctx context.Context, rideID string, kind domain.WaypointKind, at time.Time) (bool, error)
+
+	// ListCoRiderIntervals возвращает время в машине других пассажиров водителя,
+	// пересекающееся с [from, to]
+	ListCoRiderIntervals(ctx context.Context, driverID, rideID string, from, to time.Time) ([]domain.TimeInterval, error)
+}
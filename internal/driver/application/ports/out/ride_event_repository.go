@@ -0,0 +1,14 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/shared/ridestate"
+)
+
+// RideEventRepository определяет запись в журнал событий поездки (ride_events)
+type RideEventRepository interface {
+	// Append добавляет событие поездки. Внутри TxManager.WithinTx запись попадает
+	// в ту же транзакцию, что и переход статуса.
+	Append(ctx context.Context, rideID, eventType string, payload ridestate.EventPayload) error
+}
@@ -0,0 +1,10 @@
+package out
+
+// RideOfferSender доставляет офферы поездок подключенным водителям
+type RideOfferSender interface {
+	// SendRideOffer отправляет оффер водителю
+	SendRideOffer(driverID string, offer map[string]interface{}) error
+
+	// IsDriverConnected проверяет, подключен ли водитель
+	IsDriverConnected(driverID string) bool
+}
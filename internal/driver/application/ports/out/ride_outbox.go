@@ -0,0 +1,17 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/shared/contract"
+)
+
+// RideOutbox определяет публикацию изменений статуса поездки через transactional outbox.
+//
+// Сообщение сохраняется в общую таблицу outbox; внутри TxManager.WithinTx — в той же
+// транзакции, что и переход статуса. Доставку в ride_topic выполняет OutboxWorker
+// Ride Service.
+type RideOutbox interface {
+	// EnqueueRideStatus сохраняет ride.* сообщение (routing key — по типу события)
+	EnqueueRideStatus(ctx context.Context, msg *contract.RideStatusChanged) error
+}
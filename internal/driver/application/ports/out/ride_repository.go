@@ -1,20 +1,55 @@
 package out
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/shared/pricing"
+)
 
 // RideRepository определяет операции с поездками в БД
 type RideRepository interface {
 	// FindByID находит поездку по ID
 	FindByID(ctx context.Context, rideID string) (*Ride, error)
 
-	// UpdateRideDriver обновляет водителя для поездки и меняет статус на MATCHED
-	UpdateRideDriver(ctx context.Context, rideID, driverID string) error
+	// FindActiveRideIDs возвращает ID всех поездок, которые водитель сейчас выполняет
+	// (MATCHED, EN_ROUTE, ARRIVED, IN_PROGRESS), в порядке назначения; у POOL их
+	// может быть несколько, пустой список — таких нет
+	FindActiveRideIDs(ctx context.Context, driverID string) ([]string, error)
+
+	// TransitionStatus переводит поездку из статуса from в статус to (ARRIVED, IN_PROGRESS,
+	// COMPLETED) и проставляет соответствующий timestamp (arrived_at, started_at, completed_at).
+	// Переход проверяется по общей state machine: domain.ErrInvalidRideTransition, если он недопустим.
+	// Conditional UPDATE: возвращает domain.ErrRideStatusConflict, если статус в БД не равен from.
+	TransitionStatus(ctx context.Context, rideID, from, to string) error
+
+	// ListStops возвращает промежуточные остановки поездки по возрастанию stop_number
+	ListStops(ctx context.Context, rideID string) ([]RideStop, error)
 
-	// UpdateRideStatus обновляет статус поездки
-	UpdateRideStatus(ctx context.Context, rideID, status string) error
+	// MarkStopArrived проставляет arrived_at остановке.
+	// Conditional UPDATE: возвращает domain.ErrStopAlreadyArrived, если отметка уже есть.
+	MarkStopArrived(ctx context.Context, rideID string, stopNumber int) (time.Time, error)
 
-	// UpdateFinalFare обновляет финальную стоимость поездки
-	UpdateFinalFare(ctx context.Context, rideID string, finalFare float64) error
+	// CancelNoShow отменяет поездку ARRIVED → CANCELLED за неявку пассажира и сохраняет штраф.
+	// Conditional UPDATE: возвращает domain.ErrRideStatusConflict, если статус в БД не ARRIVED.
+	CancelNoShow(ctx context.Context, rideID, reason string, fee float64) (time.Time, error)
+
+	// SettleCancellationFee начисляет водителю долю share штрафа отмененной поездки
+	// (rides.driver_compensation и drivers.total_earnings в одном запросе).
+	// Однократно: false — штрафа нет или доля уже начислена.
+	SettleCancellationFee(ctx context.Context, rideID, driverID string, share float64) (float64, bool, error)
+
+	// UpdateFinalFare сохраняет финальную стоимость поездки (breakdown.Total) и ее детализацию
+	UpdateFinalFare(ctx context.Context, rideID string, breakdown pricing.Breakdown) error
+}
+
+// RideStop — промежуточная остановка поездки (ride_stops + coordinates)
+type RideStop struct {
+	StopNumber int        `json:"stop_number" db:"stop_number"`
+	Address    string     `json:"address" db:"address"`
+	Lat        float64    `json:"lat" db:"latitude"`
+	Lng        float64    `json:"lng" db:"longitude"`
+	ArrivedAt  *time.Time `json:"arrived_at,omitempty" db:"arrived_at"`
 }
 
 // Ride — упрощенная модель поездки для driver service
@@ -24,9 +59,28 @@ type Ride struct {
 	PassengerID             string   `json:"passenger_id" db:"passenger_id"`
 	DriverID                *string  `json:"driver_id,omitempty" db:"driver_id"`
 	VehicleType             string   `json:"vehicle_type" db:"vehicle_type"`
+	City                    string   `json:"city" db:"city"` // Город тарифа, зафиксирован при заказе
 	Status                  string   `json:"status" db:"status"`
 	PickupCoordinateID      *string  `json:"pickup_coordinate_id,omitempty" db:"pickup_coordinate_id"`
 	DestinationCoordinateID *string  `json:"destination_coordinate_id,omitempty" db:"destination_coordinate_id"`
 	EstimatedFare           *float64 `json:"estimated_fare,omitempty" db:"estimated_fare"`
 	FinalFare               *float64 `json:"final_fare,omitempty" db:"final_fare"`
+	SurgeMultiplier         float64  `json:"surge_multiplier" db:"surge_multiplier"` // Зафиксирован при заказе
+
+	// Точка подачи — для проверки прибытия водителя
+	PickupLat *float64 `json:"pickup_lat,omitempty" db:"pickup_lat"`
+	PickupLng *float64 `json:"pickup_lng,omitempty" db:"pickup_lng"`
+
+	// Точка назначения — для плана POOL-маршрута
+	DestLat *float64 `json:"dest_lat,omitempty" db:"dest_lat"`
+	DestLng *float64 `json:"dest_lng,omitempty" db:"dest_lng"`
+
+	// Отметки статусов: ожидание пассажира = started_at − arrived_at
+	ArrivedAt *time.Time `json:"arrived_at,omitempty" db:"arrived_at"`
+	StartedAt *time.Time `json:"started_at,omitempty" db:"started_at"`
+
+	// Оценка маршрута при заказе (из точки назначения) — запасной вариант,
+	// если водитель не передал фактические км/мин
+	EstimatedDistanceKm      *float64 `json:"estimated_distance_km,omitempty" db:"distance_km"`
+	EstimatedDurationMinutes *int     `json:"estimated_duration_minutes,omitempty" db:"duration_minutes"`
 }
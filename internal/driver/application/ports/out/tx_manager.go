@@ -0,0 +1,16 @@
+package out
+
+import "context"
+
+// TxManager — интерфейс для выполнения нескольких операций репозиториев в одной транзакции.
+//
+// Репозитории, вызванные внутри fn с переданным ctx, автоматически используют
+// открытую транзакцию. Ошибка fn (или panic) откатывает транзакцию.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// AfterCommit откладывает fn до коммита транзакции из ctx (при откате fn не
+	// вызывается); без открытой транзакции fn выполняется сразу. Для сообщений
+	// водителям и в брокер: они не должны ссылаться на незафиксированные данные.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
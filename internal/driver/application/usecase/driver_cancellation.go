@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/ridestate"
+)
+
+// ============================================================================
+// Отмены: неявка пассажира и освобождение водителя
+// ============================================================================
+// Штраф за отмену пассажиром считает Ride Service (политика отмены) и пишет
+// в rides.cancellation_fee. Неявку фиксирует водитель: отмена из ARRIVED и
+// штраф пишутся здесь. В обоих случаях водителю начисляется driverEarningsShare
+// от штрафа — однократно, по отметке rides.compensation_paid_at.
+// ============================================================================
+
+// ReportNoShow отменяет поездку, если пассажир не вышел к машине.
+//
+// ПРАВИЛА:
+//   - Поездка назначена этому водителю и находится в ARRIVED
+//   - С arrived_at прошло не меньше max(no_show_wait_minutes, бесплатное ожидание тарифа)
+//   - Пассажир платит cancellation_fee тарифа, водитель получает свою долю штрафа
+func (s *DriverService) ReportNoShow(ctx context.Context, input in.NoShowInput) (in.NoShowOutput, error) {
+	if err := validateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return in.NoShowOutput{}, fmt.Errorf("invalid coordinates: %w", err)
+	}
+
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if err != nil {
+		return in.NoShowOutput{}, fmt.Errorf("find ride: %w", err)
+	}
+
+	if ride.DriverID == nil || *ride.DriverID != input.DriverID {
+		s.log.Error(logger.Entry{
+			Action:  "no_show_driver_mismatch",
+			Message: fmt.Sprintf("driver_id=%s, ride_driver_id=%v", input.DriverID, ride.DriverID),
+			RideID:  input.RideID,
+		})
+		return in.NoShowOutput{}, fmt.Errorf("driver not assigned to this ride")
+	}
+
+	// Неявка возможна только после прибытия на точку подачи
+	if ride.Status != constants.RideStatusArrived || ride.ArrivedAt == nil {
+		return in.NoShowOutput{}, fmt.Errorf("%w: no-show requires %s, ride is %s",
+			domain.ErrInvalidRideTransition, constants.RideStatusArrived, ride.Status)
+	}
+
+	// Водитель ждет не меньше бесплатного ожидания: до его конца пассажир вправе не выйти
+	waitMinutes := s.cancellation.NoShowWaitMinutes
+	freeWaiting, err := s.pricing.FreeWaitingMinutes(ctx, ride.City, ride.VehicleType)
+	if err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "no_show_free_waiting_lookup_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+	waitMinutes = max(waitMinutes, freeWaiting)
+
+	waited := time.Now().UTC().Sub(*ride.ArrivedAt)
+	if waited < time.Duration(waitMinutes)*time.Minute {
+		s.log.Warn(logger.Entry{
+			Action:  "no_show_too_early",
+			Message: fmt.Sprintf("waited %s, required %d min", waited.Round(time.Second), waitMinutes),
+			RideID:  input.RideID,
+		})
+		return in.NoShowOutput{}, fmt.Errorf("%w: waited %d of %d min", domain.ErrNoShowTooEarly, int(waited.Minutes()), waitMinutes)
+	}
+
+	fee, err := s.pricing.CancellationFee(ctx, ride.City, ride.VehicleType)
+	if err != nil {
+		return in.NoShowOutput{}, fmt.Errorf("cancellation fee: %w", err)
+	}
+
+	// ARRIVED → CANCELLED условно (пассажир мог успеть сесть или отменить сам)
+	// и событие RIDE_CANCELLED в журнале поездки — в одной транзакции
+	var cancelledAt time.Time
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		cancelledAt, err = s.rideRepo.CancelNoShow(ctx, input.RideID, constants.CancellationReasonNoShow, fee)
+		if err != nil {
+			return fmt.Errorf("cancel ride: %w", err)
+		}
+		return s.appendRideEvent(ctx, input.RideID, constants.EventRideCancelled, ridestate.EventPayload{
+			OldStatus:       constants.RideStatusArrived,
+			NewStatus:       constants.RideStatusCancelled,
+			OccurredAt:      cancelledAt,
+			DriverID:        input.DriverID,
+			Location:        &ridestate.EventLocation{Lat: input.Latitude, Lng: input.Longitude},
+			Reason:          constants.CancellationReasonNoShow,
+			CancellationFee: &fee,
+			FeeReason:       constants.FeeReasonNoShow,
+		})
+	})
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "no_show_cancel_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return in.NoShowOutput{}, err
+	}
+
+	// Отмена уже записана: сбой начисления или освобождения не отменяет ответ водителю
+	compensation, err := s.settleCancellationFee(ctx, input.DriverID, input.RideID)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "settle_cancellation_fee_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+	if err := s.releaseDriver(ctx, input.DriverID, input.RideID); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "release_driver_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+
+	waitedMinutes := int(waited.Minutes())
+
+	// Ride Service уведомит пассажира об отмене и штрафе
+	if err := s.msgPublisher.PublishPassengerNoShow(ctx, &contract.PassengerNoShow{
+		RideID:          input.RideID,
+		DriverID:        input.DriverID,
+		WaitedMinutes:   waitedMinutes,
+		CancellationFee: fee,
+		CancelledAt:     cancelledAt.UTC().Format(time.RFC3339),
+	}); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "no_show_publish_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "ride_cancelled_no_show",
+		Message: fmt.Sprintf("driver_id=%s, waited=%d min, fee=%.2f", input.DriverID, waitedMinutes, fee),
+		RideID:  input.RideID,
+		Additional: map[string]any{
+			"driver_compensation": compensation,
+		},
+	})
+
+	return in.NoShowOutput{
+		RideID:             input.RideID,
+		Status:             constants.RideStatusCancelled,
+		CancelledAt:        cancelledAt.UTC().Format(time.RFC3339),
+		WaitedMinutes:      waitedMinutes,
+		CancellationFee:    fee,
+		DriverCompensation: compensation,
+		Message:            "Ride cancelled: passenger did not show up",
+	}, nil
+}
+
+// ReleaseCancelledRide обрабатывает отмену поездки пассажиром: начисляет водителю
+// долю штрафа (если он был) и возвращает водителя в подбор
+func (s *DriverService) ReleaseCancelledRide(ctx context.Context, input in.ReleaseCancelledRideInput) error {
+	compensation, err := s.settleCancellationFee(ctx, input.DriverID, input.RideID)
+	if err != nil {
+		return fmt.Errorf("settle cancellation fee: %w", err)
+	}
+	if err := s.releaseDriver(ctx, input.DriverID, input.RideID); err != nil {
+		return fmt.Errorf("release driver: %w", err)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "driver_released_after_cancellation",
+		Message: input.DriverID,
+		RideID:  input.RideID,
+		Additional: map[string]any{
+			"driver_compensation": compensation,
+		},
+	})
+	return nil
+}
+
+// settleCancellationFee начисляет водителю долю штрафа. Начисление идемпотентно
+// (compensation_paid_at), поэтому после ошибки его можно просто повторить.
+func (s *DriverService) settleCancellationFee(ctx context.Context, driverID, rideID string) (float64, error) {
+	compensation, settled, err := s.rideRepo.SettleCancellationFee(ctx, rideID, driverID, driverEarningsShare)
+	if err != nil {
+		return 0, err
+	}
+	if !settled {
+		return 0, nil
+	}
+	return compensation, nil
+}
+
+// releaseDriver возвращает водителя в AVAILABLE после отмены его поездки.
+// Не трогает водителя офлайн или уже занятого другой поездкой; для POOL статус
+// выводится из оставшегося плана.
+func (s *DriverService) releaseDriver(ctx context.Context, driverID, rideID string) error {
+	driver, err := s.driverRepo.FindByID(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("find driver: %w", err)
+	}
+	if driver.Status != domain.DriverStatusEnRoute && driver.Status != domain.DriverStatusBusy {
+		return nil
+	}
+
+	next := domain.DriverStatusAvailable
+	if driver.VehicleType == domain.VehicleTypePool {
+		plan, err := s.poolRepo.ListOpenWaypoints(ctx, driverID)
+		if err != nil {
+			return fmt.Errorf("list open waypoints: %w", err)
+		}
+		next = poolDriverStatus(plan)
+	} else {
+		activeRideIDs, err := s.rideRepo.FindActiveRideIDs(ctx, driverID)
+		if err != nil {
+			return fmt.Errorf("find active rides: %w", err)
+		}
+		if len(activeRideIDs) > 0 {
+			// Водитель уже едет к следующему пассажиру
+			return nil
+		}
+	}
+
+	if next == driver.Status {
+		return nil
+	}
+
+	if err := s.driverRepo.UpdateStatus(ctx, driverID, next); err != nil {
+		return fmt.Errorf("update driver status: %w", err)
+	}
+
+	if err := s.msgPublisher.PublishDriverStatus(ctx, &contract.DriverStatusChanged{
+		DriverID:  driverID,
+		Status:    string(next),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "release_driver_publish_status_failed",
+			Message: err.Error(),
+			RideID:  rideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+	return nil
+}
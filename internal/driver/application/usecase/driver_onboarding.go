@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/domain"
+	"ridehail/internal/shared/logger"
+)
+
+// ============================================================================
+// Проверка водителя: заявка на допуск к заказам
+// ============================================================================
+// Водитель отправляет права, машину и документы; заявку рассматривает Admin
+// Service (одобрение создает/обновляет строку drivers и ставит is_verified).
+// Решение приходит водителю через driver.verification.{driver_id}.
+// Истечение документов отслеживает VerificationExpiryWorker.
+// ============================================================================
+
+// SubmitApplication сохраняет заявку водителя на проверку.
+//
+// ПРАВИЛА:
+//   - Одна нерассмотренная заявка на водителя
+//   - Проверенный водитель может подать новую заявку (например, с продленными
+//     документами) — до решения по ней он остается проверенным
+func (s *DriverService) SubmitApplication(ctx context.Context, input in.SubmitApplicationInput) (in.ApplicationOutput, error) {
+	documents := make([]domain.DriverDocument, 0, len(input.Documents))
+	for _, doc := range input.Documents {
+		expiresOn, err := time.Parse(domain.DateLayout, doc.ExpiresOn)
+		if err != nil {
+			return in.ApplicationOutput{}, fmt.Errorf("%w: %s expires_on must be YYYY-MM-DD", domain.ErrApplicationInvalid, doc.Kind)
+		}
+		documents = append(documents, domain.DriverDocument{
+			Kind:      domain.DocumentKind(doc.Kind),
+			Number:    doc.Number,
+			ExpiresOn: expiresOn,
+		})
+	}
+
+	app := &domain.DriverApplication{
+		DriverID:      input.DriverID,
+		LicenseNumber: input.LicenseNumber,
+		VehicleType:   domain.VehicleType(input.VehicleType),
+		VehicleAttrs: domain.VehicleAttrs{
+			Make:  input.Vehicle.Make,
+			Model: input.Vehicle.Model,
+			Color: input.Vehicle.Color,
+			Plate: input.Vehicle.Plate,
+			Year:  input.Vehicle.Year,
+			Seats: input.Vehicle.Seats,
+		},
+		Documents: documents,
+	}
+
+	now := time.Now().UTC()
+	if err := app.Validate(now.Truncate(24 * time.Hour)); err != nil {
+		return in.ApplicationOutput{}, err
+	}
+
+	if err := s.appRepo.CreatePending(ctx, app); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "driver_application_submit_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"driver_id": input.DriverID,
+			},
+		})
+		return in.ApplicationOutput{}, fmt.Errorf("create application: %w", err)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "driver_application_submitted",
+		Message: fmt.Sprintf("driver_id=%s, application_id=%s", input.DriverID, app.ID),
+		Additional: map[string]any{
+			"vehicle_type": app.VehicleType,
+			"documents":    len(app.Documents),
+		},
+	})
+
+	return toApplicationOutput(app), nil
+}
+
+// GetApplication возвращает последнюю заявку водителя
+func (s *DriverService) GetApplication(ctx context.Context, driverID string) (in.ApplicationOutput, error) {
+	app, err := s.appRepo.FindLatest(ctx, driverID)
+	if err != nil {
+		return in.ApplicationOutput{}, fmt.Errorf("find application: %w", err)
+	}
+	return toApplicationOutput(app), nil
+}
+
+// toApplicationOutput переводит заявку в DTO ответа
+func toApplicationOutput(app *domain.DriverApplication) in.ApplicationOutput {
+	documents := make([]in.DocumentInput, 0, len(app.Documents))
+	for _, doc := range app.Documents {
+		documents = append(documents, in.DocumentInput{
+			Kind:      string(doc.Kind),
+			Number:    doc.Number,
+			ExpiresOn: doc.ExpiresOn.Format(domain.DateLayout),
+		})
+	}
+
+	output := in.ApplicationOutput{
+		ApplicationID: app.ID,
+		DriverID:      app.DriverID,
+		Status:        string(app.Status),
+		LicenseNumber: app.LicenseNumber,
+		VehicleType:   string(app.VehicleType),
+		Vehicle: in.VehicleInput{
+			Make:  app.VehicleAttrs.Make,
+			Model: app.VehicleAttrs.Model,
+			Color: app.VehicleAttrs.Color,
+			Plate: app.VehicleAttrs.Plate,
+			Year:  app.VehicleAttrs.Year,
+			Seats: app.VehicleAttrs.Seats,
+		},
+		Documents:   documents,
+		Reason:      app.ReviewReason,
+		SubmittedAt: app.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if app.ReviewedAt != nil {
+		output.ReviewedAt = app.ReviewedAt.UTC().Format(time.RFC3339)
+	}
+	return output
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/domain"
+	"ridehail/internal/shared/config"
+	"ridehail/internal/shared/logger"
+)
+
+// ============================================================================
+// POOL: совместные поездки
+// ============================================================================
+// У POOL-водителя есть план маршрута — упорядоченные посадки и высадки всех
+// пассажиров (pool_waypoints). Координатор подбора предлагает новую поездку
+// водителю в пути, если ее можно вставить в план (domain.PlanInsertion);
+// после подтверждения назначения (ride.matched) вставка повторяется уже
+// под блокировкой водителя и сохраняется.
+//
+// Статус водителя выводится из плана: есть пассажир в машине — BUSY,
+// план не пуст — EN_ROUTE, план пуст — AVAILABLE.
+// ============================================================================
+
+// GetRoute возвращает текущий план POOL-маршрута водителя
+func (s *DriverService) GetRoute(ctx context.Context, driverID string) (in.RouteOutput, error) {
+	plan, err := s.poolRepo.ListOpenWaypoints(ctx, driverID)
+	if err != nil {
+		return in.RouteOutput{}, fmt.Errorf("list pool waypoints: %w", err)
+	}
+
+	return in.RouteOutput{
+		DriverID:  driverID,
+		Onboard:   domain.OnboardRiders(plan),
+		Waypoints: toRouteWaypoints(plan),
+	}, nil
+}
+
+// joinPoolPlan ставит посадку и высадку пассажира в план водителя.
+// Возвращает число пассажиров в машине.
+func (s *DriverService) joinPoolPlan(ctx context.Context, driverID string, ride *out.Ride) (int, error) {
+	if ride.PickupCoordinateID == nil || ride.DestinationCoordinateID == nil ||
+		ride.PickupLat == nil || ride.PickupLng == nil || ride.DestLat == nil || ride.DestLng == nil {
+		return 0, fmt.Errorf("ride %s has no pickup or destination", ride.ID)
+	}
+
+	pickup := domain.PoolWaypoint{
+		RideID:       ride.ID,
+		Kind:         domain.WaypointPickup,
+		CoordinateID: *ride.PickupCoordinateID,
+		Lat:          *ride.PickupLat,
+		Lng:          *ride.PickupLng,
+	}
+	dropoff := domain.PoolWaypoint{
+		RideID:       ride.ID,
+		Kind:         domain.WaypointDropoff,
+		CoordinateID: *ride.DestinationCoordinateID,
+		Lat:          *ride.DestLat,
+		Lng:          *ride.DestLng,
+	}
+
+	driver, err := s.driverRepo.FindByID(ctx, driverID)
+	if err != nil {
+		return 0, fmt.Errorf("find driver: %w", err)
+	}
+
+	// Без известной локации считаем от точки подачи: вставка все равно валидна
+	startLat, startLng := pickup.Lat, pickup.Lng
+	if loc, err := s.locationRepo.GetCurrentLocation(ctx, driverID, "driver"); err == nil {
+		startLat, startLng = loc.Latitude, loc.Longitude
+	}
+
+	onboard := 0
+	err = s.poolRepo.UpdatePlan(ctx, driverID, func(plan []domain.PoolWaypoint) ([]domain.PoolWaypoint, error) {
+		onboard = domain.OnboardRiders(plan)
+		for _, wp := range plan {
+			if wp.RideID == ride.ID {
+				// Повторная доставка ride.matched
+				return plan, nil
+			}
+		}
+
+		insertion, err := domain.PlanInsertion(startLat, startLng, plan, pickup, dropoff, poolLimits(s.pool, driver.VehicleAttrs.Seats))
+		if err != nil {
+			// Назначение уже подтверждено — отказаться нельзя, пассажир встает в конец плана
+			s.log.Warn(logger.Entry{
+				Action:  "pool_insertion_fallback",
+				Message: err.Error(),
+				RideID:  ride.ID,
+				Additional: map[string]any{
+					"driver_id": driverID,
+					"waypoints": len(plan),
+				},
+			})
+			return domain.AppendToPlan(plan, pickup, dropoff), nil
+		}
+
+		s.log.Info(logger.Entry{
+			Action:  "pool_rider_inserted",
+			Message: fmt.Sprintf("pickup #%d, dropoff #%d, +%.2f km", insertion.PickupIndex+1, insertion.DropoffIndex+1, insertion.AddedKm),
+			RideID:  ride.ID,
+			Additional: map[string]any{
+				"driver_id":     driverID,
+				"max_detour_km": insertion.MaxDetourKm,
+				"onboard":       insertion.Onboard,
+			},
+		})
+		return insertion.Plan, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("update pool plan: %w", err)
+	}
+
+	return onboard, nil
+}
+
+// passPoolWaypoint отмечает посадку/высадку пассажира и возвращает оставшийся план.
+// Вызывается в транзакции перехода статуса поездки.
+func (s *DriverService) passPoolWaypoint(ctx context.Context, driverID, rideID string, kind domain.WaypointKind, at time.Time) ([]domain.PoolWaypoint, error) {
+	if _, err := s.poolRepo.MarkWaypointDone(ctx, rideID, kind, at); err != nil {
+		return nil, fmt.Errorf("mark pool %s done: %w", kind, err)
+	}
+
+	plan, err := s.poolRepo.ListOpenWaypoints(ctx, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("list pool waypoints: %w", err)
+	}
+	return plan, nil
+}
+
+// sharedFraction — доля поездки пассажира [startedAt, now], проведенная с попутчиками
+func (s *DriverService) sharedFraction(ctx context.Context, driverID string, ride *out.Ride, now time.Time) float64 {
+	if ride.StartedAt == nil {
+		return 0
+	}
+
+	intervals, err := s.poolRepo.ListCoRiderIntervals(ctx, driverID, ride.ID, *ride.StartedAt, now)
+	if err != nil {
+		// Без данных о попутчиках скидку не даем: цена не ниже обычного POOL-тарифа
+		s.log.Error(logger.Entry{
+			Action:  "pool_co_rider_intervals_failed",
+			Message: err.Error(),
+			RideID:  ride.ID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return 0
+	}
+
+	return domain.SharedFraction(*ride.StartedAt, now, intervals)
+}
+
+// poolDriverStatus — статус POOL-водителя по оставшемуся плану
+func poolDriverStatus(plan []domain.PoolWaypoint) domain.DriverStatus {
+	switch {
+	case domain.OnboardRiders(plan) > 0:
+		return domain.DriverStatusBusy
+	case len(plan) > 0:
+		return domain.DriverStatusEnRoute
+	default:
+		return domain.DriverStatusAvailable
+	}
+}
+
+// poolLimits — ограничения подсадки; seats из vehicle_attrs, 0 — значение по умолчанию
+func poolLimits(cfg config.PoolConfig, seats int) domain.PoolLimits {
+	if seats <= 0 {
+		seats = cfg.DefaultSeats
+	}
+	return domain.PoolLimits{
+		Seats:          seats,
+		MaxDetourRatio: cfg.MaxDetourRatio,
+		MaxDetourKm:    cfg.MaxDetourKm,
+	}
+}
+
+// toRouteWaypoints переводит план в DTO ответа
+func toRouteWaypoints(plan []domain.PoolWaypoint) []in.RouteWaypoint {
+	route := make([]in.RouteWaypoint, 0, len(plan))
+	for _, wp := range plan {
+		route = append(route, in.RouteWaypoint{
+			RideID:    wp.RideID,
+			Kind:      string(wp.Kind),
+			Address:   wp.Address,
+			Latitude:  wp.Lat,
+			Longitude: wp.Lng,
+		})
+	}
+	return route
+}
@@ -3,37 +3,70 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"ridehail/internal/driver/application/ports/in"
 	"ridehail/internal/driver/application/ports/out"
 	"ridehail/internal/driver/domain"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/pricing"
+	"ridehail/internal/shared/ridestate"
 	"ridehail/internal/shared/utils"
 )
 
 // DriverService реализует бизнес-логику управления водителем
 type DriverService struct {
+	txManager    out.TxManager
 	driverRepo   out.DriverRepository
 	locationRepo out.LocationRepository
 	rideRepo     out.RideRepository
+	poolRepo     out.PoolRepository
+	appRepo      out.ApplicationRepository
+	eventRepo    out.RideEventRepository
+	rideOutbox   out.RideOutbox
 	msgPublisher out.MessagePublisher
+	pricing      *pricing.Engine
+	tracking     config.TrackingConfig
+	pool         config.PoolConfig
+	cancellation config.CancellationConfig
 	log          *logger.Logger
 }
 
 // NewDriverService создает новый сервис управления водителем
 func NewDriverService(
+	txManager out.TxManager,
 	driverRepo out.DriverRepository,
 	locationRepo out.LocationRepository,
 	rideRepo out.RideRepository,
+	poolRepo out.PoolRepository,
+	appRepo out.ApplicationRepository,
+	eventRepo out.RideEventRepository,
+	rideOutbox out.RideOutbox,
 	msgPublisher out.MessagePublisher,
+	pricingEngine *pricing.Engine,
+	tracking config.TrackingConfig,
+	pool config.PoolConfig,
+	cancellation config.CancellationConfig,
 	log *logger.Logger,
 ) *DriverService {
 	return &DriverService{
+		txManager:    txManager,
 		driverRepo:   driverRepo,
 		locationRepo: locationRepo,
 		rideRepo:     rideRepo,
+		poolRepo:     poolRepo,
+		appRepo:      appRepo,
+		eventRepo:    eventRepo,
+		rideOutbox:   rideOutbox,
 		msgPublisher: msgPublisher,
+		pricing:      pricingEngine,
+		tracking:     tracking,
+		pool:         pool,
+		cancellation: cancellation,
 		log:          log,
 	}
 }
@@ -133,9 +166,9 @@ func (s *DriverService) GoOnline(ctx context.Context, input in.GoOnlineInput) (i
 	}
 
 	// Публикуем событие изменения статуса водителя
-	if err := s.msgPublisher.PublishDriverStatus(ctx, &out.DriverStatusDTO{
+	if err := s.msgPublisher.PublishDriverStatus(ctx, &contract.DriverStatusChanged{
 		DriverID:  input.DriverID,
-		Status:    domain.DriverStatusAvailable,
+		Status:    string(domain.DriverStatusAvailable),
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}); err != nil {
 		// Логируем ошибку, но не прерываем поток (eventual consistency)
@@ -226,9 +259,9 @@ func (s *DriverService) GoOffline(ctx context.Context, input in.GoOfflineInput)
 	}
 
 	// Публикуем событие изменения статуса водителя
-	if err := s.msgPublisher.PublishDriverStatus(ctx, &out.DriverStatusDTO{
+	if err := s.msgPublisher.PublishDriverStatus(ctx, &contract.DriverStatusChanged{
 		DriverID:  input.DriverID,
-		Status:    domain.DriverStatusOffline,
+		Status:    string(domain.DriverStatusOffline),
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}); err != nil {
 		// Логируем ошибку, но не прерываем поток
@@ -272,7 +305,7 @@ func (s *DriverService) UpdateLocation(ctx context.Context, input in.UpdateLocat
 				Msg: err.Error(),
 			},
 		})
-		return in.UpdateLocationOutput{}, fmt.Errorf("invalid coordinates: %w", err)
+		return in.UpdateLocationOutput{}, fmt.Errorf("%w: %v", domain.ErrInvalidCoordinates, err)
 	}
 
 	// Проверяем rate limit (макс 1 раз в 3 секунды)
@@ -309,55 +342,424 @@ func (s *DriverService) UpdateLocation(ctx context.Context, input in.UpdateLocat
 		return in.UpdateLocationOutput{}, fmt.Errorf("update location: %w", err)
 	}
 
+	// Активные поездки нужны истории (location_history.ride_id) и потребителям
+	// location_fanout, чтобы найти пассажиров. Ошибка не критична: точка ляжет
+	// в историю без поездки, а Ride Service умеет искать поездки по driver_id сам.
+	rideIDs, err := s.rideRepo.FindActiveRideIDs(ctx, input.DriverID)
+	if err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "update_location_find_active_rides_failed",
+			Message: err.Error(),
+			Additional: map[string]any{
+				"driver_id": input.DriverID,
+			},
+		})
+	}
+
+	// По сообщению и строке истории на каждую активную поездку (POOL везет
+	// несколько пассажиров, у каждого свой трек), без поездки — одно без ride_id
+	targets := rideIDs
+	if len(targets) == 0 {
+		targets = []string{""}
+	}
+
 	// Архивируем в location_history
-	if err := s.locationRepo.ArchiveToHistory(ctx, &out.LocationHistoryDTO{
-		CoordinateID:   coordinateID,
-		DriverID:       input.DriverID,
-		Latitude:       input.Latitude,
-		Longitude:      input.Longitude,
-		AccuracyMeters: input.AccuracyMeters,
-		SpeedKmh:       input.SpeedKmh,
-		HeadingDegrees: input.HeadingDegrees,
+	for _, rideID := range targets {
+		history := &out.LocationHistoryDTO{
+			CoordinateID:   coordinateID,
+			DriverID:       input.DriverID,
+			Latitude:       input.Latitude,
+			Longitude:      input.Longitude,
+			AccuracyMeters: input.AccuracyMeters,
+			SpeedKmh:       input.SpeedKmh,
+			HeadingDegrees: input.HeadingDegrees,
+		}
+		if rideID != "" {
+			history.RideID = &rideID
+		}
+		if err := s.locationRepo.ArchiveToHistory(ctx, history); err != nil {
+			// Логируем ошибку, но не прерываем поток
+			s.log.Error(logger.Entry{
+				Action:  "update_location_archive_failed",
+				Message: err.Error(),
+				RideID:  rideID,
+				Error: &logger.ErrObj{
+					Msg: err.Error(),
+				},
+			})
+		}
+	}
+
+	// Публикуем обновление локации в fanout exchange
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	for _, rideID := range targets {
+		if err := s.msgPublisher.PublishLocationUpdate(ctx, &contract.LocationUpdate{
+			DriverID: input.DriverID,
+			RideID:   rideID,
+			Location: contract.LatLng{
+				Lat: input.Latitude,
+				Lng: input.Longitude,
+			},
+			SpeedKmh:       input.SpeedKmh,
+			HeadingDegrees: input.HeadingDegrees,
+			Timestamp:      timestamp,
+		}); err != nil {
+			// Логируем ошибку, но не прерываем поток
+			s.log.Error(logger.Entry{
+				Action:  "update_location_publish_failed",
+				Message: err.Error(),
+				RideID:  rideID,
+				Error: &logger.ErrObj{
+					Msg: err.Error(),
+				},
+			})
+		}
+	}
+
+	s.log.Debug(logger.Entry{
+		Action:  "location_updated",
+		Message: fmt.Sprintf("driver_id=%s, coordinate_id=%s", input.DriverID, coordinateID),
+	})
+
+	return in.UpdateLocationOutput{
+		CoordinateID: coordinateID,
+		RideIDs:      rideIDs,
+		UpdatedAt:    timestamp,
+	}, nil
+}
+
+// MarkEnRoute переводит водителя в EN_ROUTE: назначение подтверждено (ride.matched),
+// водитель едет к точке подачи. POOL-пассажир встает в план маршрута; если
+// в машине уже кто-то едет, водитель остается BUSY.
+func (s *DriverService) MarkEnRoute(ctx context.Context, input in.MarkEnRouteInput) error {
+	status := domain.DriverStatusEnRoute
+
+	if input.VehicleType == constants.VehiclePool {
+		ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+		if err != nil {
+			return fmt.Errorf("find ride: %w", err)
+		}
+		onboard, err := s.joinPoolPlan(ctx, input.DriverID, ride)
+		if err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "mark_en_route_pool_plan_failed",
+				Message: err.Error(),
+				RideID:  input.RideID,
+				Error: &logger.ErrObj{
+					Msg: err.Error(),
+				},
+			})
+			return fmt.Errorf("join pool plan: %w", err)
+		}
+		if onboard > 0 {
+			status = domain.DriverStatusBusy
+		}
+	}
+
+	if err := s.driverRepo.UpdateStatus(ctx, input.DriverID, status); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "mark_en_route_update_driver_status_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return fmt.Errorf("update driver status: %w", err)
+	}
+
+	if err := s.msgPublisher.PublishDriverStatus(ctx, &contract.DriverStatusChanged{
+		DriverID:  input.DriverID,
+		Status:    string(status),
+		RideID:    input.RideID,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}); err != nil {
-		// Логируем ошибку, но не прерываем поток
 		s.log.Error(logger.Entry{
-			Action:  "update_location_archive_failed",
+			Action:  "mark_en_route_publish_status_failed",
 			Message: err.Error(),
+			RideID:  input.RideID,
 			Error: &logger.ErrObj{
 				Msg: err.Error(),
 			},
 		})
 	}
 
-	// Публикуем обновление локации в fanout exchange
-	if err := s.msgPublisher.PublishLocationUpdate(ctx, &out.LocationUpdateDTO{
-		DriverID: input.DriverID,
-		Location: out.LocationDTO{
-			Lat: input.Latitude,
-			Lng: input.Longitude,
-		},
-		SpeedKmh:       input.SpeedKmh,
-		HeadingDegrees: input.HeadingDegrees,
-		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+	s.log.Info(logger.Entry{
+		Action:  "driver_en_route",
+		Message: fmt.Sprintf("driver_id=%s, ride_id=%s, status=%s", input.DriverID, input.RideID, status),
+		RideID:  input.RideID,
+	})
+
+	return nil
+}
+
+// ArriveAtPickup отмечает прибытие водителя на точку подачи.
+// С этого момента идет ожидание пассажира (платное сверх бесплатных минут тарифа).
+func (s *DriverService) ArriveAtPickup(ctx context.Context, input in.ArriveAtPickupInput) (in.ArriveAtPickupOutput, error) {
+	// Валидация координат
+	if err := validateCoordinates(input.Latitude, input.Longitude); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "arrive_invalid_coordinates",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return in.ArriveAtPickupOutput{}, fmt.Errorf("invalid coordinates: %w", err)
+	}
+
+	// Получаем поездку
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "arrive_ride_not_found",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return in.ArriveAtPickupOutput{}, fmt.Errorf("find ride: %w", err)
+	}
+
+	// Проверяем, что водитель назначен на эту поездку
+	if ride.DriverID == nil || *ride.DriverID != input.DriverID {
+		s.log.Error(logger.Entry{
+			Action:  "arrive_driver_mismatch",
+			Message: fmt.Sprintf("driver_id=%s, ride_driver_id=%v", input.DriverID, ride.DriverID),
+			RideID:  input.RideID,
+		})
+		return in.ArriveAtPickupOutput{}, fmt.Errorf("driver not assigned to this ride")
+	}
+
+	// Прибыть можно только к поездке, на которую водитель едет
+	if err := domain.CheckRideTransition(ride.Status, constants.RideStatusArrived); err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "arrive_invalid_status",
+			Message: err.Error(),
+			RideID:  input.RideID,
+		})
+		return in.ArriveAtPickupOutput{}, err
+	}
+
+	// Водитель должен быть рядом с точкой подачи, иначе ожидание начнется раньше времени
+	if ride.PickupLat == nil || ride.PickupLng == nil {
+		return in.ArriveAtPickupOutput{}, fmt.Errorf("ride has no pickup location")
+	}
+	distance := domain.DistanceMeters(input.Latitude, input.Longitude, *ride.PickupLat, *ride.PickupLng)
+	if distance > s.tracking.ArrivalRadiusMeters {
+		s.log.Warn(logger.Entry{
+			Action:  "arrive_too_far_from_pickup",
+			Message: fmt.Sprintf("distance %.0fm, allowed %.0fm", distance, s.tracking.ArrivalRadiusMeters),
+			RideID:  input.RideID,
+		})
+		return in.ArriveAtPickupOutput{}, fmt.Errorf("%w: %.0fm away, allowed %.0fm", domain.ErrTooFarFromPickup, distance, s.tracking.ArrivalRadiusMeters)
+	}
+
+	// EN_ROUTE → ARRIVED (условный UPDATE, проставляет arrived_at) и событие
+	// DRIVER_ARRIVED в журнале поездки — в одной транзакции
+	arrivedAt := time.Now().UTC()
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.rideRepo.TransitionStatus(ctx, input.RideID, ride.Status, constants.RideStatusArrived); err != nil {
+			return fmt.Errorf("update ride status: %w", err)
+		}
+		return s.appendRideEvent(ctx, input.RideID, constants.EventDriverArrived, ridestate.EventPayload{
+			OldStatus:  ride.Status,
+			NewStatus:  constants.RideStatusArrived,
+			OccurredAt: arrivedAt,
+			DriverID:   input.DriverID,
+			Location:   &ridestate.EventLocation{Lat: input.Latitude, Lng: input.Longitude},
+		})
+	})
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "arrive_update_status_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return in.ArriveAtPickupOutput{}, err
+	}
+
+	// Бесплатное ожидание — для пассажира; без тарифа уведомление все равно уходит
+	freeWaiting, err := s.pricing.FreeWaitingMinutes(ctx, ride.City, ride.VehicleType)
+	if err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "arrive_free_waiting_lookup_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+	}
+
+	// Ride Service уведомит пассажира
+	if err := s.msgPublisher.PublishDriverArrived(ctx, &contract.DriverArrived{
+		RideID:                 input.RideID,
+		DriverID:               input.DriverID,
+		Location:               contract.LatLng{Lat: input.Latitude, Lng: input.Longitude},
+		DistanceToPickupMeters: distance,
+		FreeWaitingMinutes:     freeWaiting,
+		ArrivedAt:              arrivedAt.Format(time.RFC3339),
 	}); err != nil {
-		// Логируем ошибку, но не прерываем поток
 		s.log.Error(logger.Entry{
-			Action:  "update_location_publish_failed",
+			Action:  "arrive_publish_failed",
 			Message: err.Error(),
+			RideID:  input.RideID,
 			Error: &logger.ErrObj{
 				Msg: err.Error(),
 			},
 		})
 	}
 
-	s.log.Debug(logger.Entry{
-		Action:  "location_updated",
-		Message: fmt.Sprintf("driver_id=%s, coordinate_id=%s", input.DriverID, coordinateID),
+	s.log.Info(logger.Entry{
+		Action:  "driver_arrived",
+		Message: fmt.Sprintf("driver_id=%s, ride_id=%s, distance=%.0fm", input.DriverID, input.RideID, distance),
+		RideID:  input.RideID,
 	})
 
-	return in.UpdateLocationOutput{
-		CoordinateID: coordinateID,
-		UpdatedAt:    time.Now().UTC().Format(time.RFC3339),
+	return in.ArriveAtPickupOutput{
+		RideID:                 input.RideID,
+		Status:                 constants.RideStatusArrived,
+		ArrivedAt:              arrivedAt.Format(time.RFC3339),
+		DistanceToPickupMeters: math.Round(distance),
+		FreeWaitingMinutes:     freeWaiting,
+		Message:                "Arrival at pickup confirmed",
+	}, nil
+}
+
+// ArriveAtStop отмечает прибытие на промежуточную остановку.
+// Остановки отмечаются строго по порядку и только в пути (IN_PROGRESS).
+func (s *DriverService) ArriveAtStop(ctx context.Context, input in.ArriveAtStopInput) (in.ArriveAtStopOutput, error) {
+	// Валидация координат
+	if err := validateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return in.ArriveAtStopOutput{}, fmt.Errorf("invalid coordinates: %w", err)
+	}
+
+	// Получаем поездку
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "stop_arrive_ride_not_found",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+		return in.ArriveAtStopOutput{}, fmt.Errorf("find ride: %w", err)
+	}
+
+	// Проверяем, что водитель назначен на эту поездку
+	if ride.DriverID == nil || *ride.DriverID != input.DriverID {
+		s.log.Error(logger.Entry{
+			Action:  "stop_arrive_driver_mismatch",
+			Message: fmt.Sprintf("driver_id=%s, ride_driver_id=%v", input.DriverID, ride.DriverID),
+			RideID:  input.RideID,
+		})
+		return in.ArriveAtStopOutput{}, fmt.Errorf("driver not assigned to this ride")
+	}
+
+	// Остановки лежат между подачей и назначением — пассажир уже в машине
+	if ride.Status != constants.RideStatusInProgress {
+		s.log.Warn(logger.Entry{
+			Action:  "stop_arrive_invalid_status",
+			Message: fmt.Sprintf("ride status %s, expected %s", ride.Status, constants.RideStatusInProgress),
+			RideID:  input.RideID,
+		})
+		return in.ArriveAtStopOutput{}, fmt.Errorf("%w: stops can be visited only in %s, ride is %s",
+			domain.ErrInvalidRideTransition, constants.RideStatusInProgress, ride.Status)
+	}
+
+	stops, err := s.rideRepo.ListStops(ctx, input.RideID)
+	if err != nil {
+		return in.ArriveAtStopOutput{}, fmt.Errorf("list ride stops: %w", err)
+	}
+	if input.StopNumber < 1 || input.StopNumber > len(stops) {
+		return in.ArriveAtStopOutput{}, fmt.Errorf("%w: stop %d of %d", domain.ErrStopNotFound, input.StopNumber, len(stops))
+	}
+
+	stop := stops[input.StopNumber-1]
+	if stop.ArrivedAt != nil {
+		return in.ArriveAtStopOutput{}, fmt.Errorf("%w: stop %d", domain.ErrStopAlreadyArrived, input.StopNumber)
+	}
+	for _, prev := range stops[:input.StopNumber-1] {
+		if prev.ArrivedAt == nil {
+			return in.ArriveAtStopOutput{}, fmt.Errorf("%w: stop %d is next", domain.ErrStopOutOfOrder, prev.StopNumber)
+		}
+	}
+
+	// Водитель должен быть рядом с остановкой
+	distance := domain.DistanceMeters(input.Latitude, input.Longitude, stop.Lat, stop.Lng)
+	if distance > s.tracking.ArrivalRadiusMeters {
+		s.log.Warn(logger.Entry{
+			Action:  "stop_arrive_too_far",
+			Message: fmt.Sprintf("stop %d: distance %.0fm, allowed %.0fm", input.StopNumber, distance, s.tracking.ArrivalRadiusMeters),
+			RideID:  input.RideID,
+		})
+		return in.ArriveAtStopOutput{}, fmt.Errorf("%w: %.0fm away, allowed %.0fm", domain.ErrTooFarFromStop, distance, s.tracking.ArrivalRadiusMeters)
+	}
+
+	// Условная отметка (при двойном нажатии пройдет только первая) и событие
+	// STOP_ARRIVED в журнале поездки — в одной транзакции
+	var arrivedAt time.Time
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		arrivedAt, err = s.rideRepo.MarkStopArrived(ctx, input.RideID, input.StopNumber)
+		if err != nil {
+			return fmt.Errorf("mark stop arrived: %w", err)
+		}
+		return s.appendRideEvent(ctx, input.RideID, constants.EventStopArrived, ridestate.EventPayload{
+			OccurredAt: arrivedAt,
+			DriverID:   input.DriverID,
+			Location:   &ridestate.EventLocation{Lat: input.Latitude, Lng: input.Longitude},
+			StopNumber: input.StopNumber,
+		})
+	})
+	if err != nil {
+		return in.ArriveAtStopOutput{}, err
+	}
+
+	// Ride Service уведомит пассажира
+	if err := s.msgPublisher.PublishStopArrived(ctx, &contract.StopArrived{
+		RideID:               input.RideID,
+		DriverID:             input.DriverID,
+		StopNumber:           input.StopNumber,
+		TotalStops:           len(stops),
+		Address:              stop.Address,
+		Location:             contract.LatLng{Lat: input.Latitude, Lng: input.Longitude},
+		DistanceToStopMeters: distance,
+		ArrivedAt:            arrivedAt.Format(time.RFC3339),
+	}); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "stop_arrive_publish_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error: &logger.ErrObj{
+				Msg: err.Error(),
+			},
+		})
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "driver_arrived_at_stop",
+		Message: fmt.Sprintf("driver_id=%s, stop=%d/%d, distance=%.0fm", input.DriverID, input.StopNumber, len(stops), distance),
+		RideID:  input.RideID,
+	})
+
+	return in.ArriveAtStopOutput{
+		RideID:               input.RideID,
+		StopNumber:           input.StopNumber,
+		TotalStops:           len(stops),
+		RemainingStops:       len(stops) - input.StopNumber,
+		Address:              stop.Address,
+		ArrivedAt:            arrivedAt.Format(time.RFC3339),
+		DistanceToStopMeters: math.Round(distance),
+		Message:              "Arrival at stop confirmed",
 	}, nil
 }
 
@@ -399,8 +801,57 @@ func (s *DriverService) StartRide(ctx context.Context, input in.StartRideInput)
 		return in.StartRideOutput{}, fmt.Errorf("driver not assigned to this ride")
 	}
 
-	// Обновляем статус поездки на IN_PROGRESS
-	if err := s.rideRepo.UpdateRideStatus(ctx, input.RideID, "IN_PROGRESS"); err != nil {
+	// Начать можно только поездку, к которой водитель уже прибыл
+	if err := domain.CheckRideTransition(ride.Status, constants.RideStatusInProgress); err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "start_ride_invalid_status",
+			Message: err.Error(),
+			RideID:  input.RideID,
+		})
+		return in.StartRideOutput{}, err
+	}
+
+	now := time.Now().UTC()
+	location := &ridestate.EventLocation{Lat: input.Latitude, Lng: input.Longitude}
+
+	// ARRIVED → IN_PROGRESS (условный UPDATE), событие RIDE_STARTED в журнале и ride.started
+	// в outbox, для POOL — посадка пассажира в плане маршрута: все в одной транзакции
+	var plan []domain.PoolWaypoint
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.rideRepo.TransitionStatus(ctx, input.RideID, ride.Status, constants.RideStatusInProgress); err != nil {
+			return fmt.Errorf("update ride status: %w", err)
+		}
+		if err := s.appendRideEvent(ctx, input.RideID, constants.EventRideStarted, ridestate.EventPayload{
+			OldStatus:  ride.Status,
+			NewStatus:  constants.RideStatusInProgress,
+			OccurredAt: now,
+			DriverID:   input.DriverID,
+			Location:   location,
+		}); err != nil {
+			return err
+		}
+		if err := s.enqueueRideStatus(ctx, ride, constants.EventRideStarted, constants.RideStatusInProgress, map[string]interface{}{
+			"started_at": now.Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+
+		// POOL: посадка пассажира — отдельное событие с числом пассажиров в машине
+		if ride.VehicleType != constants.VehiclePool {
+			return nil
+		}
+		var err error
+		if plan, err = s.passPoolWaypoint(ctx, input.DriverID, input.RideID, domain.WaypointPickup, now); err != nil {
+			return err
+		}
+		return s.appendRideEvent(ctx, input.RideID, constants.EventPassengerPickedUp, ridestate.EventPayload{
+			OccurredAt: now,
+			DriverID:   input.DriverID,
+			Location:   location,
+			Pool:       &ridestate.PoolEvent{Onboard: domain.OnboardRiders(plan)},
+		})
+	})
+	if err != nil {
 		s.log.Error(logger.Entry{
 			Action:  "start_ride_update_status_failed",
 			Message: err.Error(),
@@ -409,7 +860,7 @@ func (s *DriverService) StartRide(ctx context.Context, input in.StartRideInput)
 				Msg: err.Error(),
 			},
 		})
-		return in.StartRideOutput{}, fmt.Errorf("update ride status: %w", err)
+		return in.StartRideOutput{}, err
 	}
 
 	// Обновляем статус водителя на BUSY
@@ -426,9 +877,9 @@ func (s *DriverService) StartRide(ctx context.Context, input in.StartRideInput)
 	}
 
 	// Публикуем изменение статуса водителя
-	if err := s.msgPublisher.PublishDriverStatus(ctx, &out.DriverStatusDTO{
+	if err := s.msgPublisher.PublishDriverStatus(ctx, &contract.DriverStatusChanged{
 		DriverID:  input.DriverID,
-		Status:    domain.DriverStatusBusy,
+		Status:    string(domain.DriverStatusBusy),
 		RideID:    input.RideID,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}); err != nil {
@@ -442,19 +893,31 @@ func (s *DriverService) StartRide(ctx context.Context, input in.StartRideInput)
 		})
 	}
 
-	startedAt := time.Now().UTC().Format(time.RFC3339)
+	startedAt := now.Format(time.RFC3339)
+
+	var route []in.RouteWaypoint
+	if ride.VehicleType == constants.VehiclePool {
+		route = toRouteWaypoints(plan)
+	}
+
+	waitingMinutes := 0
+	if ride.ArrivedAt != nil {
+		waitingMinutes = pricing.WaitingMinutes(*ride.ArrivedAt, now)
+	}
 
 	s.log.Info(logger.Entry{
 		Action:  "ride_started",
-		Message: fmt.Sprintf("driver_id=%s, ride_id=%s", input.DriverID, input.RideID),
+		Message: fmt.Sprintf("driver_id=%s, ride_id=%s, waited=%dm", input.DriverID, input.RideID, waitingMinutes),
 		RideID:  input.RideID,
 	})
 
 	return in.StartRideOutput{
-		RideID:    input.RideID,
-		Status:    "IN_PROGRESS",
-		StartedAt: startedAt,
-		Message:   "Ride started successfully",
+		RideID:         input.RideID,
+		Status:         constants.RideStatusInProgress,
+		StartedAt:      startedAt,
+		WaitingMinutes: waitingMinutes,
+		Message:        "Ride started successfully",
+		Route:          route,
 	}, nil
 }
 
@@ -497,39 +960,105 @@ func (s *DriverService) CompleteRide(ctx context.Context, input in.CompleteRideI
 		return in.CompleteRideOutput{}, fmt.Errorf("driver not assigned to this ride")
 	}
 
-	// Вычисляем финальную стоимость (можно использовать базовую логику)
-	// Берем estimate или пересчитываем на основе actual distance/duration
-	var finalFare float64
-	if ride.EstimatedFare != nil {
-		// Используем примерную оценку, можно улучшить расчет
-		finalFare = *ride.EstimatedFare
-		// Можно добавить коррекцию на основе фактических данных
-		if input.ActualDistanceKm > 0 {
-			// Простая логика: базовая ставка + стоимость за км + стоимость за минуту
-			baseRate := 500.0 // для ECONOMY
-			ratePerKm := 100.0
-			ratePerMin := 50.0
-			finalFare = baseRate + (input.ActualDistanceKm * ratePerKm) + (float64(input.ActualDurationMinutes) * ratePerMin)
-		}
-	} else {
-		finalFare = 1000.0 // fallback
+	// Завершить можно только начатую поездку
+	if err := domain.CheckRideTransition(ride.Status, constants.RideStatusCompleted); err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "complete_ride_invalid_status",
+			Message: err.Error(),
+			RideID:  input.RideID,
+		})
+		return in.CompleteRideOutput{}, err
 	}
 
-	// Обновляем финальную стоимость поездки
-	if err := s.rideRepo.UpdateFinalFare(ctx, input.RideID, finalFare); err != nil {
+	// Финальная стоимость — тот же тариф, что и оценка, но по фактическим км/мин
+	trip := pricing.Trip{
+		City:            ride.City,
+		VehicleType:     ride.VehicleType,
+		DistanceKm:      input.ActualDistanceKm,
+		DurationMinutes: input.ActualDurationMinutes,
+		SurgeMultiplier: ride.SurgeMultiplier,
+	}
+	// Ожидание пассажира: от прибытия водителя до старта поездки (метки из БД)
+	if ride.ArrivedAt != nil && ride.StartedAt != nil {
+		trip.WaitingMinutes = pricing.WaitingMinutes(*ride.ArrivedAt, *ride.StartedAt)
+	}
+	isPool := ride.VehicleType == constants.VehiclePool
+	now := time.Now().UTC()
+	sharedFraction := 0.0
+	if isPool {
+		// Попутчик не платит за объезды ради других: цена — по его собственному маршруту,
+		// минус скидка пропорционально времени, проведенному с попутчиками
+		trip.DistanceKm, trip.DurationMinutes = 0, 0
+		sharedFraction = s.sharedFraction(ctx, input.DriverID, ride, now)
+		trip.PoolDiscount = s.pool.SharedDiscount * sharedFraction
+	}
+	if trip.DistanceKm <= 0 && ride.EstimatedDistanceKm != nil {
+		trip.DistanceKm = *ride.EstimatedDistanceKm
+	}
+	if trip.DurationMinutes <= 0 && ride.EstimatedDurationMinutes != nil {
+		trip.DurationMinutes = *ride.EstimatedDurationMinutes
+	}
+
+	fare, err := s.pricing.Quote(ctx, trip)
+	if err != nil {
 		s.log.Error(logger.Entry{
-			Action:  "complete_ride_update_fare_failed",
+			Action:  "complete_ride_calculate_fare_failed",
 			Message: err.Error(),
 			RideID:  input.RideID,
 			Error: &logger.ErrObj{
 				Msg: err.Error(),
 			},
 		})
-		return in.CompleteRideOutput{}, fmt.Errorf("update final fare: %w", err)
+		return in.CompleteRideOutput{}, fmt.Errorf("calculate final fare: %w", err)
 	}
+	finalFare := fare.Total
+
+	location := &ridestate.EventLocation{Lat: input.FinalLatitude, Lng: input.FinalLongitude}
+
+	// Одна транзакция: сначала условный переход IN_PROGRESS → COMPLETED (при гонке
+	// проигравший не перезапишет стоимость), затем финальная стоимость, событие
+	// RIDE_COMPLETED в журнале и ride.completed в outbox, для POOL — высадка пассажира
+	var plan []domain.PoolWaypoint
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.rideRepo.TransitionStatus(ctx, input.RideID, ride.Status, constants.RideStatusCompleted); err != nil {
+			return fmt.Errorf("update ride status: %w", err)
+		}
+		if err := s.rideRepo.UpdateFinalFare(ctx, input.RideID, fare); err != nil {
+			return fmt.Errorf("update final fare: %w", err)
+		}
+		if err := s.appendRideEvent(ctx, input.RideID, constants.EventRideCompleted, ridestate.EventPayload{
+			OldStatus:     ride.Status,
+			NewStatus:     constants.RideStatusCompleted,
+			OccurredAt:    now,
+			DriverID:      input.DriverID,
+			FinalFare:     &finalFare,
+			FareBreakdown: &fare,
+			Location:      location,
+		}); err != nil {
+			return err
+		}
+		if err := s.enqueueRideStatus(ctx, ride, constants.EventRideCompleted, constants.RideStatusCompleted, map[string]interface{}{
+			"completed_at": now.Format(time.RFC3339),
+			"final_fare":   finalFare,
+		}); err != nil {
+			return err
+		}
 
-	// Обновляем статус поездки на COMPLETED
-	if err := s.rideRepo.UpdateRideStatus(ctx, input.RideID, "COMPLETED"); err != nil {
+		if !isPool {
+			return nil
+		}
+		var err error
+		if plan, err = s.passPoolWaypoint(ctx, input.DriverID, input.RideID, domain.WaypointDropoff, now); err != nil {
+			return err
+		}
+		return s.appendRideEvent(ctx, input.RideID, constants.EventPassengerDroppedOff, ridestate.EventPayload{
+			OccurredAt: now,
+			DriverID:   input.DriverID,
+			Location:   location,
+			Pool:       &ridestate.PoolEvent{Onboard: domain.OnboardRiders(plan), SharedFraction: sharedFraction},
+		})
+	})
+	if err != nil {
 		s.log.Error(logger.Entry{
 			Action:  "complete_ride_update_status_failed",
 			Message: err.Error(),
@@ -538,11 +1067,19 @@ func (s *DriverService) CompleteRide(ctx context.Context, input in.CompleteRideI
 				Msg: err.Error(),
 			},
 		})
-		return in.CompleteRideOutput{}, fmt.Errorf("update ride status: %w", err)
+		return in.CompleteRideOutput{}, err
 	}
 
-	// Обновляем статус водителя на AVAILABLE
-	if err := s.driverRepo.UpdateStatus(ctx, input.DriverID, domain.DriverStatusAvailable); err != nil {
+	// POOL: водитель свободен, только когда план маршрута пуст
+	driverStatus := domain.DriverStatusAvailable
+	var route []in.RouteWaypoint
+	if isPool {
+		driverStatus = poolDriverStatus(plan)
+		route = toRouteWaypoints(plan)
+	}
+
+	// Обновляем статус водителя (AVAILABLE, если больше никого не везет)
+	if err := s.driverRepo.UpdateStatus(ctx, input.DriverID, driverStatus); err != nil {
 		s.log.Error(logger.Entry{
 			Action:  "complete_ride_update_driver_status_failed",
 			Message: err.Error(),
@@ -571,9 +1108,9 @@ func (s *DriverService) CompleteRide(ctx context.Context, input in.CompleteRideI
 	}
 
 	// Публикуем изменение статуса водителя
-	if err := s.msgPublisher.PublishDriverStatus(ctx, &out.DriverStatusDTO{
+	if err := s.msgPublisher.PublishDriverStatus(ctx, &contract.DriverStatusChanged{
 		DriverID:  input.DriverID,
-		Status:    domain.DriverStatusAvailable,
+		Status:    string(driverStatus),
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}); err != nil {
 		s.log.Error(logger.Entry{
@@ -596,13 +1133,47 @@ func (s *DriverService) CompleteRide(ctx context.Context, input in.CompleteRideI
 
 	return in.CompleteRideOutput{
 		RideID:         input.RideID,
-		Status:         "COMPLETED",
+		Status:         constants.RideStatusCompleted,
 		CompletedAt:    completedAt,
+		FinalFare:      finalFare,
+		FareBreakdown:  fare,
 		DriverEarnings: driverEarnings,
 		Message:        "Ride completed successfully",
+		SharedFraction: math.Round(sharedFraction*100) / 100,
+		Route:          route,
 	}, nil
 }
 
+// enqueueRideStatus сохраняет ride.* сообщение об изменении статуса поездки в outbox.
+// Вызывается в транзакции перехода статуса: сообщение уйдет, только если переход закоммичен.
+func (s *DriverService) enqueueRideStatus(ctx context.Context, ride *out.Ride, eventType, status string, additional map[string]interface{}) error {
+	if err := s.rideOutbox.EnqueueRideStatus(ctx, &contract.RideStatusChanged{
+		EventType:      eventType,
+		RideID:         ride.ID,
+		PassengerID:    ride.PassengerID,
+		DriverID:       ride.DriverID,
+		Status:         status,
+		VehicleType:    ride.VehicleType,
+		AdditionalData: additional,
+	}); err != nil {
+		return fmt.Errorf("enqueue %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// appendRideEvent записывает событие в журнал поездки.
+// Вызывается в транзакции перехода статуса: без события откатывается и переход.
+func (s *DriverService) appendRideEvent(ctx context.Context, rideID, eventType string, payload ridestate.EventPayload) error {
+	if payload.OccurredAt.IsZero() {
+		payload.OccurredAt = time.Now().UTC()
+	}
+
+	if err := s.eventRepo.Append(ctx, rideID, eventType, payload); err != nil {
+		return fmt.Errorf("append %s ride event: %w", eventType, err)
+	}
+	return nil
+}
+
 // validateCoordinates проверяет корректность координат
 func validateCoordinates(lat, lng float64) error {
 	if lat < -90 || lat > 90 {
@@ -0,0 +1,675 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/domain"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+)
+
+// Параметры координатора по умолчанию
+const (
+	matchingSweepInterval  = 1 * time.Second
+	matchingSweepBatch     = 50 // Сессий за один проход одной реплики
+	matchingCandidateLimit = 20
+	driverEarningsShare    = 0.8 // 80% стоимости — водителю
+
+	matchingStaleInterval = 30 * time.Second // Как часто искать зависшие поездки REQUESTED
+	matchingStaleGrace    = 1 * time.Minute  // Запас сверх крайнего срока подбора
+	matchingStaleBatch    = 100
+)
+
+// RideMatchingService — координатор подбора водителя.
+//
+// ЦИКЛ ПОДБОРА:
+//  1. Раунд: оффер получают до BatchSize подключенных водителей с лучшей оценкой
+//     (расстояние, рейтинг, время простоя — см. domain.ScoreCandidate)
+//  2. Раунд закрывается, когда все офферы отклонены или истек expires_at
+//  3. Следующий раунд берет новых кандидатов; если их нет — радиус расширяется
+//  4. По истечении DeadlineSeconds публикуется NO_DRIVERS_AVAILABLE,
+//     и Ride Service отменяет поездку
+//
+// Для POOL к свободным водителям добавляются водители в пути, в план которых
+// поездку можно вставить без превышения мест и допустимого объезда.
+//
+// Каждый этап публикуется в driver.matching.{ride_id}, Ride Service
+// пересылает его пассажиру через WebSocket.
+//
+// Сессии и офферы хранятся в Postgres (matching_sessions, matching_offers), поэтому
+// подбор переживает рестарт, а ответ водителя и ride.matched/ride.cancelled может
+// обработать любая реплика. Каждое изменение сессии — в транзакции под блокировкой
+// ее строки. Таймауты ведут координаторы всех реплик: сессию с наступившим due_at
+// забирает одна из них (SKIP LOCKED).
+//
+// Поездки REQUESTED, которые ждут дольше крайнего срока без активной сессии
+// (ride.requested потерян), получают NO_DRIVERS_AVAILABLE — Ride Service их отменит.
+type RideMatchingService struct {
+	txManager    out.TxManager
+	matchingRepo out.MatchingRepository
+	locationRepo out.LocationRepository
+	poolRepo     out.PoolRepository
+	offerSender  out.RideOfferSender
+	msgPublisher out.MessagePublisher
+	cfg          config.MatchingConfig
+	pool         config.PoolConfig
+	log          *logger.Logger
+}
+
+// NewRideMatchingService создает новый координатор подбора
+func NewRideMatchingService(
+	txManager out.TxManager,
+	matchingRepo out.MatchingRepository,
+	locationRepo out.LocationRepository,
+	poolRepo out.PoolRepository,
+	offerSender out.RideOfferSender,
+	msgPublisher out.MessagePublisher,
+	cfg config.MatchingConfig,
+	pool config.PoolConfig,
+	log *logger.Logger,
+) *RideMatchingService {
+	return &RideMatchingService{
+		txManager:    txManager,
+		matchingRepo: matchingRepo,
+		locationRepo: locationRepo,
+		poolRepo:     poolRepo,
+		offerSender:  offerSender,
+		msgPublisher: msgPublisher,
+		cfg:          cfg,
+		pool:         pool,
+		log:          log,
+	}
+}
+
+// StartMatching начинает подбор водителя и сразу отправляет первый раунд офферов
+func (s *RideMatchingService) StartMatching(ctx context.Context, input in.StartMatchingInput) error {
+	now := time.Now().UTC()
+
+	offerTimeout := time.Duration(s.cfg.OfferTimeoutSeconds) * time.Second
+	if input.TimeoutSeconds > 0 {
+		offerTimeout = time.Duration(input.TimeoutSeconds) * time.Second
+	}
+
+	radiusKm := s.cfg.InitialRadiusKm
+	if input.MaxDistanceKm > 0 {
+		radiusKm = input.MaxDistanceKm
+	}
+
+	stops := make([]domain.MatchingLocation, 0, len(input.Stops))
+	for _, stop := range input.Stops {
+		stops = append(stops, domain.MatchingLocation{Lat: stop.Lat, Lng: stop.Lng, Address: stop.Address})
+	}
+
+	session := &domain.MatchingSession{
+		RideID:                   input.RideID,
+		RideNumber:               input.RideNumber,
+		VehicleType:              input.VehicleType,
+		Pickup:                   domain.MatchingLocation{Lat: input.PickupLat, Lng: input.PickupLng, Address: input.PickupAddress},
+		Destination:              domain.MatchingLocation{Lat: input.DestLat, Lng: input.DestLng, Address: input.DestAddress},
+		Stops:                    stops,
+		EstimatedFare:            input.EstimatedFare,
+		EstimatedDurationMinutes: input.EstimatedDurationMinutes,
+		OfferTimeout:             offerTimeout,
+		State:                    domain.MatchingStateActive,
+		RadiusKm:                 radiusKm,
+		Offers:                   make(map[string]*domain.RideOffer),
+		StartedAt:                now,
+		Deadline:                 now.Add(time.Duration(s.cfg.DeadlineSeconds) * time.Second),
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := s.matchingRepo.CreateSession(ctx, session)
+		if err != nil {
+			return fmt.Errorf("create matching session: %w", err)
+		}
+		if !created {
+			s.log.Debug(logger.Entry{
+				Action:  "matching_already_started",
+				Message: "duplicate ride request ignored",
+				RideID:  input.RideID,
+			})
+			return nil
+		}
+
+		s.log.Info(logger.Entry{
+			Action:  "matching_started",
+			Message: input.RideNumber,
+			RideID:  input.RideID,
+			Additional: map[string]any{
+				"radius_km":     radiusKm,
+				"offer_timeout": offerTimeout.String(),
+				"deadline":      session.Deadline.Format(time.RFC3339),
+			},
+		})
+
+		s.publishStatus(ctx, session, constants.MatchingStatusSearching, 0, "")
+		s.dispatch(ctx, session, now)
+
+		return s.matchingRepo.SaveSession(ctx, session)
+	})
+}
+
+// HandleOfferResponse фиксирует ответ водителя.
+// Принятие завершает подбор, отказ последнего ожидающего водителя запускает следующий раунд.
+func (s *RideMatchingService) HandleOfferResponse(ctx context.Context, input in.OfferResponseInput) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		session, err := s.matchingRepo.LockSession(ctx, input.RideID)
+		if errors.Is(err, domain.ErrMatchingSessionNotFound) {
+			return domain.ErrOfferNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("lock matching session: %w", err)
+		}
+
+		now := time.Now().UTC()
+		if err := session.RespondToOffer(input.DriverID, input.Accepted, now); err != nil {
+			s.log.Warn(logger.Entry{
+				Action:  "offer_response_rejected",
+				Message: err.Error(),
+				RideID:  input.RideID,
+				Additional: map[string]any{
+					"driver_id": input.DriverID,
+				},
+			})
+			return err
+		}
+
+		if input.Accepted {
+			// Назначение выполняет Ride Service; остальные офферы больше не актуальны
+			session.State = domain.MatchingStateClosed
+			s.log.Info(logger.Entry{
+				Action:  "matching_offer_accepted",
+				Message: input.DriverID,
+				RideID:  input.RideID,
+				Additional: map[string]any{
+					"round":     session.Round,
+					"radius_km": session.RadiusKm,
+				},
+			})
+			return s.matchingRepo.SaveSession(ctx, session)
+		}
+
+		s.log.Info(logger.Entry{
+			Action:  "matching_offer_declined",
+			Message: input.DriverID,
+			RideID:  input.RideID,
+		})
+
+		if session.PendingOffers() == 0 {
+			s.publishStatus(ctx, session, constants.MatchingStatusRetrying, 0, "declined")
+			s.dispatch(ctx, session, now)
+		}
+
+		return s.matchingRepo.SaveSession(ctx, session)
+	})
+}
+
+// StopMatching прекращает подбор без публикации статуса
+func (s *RideMatchingService) StopMatching(ctx context.Context, rideID string) error {
+	stopped, err := s.matchingRepo.CloseSession(ctx, rideID)
+	if err != nil {
+		return fmt.Errorf("close matching session: %w", err)
+	}
+	if !stopped {
+		return nil
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "matching_stopped",
+		Message: "ride is no longer waiting for a driver",
+		RideID:  rideID,
+	})
+	return nil
+}
+
+// Run продвигает сессии с наступившим due_at (таймауты офферов, новые раунды,
+// крайний срок) и ищет зависшие поездки REQUESTED до отмены контекста (блокирующий)
+func (s *RideMatchingService) Run(ctx context.Context) {
+	s.log.Info(logger.Entry{
+		Action:  "matching_coordinator_started",
+		Message: fmt.Sprintf("sweep interval %s, stale check interval %s", matchingSweepInterval, matchingStaleInterval),
+	})
+
+	ticker := time.NewTicker(matchingSweepInterval)
+	defer ticker.Stop()
+	staleTicker := time.NewTicker(matchingStaleInterval)
+	defer staleTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info(logger.Entry{Action: "matching_coordinator_stopped", Message: "context cancelled"})
+			return
+		case <-ticker.C:
+			s.sweepDueSessions(ctx, time.Now().UTC())
+		case <-staleTicker.C:
+			s.expireStaleRides(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// sweepDueSessions продвигает сессии, у которых наступил due_at: по одной сессии
+// на транзакцию, чтобы сбой одной не откатывал остальные
+func (s *RideMatchingService) sweepDueSessions(ctx context.Context, now time.Time) {
+	for i := 0; i < matchingSweepBatch; i++ {
+		claimed := false
+		err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			session, err := s.matchingRepo.ClaimDueSession(ctx, now)
+			if err != nil {
+				return fmt.Errorf("claim matching session: %w", err)
+			}
+			if session == nil {
+				return nil
+			}
+			claimed = true
+
+			s.dispatch(ctx, session, now)
+			return s.matchingRepo.SaveSession(ctx, session)
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error(logger.Entry{
+					Action:  "matching_sweep_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+			}
+			return
+		}
+		if !claimed {
+			return
+		}
+	}
+}
+
+// expireStaleRides сообщает NO_DRIVERS_AVAILABLE по поездкам REQUESTED, которые ждут
+// дольше крайнего срока подбора, но активной сессии у них нет. Поездка помечается
+// EXPIRED в той же транзакции, поэтому сообщение уходит один раз.
+func (s *RideMatchingService) expireStaleRides(ctx context.Context, now time.Time) {
+	olderThan := now.Add(-time.Duration(s.cfg.DeadlineSeconds)*time.Second - matchingStaleGrace)
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		rideIDs, err := s.matchingRepo.ClaimStaleRequestedRides(ctx, olderThan, matchingStaleBatch)
+		if err != nil {
+			return fmt.Errorf("claim stale rides: %w", err)
+		}
+
+		for _, rideID := range rideIDs {
+			if err := s.matchingRepo.MarkSessionExpired(ctx, rideID); err != nil {
+				return err
+			}
+			if err := s.msgPublisher.PublishMatchingStatus(ctx, &contract.MatchingStatus{
+				RideID:    rideID,
+				Status:    constants.MatchingStatusNoDrivers,
+				Reason:    constants.CancellationReasonNoDrivers,
+				Timestamp: now.Format(time.RFC3339),
+			}); err != nil {
+				return fmt.Errorf("publish matching status: %w", err)
+			}
+
+			s.log.Warn(logger.Entry{
+				Action:  "matching_stale_ride_expired",
+				Message: "ride waited for a driver without an active matching session",
+				RideID:  rideID,
+			})
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		s.log.Error(logger.Entry{
+			Action:  "matching_stale_check_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+}
+
+// dispatch продвигает подбор: закрывает просроченные офферы и при необходимости
+// начинает новый раунд. Вызывается в транзакции с заблокированной сессией;
+// офферы и статусы подбора уходят только после ее коммита.
+func (s *RideMatchingService) dispatch(ctx context.Context, session *domain.MatchingSession, now time.Time) {
+	if session.Expired(now) {
+		session.State = domain.MatchingStateExpired
+		s.log.Warn(logger.Entry{
+			Action:  "matching_deadline_exceeded",
+			Message: "no driver accepted the ride in time",
+			RideID:  session.RideID,
+			Additional: map[string]any{
+				"rounds":    session.Round,
+				"radius_km": session.RadiusKm,
+				"offered":   len(session.Offers),
+			},
+		})
+		s.publishStatus(ctx, session, constants.MatchingStatusNoDrivers, 0, constants.CancellationReasonNoDrivers)
+		return
+	}
+
+	if expired := session.ExpireOffers(now); expired > 0 && session.PendingOffers() == 0 {
+		s.publishStatus(ctx, session, constants.MatchingStatusRetrying, 0, "offer_timeout")
+	}
+
+	if session.PendingOffers() > 0 || now.Before(session.NextSearchAt) {
+		return
+	}
+
+	offersSent, err := s.offerRound(ctx, session, now)
+	for err == nil && offersSent == 0 && session.WidenRadius(s.cfg.RadiusStepKm, s.cfg.MaxRadiusKm) {
+		s.publishStatus(ctx, session, constants.MatchingStatusRadiusExpanded, 0, "")
+		offersSent, err = s.offerRound(ctx, session, now)
+	}
+
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "matching_round_failed",
+			Message: err.Error(),
+			RideID:  session.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+
+	if offersSent == 0 {
+		// Кандидатов нет даже в максимальном радиусе — повторим поиск позже
+		session.NextSearchAt = now.Add(session.OfferTimeout)
+	}
+}
+
+// offerRound отправляет офферы лучшим по оценке новым кандидатам в текущем радиусе.
+// Решение по каждому кандидату (оценка и причина пропуска) пишется в лог.
+func (s *RideMatchingService) offerRound(ctx context.Context, session *domain.MatchingSession, now time.Time) (int, error) {
+	candidates, err := s.locationRepo.FindNearbyOnlineDrivers(
+		ctx,
+		session.Pickup.Lat,
+		session.Pickup.Lng,
+		session.RadiusKm,
+		session.VehicleType,
+		matchingCandidateLimit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("find nearby drivers: %w", err)
+	}
+
+	scores := make([]domain.CandidateScore, 0, len(candidates))
+	for _, candidate := range candidates {
+		scores = append(scores, domain.ScoreCandidate(
+			candidate.DriverID,
+			candidate.Distance/1000.0, // конвертируем метры в км
+			candidate.Rating,
+			candidate.IdleSeconds,
+			session.RadiusKm,
+			s.weights(),
+		))
+	}
+
+	decisions := make([]map[string]any, 0, len(scores))
+	insertions := make(map[string]*domain.PoolInsertion)
+	if session.VehicleType == constants.VehiclePool {
+		pooled, rejected, err := s.poolCandidates(ctx, session)
+		if err != nil {
+			return 0, err
+		}
+		for _, candidate := range pooled {
+			insertion := candidate.Insertion
+			insertions[candidate.Score.DriverID] = &insertion
+			scores = append(scores, candidate.Score)
+		}
+		decisions = append(decisions, rejected...)
+	}
+	domain.RankCandidates(scores)
+
+	offersSent := 0
+	for rank, score := range scores {
+		decision := "offered"
+		switch {
+		case session.HasOffer(score.DriverID):
+			decision = "already_offered"
+		case offersSent >= s.cfg.BatchSize:
+			decision = "batch_full"
+		case !s.offerSender.IsDriverConnected(score.DriverID):
+			decision = "not_connected"
+		default:
+			s.sendOffer(ctx, session, score, insertions[score.DriverID], now)
+			offersSent++
+		}
+
+		decisions = append(decisions, map[string]any{
+			"rank":           rank + 1,
+			"driver_id":      score.DriverID,
+			"decision":       decision,
+			"total":          score.Total,
+			"distance_km":    score.DistanceKm,
+			"rating":         score.Rating,
+			"idle_seconds":   score.IdleSeconds,
+			"distance_score": score.DistanceScore,
+			"rating_score":   score.RatingScore,
+			"idle_score":     score.IdleScore,
+			"pooled":         insertions[score.DriverID] != nil,
+		})
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "matching_round_decisions",
+		Message: fmt.Sprintf("%d candidates, %d offers", len(scores), offersSent),
+		RideID:  session.RideID,
+		Additional: map[string]any{
+			"vehicle_type": session.VehicleType,
+			"radius_km":    session.RadiusKm,
+			"filters":      "status=AVAILABLE, is_verified=true, vehicle_type match",
+			"weights": map[string]any{
+				"distance":         s.cfg.DistanceWeight,
+				"rating":           s.cfg.RatingWeight,
+				"idle":             s.cfg.IdleWeight,
+				"idle_cap_minutes": s.cfg.IdleCapMinutes,
+			},
+			"candidates": decisions,
+		},
+	})
+
+	if offersSent > 0 {
+		session.Round++
+		s.publishStatus(ctx, session, constants.MatchingStatusOffersSent, offersSent, "")
+	}
+
+	return offersSent, nil
+}
+
+// poolCandidates находит POOL-водителей в пути, в план которых помещается поездка.
+// Отклоненные кандидаты возвращаются как решения для лога раунда.
+func (s *RideMatchingService) poolCandidates(ctx context.Context, session *domain.MatchingSession) ([]domain.PoolCandidate, []map[string]any, error) {
+	drivers, err := s.locationRepo.FindPoolDriversOnTrip(ctx, session.Pickup.Lat, session.Pickup.Lng, session.RadiusKm, matchingCandidateLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find pool drivers on trip: %w", err)
+	}
+
+	pickup := domain.PoolWaypoint{
+		RideID:  session.RideID,
+		Kind:    domain.WaypointPickup,
+		Address: session.Pickup.Address,
+		Lat:     session.Pickup.Lat,
+		Lng:     session.Pickup.Lng,
+	}
+	dropoff := domain.PoolWaypoint{
+		RideID:  session.RideID,
+		Kind:    domain.WaypointDropoff,
+		Address: session.Destination.Address,
+		Lat:     session.Destination.Lat,
+		Lng:     session.Destination.Lng,
+	}
+
+	candidates := make([]domain.PoolCandidate, 0, len(drivers))
+	rejected := make([]map[string]any, 0)
+	for _, driver := range drivers {
+		plan, err := s.poolRepo.ListOpenWaypoints(ctx, driver.DriverID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list pool waypoints: %w", err)
+		}
+
+		insertion, err := domain.PlanInsertion(driver.Lat, driver.Lng, plan, pickup, dropoff, poolLimits(s.pool, driver.Seats))
+		if err != nil {
+			decision := "detour_too_long"
+			if errors.Is(err, domain.ErrPoolNoSeats) {
+				decision = "no_seats"
+			}
+			rejected = append(rejected, map[string]any{
+				"driver_id":   driver.DriverID,
+				"decision":    decision,
+				"distance_km": driver.Distance / 1000.0,
+				"waypoints":   len(plan),
+				"pooled":      true,
+			})
+			continue
+		}
+
+		// Водитель в пути не простаивает: оценка только по расстоянию и рейтингу
+		candidates = append(candidates, domain.PoolCandidate{
+			Score: domain.ScoreCandidate(
+				driver.DriverID,
+				driver.Distance/1000.0,
+				driver.Rating,
+				0,
+				session.RadiusKm,
+				s.weights(),
+			),
+			Insertion: insertion,
+		})
+	}
+
+	return candidates, rejected, nil
+}
+
+// sendOffer регистрирует оффер в сессии и отправляет его водителю после коммита
+// сессии: водитель не должен получить оффер, которого нет в matching_offers.
+// Неотправленный оффер истекает по таймауту, и подбор идет дальше.
+// insertion != nil — подсадка в POOL-поездку, которую водитель уже выполняет.
+func (s *RideMatchingService) sendOffer(ctx context.Context, session *domain.MatchingSession, score domain.CandidateScore, insertion *domain.PoolInsertion, now time.Time) {
+	offerID := fmt.Sprintf("offer_%s_%s", session.RideID, score.DriverID)
+	offer := session.AddOffer(offerID, score.DriverID, score.DistanceKm, now)
+	offer.Pool = insertion
+
+	rideID := session.RideID
+	payload := buildOfferPayload(session, offer)
+	s.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.offerSender.SendRideOffer(score.DriverID, payload); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "send_offer_failed",
+				Message: err.Error(),
+				RideID:  rideID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]any{
+					"driver_id": score.DriverID,
+				},
+			})
+			return
+		}
+
+		s.log.Info(logger.Entry{
+			Action:  "ride_offer_sent",
+			Message: score.DriverID,
+			RideID:  rideID,
+			Additional: map[string]any{
+				"driver_id":   score.DriverID,
+				"distance_km": offer.DistanceKm,
+				"score":       score.Total,
+				"expires_at":  offer.ExpiresAt.Format(time.RFC3339),
+			},
+		})
+	})
+}
+
+// weights возвращает веса ранжирования из конфигурации
+func (s *RideMatchingService) weights() domain.ScoringWeights {
+	return domain.ScoringWeights{
+		Distance: s.cfg.DistanceWeight,
+		Rating:   s.cfg.RatingWeight,
+		Idle:     s.cfg.IdleWeight,
+		IdleCap:  time.Duration(s.cfg.IdleCapMinutes) * time.Minute,
+	}
+}
+
+// buildOfferPayload формирует сообщение ride_offer для водителя
+func buildOfferPayload(session *domain.MatchingSession, offer *domain.RideOffer) map[string]interface{} {
+	stops := make([]map[string]interface{}, 0, len(session.Stops))
+	for i, stop := range session.Stops {
+		stops = append(stops, map[string]interface{}{
+			"stop_number": i + 1,
+			"latitude":    stop.Lat,
+			"longitude":   stop.Lng,
+			"address":     stop.Address,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"offer_id":    offer.OfferID,
+		"ride_id":     session.RideID,
+		"ride_number": session.RideNumber,
+		"pickup_location": map[string]interface{}{
+			"latitude":  session.Pickup.Lat,
+			"longitude": session.Pickup.Lng,
+			"address":   session.Pickup.Address,
+		},
+		"destination_location": map[string]interface{}{
+			"latitude":  session.Destination.Lat,
+			"longitude": session.Destination.Lng,
+			"address":   session.Destination.Address,
+		},
+		"stops":                 stops,
+		"estimated_fare":        session.EstimatedFare,
+		"driver_earnings":       session.EstimatedFare * driverEarningsShare,
+		"distance_to_pickup_km": offer.DistanceKm,
+		"expires_at":            offer.ExpiresAt.Format(time.RFC3339),
+	}
+
+	// Оценка по маршруту из ride.requested (тот же расчет, что и в цене поездки)
+	if session.EstimatedDurationMinutes > 0 {
+		payload["estimated_ride_duration_min"] = session.EstimatedDurationMinutes
+	}
+
+	// POOL: водитель видит, куда в его маршрут встанет новый пассажир
+	if session.VehicleType == constants.VehiclePool {
+		pool := map[string]interface{}{"shared": offer.Pool != nil}
+		if offer.Pool != nil {
+			pool["pickup_order"] = offer.Pool.PickupIndex + 1
+			pool["dropoff_order"] = offer.Pool.DropoffIndex + 1
+			pool["added_km"] = math.Round(offer.Pool.AddedKm*100) / 100
+			pool["riders_on_board"] = offer.Pool.Onboard
+		}
+		payload["pool"] = pool
+	}
+
+	return payload
+}
+
+// publishStatus сообщает Ride Service об этапе подбора после коммита сессии
+// (ошибка публикации не прерывает подбор)
+func (s *RideMatchingService) publishStatus(ctx context.Context, session *domain.MatchingSession, status string, offersSent int, reason string) {
+	dto := &contract.MatchingStatus{
+		RideID:     session.RideID,
+		Status:     status,
+		Round:      session.Round,
+		RadiusKm:   session.RadiusKm,
+		OffersSent: offersSent,
+		Reason:     reason,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	s.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		if err := s.msgPublisher.PublishMatchingStatus(ctx, dto); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "publish_matching_status_failed",
+				Message: err.Error(),
+				RideID:  dto.RideID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]any{
+					"status": status,
+				},
+			})
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ridehail/internal/driver/application/ports/in"
+	"ridehail/internal/driver/application/ports/out"
+	"ridehail/internal/driver/domain"
+	"ridehail/internal/shared/config"
+	"ridehail/internal/shared/logger"
+)
+
+// VerificationExpiryWorker снимает с проверки водителей с истекшими документами.
+//
+// ЦИКЛ (раз в interval):
+//  1. drivers.verified_until раньше сегодняшней даты → is_verified = false,
+//     одобренная заявка → EXPIRED (ApplicationRepository.ExpireLapsed)
+//  2. Водитель на линии без поездки (AVAILABLE) уходит в офлайн: сессия закрывается
+//     как при GoOffline. Поездку в работе водитель завершает, но новых заказов
+//     не получит — подбор ищет только is_verified
+//  3. Подключенному водителю уходит verification_update со статусом EXPIRED
+type VerificationExpiryWorker struct {
+	appRepo   out.ApplicationRepository
+	drivers   in.DriverUseCase
+	notifier  out.DriverNotifier
+	interval  time.Duration
+	batchSize int
+	log       *logger.Logger
+}
+
+// NewVerificationExpiryWorker создает воркер истечения документов
+func NewVerificationExpiryWorker(
+	appRepo out.ApplicationRepository,
+	drivers in.DriverUseCase,
+	notifier out.DriverNotifier,
+	cfg config.VerificationConfig,
+	log *logger.Logger,
+) *VerificationExpiryWorker {
+	return &VerificationExpiryWorker{
+		appRepo:   appRepo,
+		drivers:   drivers,
+		notifier:  notifier,
+		interval:  time.Duration(cfg.ExpiryCheckIntervalSeconds) * time.Second,
+		batchSize: cfg.BatchSize,
+		log:       log,
+	}
+}
+
+// Run запускает цикл до отмены контекста (блокирующий).
+// Первый проход — сразу при старте: документы могли истечь, пока сервис не работал.
+func (w *VerificationExpiryWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		w.log.Info(logger.Entry{Action: "verification_expiry_worker_disabled", Message: "expiry_check_interval_seconds = 0"})
+		return
+	}
+
+	w.log.Info(logger.Entry{
+		Action:  "verification_expiry_worker_started",
+		Message: fmt.Sprintf("interval %s", w.interval),
+	})
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			w.log.Info(logger.Entry{Action: "verification_expiry_worker_stopped", Message: "context cancelled"})
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick снимает с проверки всех просроченных водителей, пачками по batchSize
+func (w *VerificationExpiryWorker) tick(ctx context.Context, now time.Time) {
+	today := now.Truncate(24 * time.Hour)
+
+	for ctx.Err() == nil {
+		lapsed, err := w.appRepo.ExpireLapsed(ctx, today, w.batchSize)
+		if err != nil {
+			w.log.Error(logger.Entry{
+				Action:  "verification_expiry_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+			return
+		}
+
+		for _, l := range lapsed {
+			w.handleLapsed(ctx, l)
+		}
+
+		if len(lapsed) < w.batchSize {
+			return
+		}
+	}
+}
+
+// handleLapsed уводит водителя с линии и уведомляет его
+func (w *VerificationExpiryWorker) handleLapsed(ctx context.Context, l domain.LapsedVerification) {
+	w.log.Warn(logger.Entry{
+		Action:  "driver_verification_expired",
+		Message: l.DriverID,
+		Additional: map[string]any{
+			"verified_until": l.VerifiedUntil.Format(domain.DateLayout),
+			"status":         l.Status,
+		},
+	})
+
+	if l.Status == domain.DriverStatusAvailable {
+		_, err := w.drivers.GoOffline(ctx, in.GoOfflineInput{DriverID: l.DriverID})
+		// Водитель мог успеть принять заказ или уйти сам — тогда оставляем как есть
+		if err != nil && !errors.Is(err, domain.ErrDriverCannotGoOffline) {
+			w.log.Error(logger.Entry{
+				Action:  "verification_expired_go_offline_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]any{
+					"driver_id": l.DriverID,
+				},
+			})
+		}
+	}
+
+	if !w.notifier.IsDriverConnected(l.DriverID) {
+		return
+	}
+	if err := w.notifier.SendVerificationUpdate(l.DriverID, map[string]interface{}{
+		"status":         string(domain.ApplicationExpired),
+		"verified_until": l.VerifiedUntil.Format(domain.DateLayout),
+		"message":        "A document has expired. Submit a new application to keep accepting rides",
+	}); err != nil {
+		w.log.Error(logger.Entry{
+			Action:  "verification_expired_notify_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"driver_id": l.DriverID,
+			},
+		})
+	}
+}
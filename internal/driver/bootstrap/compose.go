@@ -17,6 +17,8 @@ import (
 	db_conn "ridehail/internal/shared/db"
 	"ridehail/internal/shared/logger"
 	"ridehail/internal/shared/mq"
+	"ridehail/internal/shared/pricing"
+	"ridehail/internal/shared/user"
 )
 
 // Run запускает Driver Service
@@ -66,19 +68,41 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	}
 
 	// 3. Инициализация репозиториев
+	txManager := repo.NewPgTxManager(dbPool, log) // переход статуса поездки и событие журнала — в одной транзакции
 	driverRepo := repo.NewDriverPgRepository(dbPool)
 	locationRepo := repo.NewLocationRepository(dbPool)
 	rideRepo := repo.NewRidePgRepository(dbPool)
+	poolRepo := repo.NewPoolPgRepository(dbPool)
+	matchingRepo := repo.NewMatchingPgRepository(dbPool)
+	appRepo := repo.NewApplicationPgRepository(dbPool)
+	rideEventRepo := repo.NewRideEventPgRepository(dbPool)
+	rideOutbox := repo.NewRideOutboxPgRepository(dbPool) // ride.started / ride.completed доставляет relay Ride Service
+	userRepo := user.NewPgRepository(dbPool, log)        // статус и роль для auth middleware и WebSocket
+
+	// Тарифы общие с Ride Service: финальная стоимость считается тем же движком, что и оценка.
+	// Surge и город здесь не определяются — они фиксируются на поездке при заказе.
+	tariffSource := pricing.NewPgSource(dbPool, pricing.NewConfigSource(cfg.Pricing),
+		time.Duration(cfg.Pricing.TariffCacheTTLSeconds)*time.Second, log)
+	pricingEngine := pricing.NewEngine(tariffSource, nil, nil, cfg.Pricing, log)
 
 	// 4. Инициализация MessagePublisher
 	msgPublisher := messaging.NewMessagePublisher(mqConn, log)
 
 	// 5. Инициализация use cases
 	driverService := usecase.NewDriverService(
+		txManager,
 		driverRepo,
 		locationRepo,
 		rideRepo,
+		poolRepo,
+		appRepo,
+		rideEventRepo,
+		rideOutbox,
 		msgPublisher,
+		pricingEngine,
+		cfg.Tracking,     // Радиус отметки прибытия на точку подачи
+		cfg.Pool,         // Места и объезд совместных поездок, скидка за попутчиков
+		cfg.Cancellation, // Ожидание пассажира до отмены за неявку
 		log,
 	)
 
@@ -86,12 +110,18 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	jwtService := auth.NewJWTService(cfg.JWT)
 
 	// 6.1. Инициализация WebSocket Hub для водителей
-	driverWS := in_ws.NewDriverWSHandler(jwtService, msgPublisher, log)
+	driverWS := in_ws.NewDriverWSHandler(jwtService, userRepo, msgPublisher, driverService, log)
 	wsHub := driverWS.GetHub()
 	go wsHub.Run(ctx)
 
-	// 6.2. Инициализация RabbitMQ Consumer для ride requests
-	rideConsumer := in_amqp.NewRideRequestConsumer(mqConn, locationRepo, driverWS, log)
+	// 6.2. Координатор подбора: раунды офферов, таймауты, расширение радиуса.
+	// Сессии в Postgres — таймауты ведут координаторы всех реплик
+	matchingService := usecase.NewRideMatchingService(txManager, matchingRepo, locationRepo, poolRepo, driverWS, msgPublisher, cfg.Matching, cfg.Pool, log)
+	driverWS.SetRideMatchingUseCase(matchingService)
+	go matchingService.Run(ctx)
+
+	// Consumer ride requests передает запросы координатору
+	rideConsumer := in_amqp.NewRideRequestConsumer(mqConn, matchingService, log)
 	go func() {
 		if err := rideConsumer.Start(ctx); err != nil {
 			log.Error(logger.Entry{
@@ -102,6 +132,46 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 		}
 	}()
 
+	// 6.3. Consumer изменений статуса поездок (ride.cancelled → водителю, остановка подбора)
+	rideStatusConsumer := in_amqp.NewRideStatusConsumer(mqConn, driverWS, matchingService, driverService, log)
+	go func() {
+		if err := rideStatusConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "ride_status_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// 6.4. Решения администратора по заявкам водителей → водителю по WebSocket
+	verificationConsumer := in_amqp.NewVerificationConsumer(mqConn, driverWS, log)
+	go func() {
+		if err := verificationConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "verification_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// 6.5. Отзыв сессий администратором: водитель уходит с линии, WebSocket закрывается
+	sessionsRevokedConsumer := in_amqp.NewSessionsRevokedConsumer(mqConn, driverWS, driverService, log)
+	go func() {
+		if err := sessionsRevokedConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "sessions_revoked_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// 6.6. Снятие с проверки водителей с истекшими документами
+	expiryWorker := usecase.NewVerificationExpiryWorker(appRepo, driverService, driverWS, cfg.Verification, log)
+	go expiryWorker.Run(ctx)
+
 	// 7. Инициализация HTTP handlers
 	driverHandler := transport.NewDriverHandler(driverService, log)
 
@@ -120,11 +190,17 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	protectedMux.HandleFunc("POST /drivers/{driver_id}/online", driverHandler.HandleGoOnline)
 	protectedMux.HandleFunc("POST /drivers/{driver_id}/offline", driverHandler.HandleGoOffline)
 	protectedMux.HandleFunc("POST /drivers/{driver_id}/location", driverHandler.HandleUpdateLocation)
+	protectedMux.HandleFunc("POST /drivers/{driver_id}/arrived", driverHandler.HandleArrived)
+	protectedMux.HandleFunc("POST /drivers/{driver_id}/stops/{n}/arrived", driverHandler.HandleStopArrived)
 	protectedMux.HandleFunc("POST /drivers/{driver_id}/start", driverHandler.HandleStartRide)
 	protectedMux.HandleFunc("POST /drivers/{driver_id}/complete", driverHandler.HandleCompleteRide)
+	protectedMux.HandleFunc("POST /drivers/{driver_id}/no-show", driverHandler.HandleNoShow)
+	protectedMux.HandleFunc("GET /drivers/{driver_id}/route", driverHandler.HandleGetRoute)
+	protectedMux.HandleFunc("POST /drivers/{driver_id}/application", driverHandler.HandleSubmitApplication)
+	protectedMux.HandleFunc("GET /drivers/{driver_id}/application", driverHandler.HandleGetApplication)
 
 	// Применяем middleware только к защищенным endpoints
-	protectedHandler := transport.AuthMiddleware(jwtService, log)(protectedMux)
+	protectedHandler := transport.AuthMiddleware(jwtService, userRepo, log)(protectedMux)
 
 	// Объединяем в финальный handler
 	finalMux := http.NewServeMux()
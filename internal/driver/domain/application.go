@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ApplicationStatus — статус заявки водителя на проверку
+type ApplicationStatus string
+
+const (
+	ApplicationPending  ApplicationStatus = "PENDING"  // ждет решения администратора
+	ApplicationApproved ApplicationStatus = "APPROVED" // водитель проверен
+	ApplicationRejected ApplicationStatus = "REJECTED" // отклонена, причина в ReviewReason
+	ApplicationExpired  ApplicationStatus = "EXPIRED"  // одобрена, но истек срок документа
+)
+
+// DocumentKind — тип документа водителя
+type DocumentKind string
+
+const (
+	DocumentDriverLicense       DocumentKind = "DRIVER_LICENSE"
+	DocumentVehicleRegistration DocumentKind = "VEHICLE_REGISTRATION"
+	DocumentInsurance           DocumentKind = "INSURANCE"
+)
+
+// DateLayout — формат сроков действия документов (дата без времени)
+const DateLayout = "2006-01-02"
+
+// DriverDocument — метаданные документа (сам скан хранится вне системы)
+type DriverDocument struct {
+	Kind      DocumentKind
+	Number    string
+	ExpiresOn time.Time // Документ действителен до конца этого дня (UTC)
+}
+
+// DriverApplication — заявка водителя: права, машина и документы
+type DriverApplication struct {
+	ID            string
+	DriverID      string
+	Status        ApplicationStatus
+	LicenseNumber string
+	VehicleType   VehicleType
+	VehicleAttrs  VehicleAttrs
+	Documents     []DriverDocument
+	ReviewReason  string
+	ReviewedAt    *time.Time
+	CreatedAt     time.Time
+}
+
+// LapsedVerification — водитель, снятый с проверки из-за истекшего документа
+type LapsedVerification struct {
+	DriverID      string
+	Status        DriverStatus
+	VerifiedUntil time.Time
+}
+
+// IsValidVehicleType проверяет тип транспорта
+func IsValidVehicleType(vt VehicleType) bool {
+	switch vt {
+	case VehicleTypeEconomy, VehicleTypePremium, VehicleTypeXL, VehicleTypePool:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate проверяет заявку перед отправкой на проверку.
+//
+// ПРАВИЛА:
+//   - Номер прав и тип транспорта обязательны
+//   - Водительское удостоверение среди документов обязательно
+//   - Каждый тип документа — не больше одного раза
+//   - Срок действия каждого документа не истек на дату today
+func (a *DriverApplication) Validate(today time.Time) error {
+	if a.LicenseNumber == "" {
+		return fmt.Errorf("%w: license_number is required", ErrApplicationInvalid)
+	}
+	if !IsValidVehicleType(a.VehicleType) {
+		return fmt.Errorf("%w: unknown vehicle_type %q", ErrApplicationInvalid, a.VehicleType)
+	}
+
+	seen := make(map[DocumentKind]bool, len(a.Documents))
+	for _, doc := range a.Documents {
+		switch doc.Kind {
+		case DocumentDriverLicense, DocumentVehicleRegistration, DocumentInsurance:
+		default:
+			return fmt.Errorf("%w: unknown document kind %q", ErrApplicationInvalid, doc.Kind)
+		}
+		if seen[doc.Kind] {
+			return fmt.Errorf("%w: duplicate document %s", ErrApplicationInvalid, doc.Kind)
+		}
+		if doc.Number == "" {
+			return fmt.Errorf("%w: %s number is required", ErrApplicationInvalid, doc.Kind)
+		}
+		seen[doc.Kind] = true
+
+		if doc.ExpiresOn.Before(today) {
+			return fmt.Errorf("%w: %s expired on %s", ErrDocumentExpired, doc.Kind, doc.ExpiresOn.Format(DateLayout))
+		}
+	}
+
+	if !seen[DocumentDriverLicense] {
+		return fmt.Errorf("%w: %s is required", ErrApplicationInvalid, DocumentDriverLicense)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ const (
 	VehicleTypeEconomy VehicleType = "ECONOMY"
 	VehicleTypePremium VehicleType = "PREMIUM"
 	VehicleTypeXL      VehicleType = "XL"
+	VehicleTypePool    VehicleType = "POOL" // совместные поездки: несколько пассажиров по пути
 )
 
 // Driver — доменная модель водителя
@@ -43,6 +44,7 @@ type VehicleAttrs struct {
 	Color string `json:"vehicle_color"`
 	Plate string `json:"vehicle_plate"`
 	Year  int    `json:"vehicle_year"`
+	Seats int    `json:"vehicle_seats,omitempty"` // Мест для пассажиров (вместимость для POOL)
 }
 
 // DriverSession — сессия водителя (online/offline период)
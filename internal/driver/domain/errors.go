@@ -27,6 +27,57 @@ var (
 	// ErrRideNotFound возникает, когда поездка не найдена
 	ErrRideNotFound = errors.New("ride not found")
 
+	// ErrInvalidRideTransition возникает при недопустимом переходе статуса поездки
+	ErrInvalidRideTransition = errors.New("invalid ride status transition")
+
+	// ErrRideStatusConflict возникает, когда статус поездки изменился конкурентно
+	ErrRideStatusConflict = errors.New("ride status changed concurrently")
+
+	// ErrOfferNotFound возникает, когда водитель отвечает на оффер, который ему не отправлялся
+	ErrOfferNotFound = errors.New("ride offer not found")
+
+	// ErrOfferExpired возникает при ответе на просроченный или уже обработанный оффер
+	ErrOfferExpired = errors.New("ride offer expired")
+
+	// ErrMatchingSessionNotFound возникает, когда по поездке нет активного подбора
+	ErrMatchingSessionNotFound = errors.New("matching session not found")
+
+	// ErrTooFarFromPickup возникает, когда водитель отмечает прибытие вдали от точки подачи
+	ErrTooFarFromPickup = errors.New("driver is too far from pickup location")
+
+	// ErrStopNotFound возникает, когда у поездки нет остановки с таким номером
+	ErrStopNotFound = errors.New("ride stop not found")
+
+	// ErrStopOutOfOrder возникает при отметке остановки раньше предыдущих
+	ErrStopOutOfOrder = errors.New("previous stops are not visited yet")
+
+	// ErrStopAlreadyArrived возникает при повторной отметке остановки
+	ErrStopAlreadyArrived = errors.New("stop already visited")
+
+	// ErrTooFarFromStop возникает, когда водитель отмечает остановку вдали от нее
+	ErrTooFarFromStop = errors.New("driver is too far from stop location")
+
+	// ErrNoShowTooEarly возникает при отмене за неявку до истечения ожидания на точке подачи
+	ErrNoShowTooEarly = errors.New("passenger wait time has not expired yet")
+
+	// ErrPoolNoSeats возникает, когда в POOL-машине не хватает мест для подсадки
+	ErrPoolNoSeats = errors.New("no free seats for pooled ride")
+
+	// ErrPoolDetourTooLong возникает, когда подсадка удлиняет путь попутчиков сверх лимита
+	ErrPoolDetourTooLong = errors.New("pooled ride detour exceeds limit")
+
 	// ErrRideAlreadyMatched возникает, когда поездка уже назначена другому водителю
 	ErrRideAlreadyMatched = errors.New("ride already matched to another driver")
+
+	// ErrApplicationInvalid возникает при неполной или некорректной заявке на проверку
+	ErrApplicationInvalid = errors.New("invalid driver application")
+
+	// ErrDocumentExpired возникает, когда в заявке есть документ с истекшим сроком
+	ErrDocumentExpired = errors.New("document is expired")
+
+	// ErrApplicationPending возникает при повторной заявке, пока предыдущая не рассмотрена
+	ErrApplicationPending = errors.New("driver application is already pending review")
+
+	// ErrApplicationNotFound возникает, когда водитель еще не подавал заявку
+	ErrApplicationNotFound = errors.New("driver application not found")
 )
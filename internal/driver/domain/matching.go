@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// OfferState — состояние оффера, отправленного водителю
+type OfferState string
+
+const (
+	OfferStatePending  OfferState = "PENDING"  // ждем ответа водителя
+	OfferStateDeclined OfferState = "DECLINED" // водитель отклонил
+	OfferStateExpired  OfferState = "EXPIRED"  // водитель не ответил до expires_at
+	OfferStateAccepted OfferState = "ACCEPTED" // водитель принял
+)
+
+// MatchingState — состояние сессии подбора
+type MatchingState string
+
+const (
+	MatchingStateActive  MatchingState = "ACTIVE"  // подбор идет
+	MatchingStateClosed  MatchingState = "CLOSED"  // водитель принял оффер или поездка больше не ждет водителя
+	MatchingStateExpired MatchingState = "EXPIRED" // крайний срок подбора истек
+)
+
+// RideOffer — оффер поездки конкретному водителю
+type RideOffer struct {
+	OfferID    string
+	DriverID   string
+	DistanceKm float64
+	SentAt     time.Time
+	ExpiresAt  time.Time
+	State      OfferState
+	Pool       *PoolInsertion // POOL: подсадка в идущую поездку; nil — водитель свободен
+}
+
+// MatchingLocation — точка маршрута в запросе на подбор
+type MatchingLocation struct {
+	Lat     float64
+	Lng     float64
+	Address string
+}
+
+// MatchingSession — состояние подбора водителя для одной поездки.
+//
+// Хранит все отправленные офферы (водитель получает оффер на поездку не более
+// одного раза), текущий радиус поиска и крайний срок подбора.
+type MatchingSession struct {
+	RideID                   string
+	RideNumber               string
+	VehicleType              string
+	Pickup                   MatchingLocation
+	Destination              MatchingLocation
+	Stops                    []MatchingLocation // промежуточные остановки по порядку
+	EstimatedFare            float64
+	EstimatedDurationMinutes int // оценка времени в пути по маршруту; 0 — неизвестна
+	OfferTimeout             time.Duration
+
+	State        MatchingState
+	RadiusKm     float64
+	Round        int
+	Offers       map[string]*RideOffer // driver_id → оффер
+	StartedAt    time.Time
+	Deadline     time.Time
+	NextSearchAt time.Time // не ищем кандидатов повторно раньше этого времени
+}
+
+// HasOffer проверяет, получал ли водитель оффер на эту поездку
+func (s *MatchingSession) HasOffer(driverID string) bool {
+	_, ok := s.Offers[driverID]
+	return ok
+}
+
+// AddOffer регистрирует новый оффер водителю
+func (s *MatchingSession) AddOffer(offerID, driverID string, distanceKm float64, now time.Time) *RideOffer {
+	offer := &RideOffer{
+		OfferID:    offerID,
+		DriverID:   driverID,
+		DistanceKm: distanceKm,
+		SentAt:     now,
+		ExpiresAt:  now.Add(s.OfferTimeout),
+		State:      OfferStatePending,
+	}
+	s.Offers[driverID] = offer
+	return offer
+}
+
+// ExpireOffers помечает просроченные офферы и возвращает их количество
+func (s *MatchingSession) ExpireOffers(now time.Time) int {
+	expired := 0
+	for _, offer := range s.Offers {
+		if offer.State == OfferStatePending && !now.Before(offer.ExpiresAt) {
+			offer.State = OfferStateExpired
+			expired++
+		}
+	}
+	return expired
+}
+
+// PendingOffers возвращает количество офферов, ожидающих ответа
+func (s *MatchingSession) PendingOffers() int {
+	pending := 0
+	for _, offer := range s.Offers {
+		if offer.State == OfferStatePending {
+			pending++
+		}
+	}
+	return pending
+}
+
+// RespondToOffer фиксирует ответ водителя на оффер.
+//
+// Возвращает ErrOfferNotFound, если оффер этому водителю не отправлялся,
+// и ErrOfferExpired, если оффер уже просрочен или на него уже ответили.
+func (s *MatchingSession) RespondToOffer(driverID string, accepted bool, now time.Time) error {
+	offer, ok := s.Offers[driverID]
+	if !ok {
+		return ErrOfferNotFound
+	}
+	if offer.State != OfferStatePending || !now.Before(offer.ExpiresAt) {
+		return ErrOfferExpired
+	}
+
+	if accepted {
+		offer.State = OfferStateAccepted
+	} else {
+		offer.State = OfferStateDeclined
+	}
+	return nil
+}
+
+// WidenRadius расширяет радиус поиска на step, но не больше maxKm.
+// Возвращает false, если радиус уже максимальный.
+func (s *MatchingSession) WidenRadius(step, maxKm float64) bool {
+	if s.RadiusKm >= maxKm || step <= 0 {
+		return false
+	}
+	s.RadiusKm += step
+	if s.RadiusKm > maxKm {
+		s.RadiusKm = maxKm
+	}
+	return true
+}
+
+// Expired проверяет, истек ли крайний срок подбора
+func (s *MatchingSession) Expired(now time.Time) bool {
+	return !now.Before(s.Deadline)
+}
+
+// DueAt возвращает момент, когда подбор нужно продвинуть без ответа водителей:
+// истечение ожидающего оффера, повторный поиск кандидатов или крайний срок
+func (s *MatchingSession) DueAt() time.Time {
+	due := s.Deadline
+	pending := false
+	for _, offer := range s.Offers {
+		if offer.State != OfferStatePending {
+			continue
+		}
+		pending = true
+		if offer.ExpiresAt.Before(due) {
+			due = offer.ExpiresAt
+		}
+	}
+	if !pending && s.NextSearchAt.Before(due) {
+		due = s.NextSearchAt
+	}
+	return due
+}
+
+// ScoringWeights — веса факторов ранжирования кандидатов
+type ScoringWeights struct {
+	Distance float64
+	Rating   float64
+	Idle     float64
+	IdleCap  time.Duration // простой дольше этого срока не дает дополнительного преимущества
+}
+
+// CandidateScore — оценка кандидата с разбивкой по факторам.
+// Разбивка пишется в логи, чтобы решение о выборе водителя можно было объяснить.
+type CandidateScore struct {
+	DriverID      string
+	DistanceKm    float64
+	Rating        float64
+	IdleSeconds   float64
+	DistanceScore float64 // 1 — у точки подачи, 0 — на границе радиуса
+	RatingScore   float64 // 1 — рейтинг 5.0, 0 — рейтинг 1.0
+	IdleScore     float64 // 1 — простой не меньше IdleCap
+	Total         float64
+}
+
+// PoolCandidate — POOL-водитель в пути, в план которого можно вставить поездку
+type PoolCandidate struct {
+	Score     CandidateScore
+	Insertion PoolInsertion
+}
+
+// ScoreCandidate оценивает кандидата: каждый фактор нормализуется в [0, 1]
+// и умножается на свой вес
+func ScoreCandidate(driverID string, distanceKm, rating, idleSeconds, radiusKm float64, w ScoringWeights) CandidateScore {
+	score := CandidateScore{
+		DriverID:    driverID,
+		DistanceKm:  distanceKm,
+		Rating:      rating,
+		IdleSeconds: idleSeconds,
+	}
+
+	if radiusKm > 0 {
+		score.DistanceScore = clamp01(1 - distanceKm/radiusKm)
+	}
+	score.RatingScore = clamp01((rating - 1) / 4)
+	if w.IdleCap > 0 {
+		score.IdleScore = clamp01(idleSeconds / w.IdleCap.Seconds())
+	}
+
+	score.Total = w.Distance*score.DistanceScore + w.Rating*score.RatingScore + w.Idle*score.IdleScore
+	return score
+}
+
+// RankCandidates сортирует кандидатов по убыванию оценки (при равенстве — ближайший первым)
+func RankCandidates(scores []CandidateScore) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Total != scores[j].Total {
+			return scores[i].Total > scores[j].Total
+		}
+		return scores[i].DistanceKm < scores[j].DistanceKm
+	})
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestScoreCandidate(t *testing.T) {
+	weights := ScoringWeights{Distance: 0.5, Rating: 0.3, Idle: 0.2, IdleCap: 10 * time.Minute}
+
+	tests := []struct {
+		name        string
+		distanceKm  float64
+		rating      float64
+		idleSeconds float64
+		radiusKm    float64
+		weights     ScoringWeights
+		want        CandidateScore
+	}{
+		{
+			name:        "best possible candidate",
+			distanceKm:  0,
+			rating:      5,
+			idleSeconds: 600,
+			radiusKm:    5,
+			weights:     weights,
+			want:        CandidateScore{DistanceScore: 1, RatingScore: 1, IdleScore: 1, Total: 1},
+		},
+		{
+			name:       "worst possible candidate",
+			distanceKm: 5,
+			rating:     1,
+			radiusKm:   5,
+			weights:    weights,
+			want:       CandidateScore{},
+		},
+		{
+			name:        "midpoints",
+			distanceKm:  2.5,
+			rating:      3,
+			idleSeconds: 300,
+			radiusKm:    5,
+			weights:     weights,
+			want:        CandidateScore{DistanceScore: 0.5, RatingScore: 0.5, IdleScore: 0.5, Total: 0.5},
+		},
+		{
+			name:        "factors are clamped to [0, 1]",
+			distanceKm:  7,
+			rating:      0,
+			idleSeconds: 3600,
+			radiusKm:    5,
+			weights:     weights,
+			want:        CandidateScore{IdleScore: 1, Total: 0.2},
+		},
+		{
+			name:        "zero radius and idle cap disable their factors",
+			distanceKm:  0,
+			rating:      5,
+			idleSeconds: 600,
+			radiusKm:    0,
+			weights:     ScoringWeights{Distance: 0.5, Rating: 0.3, Idle: 0.2},
+			want:        CandidateScore{RatingScore: 1, Total: 0.3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ScoreCandidate("driver-1", tc.distanceKm, tc.rating, tc.idleSeconds, tc.radiusKm, tc.weights)
+
+			if got.DriverID != "driver-1" || got.DistanceKm != tc.distanceKm || got.Rating != tc.rating || got.IdleSeconds != tc.idleSeconds {
+				t.Errorf("inputs not copied into score: %+v", got)
+			}
+			for _, f := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"DistanceScore", got.DistanceScore, tc.want.DistanceScore},
+				{"RatingScore", got.RatingScore, tc.want.RatingScore},
+				{"IdleScore", got.IdleScore, tc.want.IdleScore},
+				{"Total", got.Total, tc.want.Total},
+			} {
+				if math.Abs(f.got-f.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRankCandidates(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []CandidateScore
+		want   []string
+	}{
+		{
+			name:   "empty",
+			scores: nil,
+			want:   nil,
+		},
+		{
+			name: "by total descending",
+			scores: []CandidateScore{
+				{DriverID: "a", Total: 0.2, DistanceKm: 1},
+				{DriverID: "b", Total: 0.9, DistanceKm: 3},
+				{DriverID: "c", Total: 0.5, DistanceKm: 2},
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name: "equal totals: nearest first",
+			scores: []CandidateScore{
+				{DriverID: "far", Total: 0.7, DistanceKm: 4},
+				{DriverID: "near", Total: 0.7, DistanceKm: 1},
+				{DriverID: "best", Total: 0.8, DistanceKm: 5},
+			},
+			want: []string{"best", "near", "far"},
+		},
+		{
+			name: "full ties keep input order",
+			scores: []CandidateScore{
+				{DriverID: "first", Total: 0.5, DistanceKm: 2},
+				{DriverID: "second", Total: 0.5, DistanceKm: 2},
+			},
+			want: []string{"first", "second"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			RankCandidates(tc.scores)
+
+			if len(tc.scores) != len(tc.want) {
+				t.Fatalf("len = %d, want %d", len(tc.scores), len(tc.want))
+			}
+			for i, score := range tc.scores {
+				if score.DriverID != tc.want[i] {
+					t.Errorf("position %d = %s, want %s", i, score.DriverID, tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// WaypointKind — тип точки маршрута POOL-водителя
+type WaypointKind string
+
+const (
+	WaypointPickup  WaypointKind = "PICKUP"  // посадка пассажира
+	WaypointDropoff WaypointKind = "DROPOFF" // высадка пассажира
+)
+
+// PoolWaypoint — еще не пройденная точка маршрута POOL-водителя (таблица pool_waypoints).
+// Пассажир в машине, если в плане осталась только его высадка.
+type PoolWaypoint struct {
+	RideID       string
+	Kind         WaypointKind
+	CoordinateID string
+	Address      string
+	Lat          float64
+	Lng          float64
+}
+
+// PoolLimits — ограничения подсадки в совместную поездку
+type PoolLimits struct {
+	Seats          int     // Мест для пассажиров в машине
+	MaxDetourRatio float64 // Допустимое удлинение пути пассажира (доля от исходного)
+	MaxDetourKm    float64 // Абсолютный потолок удлинения
+}
+
+// PoolInsertion — лучший вариант подсадки нового пассажира
+type PoolInsertion struct {
+	Plan         []PoolWaypoint
+	PickupIndex  int     // Позиция посадки в новом плане
+	DropoffIndex int     // Позиция высадки в новом плане
+	AddedKm      float64 // На сколько вырос весь оставшийся маршрут
+	MaxDetourKm  float64 // Наибольшее удлинение пути среди пассажиров, уже бывших в плане
+	Onboard      int     // Пассажиров в машине в момент посадки нового
+}
+
+// OnboardRiders — сколько пассажиров сейчас в машине
+func OnboardRiders(plan []PoolWaypoint) int {
+	pickups := make(map[string]bool, len(plan))
+	for _, wp := range plan {
+		if wp.Kind == WaypointPickup {
+			pickups[wp.RideID] = true
+		}
+	}
+
+	onboard := 0
+	for _, wp := range plan {
+		if wp.Kind == WaypointDropoff && !pickups[wp.RideID] {
+			onboard++
+		}
+	}
+	return onboard
+}
+
+// PlanInsertion вставляет посадку и высадку нового пассажира в план водителя.
+//
+// ЭВРИСТИКА ВСТАВКИ: перебираются все позиции посадки i и высадки j > i
+// (O(n²) вариантов, n — не больше двух точек на место). Вариант допустим, если
+//   - ни в одной точке в машине не больше limits.Seats пассажиров
+//   - путь каждого пассажира (для едущих — от текущей точки до высадки,
+//     для ожидающих — от посадки до высадки) вырос не больше чем на
+//     min(MaxDetourRatio · исходный путь, MaxDetourKm)
+//   - путь нового пассажира длиннее прямого не больше чем на то же ограничение
+//
+// Из допустимых выбирается вариант с наименьшим приростом всего маршрута.
+// Возвращает ErrPoolNoSeats, если мест не хватает ни в одном варианте,
+// и ErrPoolDetourTooLong, если места есть, но объезд слишком большой.
+func PlanInsertion(startLat, startLng float64, plan []PoolWaypoint, pickup, dropoff PoolWaypoint, limits PoolLimits) (PoolInsertion, error) {
+	baseLengths, baseTotal := riderLengths(startLat, startLng, plan)
+	onboardNow := OnboardRiders(plan)
+	direct := DistanceMeters(pickup.Lat, pickup.Lng, dropoff.Lat, dropoff.Lng) / 1000
+
+	var best *PoolInsertion
+	seatsFit := false
+
+	for i := 0; i <= len(plan); i++ {
+		for j := i + 1; j <= len(plan)+1; j++ {
+			candidate := make([]PoolWaypoint, 0, len(plan)+2)
+			candidate = append(candidate, plan[:i]...)
+			candidate = append(candidate, pickup)
+			candidate = append(candidate, plan[i:j-1]...)
+			candidate = append(candidate, dropoff)
+			candidate = append(candidate, plan[j-1:]...)
+
+			onboardAtPickup, ok := fitsSeats(candidate, onboardNow, pickup.RideID, limits.Seats)
+			if !ok {
+				continue
+			}
+			seatsFit = true
+
+			lengths, total := riderLengths(startLat, startLng, candidate)
+			if lengths[pickup.RideID]-direct > detourAllowance(direct, limits) {
+				continue
+			}
+
+			worst := 0.0
+			fits := true
+			for rideID, before := range baseLengths {
+				extra := lengths[rideID] - before
+				if extra > detourAllowance(before, limits) {
+					fits = false
+					break
+				}
+				worst = math.Max(worst, extra)
+			}
+			if !fits {
+				continue
+			}
+
+			added := total - baseTotal
+			if best == nil || added < best.AddedKm {
+				best = &PoolInsertion{
+					Plan:         candidate,
+					PickupIndex:  i,
+					DropoffIndex: j,
+					AddedKm:      added,
+					MaxDetourKm:  worst,
+					Onboard:      onboardAtPickup,
+				}
+			}
+		}
+	}
+
+	switch {
+	case best != nil:
+		return *best, nil
+	case !seatsFit:
+		return PoolInsertion{}, ErrPoolNoSeats
+	default:
+		return PoolInsertion{}, ErrPoolDetourTooLong
+	}
+}
+
+// AppendToPlan добавляет пассажира в конец плана — запасной вариант, когда поездка
+// уже назначена, а план водителя успел измениться и вставка стала недопустимой
+func AppendToPlan(plan []PoolWaypoint, pickup, dropoff PoolWaypoint) []PoolWaypoint {
+	result := make([]PoolWaypoint, 0, len(plan)+2)
+	result = append(result, plan...)
+	return append(result, pickup, dropoff)
+}
+
+// fitsSeats проходит план и проверяет вместимость.
+// Возвращает число пассажиров в машине в момент посадки rideID.
+func fitsSeats(plan []PoolWaypoint, onboard int, rideID string, seats int) (int, bool) {
+	atPickup := 0
+	for _, wp := range plan {
+		switch wp.Kind {
+		case WaypointPickup:
+			if wp.RideID == rideID {
+				atPickup = onboard
+			}
+			onboard++
+			if onboard > seats {
+				return 0, false
+			}
+		case WaypointDropoff:
+			onboard--
+		}
+	}
+	return atPickup, true
+}
+
+// riderLengths считает путь каждого пассажира по плану (км) и длину всего плана
+func riderLengths(startLat, startLng float64, plan []PoolWaypoint) (map[string]float64, float64) {
+	lengths := make(map[string]float64, len(plan)/2+1)
+	pickedAt := make(map[string]float64, len(plan)/2+1)
+
+	total := 0.0
+	lat, lng := startLat, startLng
+	for _, wp := range plan {
+		total += DistanceMeters(lat, lng, wp.Lat, wp.Lng) / 1000
+		lat, lng = wp.Lat, wp.Lng
+
+		switch wp.Kind {
+		case WaypointPickup:
+			pickedAt[wp.RideID] = total
+		case WaypointDropoff:
+			// Для едущего пассажира путь считается от текущей точки водителя
+			lengths[wp.RideID] = total - pickedAt[wp.RideID]
+		}
+	}
+	return lengths, total
+}
+
+// detourAllowance — насколько может вырасти путь длиной base
+func detourAllowance(base float64, limits PoolLimits) float64 {
+	return math.Min(base*limits.MaxDetourRatio, limits.MaxDetourKm)
+}
+
+// TimeInterval — отрезок времени; End == nil — отрезок еще не закончился
+type TimeInterval struct {
+	Start time.Time
+	End   *time.Time
+}
+
+// SharedFraction — доля отрезка [from, to], когда в машине был хотя бы один попутчик.
+// Пересекающиеся поездки попутчиков объединяются, чтобы время не считалось дважды.
+func SharedFraction(from, to time.Time, others []TimeInterval) float64 {
+	if !to.After(from) {
+		return 0
+	}
+
+	clipped := make([]TimeInterval, 0, len(others))
+	for _, iv := range others {
+		start, end := iv.Start, to
+		if iv.End != nil && iv.End.Before(to) {
+			end = *iv.End
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			e := end
+			clipped = append(clipped, TimeInterval{Start: start, End: &e})
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i].Start.Before(clipped[j].Start) })
+
+	var shared time.Duration
+	var cursor time.Time
+	for _, iv := range clipped {
+		start := iv.Start
+		if start.Before(cursor) {
+			start = cursor
+		}
+		if iv.End.After(start) {
+			shared += iv.End.Sub(start)
+			cursor = *iv.End
+		}
+	}
+
+	return math.Min(float64(shared)/float64(to.Sub(from)), 1)
+}
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// Точки на экваторе: 0.01° долготы ≈ 1.11 км
+func waypoint(rideID string, kind WaypointKind, lat, lng float64) PoolWaypoint {
+	return PoolWaypoint{RideID: rideID, Kind: kind, Lat: lat, Lng: lng}
+}
+
+func TestPlanInsertion(t *testing.T) {
+	generous := PoolLimits{Seats: 2, MaxDetourRatio: 0.5, MaxDetourKm: 5}
+
+	tests := []struct {
+		name         string
+		plan         []PoolWaypoint
+		pickup       PoolWaypoint
+		dropoff      PoolWaypoint
+		limits       PoolLimits
+		wantErr      error
+		wantPickup   int
+		wantDropoff  int
+		wantOnboard  int
+		wantSequence []string // RideID:Kind нового плана
+	}{
+		{
+			name:         "empty plan",
+			pickup:       waypoint("new", WaypointPickup, 0, 0.01),
+			dropoff:      waypoint("new", WaypointDropoff, 0, 0.05),
+			limits:       generous,
+			wantPickup:   0,
+			wantDropoff:  1,
+			wantSequence: []string{"new:PICKUP", "new:DROPOFF"},
+		},
+		{
+			name:         "on the way of an onboard rider",
+			plan:         []PoolWaypoint{waypoint("a", WaypointDropoff, 0, 0.10)},
+			pickup:       waypoint("new", WaypointPickup, 0, 0.02),
+			dropoff:      waypoint("new", WaypointDropoff, 0, 0.04),
+			limits:       generous,
+			wantPickup:   0,
+			wantDropoff:  1,
+			wantOnboard:  1,
+			wantSequence: []string{"new:PICKUP", "new:DROPOFF", "a:DROPOFF"},
+		},
+		{
+			name:         "single seat waits for the onboard rider to leave",
+			plan:         []PoolWaypoint{waypoint("a", WaypointDropoff, 0, 0.10)},
+			pickup:       waypoint("new", WaypointPickup, 0, 0.02),
+			dropoff:      waypoint("new", WaypointDropoff, 0, 0.04),
+			limits:       PoolLimits{Seats: 1, MaxDetourRatio: 0.5, MaxDetourKm: 5},
+			wantPickup:   1,
+			wantDropoff:  2,
+			wantSequence: []string{"a:DROPOFF", "new:PICKUP", "new:DROPOFF"},
+		},
+		{
+			name:         "small detour is accepted",
+			plan:         []PoolWaypoint{waypoint("a", WaypointDropoff, 0, 0.05)},
+			pickup:       waypoint("new", WaypointPickup, 0.01, 0.01),
+			dropoff:      waypoint("new", WaypointDropoff, 0.01, 0.08),
+			limits:       generous,
+			wantPickup:   0,
+			wantDropoff:  2,
+			wantOnboard:  1,
+			wantSequence: []string{"new:PICKUP", "a:DROPOFF", "new:DROPOFF"},
+		},
+		{
+			name:         "detour over the limit moves the rider to the end",
+			plan:         []PoolWaypoint{waypoint("a", WaypointDropoff, 0, 0.05)},
+			pickup:       waypoint("new", WaypointPickup, 0.01, 0.01),
+			dropoff:      waypoint("new", WaypointDropoff, 0.01, 0.08),
+			limits:       PoolLimits{Seats: 2, MaxDetourRatio: 0.5, MaxDetourKm: 0.3},
+			wantPickup:   1,
+			wantDropoff:  2,
+			wantSequence: []string{"a:DROPOFF", "new:PICKUP", "new:DROPOFF"},
+		},
+		{
+			name:    "no seats",
+			plan:    []PoolWaypoint{waypoint("a", WaypointDropoff, 0, 0.10)},
+			pickup:  waypoint("new", WaypointPickup, 0, 0.02),
+			dropoff: waypoint("new", WaypointDropoff, 0, 0.04),
+			limits:  PoolLimits{Seats: 0, MaxDetourRatio: 0.5, MaxDetourKm: 5},
+			wantErr: ErrPoolNoSeats,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PlanInsertion(0, 0, tc.plan, tc.pickup, tc.dropoff, tc.limits)
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.PickupIndex != tc.wantPickup || got.DropoffIndex != tc.wantDropoff {
+				t.Errorf("indices = (%d, %d), want (%d, %d)", got.PickupIndex, got.DropoffIndex, tc.wantPickup, tc.wantDropoff)
+			}
+			if got.Onboard != tc.wantOnboard {
+				t.Errorf("Onboard = %d, want %d", got.Onboard, tc.wantOnboard)
+			}
+			if len(got.Plan) != len(tc.wantSequence) {
+				t.Fatalf("plan length = %d, want %d", len(got.Plan), len(tc.wantSequence))
+			}
+			for i, wp := range got.Plan {
+				if step := wp.RideID + ":" + string(wp.Kind); step != tc.wantSequence[i] {
+					t.Errorf("plan[%d] = %s, want %s", i, step, tc.wantSequence[i])
+				}
+			}
+			if got.AddedKm < -1e-9 {
+				t.Errorf("AddedKm = %v, want non-negative", got.AddedKm)
+			}
+			if got.MaxDetourKm > tc.limits.MaxDetourKm {
+				t.Errorf("MaxDetourKm = %v exceeds limit %v", got.MaxDetourKm, tc.limits.MaxDetourKm)
+			}
+		})
+	}
+}
+
+func TestPlanInsertionKeepsInputPlan(t *testing.T) {
+	plan := []PoolWaypoint{waypoint("a", WaypointDropoff, 0, 0.10)}
+	limits := PoolLimits{Seats: 2, MaxDetourRatio: 0.5, MaxDetourKm: 5}
+
+	if _, err := PlanInsertion(0, 0, plan, waypoint("new", WaypointPickup, 0, 0.02), waypoint("new", WaypointDropoff, 0, 0.04), limits); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan) != 1 || plan[0].RideID != "a" {
+		t.Errorf("input plan modified: %+v", plan)
+	}
+}
+
+func TestOnboardRiders(t *testing.T) {
+	tests := []struct {
+		name string
+		plan []PoolWaypoint
+		want int
+	}{
+		{name: "empty", want: 0},
+		{
+			name: "waiting rider is not onboard",
+			plan: []PoolWaypoint{waypoint("a", WaypointPickup, 0, 0.01), waypoint("a", WaypointDropoff, 0, 0.02)},
+			want: 0,
+		},
+		{
+			name: "only dropoff left",
+			plan: []PoolWaypoint{
+				waypoint("a", WaypointDropoff, 0, 0.01),
+				waypoint("b", WaypointPickup, 0, 0.02),
+				waypoint("c", WaypointDropoff, 0, 0.03),
+				waypoint("b", WaypointDropoff, 0, 0.04),
+			},
+			want: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := OnboardRiders(tc.plan); got != tc.want {
+			t.Errorf("%s: OnboardRiders = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSharedFraction(t *testing.T) {
+	from := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(60 * time.Minute)
+	at := func(minutes int) time.Time { return from.Add(time.Duration(minutes) * time.Minute) }
+	interval := func(start, end int) TimeInterval {
+		e := at(end)
+		return TimeInterval{Start: at(start), End: &e}
+	}
+
+	tests := []struct {
+		name   string
+		from   time.Time
+		to     time.Time
+		others []TimeInterval
+		want   float64
+	}{
+		{name: "alone", from: from, to: to, want: 0},
+		{name: "empty ride", from: from, to: from, others: []TimeInterval{interval(0, 60)}, want: 0},
+		{name: "first half", from: from, to: to, others: []TimeInterval{interval(0, 30)}, want: 0.5},
+		{name: "overlapping riders are merged", from: from, to: to, others: []TimeInterval{interval(15, 45), interval(0, 30)}, want: 0.75},
+		{name: "nested rider counted once", from: from, to: to, others: []TimeInterval{interval(10, 50), interval(20, 30)}, want: 40.0 / 60},
+		{name: "disjoint riders add up", from: from, to: to, others: []TimeInterval{interval(0, 15), interval(45, 60)}, want: 0.5},
+		{name: "rider still in the car", from: from, to: to, others: []TimeInterval{{Start: at(30)}}, want: 0.5},
+		{name: "rider outside the ride", from: from, to: to, others: []TimeInterval{interval(-30, -10), interval(70, 90)}, want: 0},
+		{name: "rider covers the whole ride", from: from, to: to, others: []TimeInterval{interval(-10, 90)}, want: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SharedFraction(tc.from, tc.to, tc.others); math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("SharedFraction = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"fmt"
+
+	"ridehail/internal/shared/ridestate"
+)
+
+// CheckRideTransition проверяет переход статуса поездки from → to по общей
+// state machine поездки (ridestate). Возвращает ErrInvalidRideTransition.
+func CheckRideTransition(from, to string) error {
+	if !ridestate.CanTransition(from, to) {
+		return fmt.Errorf("%w: %s → %s", ErrInvalidRideTransition, from, to)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	constants "ridehail/internal/shared/const"
+)
+
+func TestCheckRideTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{from: constants.RideStatusRequested, to: constants.RideStatusMatched},
+		{from: constants.RideStatusEnRoute, to: constants.RideStatusArrived},
+		{from: constants.RideStatusArrived, to: constants.RideStatusInProgress},
+		{from: constants.RideStatusInProgress, to: constants.RideStatusCompleted},
+		{from: constants.RideStatusMatched, to: constants.RideStatusInProgress, wantErr: true},
+		{from: constants.RideStatusInProgress, to: constants.RideStatusCancelled, wantErr: true},
+		{from: constants.RideStatusCompleted, to: constants.RideStatusInProgress, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		err := CheckRideTransition(tc.from, tc.to)
+		if tc.wantErr != errors.Is(err, ErrInvalidRideTransition) {
+			t.Errorf("CheckRideTransition(%s, %s) = %v, want error: %v", tc.from, tc.to, err, tc.wantErr)
+		}
+		if !tc.wantErr && err != nil {
+			t.Errorf("CheckRideTransition(%s, %s) = %v, want nil", tc.from, tc.to, err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package inamqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DriverArrivedConsumer — слушатель прибытия водителей на точку подачи
+type DriverArrivedConsumer struct {
+	mqConn                *mq.RabbitMQ
+	handleDriverArrivedUC in.HandleDriverArrivedUseCase
+	log                   *logger.Logger
+}
+
+// NewDriverArrivedConsumer создает новый consumer
+func NewDriverArrivedConsumer(
+	mqConn *mq.RabbitMQ,
+	handleDriverArrivedUC in.HandleDriverArrivedUseCase,
+	log *logger.Logger,
+) *DriverArrivedConsumer {
+	return &DriverArrivedConsumer{
+		mqConn:                mqConn,
+		handleDriverArrivedUC: handleDriverArrivedUC,
+		log:                   log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *DriverArrivedConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	queueName := "ride_service_driver_arrived"
+	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(queueName, contract.RoutingPatternDriverArrived, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,
+		"ride-service-driver-arrived", // consumer tag
+		false,                         // auto-ack
+		false,                         // exclusive
+		false,                         // no-local
+		false,                         // no-wait
+		nil,                           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "driver_arrived_consumer_started",
+		Message: fmt.Sprintf("listening on driver_topic (queue: %s, pattern: driver.arrived.*)", queueName),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "driver_arrived_consumer_stopping",
+				Message: "context cancelled",
+			})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "driver_arrived_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleDriverArrived(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "handle_driver_arrived_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				// Временный сбой (БД) — вернем сообщение в очередь
+				_ = msg.Nack(false, true)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleDriverArrived обрабатывает одно сообщение
+func (c *DriverArrivedConsumer) handleDriverArrived(ctx context.Context, msg amqp.Delivery) error {
+	var arrived contract.DriverArrived
+	if err := contract.Decode(msg.Body, &arrived); err != nil {
+		// Невалидное сообщение (или чужая версия контракта) не станет валидным при повторе
+		c.log.Error(logger.Entry{
+			Action:  "driver_arrived_parse_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	c.log.Debug(logger.Entry{
+		Action:  "driver_arrived_received",
+		Message: arrived.DriverID,
+		RideID:  arrived.RideID,
+		Additional: map[string]any{
+			"distance_to_pickup_meters": arrived.DistanceToPickupMeters,
+		},
+	})
+
+	err := c.handleDriverArrivedUC.Execute(ctx, in.HandleDriverArrivedInput{
+		RideID:                 arrived.RideID,
+		DriverID:               arrived.DriverID,
+		Lat:                    arrived.Location.Lat,
+		Lng:                    arrived.Location.Lng,
+		DistanceToPickupMeters: arrived.DistanceToPickupMeters,
+		FreeWaitingMinutes:     arrived.FreeWaitingMinutes,
+		ArrivedAt:              arrived.ArrivedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("execute use case: %w", err)
+	}
+
+	return nil
+}
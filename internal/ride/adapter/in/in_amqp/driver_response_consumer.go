@@ -2,12 +2,14 @@ package inamqp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"ridehail/internal/ride/adapter/in/in_ws"
 	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
 	"ridehail/internal/shared/mq"
 
@@ -32,56 +34,6 @@ import (
 // - Use case НЕ знает о RabbitMQ, WebSocket, HTTP
 // ============================================================================
 
-// DriverResponseMessage — формат JSON сообщения из RabbitMQ.
-//
-// Публикуется Driver Service в топик "driver_topic" с routing key:
-// "driver.response.{ride_id}"
-//
-// Пример сообщения:
-//
-//	{
-//	  "ride_id": "uuid-123",
-//	  "driver_id": "uuid-456",
-//	  "accepted": true,
-//	  "estimated_arrival_minutes": 5,
-//	  "driver_location": {"lat": 43.238, "lng": 76.889},
-//	  "driver_info": {
-//	    "name": "Иван Петров",
-//	    "rating": 4.8,
-//	    "vehicle": {"make": "Toyota", "model": "Camry", "plate": "KZ 777 ABC"}
-//	  }
-//	}
-type DriverResponseMessage struct {
-	RideID                  string  `json:"ride_id"`                             // UUID поездки
-	DriverID                string  `json:"driver_id"`                           // UUID водителя
-	Accepted                bool    `json:"accepted"`                            // true=принял, false=отклонил
-	EstimatedArrivalMinutes int     `json:"estimated_arrival_minutes,omitempty"` // ETA в минутах
-	DriverLocation          *LocDTO `json:"driver_location,omitempty"`           // Координаты водителя
-	DriverInfo              *DrvDTO `json:"driver_info,omitempty"`               // Данные для пассажира
-	CorrelationID           string  `json:"correlation_id,omitempty"`            // ID для трейсинга
-}
-
-// LocDTO — координаты (lat/lng).
-type LocDTO struct {
-	Lat float64 `json:"lat"` // Широта (Latitude)
-	Lng float64 `json:"lng"` // Долгота (Longitude)
-}
-
-// DrvDTO — информация о водителе для отображения пассажиру.
-type DrvDTO struct {
-	Name    string      `json:"name"`              // Имя водителя
-	Rating  float64     `json:"rating"`            // Рейтинг (1.0 - 5.0)
-	Vehicle *VehicleDTO `json:"vehicle,omitempty"` // Данные автомобиля
-}
-
-// VehicleDTO — данные автомобиля.
-type VehicleDTO struct {
-	Make  string `json:"make"`  // Марка (Toyota, BMW, ...)
-	Model string `json:"model"` // Модель (Camry, X5, ...)
-	Color string `json:"color"` // Цвет (White, Black, ...)
-	Plate string `json:"plate"` // Гос. номер (KZ 777 ABC)
-}
-
 // DriverResponseConsumer — слушатель очереди RabbitMQ для ответов водителей.
 //
 // Зависимости:
@@ -160,11 +112,11 @@ func (c *DriverResponseConsumer) Start(ctx context.Context) error {
 	// * (звездочка) = ровно одно слово
 	// # (решетка) = ноль или больше слов
 	err = ch.QueueBind(
-		queue.Name,          // queue name: наша очередь
-		"driver.response.*", // routing key: шаблон для фильтрации сообщений
-		"driver_topic",      // exchange: откуда берем сообщения
-		false,               // no-wait: ждать подтверждения
-		nil,                 // arguments: дополнительные параметры
+		queue.Name,                            // queue name: наша очередь
+		contract.RoutingPatternDriverResponse, // routing key: шаблон для фильтрации сообщений
+		contract.ExchangeDriver,               // exchange: откуда берем сообщения
+		false,                                 // no-wait: ждать подтверждения
+		nil,                                   // arguments: дополнительные параметры
 	)
 	if err != nil {
 		return fmt.Errorf("failed to bind queue: %w", err)
@@ -253,25 +205,34 @@ func (c *DriverResponseConsumer) Start(ctx context.Context) error {
 // handleDriverResponse — обработчик одного сообщения.
 //
 // ПОТОК ВЫПОЛНЕНИЯ:
-// 1. Парсинг JSON → DriverResponseMessage
+// 1. Декодирование contract.DriverResponse (с проверкой версии контракта)
 // 2. Логирование для мониторинга
 // 3. Преобразование в Input для use case
 // 4. Вызов бизнес-логики (use case)
 // 5. Отправка WebSocket уведомления пассажиру
 //
 // ОБРАБОТКА ОШИБОК:
-// - JSON parse error → возвращаем ошибку (Nack + requeue)
+// - Невалидный JSON / чужая версия контракта → логируем и Ack (повтор не поможет)
 // - Use case error → возвращаем ошибку (Nack + requeue)
+// - Устаревший ответ (поездка уже назначена/отменена) → Ack без повторов
 // - WebSocket error → логируем, но НЕ возвращаем ошибку (Ack сообщение)
 //
 // Почему WebSocket ошибка не критична?
 // Потому что данные уже сохранены в БД. Пассажир увидит обновление
 // при следующем pull или reconnect к WebSocket.
 func (c *DriverResponseConsumer) handleDriverResponse(ctx context.Context, msg amqp.Delivery) error {
-	// ШАГ 1: Десериализация JSON в структуру Go
-	var response DriverResponseMessage
-	if err := json.Unmarshal(msg.Body, &response); err != nil {
-		return fmt.Errorf("failed to parse driver response: %w", err)
+	// ШАГ 1: Декодирование сообщения по контракту
+	var response contract.DriverResponse
+	if err := contract.Decode(msg.Body, &response); err != nil {
+		c.log.Error(logger.Entry{
+			Action:  "driver_response_rejected",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"routing_key": msg.RoutingKey,
+			},
+		})
+		return nil
 	}
 
 	// ШАГ 2: Логирование для отладки и мониторинга
@@ -308,6 +269,18 @@ func (c *DriverResponseConsumer) handleDriverResponse(ctx context.Context, msg a
 	}
 
 	output, err := c.handleDriverResponseUseCase.Execute(ctx, useCaseInput)
+	if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrStatusConflict) {
+		// Поездку уже взял другой водитель или она отменена — повтор не поможет
+		c.log.Warn(logger.Entry{
+			Action:  "driver_response_outdated",
+			Message: err.Error(),
+			RideID:  rideID,
+			Additional: map[string]any{
+				"driver_id": response.DriverID,
+			},
+		})
+		return nil
+	}
 	if err != nil {
 		c.log.Error(logger.Entry{
 			Action:  "handle_driver_response_usecase_failed",
@@ -330,16 +303,38 @@ func (c *DriverResponseConsumer) handleDriverResponse(ctx context.Context, msg a
 			},
 		})
 
-		// TODO: Отправить WebSocket уведомление пассажиру
-		// notification := map[string]any{
-		//   "type": "ride_matched",
-		//   "ride_id": rideID,
-		//   "driver_id": response.DriverID,
-		//   "estimated_arrival_minutes": response.EstimatedArrivalMinutes,
-		//   "driver_info": response.DriverInfo,
-		//   "driver_location": response.DriverLocation,
-		// }
-		// c.passengerWS.SendToPassenger(output.PassengerID, notification)
+		driverInfo := map[string]interface{}{
+			"driver_id":                 response.DriverID,
+			"estimated_arrival_minutes": response.EstimatedArrivalMinutes,
+		}
+		if response.DriverInfo != nil {
+			driverInfo["name"] = response.DriverInfo.Name
+			driverInfo["rating"] = response.DriverInfo.Rating
+			driverInfo["vehicle"] = response.DriverInfo.Vehicle
+		}
+		if response.DriverLocation != nil {
+			driverInfo["location"] = response.DriverLocation
+		}
+
+		if err := c.passengerWS.SendMatchNotification(output.PassengerID, rideID, driverInfo); err != nil {
+			c.log.Warn(logger.Entry{
+				Action:  "ride_matched_notification_failed",
+				Message: err.Error(),
+				RideID:  rideID,
+			})
+		}
+
+		// Поездка уже EN_ROUTE — отдельное обновление статуса для экрана ожидания
+		if err := c.passengerWS.SendRideStatusUpdate(output.PassengerID, rideID, output.Status, "Your driver is on the way", map[string]interface{}{
+			"driver_id":                 response.DriverID,
+			"estimated_arrival_minutes": response.EstimatedArrivalMinutes,
+		}); err != nil {
+			c.log.Warn(logger.Entry{
+				Action:  "ride_en_route_notification_failed",
+				Message: err.Error(),
+				RideID:  rideID,
+			})
+		}
 	}
 
 	return nil
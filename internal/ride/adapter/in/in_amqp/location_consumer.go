@@ -2,31 +2,22 @@ package inamqp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"ridehail/internal/ride/adapter/in/in_ws"
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
 	"ridehail/internal/shared/mq"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-// LocationUpdateMessage структура обновления локации водителя
-type LocationUpdateMessage struct {
-	DriverID  string  `json:"driver_id"`
-	RideID    string  `json:"ride_id,omitempty"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Heading   float64 `json:"heading,omitempty"`
-	Speed     float64 `json:"speed,omitempty"`
-	Accuracy  float64 `json:"accuracy,omitempty"`
-	Timestamp string  `json:"timestamp"`
-}
-
 // LocationConsumer обрабатывает обновления локации и отправляет их пассажирам
 type LocationConsumer struct {
 	mqConn      *mq.RabbitMQ
+	tracking    in.TrackDriverLocationUseCase
 	passengerWS *in_ws.PassengerWSHandler
 	log         *logger.Logger
 }
@@ -34,11 +25,13 @@ type LocationConsumer struct {
 // NewLocationConsumer создает новый consumer для location updates
 func NewLocationConsumer(
 	mqConn *mq.RabbitMQ,
+	tracking in.TrackDriverLocationUseCase,
 	passengerWS *in_ws.PassengerWSHandler,
 	log *logger.Logger,
 ) *LocationConsumer {
 	return &LocationConsumer{
 		mqConn:      mqConn,
+		tracking:    tracking,
 		passengerWS: passengerWS,
 		log:         log,
 	}
@@ -51,14 +44,15 @@ func (c *LocationConsumer) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to get RabbitMQ channel")
 	}
 
-	// Объявляем временную очередь для этого сервиса
+	// Временная очередь на реплику: сокет пассажира держит одна из реплик,
+	// и локация водителя должна дойти до каждой, а не до одной по кругу
 	queue, err := ch.QueueDeclare(
-		"ride_service_locations", // name
-		false,                    // durable
-		true,                     // auto-delete
-		false,                    // exclusive
-		false,                    // no-wait
-		nil,                      // arguments
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to declare queue: %w", err)
@@ -66,11 +60,11 @@ func (c *LocationConsumer) Start(ctx context.Context) error {
 
 	// Привязываем очередь к location_fanout exchange
 	err = ch.QueueBind(
-		queue.Name,        // queue name
-		"",                // routing key (игнорируется для fanout)
-		"location_fanout", // exchange
-		false,             // no-wait
-		nil,               // arguments
+		queue.Name,                // queue name
+		"",                        // routing key (игнорируется для fanout)
+		contract.ExchangeLocation, // exchange
+		false,                     // no-wait
+		nil,                       // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to bind queue: %w", err)
@@ -81,7 +75,7 @@ func (c *LocationConsumer) Start(ctx context.Context) error {
 		queue.Name, // queue
 		"",         // consumer tag
 		false,      // auto-ack
-		false,      // exclusive
+		true,       // exclusive
 		false,      // no-local
 		false,      // no-wait
 		nil,        // args
@@ -114,7 +108,8 @@ func (c *LocationConsumer) Start(ctx context.Context) error {
 					Message: err.Error(),
 					Error:   &logger.ErrObj{Msg: err.Error()},
 				})
-				_ = msg.Nack(false, true)
+				// Не возвращаем в очередь: через несколько секунд придет более свежая локация
+				_ = msg.Nack(false, false)
 			} else {
 				_ = msg.Ack(false)
 			}
@@ -124,39 +119,65 @@ func (c *LocationConsumer) Start(ctx context.Context) error {
 
 // handleLocationUpdate обрабатывает обновление локации и отправляет пассажиру
 func (c *LocationConsumer) handleLocationUpdate(ctx context.Context, msg amqp.Delivery) error {
-	var locationUpdate LocationUpdateMessage
-	if err := json.Unmarshal(msg.Body, &locationUpdate); err != nil {
-		return fmt.Errorf("failed to parse location update: %w", err)
+	var locationUpdate contract.LocationUpdate
+	if err := contract.Decode(msg.Body, &locationUpdate); err != nil {
+		// Устаревшая координата не нужна при повторе — отбрасываем
+		c.log.Warn(logger.Entry{
+			Action:  "location_update_rejected",
+			Message: err.Error(),
+		})
+		return nil
 	}
 
 	c.log.Debug(logger.Entry{
 		Action: "location_update_received",
 		Message: fmt.Sprintf("driver=%s, ride=%s, lat=%f, lng=%f",
 			locationUpdate.DriverID, locationUpdate.RideID,
-			locationUpdate.Latitude, locationUpdate.Longitude),
+			locationUpdate.Location.Lat, locationUpdate.Location.Lng),
 	})
 
-	// Если есть ride_id, отправляем обновление пассажиру через WebSocket
-	if locationUpdate.RideID != "" {
+	updates, err := c.tracking.Execute(ctx, in.TrackDriverLocationInput{
+		DriverID:       locationUpdate.DriverID,
+		RideID:         locationUpdate.RideID,
+		Lat:            locationUpdate.Location.Lat,
+		Lng:            locationUpdate.Location.Lng,
+		SpeedKmh:       locationUpdate.SpeedKmh,
+		HeadingDegrees: locationUpdate.HeadingDegrees,
+		Timestamp:      locationUpdate.Timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("track driver location: %w", err)
+	}
+
+	// Пустой список — водитель без активной поездки или обновления отброшены throttle;
+	// у POOL-водителя обновление получает каждый пассажир
+	for _, update := range updates {
 		locationData := map[string]interface{}{
-			"type":      "driver_location",
-			"ride_id":   locationUpdate.RideID,
-			"driver_id": locationUpdate.DriverID,
-			"latitude":  locationUpdate.Latitude,
-			"longitude": locationUpdate.Longitude,
-			"heading":   locationUpdate.Heading,
-			"speed":     locationUpdate.Speed,
-			"timestamp": locationUpdate.Timestamp,
+			"lat":             update.Lat,
+			"lng":             update.Lng,
+			"speed_kmh":       update.SpeedKmh,
+			"heading_degrees": update.HeadingDegrees,
 		}
 
-		c.log.Debug(logger.Entry{
-			Action:  "sending_location_to_passenger",
-			Message: fmt.Sprintf("ride_id=%s", locationUpdate.RideID),
-		})
-
-		// TODO: Получить passenger_id из ride_id и отправить конкретному пассажиру
-		// c.passengerWS.SendDriverLocationUpdate(passengerID, locationData)
-		_ = locationData // suppress unused warning
+		// Формат из регламента: estimated_arrival + distance_to_pickup_km (или до точки назначения)
+		etaData := map[string]interface{}{
+			"driver_id":         locationUpdate.DriverID,
+			"ride_status":       update.RideStatus,
+			"eta_target":        update.ETATarget,
+			"eta_minutes":       update.ETAMinutes,
+			"estimated_arrival": time.Now().UTC().Add(time.Duration(update.ETAMinutes) * time.Minute).Format(time.RFC3339),
+			"timestamp":         update.Timestamp,
+		}
+		etaData["distance_to_"+update.ETATarget+"_km"] = update.DistanceKm
+
+		// Пассажир может быть не подключен — локация устареет раньше, чем он вернется
+		if err := c.passengerWS.SendDriverLocationUpdate(update.PassengerID, update.RideID, locationData, etaData); err != nil {
+			c.log.Debug(logger.Entry{
+				Action:  "driver_location_not_delivered",
+				Message: err.Error(),
+				RideID:  update.RideID,
+			})
+		}
 	}
 
 	return nil
@@ -0,0 +1,138 @@
+package inamqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// MatchingStatusConsumer — слушатель этапов подбора водителя
+type MatchingStatusConsumer struct {
+	mqConn                 *mq.RabbitMQ
+	handleMatchingStatusUC in.HandleMatchingStatusUseCase
+	log                    *logger.Logger
+}
+
+// NewMatchingStatusConsumer создает новый consumer
+func NewMatchingStatusConsumer(
+	mqConn *mq.RabbitMQ,
+	handleMatchingStatusUC in.HandleMatchingStatusUseCase,
+	log *logger.Logger,
+) *MatchingStatusConsumer {
+	return &MatchingStatusConsumer{
+		mqConn:                 mqConn,
+		handleMatchingStatusUC: handleMatchingStatusUC,
+		log:                    log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *MatchingStatusConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	queueName := "ride_service_matching_status"
+	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(queueName, contract.RoutingPatternMatchingStatus, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,
+		"ride-service-matching-status", // consumer tag
+		false,                          // auto-ack
+		false,                          // exclusive
+		false,                          // no-local
+		false,                          // no-wait
+		nil,                            // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "matching_status_consumer_started",
+		Message: fmt.Sprintf("listening on driver_topic (queue: %s, pattern: driver.matching.*)", queueName),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "matching_status_consumer_stopping",
+				Message: "context cancelled",
+			})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "matching_status_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleMatchingStatus(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "handle_matching_status_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				// Временный сбой (БД) — вернем сообщение в очередь
+				_ = msg.Nack(false, true)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleMatchingStatus обрабатывает одно сообщение
+func (c *MatchingStatusConsumer) handleMatchingStatus(ctx context.Context, msg amqp.Delivery) error {
+	var status contract.MatchingStatus
+	if err := contract.Decode(msg.Body, &status); err != nil {
+		// Невалидное сообщение (или чужая версия контракта) не станет валидным при повторе
+		c.log.Error(logger.Entry{
+			Action:  "matching_status_parse_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	c.log.Debug(logger.Entry{
+		Action:  "matching_status_received",
+		Message: status.Status,
+		RideID:  status.RideID,
+		Additional: map[string]any{
+			"round":     status.Round,
+			"radius_km": status.RadiusKm,
+		},
+	})
+
+	err := c.handleMatchingStatusUC.Execute(ctx, in.HandleMatchingStatusInput{
+		RideID:     status.RideID,
+		Status:     status.Status,
+		Round:      status.Round,
+		RadiusKm:   status.RadiusKm,
+		OffersSent: status.OffersSent,
+		Reason:     status.Reason,
+	})
+	if err != nil {
+		return fmt.Errorf("execute use case: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package inamqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// NoShowConsumer — слушатель отмен поездок водителями за неявку пассажира
+type NoShowConsumer struct {
+	mqConn         *mq.RabbitMQ
+	handleNoShowUC in.HandlePassengerNoShowUseCase
+	log            *logger.Logger
+}
+
+// NewNoShowConsumer создает новый consumer
+func NewNoShowConsumer(
+	mqConn *mq.RabbitMQ,
+	handleNoShowUC in.HandlePassengerNoShowUseCase,
+	log *logger.Logger,
+) *NoShowConsumer {
+	return &NoShowConsumer{
+		mqConn:         mqConn,
+		handleNoShowUC: handleNoShowUC,
+		log:            log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *NoShowConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	queueName := "ride_service_no_show"
+	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(queueName, contract.RoutingPatternNoShow, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,
+		"ride-service-no-show", // consumer tag
+		false,                  // auto-ack
+		false,                  // exclusive
+		false,                  // no-local
+		false,                  // no-wait
+		nil,                    // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "no_show_consumer_started",
+		Message: fmt.Sprintf("listening on driver_topic (queue: %s, pattern: driver.no_show.*)", queueName),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "no_show_consumer_stopping",
+				Message: "context cancelled",
+			})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "no_show_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleNoShow(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "handle_no_show_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				// Временный сбой (БД) — вернем сообщение в очередь
+				_ = msg.Nack(false, true)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleNoShow обрабатывает одно сообщение
+func (c *NoShowConsumer) handleNoShow(ctx context.Context, msg amqp.Delivery) error {
+	var noShow contract.PassengerNoShow
+	if err := contract.Decode(msg.Body, &noShow); err != nil {
+		// Невалидное сообщение (или чужая версия контракта) не станет валидным при повторе
+		c.log.Error(logger.Entry{
+			Action:  "no_show_parse_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	c.log.Debug(logger.Entry{
+		Action:  "no_show_received",
+		Message: noShow.DriverID,
+		RideID:  noShow.RideID,
+		Additional: map[string]any{
+			"waited_minutes":   noShow.WaitedMinutes,
+			"cancellation_fee": noShow.CancellationFee,
+		},
+	})
+
+	err := c.handleNoShowUC.Execute(ctx, in.HandlePassengerNoShowInput{
+		RideID:          noShow.RideID,
+		DriverID:        noShow.DriverID,
+		WaitedMinutes:   noShow.WaitedMinutes,
+		CancellationFee: noShow.CancellationFee,
+		CancelledAt:     noShow.CancelledAt,
+	})
+	if err != nil {
+		return fmt.Errorf("execute use case: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package inamqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/adapter/in/in_ws"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// SessionsRevokedConsumer закрывает WebSocket-соединения пользователя, которого
+// администратор заблокировал, деактивировал, удалил или сменил ему роль.
+// Поездки не трогает: новые запросы отсекает JWTMiddleware по статусу в БД.
+type SessionsRevokedConsumer struct {
+	mqConn      *mq.RabbitMQ
+	passengerWS *in_ws.PassengerWSHandler
+	log         *logger.Logger
+}
+
+// NewSessionsRevokedConsumer создает новый consumer
+func NewSessionsRevokedConsumer(
+	mqConn *mq.RabbitMQ,
+	passengerWS *in_ws.PassengerWSHandler,
+	log *logger.Logger,
+) *SessionsRevokedConsumer {
+	return &SessionsRevokedConsumer{
+		mqConn:      mqConn,
+		passengerWS: passengerWS,
+		log:         log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *SessionsRevokedConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	// Своя очередь у каждой реплики: соединения пользователя могут висеть на любой из них,
+	// общая очередь отдала бы отзыв только одной
+	queue, err := ch.QueueDeclare(
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(queue.Name, contract.RoutingPatternSessionsRevoked, contract.ExchangeUser, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queue.Name,
+		"ride-service-sessions-revoked", // consumer tag
+		false,                           // auto-ack
+		true,                            // exclusive
+		false,                           // no-local
+		false,                           // no-wait
+		nil,                             // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "sessions_revoked_consumer_started",
+		Message: fmt.Sprintf("listening on user_topic (queue: %s, pattern: user.sessions_revoked.*)", queue.Name),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "sessions_revoked_consumer_stopping",
+				Message: "context cancelled",
+			})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "sessions_revoked_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			c.handleSessionsRevoked(msg)
+			_ = msg.Ack(false)
+		}
+	}
+}
+
+// handleSessionsRevoked закрывает соединения пользователя в hub пассажиров
+func (c *SessionsRevokedConsumer) handleSessionsRevoked(msg amqp.Delivery) {
+	var revoked contract.UserSessionsRevoked
+	if err := contract.Decode(msg.Body, &revoked); err != nil {
+		// Невалидное сообщение не станет валидным при повторе
+		c.log.Error(logger.Entry{
+			Action:  "sessions_revoked_parse_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return
+	}
+
+	closed := c.passengerWS.GetHub().DisconnectUser(revoked.UserID, revoked.Reason)
+
+	c.log.Info(logger.Entry{
+		Action:  "passenger_sessions_revoked",
+		Message: revoked.Reason,
+		Additional: map[string]any{
+			"user_id":     revoked.UserID,
+			"connections": closed,
+		},
+	})
+}
@@ -0,0 +1,140 @@
+package inamqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// StopArrivedConsumer — слушатель прибытия водителей на промежуточные остановки
+type StopArrivedConsumer struct {
+	mqConn              *mq.RabbitMQ
+	handleStopArrivedUC in.HandleStopArrivedUseCase
+	log                 *logger.Logger
+}
+
+// NewStopArrivedConsumer создает новый consumer
+func NewStopArrivedConsumer(
+	mqConn *mq.RabbitMQ,
+	handleStopArrivedUC in.HandleStopArrivedUseCase,
+	log *logger.Logger,
+) *StopArrivedConsumer {
+	return &StopArrivedConsumer{
+		mqConn:              mqConn,
+		handleStopArrivedUC: handleStopArrivedUC,
+		log:                 log,
+	}
+}
+
+// Start запускает прослушивание очереди (блокирующий, запускать в горутине)
+func (c *StopArrivedConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	queueName := "ride_service_stop_arrived"
+	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(queueName, contract.RoutingPatternStopArrived, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,
+		"ride-service-stop-arrived", // consumer tag
+		false,                       // auto-ack
+		false,                       // exclusive
+		false,                       // no-local
+		false,                       // no-wait
+		nil,                         // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "stop_arrived_consumer_started",
+		Message: fmt.Sprintf("listening on driver_topic (queue: %s, pattern: driver.stop_arrived.*)", queueName),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{
+				Action:  "stop_arrived_consumer_stopping",
+				Message: "context cancelled",
+			})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{
+					Action:  "stop_arrived_consumer_channel_closed",
+					Message: "message channel closed",
+				})
+				return fmt.Errorf("message channel closed")
+			}
+
+			if err := c.handleStopArrived(ctx, msg); err != nil {
+				c.log.Error(logger.Entry{
+					Action:  "handle_stop_arrived_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				// Временный сбой (БД) — вернем сообщение в очередь
+				_ = msg.Nack(false, true)
+			} else {
+				_ = msg.Ack(false)
+			}
+		}
+	}
+}
+
+// handleDriverArrived обрабатывает одно сообщение
+func (c *StopArrivedConsumer) handleStopArrived(ctx context.Context, msg amqp.Delivery) error {
+	var arrived contract.StopArrived
+	if err := contract.Decode(msg.Body, &arrived); err != nil {
+		// Невалидное сообщение (или чужая версия контракта) не станет валидным при повторе
+		c.log.Error(logger.Entry{
+			Action:  "stop_arrived_parse_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil
+	}
+
+	c.log.Debug(logger.Entry{
+		Action:  "stop_arrived_received",
+		Message: arrived.DriverID,
+		RideID:  arrived.RideID,
+		Additional: map[string]any{
+			"stop_number":             arrived.StopNumber,
+			"distance_to_stop_meters": arrived.DistanceToStopMeters,
+		},
+	})
+
+	err := c.handleStopArrivedUC.Execute(ctx, in.HandleStopArrivedInput{
+		RideID:     arrived.RideID,
+		DriverID:   arrived.DriverID,
+		StopNumber: arrived.StopNumber,
+		TotalStops: arrived.TotalStops,
+		Address:    arrived.Address,
+		Lat:        arrived.Location.Lat,
+		Lng:        arrived.Location.Lng,
+		ArrivedAt:  arrived.ArrivedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("execute use case: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package inamqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// trackingRideKeys — события поездки, после которых у водителя меняется активная поездка
+// или цель ETA (подача → назначение). Ключи выводятся из типов событий той же функцией,
+// что и у издателей, — привязка не разойдется с тем, что реально публикуется.
+var trackingRideKeys = []string{
+	contract.RideRoutingKey(constants.EventDriverMatched), // Ride Service: водитель назначен
+	contract.RideRoutingKey(constants.EventRideStarted),   // Driver Service (outbox): цель ETA — назначение
+	contract.RideRoutingKey(constants.EventRideCompleted), // Driver Service (outbox): поездка больше не активна
+	contract.RideRoutingKey(constants.EventRideCancelled), // Ride Service: отмена пассажиром, водителем или по сроку подбора
+}
+
+// TrackingInvalidationConsumer сбрасывает кэш "водитель → активная поездка"
+// live-трекинга на событиях ride.* и driver.status.*.
+//
+// Кэш живет в памяти каждой реплики, поэтому очередь у каждой реплики своя
+// (server-named, exclusive, auto-delete) — событие получают все реплики.
+type TrackingInvalidationConsumer struct {
+	mqConn   *mq.RabbitMQ
+	tracking in.TrackDriverLocationUseCase
+	log      *logger.Logger
+}
+
+// NewTrackingInvalidationConsumer создает новый consumer
+func NewTrackingInvalidationConsumer(
+	mqConn *mq.RabbitMQ,
+	tracking in.TrackDriverLocationUseCase,
+	log *logger.Logger,
+) *TrackingInvalidationConsumer {
+	return &TrackingInvalidationConsumer{
+		mqConn:   mqConn,
+		tracking: tracking,
+		log:      log,
+	}
+}
+
+// Start запускает прослушивание (блокирующий, запускать в горутине)
+func (c *TrackingInvalidationConsumer) Start(ctx context.Context) error {
+	ch := c.mqConn.Channel()
+	if ch == nil {
+		return fmt.Errorf("failed to get RabbitMQ channel")
+	}
+
+	queue, err := ch.QueueDeclare(
+		"",    // name: RabbitMQ сгенерирует уникальное имя
+		false, // durable
+		true,  // auto-delete
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	for _, key := range trackingRideKeys {
+		if err := ch.QueueBind(queue.Name, key, contract.ExchangeRide, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue to %s: %w", key, err)
+		}
+	}
+	if err := ch.QueueBind(queue.Name, contract.RoutingPatternDriverStatus, contract.ExchangeDriver, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue to %s: %w", contract.RoutingPatternDriverStatus, err)
+	}
+
+	// auto-ack: потерянная инвалидация не страшна — кэш ограничен TTL
+	msgs, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	c.log.Info(logger.Entry{
+		Action:  "tracking_invalidation_consumer_started",
+		Message: fmt.Sprintf("listening on ride_topic + driver_topic (queue: %s)", queue.Name),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info(logger.Entry{Action: "tracking_invalidation_consumer_stopping", Message: "context cancelled"})
+			return ctx.Err()
+
+		case msg, ok := <-msgs:
+			if !ok {
+				c.log.Warn(logger.Entry{Action: "tracking_invalidation_consumer_channel_closed", Message: "message channel closed"})
+				return fmt.Errorf("message channel closed")
+			}
+			c.handle(msg)
+		}
+	}
+}
+
+// handle извлекает driver_id из события и сбрасывает его кэш
+func (c *TrackingInvalidationConsumer) handle(msg amqp.Delivery) {
+	var driverID string
+
+	switch msg.Exchange {
+	case contract.ExchangeDriver:
+		var event contract.DriverStatusChanged
+		if err := contract.Decode(msg.Body, &event); err != nil {
+			c.logRejected(msg, err)
+			return
+		}
+		driverID = event.DriverID
+
+	default:
+		var event contract.RideStatusChanged
+		if err := contract.Decode(msg.Body, &event); err != nil {
+			c.logRejected(msg, err)
+			return
+		}
+		if event.DriverID != nil {
+			driverID = *event.DriverID
+		}
+	}
+
+	// Поездка без водителя (например, отмена до назначения) кэш не затрагивает
+	if driverID == "" {
+		return
+	}
+
+	c.tracking.InvalidateDriver(driverID)
+}
+
+func (c *TrackingInvalidationConsumer) logRejected(msg amqp.Delivery, err error) {
+	c.log.Warn(logger.Entry{
+		Action:  "tracking_invalidation_rejected",
+		Message: err.Error(),
+		Additional: map[string]any{
+			"exchange":    msg.Exchange,
+			"routing_key": msg.RoutingKey,
+		},
+	})
+}
@@ -1,24 +1,32 @@
 package in_ws
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"ridehail/internal/ride/application/ports/in"
 	"ridehail/internal/shared/auth"
+	constants "ridehail/internal/shared/const"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/user"
 	"ridehail/internal/shared/ws"
+
+	"github.com/google/uuid"
 )
 
 // PassengerWSHandler обрабатывает WebSocket соединения для пассажиров
 type PassengerWSHandler struct {
-	hub    *ws.Hub
-	jwtSvc *auth.JWTService
-	log    *logger.Logger
+	hub          *ws.Hub
+	jwtSvc       *auth.JWTService
+	cancelRideUC in.CancelRideUseCase
+	log          *logger.Logger
 }
 
 // NewPassengerWSHandler создает новый handler для пассажиров
-func NewPassengerWSHandler(jwtSvc *auth.JWTService, log *logger.Logger) *PassengerWSHandler {
+func NewPassengerWSHandler(jwtSvc *auth.JWTService, userRepo user.Repository, log *logger.Logger) *PassengerWSHandler {
 	// Создаем auth функцию для валидации токенов
 	authFunc := func(token string) (userID, role string, err error) {
 		claims, err := jwtSvc.ValidateToken(token)
@@ -26,12 +34,21 @@ func NewPassengerWSHandler(jwtSvc *auth.JWTService, log *logger.Logger) *Passeng
 			return "", "", err
 		}
 
+		// Роль и статус — из БД, как в JWTMiddleware
+		u, err := userRepo.FindByID(context.Background(), claims.UserID)
+		if err != nil {
+			return "", "", err
+		}
+		if !u.IsActive() {
+			return "", "", fmt.Errorf("user is %s", strings.ToLower(u.Status))
+		}
+
 		// Проверяем, что пользователь - PASSENGER или ADMIN
-		if claims.Role != "PASSENGER" && claims.Role != "ADMIN" {
-			return "", "", fmt.Errorf("invalid role: %s (expected PASSENGER or ADMIN)", claims.Role)
+		if u.Role != "PASSENGER" && u.Role != "ADMIN" {
+			return "", "", fmt.Errorf("invalid role: %s (expected PASSENGER or ADMIN)", u.Role)
 		}
 
-		return claims.UserID, claims.Role, nil
+		return u.ID, u.Role, nil
 	}
 
 	hub := ws.NewHub(authFunc, log)
@@ -53,6 +70,19 @@ func (h *PassengerWSHandler) GetHub() *ws.Hub {
 	return h.hub
 }
 
+// SetCancelRideUseCase подключает use case отмены поездки.
+// Hub создается раньше use cases (им нужен notifier), поэтому зависимость
+// передается после конструирования.
+func (h *PassengerWSHandler) SetCancelRideUseCase(uc in.CancelRideUseCase) {
+	h.cancelRideUC = uc
+}
+
+// CancelRideMessage структура запроса отмены поездки от пассажира
+type CancelRideMessage struct {
+	RideID string `json:"ride_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // ServeWS обрабатывает WebSocket соединение для пассажира
 func (h *PassengerWSHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
 	h.hub.ServeWS(w, r)
@@ -76,6 +106,9 @@ func (h *PassengerWSHandler) handleMessage(client *ws.Client, msgType string, da
 			"status": "ok",
 		})
 
+	case "cancel_ride":
+		return h.handleCancelRide(client, data)
+
 	default:
 		h.log.Warn(logger.Entry{
 			Action:  "passenger_ws_unknown_message_type",
@@ -89,6 +122,50 @@ func (h *PassengerWSHandler) handleMessage(client *ws.Client, msgType string, da
 	return nil
 }
 
+// handleCancelRide обрабатывает запрос отмены поездки через WebSocket.
+// Результат (или ошибка) отправляется обратно пассажиру сообщением ride_cancel_result.
+func (h *PassengerWSHandler) handleCancelRide(client *ws.Client, data json.RawMessage) error {
+	if h.cancelRideUC == nil {
+		return fmt.Errorf("cancel ride use case is not configured")
+	}
+
+	var msg CancelRideMessage
+	if err := json.Unmarshal(data, &msg); err != nil || uuid.Validate(msg.RideID) != nil {
+		return h.hub.SendTypedMessage(client.UserID, "ride_cancel_result", map[string]interface{}{
+			"success": false,
+			"error":   "invalid cancel_ride format",
+		})
+	}
+
+	output, err := h.cancelRideUC.Execute(context.Background(), in.CancelRideInput{
+		RideID:        msg.RideID,
+		RequesterID:   client.UserID,
+		RequesterRole: constants.RolePassenger,
+		Reason:        msg.Reason,
+	})
+	if err != nil {
+		h.log.Warn(logger.Entry{
+			Action:  "passenger_ws_cancel_ride_failed",
+			Message: err.Error(),
+			RideID:  msg.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return h.hub.SendTypedMessage(client.UserID, "ride_cancel_result", map[string]interface{}{
+			"success": false,
+			"ride_id": msg.RideID,
+			"error":   err.Error(),
+		})
+	}
+
+	return h.hub.SendTypedMessage(client.UserID, "ride_cancel_result", map[string]interface{}{
+		"success":      true,
+		"ride_id":      output.RideID,
+		"status":       output.Status,
+		"cancelled_at": output.CancelledAt,
+		"message":      output.Message,
+	})
+}
+
 // SendRideStatusUpdate отправляет обновление статуса поездки пассажиру
 func (h *PassengerWSHandler) SendRideStatusUpdate(passengerID, rideID, status, message string, additionalData map[string]interface{}) error {
 	data := map[string]interface{}{
@@ -105,12 +182,16 @@ func (h *PassengerWSHandler) SendRideStatusUpdate(passengerID, rideID, status, m
 	return h.hub.SendTypedMessage(passengerID, "ride_status_update", data)
 }
 
-// SendDriverLocationUpdate отправляет обновление локации водителя пассажиру
-func (h *PassengerWSHandler) SendDriverLocationUpdate(passengerID, rideID string, location map[string]interface{}) error {
+// SendDriverLocationUpdate отправляет обновление локации водителя пассажиру.
+// eta — поля верхнего уровня (estimated_arrival, distance_to_pickup_km, ...), может быть nil.
+func (h *PassengerWSHandler) SendDriverLocationUpdate(passengerID, rideID string, location, eta map[string]interface{}) error {
 	data := map[string]interface{}{
 		"ride_id":         rideID,
 		"driver_location": location,
 	}
+	for k, v := range eta {
+		data[k] = v
+	}
 
 	return h.hub.SendTypedMessage(passengerID, "driver_location_update", data)
 }
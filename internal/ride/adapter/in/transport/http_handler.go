@@ -5,10 +5,14 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"ridehail/internal/ride/application/ports/in"
 	"ridehail/internal/ride/domain"
 	"ridehail/internal/shared/logger"
+
+	"github.com/google/uuid"
 )
 
 const maxBodySize = 1 << 20 // 1MB
@@ -16,24 +20,64 @@ const maxBodySize = 1 << 20 // 1MB
 // HTTPHandler обрабатывает HTTP запросы для Ride Service
 type HTTPHandler struct {
 	requestRideUC in.RequestRideUseCase
+	cancelRideUC  in.CancelRideUseCase
+	rideEventsUC  in.GetRideEventsUseCase
+	getRideUC     in.GetRideUseCase
+	listRidesUC   in.ListRidesUseCase
+	quoteFareUC   in.QuoteFareUseCase
+	rateRideUC    in.RateRideUseCase
 	log           *logger.Logger
 }
 
 // NewHTTPHandler создает новый HTTP handler
-func NewHTTPHandler(requestRideUC in.RequestRideUseCase, log *logger.Logger) *HTTPHandler {
+func NewHTTPHandler(
+	requestRideUC in.RequestRideUseCase,
+	cancelRideUC in.CancelRideUseCase,
+	rideEventsUC in.GetRideEventsUseCase,
+	getRideUC in.GetRideUseCase,
+	listRidesUC in.ListRidesUseCase,
+	quoteFareUC in.QuoteFareUseCase,
+	rateRideUC in.RateRideUseCase,
+	log *logger.Logger,
+) *HTTPHandler {
 	return &HTTPHandler{
 		requestRideUC: requestRideUC,
+		cancelRideUC:  cancelRideUC,
+		rideEventsUC:  rideEventsUC,
+		getRideUC:     getRideUC,
+		listRidesUC:   listRidesUC,
+		quoteFareUC:   quoteFareUC,
+		rateRideUC:    rateRideUC,
 		log:           log,
 	}
 }
 
-// RegisterRoutes регистрирует все HTTP маршруты
-func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware Middleware) {
+// RegisterRoutes регистрирует все HTTP маршруты.
+// participantMiddleware пропускает и водителей: отменить поездку, оценить ее
+// и прочитать карточку назначенной поездки могут обе стороны.
+func (h *HTTPHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware, participantMiddleware Middleware) {
 	// liveness
 	mux.HandleFunc("GET /health", h.handleHealth)
 
 	// ride request
+	mux.Handle("POST /rides/quote", authMiddleware(http.HandlerFunc(h.handleQuoteFare)))
 	mux.Handle("POST /rides", authMiddleware(http.HandlerFunc(h.handleRequestRide)))
+
+	// ride read API
+	mux.Handle("GET /rides", authMiddleware(http.HandlerFunc(h.handleListRides)))
+	mux.Handle("GET /rides/{ride_id}", participantMiddleware(http.HandlerFunc(h.handleGetRide)))
+
+	// ride cancellation
+	mux.Handle("POST /rides/{ride_id}/cancel", participantMiddleware(http.HandlerFunc(h.handleCancelRide)))
+
+	// ratings after completion (passenger → driver, driver → passenger)
+	mux.Handle("POST /rides/{ride_id}/rating", participantMiddleware(http.HandlerFunc(h.handleRateRide)))
+
+	// ride timeline (event store) + ride by number:
+	// GET /rides/{ride_id}/events и GET /rides/by-number/{ride_number} для ServeMux
+	// конфликтуют (оба совпадают с /rides/by-number/events), поэтому один шаблон + диспетчер.
+	// Водителя пропускаем: доступ к конкретной поездке проверяют use cases
+	mux.Handle("GET /rides/{ride_id}/{sub}", participantMiddleware(http.HandlerFunc(h.handleRideSubresource)))
 }
 
 // handleHealth обрабатывает health check
@@ -53,6 +97,23 @@ type RequestRideHTTPRequest struct {
 	DestLng       float64 `json:"destination_lng"`
 	DestAddress   string  `json:"destination_address"`
 	Priority      int     `json:"priority,omitempty"`
+	QuoteID       string  `json:"quote_id,omitempty"`
+
+	// QuoteSignature — подпись квоты из ответа POST /rides/quote, обязательна с quote_id
+	QuoteSignature string `json:"quote_signature,omitempty"`
+
+	// ScheduledFor — RFC 3339; если задано, поездка создается в статусе SCHEDULED
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+
+	// Stops — промежуточные остановки по порядку ("заехать за коллегой")
+	Stops []RideStopHTTPRequest `json:"stops,omitempty"`
+}
+
+// RideStopHTTPRequest — промежуточная остановка в POST /rides
+type RideStopHTTPRequest struct {
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+	Address string  `json:"address"`
 }
 
 // handleRequestRide обрабатывает POST /rides
@@ -101,6 +162,16 @@ func (h *HTTPHandler) handleRequestRide(w http.ResponseWriter, r *http.Request)
 		h.respondError(w, http.StatusBadRequest, "destination_address is required")
 		return
 	}
+	if req.QuoteID != "" {
+		if _, err := uuid.Parse(req.QuoteID); err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid quote_id")
+			return
+		}
+		if req.QuoteSignature == "" {
+			h.respondError(w, http.StatusBadRequest, "quote_signature is required with quote_id")
+			return
+		}
+	}
 
 	// Маппинг HTTP DTO → Use Case Input
 	input := in.RequestRideInput{
@@ -113,6 +184,17 @@ func (h *HTTPHandler) handleRequestRide(w http.ResponseWriter, r *http.Request)
 		DestLng:       req.DestLng,
 		DestAddress:   req.DestAddress,
 		Priority:      req.Priority,
+		QuoteID:       req.QuoteID,
+		ScheduledFor:  req.ScheduledFor,
+
+		QuoteSignature: req.QuoteSignature,
+	}
+	for _, stop := range req.Stops {
+		input.Stops = append(input.Stops, in.StopInput{
+			Lat:     stop.Lat,
+			Lng:     stop.Lng,
+			Address: stop.Address,
+		})
 	}
 
 	output, err := h.requestRideUC.Execute(ctx, input)
@@ -124,6 +206,325 @@ func (h *HTTPHandler) handleRequestRide(w http.ResponseWriter, r *http.Request)
 	h.respondJSON(w, http.StatusCreated, output)
 }
 
+// QuoteFareHTTPRequest — HTTP DTO для расчета цены до заказа
+type QuoteFareHTTPRequest struct {
+	PickupLat     float64 `json:"pickup_lat"`
+	PickupLng     float64 `json:"pickup_lng"`
+	PickupAddress string  `json:"pickup_address"`
+	DestLat       float64 `json:"destination_lat"`
+	DestLng       float64 `json:"destination_lng"`
+	DestAddress   string  `json:"destination_address"`
+}
+
+// handleQuoteFare обрабатывает POST /rides/quote
+func (h *HTTPHandler) handleQuoteFare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(ContextKeyUserID).(string)
+	if !ok || userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	var req QuoteFareHTTPRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			h.respondError(w, http.StatusBadRequest, "empty request body")
+			return
+		}
+		h.log.Error(logger.Entry{
+			Action:  "parse_request_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		h.respondError(w, http.StatusBadRequest, "invalid request format")
+		return
+	}
+
+	if req.PickupAddress == "" {
+		h.respondError(w, http.StatusBadRequest, "pickup_address is required")
+		return
+	}
+	if req.DestAddress == "" {
+		h.respondError(w, http.StatusBadRequest, "destination_address is required")
+		return
+	}
+
+	output, err := h.quoteFareUC.Execute(ctx, in.QuoteFareInput{
+		PassengerID:   userID,
+		PickupLat:     req.PickupLat,
+		PickupLng:     req.PickupLng,
+		PickupAddress: req.PickupAddress,
+		DestLat:       req.DestLat,
+		DestLng:       req.DestLng,
+		DestAddress:   req.DestAddress,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, output)
+}
+
+// CancelRideHTTPRequest — HTTP DTO для отмены поездки
+type CancelRideHTTPRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleCancelRide обрабатывает POST /rides/{ride_id}/cancel.
+// Отменяет пассажир поездки или назначенный на нее водитель (по роли из JWT).
+func (h *HTTPHandler) handleCancelRide(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(ContextKeyUserID).(string)
+	if !ok || userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := ctx.Value(ContextKeyUserRole).(string)
+
+	rideID := r.PathValue("ride_id")
+	if _, err := uuid.Parse(rideID); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid ride_id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	// Тело запроса опционально: причина отмены может отсутствовать
+	var req CancelRideHTTPRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.log.Error(logger.Entry{
+			Action:  "parse_request_failed",
+			Message: err.Error(),
+			RideID:  rideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		h.respondError(w, http.StatusBadRequest, "invalid request format")
+		return
+	}
+
+	output, err := h.cancelRideUC.Execute(ctx, in.CancelRideInput{
+		RideID:        rideID,
+		RequesterID:   userID,
+		RequesterRole: role,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// RateRideHTTPRequest — HTTP DTO для оценки поездки
+type RateRideHTTPRequest struct {
+	Score   int      `json:"score"`
+	Tags    []string `json:"tags,omitempty"`
+	Comment *string  `json:"comment,omitempty"`
+}
+
+// handleRateRide обрабатывает POST /rides/{ride_id}/rating
+func (h *HTTPHandler) handleRateRide(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(ContextKeyUserID).(string)
+	if !ok || userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := ctx.Value(ContextKeyUserRole).(string)
+
+	rideID := r.PathValue("ride_id")
+	if _, err := uuid.Parse(rideID); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid ride_id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	var req RateRideHTTPRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			h.respondError(w, http.StatusBadRequest, "empty request body")
+			return
+		}
+		h.log.Error(logger.Entry{
+			Action:  "parse_request_failed",
+			Message: err.Error(),
+			RideID:  rideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		h.respondError(w, http.StatusBadRequest, "invalid request format")
+		return
+	}
+
+	output, err := h.rateRideUC.Execute(ctx, in.RateRideInput{
+		RideID:    rideID,
+		RaterID:   userID,
+		RaterRole: role,
+		Score:     req.Score,
+		Tags:      req.Tags,
+		Comment:   req.Comment,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, output)
+}
+
+// handleGetRideEvents обрабатывает GET /rides/{ride_id}/events
+func (h *HTTPHandler) handleGetRideEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(ContextKeyUserID).(string)
+	if !ok || userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := ctx.Value(ContextKeyUserRole).(string)
+
+	rideID := r.PathValue("ride_id")
+	if _, err := uuid.Parse(rideID); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid ride_id")
+		return
+	}
+
+	output, err := h.rideEventsUC.Execute(ctx, in.GetRideEventsInput{
+		RideID:        rideID,
+		RequesterID:   userID,
+		RequesterRole: role,
+	})
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleGetRide обрабатывает GET /rides/{ride_id}
+func (h *HTTPHandler) handleGetRide(w http.ResponseWriter, r *http.Request) {
+	rideID := r.PathValue("ride_id")
+	if _, err := uuid.Parse(rideID); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid ride_id")
+		return
+	}
+
+	h.getRide(w, r, in.GetRideInput{RideID: rideID})
+}
+
+// handleRideSubresource маршрутизирует GET /rides/by-number/{ride_number}
+// и GET /rides/{ride_id}/events
+func (h *HTTPHandler) handleRideSubresource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("ride_id") == "by-number":
+		h.getRide(w, r, in.GetRideInput{RideNumber: r.PathValue("sub")})
+	case r.PathValue("sub") == "events":
+		h.handleGetRideEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// getRide — общая часть чтения поездки по ID и по номеру
+func (h *HTTPHandler) getRide(w http.ResponseWriter, r *http.Request, input in.GetRideInput) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(ContextKeyUserID).(string)
+	if !ok || userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := ctx.Value(ContextKeyUserRole).(string)
+
+	input.RequesterID = userID
+	input.RequesterRole = role
+
+	output, err := h.getRideUC.Execute(ctx, input)
+	if err != nil {
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
+// handleListRides обрабатывает GET /rides?status=&from=&to=&cursor=&limit=
+func (h *HTTPHandler) handleListRides(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := ctx.Value(ContextKeyUserID).(string)
+	if !ok || userID == "" {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+
+	input := in.ListRidesInput{
+		PassengerID: userID,
+		Status:      query.Get("status"),
+		Cursor:      query.Get("cursor"),
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			h.respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		input.Limit = limit
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"from", &input.From},
+		{"to", &input.To},
+	} {
+		value := query.Get(p.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid "+p.name+" (expected RFC3339)")
+			return
+		}
+		*p.dst = &t
+	}
+
+	output, err := h.listRidesUC.Execute(ctx, input)
+	if err != nil {
+		// Неизвестный статус в фильтре — ошибка запроса, а не конфликт состояния
+		if errors.Is(err, domain.ErrInvalidStatus) {
+			h.respondError(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		h.handleUseCaseError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, output)
+}
+
 // handleUseCaseError обрабатывает ошибки use case
 func (h *HTTPHandler) handleUseCaseError(w http.ResponseWriter, err error) {
 	switch {
@@ -131,8 +532,36 @@ func (h *HTTPHandler) handleUseCaseError(w http.ResponseWriter, err error) {
 		h.respondError(w, http.StatusBadRequest, err.Error())
 	case errors.Is(err, domain.ErrInvalidVehicleType):
 		h.respondError(w, http.StatusBadRequest, "invalid vehicle type")
+	case errors.Is(err, domain.ErrInvalidSchedule),
+		errors.Is(err, domain.ErrInvalidStops):
+		h.respondError(w, http.StatusBadRequest, err.Error())
 	case errors.Is(err, domain.ErrUnauthorized):
 		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+	case errors.Is(err, domain.ErrForbidden):
+		h.respondError(w, http.StatusForbidden, "forbidden")
+	case errors.Is(err, domain.ErrInvalidCursor):
+		h.respondError(w, http.StatusBadRequest, "invalid cursor")
+	case errors.Is(err, domain.ErrQuoteNotFound):
+		h.respondError(w, http.StatusNotFound, "fare quote not found")
+	case errors.Is(err, domain.ErrQuoteMismatch):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrQuoteExpired),
+		errors.Is(err, domain.ErrQuoteAlreadyUsed):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrRideNotFound):
+		h.respondError(w, http.StatusNotFound, "ride not found")
+	case errors.Is(err, domain.ErrInvalidRating):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrRideNotCompleted),
+		errors.Is(err, domain.ErrRatingWindowClosed),
+		errors.Is(err, domain.ErrAlreadyRated):
+		h.respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrRideAlreadyCancelled),
+		errors.Is(err, domain.ErrRideAlreadyCompleted),
+		errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrStatusConflict),
+		errors.Is(err, domain.ErrInvalidStatus):
+		h.respondError(w, http.StatusConflict, err.Error())
 	default:
 		h.log.Error(logger.Entry{
 			Action:  "usecase_error",
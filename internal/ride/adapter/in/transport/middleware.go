@@ -24,8 +24,16 @@ const (
 // Принимает http.Handler и возвращает обёрнутый http.Handler
 type Middleware func(http.Handler) http.Handler
 
-// JWTMiddleware создает middleware для валидации JWT токенов + проверки пользователя в БД
-func JWTMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *logger.Logger) Middleware {
+// defaultRoles — роли, которым по умолчанию открыт ride-сервис
+var defaultRoles = []string{"PASSENGER", "ADMIN"}
+
+// JWTMiddleware создает middleware для валидации JWT токенов + проверки пользователя в БД.
+// roles — допустимые роли пользователя; без них — PASSENGER и ADMIN.
+func JWTMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *logger.Logger, roles ...string) Middleware {
+	if len(roles) == 0 {
+		roles = defaultRoles
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -113,8 +121,8 @@ func JWTMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *l
 				return
 			}
 
-			// Проверяем роль (для ride-сервиса разрешены PASSENGER и ADMIN)
-			if !userEntity.HasRole("PASSENGER") && !userEntity.HasRole("ADMIN") {
+			// Проверяем роль (по умолчанию для ride-сервиса разрешены PASSENGER и ADMIN)
+			if !hasAnyRole(userEntity, roles) {
 				log.Warn(logger.Entry{
 					Action:  "user_invalid_role",
 					Message: "user does not have required role",
@@ -132,15 +140,16 @@ func JWTMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *l
 				Message: "user authenticated successfully",
 				Additional: map[string]interface{}{
 					"user_id": claims.UserID,
-					"role":    claims.Role,
+					"role":    userEntity.Role,
 					"status":  userEntity.Status,
 				},
 			})
 
-			// Добавляем данные пользователя в контекст
+			// Добавляем данные пользователя в контекст. Роль — из БД, а не из токена:
+			// пониженный администратор не должен сохранять доступ до истечения токена
 			ctx = context.WithValue(ctx, ContextKeyUserID, claims.UserID)
 			ctx = context.WithValue(ctx, ContextKeyUserEmail, claims.Email)
-			ctx = context.WithValue(ctx, ContextKeyUserRole, claims.Role)
+			ctx = context.WithValue(ctx, ContextKeyUserRole, userEntity.Role)
 
 			// Передаем управление следующему обработчику
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -148,6 +157,16 @@ func JWTMiddleware(jwtService *auth.JWTService, userRepo user.Repository, log *l
 	}
 }
 
+// hasAnyRole возвращает true, если у пользователя есть одна из ролей
+func hasAnyRole(u *user.User, roles []string) bool {
+	for _, role := range roles {
+		if u.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
 // respondUnauthorized отправляет 401 ответ
 func respondUnauthorized(w http.ResponseWriter, message string) {
 	w.Header().Set("Content-Type", "application/json")
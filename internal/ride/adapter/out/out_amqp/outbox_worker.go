@@ -1 +1,153 @@
 package out_amqp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/mq"
+)
+
+// Параметры relay по умолчанию
+const (
+	outboxPollInterval = 1 * time.Second
+	outboxBatchSize    = 50
+	outboxMaxRetries   = 10
+	outboxBaseBackoff  = 2 * time.Second
+	outboxMaxBackoff   = 5 * time.Minute
+)
+
+// OutboxWorker — relay, доставляющий сообщения из outbox в RabbitMQ.
+//
+// ЦИКЛ:
+// 1. В транзакции блокирует пачку PENDING/FAILED строк (FOR UPDATE SKIP LOCKED)
+// 2. Публикует каждую в ride_topic с publisher confirms
+// 3. Помечает SENT, либо FAILED с экспоненциальной задержкой по retry_count
+// 4. Коммитит транзакцию, снимая блокировки
+//
+// Гарантия доставки — at-least-once: если коммит не удался после публикации,
+// сообщение будет отправлено повторно. Потребители должны быть идемпотентны.
+type OutboxWorker struct {
+	txManager out.TxManager
+	outbox    out.OutboxRepository
+	mq        *mq.RabbitMQ
+	log       *logger.Logger
+}
+
+// NewOutboxWorker создает новый relay
+func NewOutboxWorker(
+	txManager out.TxManager,
+	outbox out.OutboxRepository,
+	mqConn *mq.RabbitMQ,
+	log *logger.Logger,
+) *OutboxWorker {
+	return &OutboxWorker{
+		txManager: txManager,
+		outbox:    outbox,
+		mq:        mqConn,
+		log:       log,
+	}
+}
+
+// Run запускает цикл relay до отмены контекста (блокирующий)
+func (w *OutboxWorker) Run(ctx context.Context) {
+	w.log.Info(logger.Entry{
+		Action:  "outbox_worker_started",
+		Message: fmt.Sprintf("poll interval %s, batch size %d", outboxPollInterval, outboxBatchSize),
+	})
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info(logger.Entry{Action: "outbox_worker_stopped", Message: "context cancelled"})
+			return
+		case <-ticker.C:
+			// Пока пачки полные — разгребаем без ожидания тика
+			for {
+				processed, err := w.relayBatch(ctx)
+				if err != nil {
+					w.log.Error(logger.Entry{
+						Action:  "outbox_relay_failed",
+						Message: err.Error(),
+						Error:   &logger.ErrObj{Msg: err.Error()},
+					})
+					break
+				}
+				if processed < outboxBatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// relayBatch обрабатывает одну пачку сообщений и возвращает их количество
+func (w *OutboxWorker) relayBatch(ctx context.Context) (int, error) {
+	processed := 0
+
+	err := w.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		messages, err := w.outbox.ClaimBatch(txCtx, outboxBatchSize, outboxMaxRetries)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			if pubErr := w.mq.PublishConfirmed(txCtx, contract.ExchangeRide, msg.RoutingKey, msg.Payload); pubErr != nil {
+				nextAttempt := time.Now().Add(backoff(msg.RetryCount))
+
+				w.log.Warn(logger.Entry{
+					Action:  "outbox_publish_failed",
+					Message: pubErr.Error(),
+					Error:   &logger.ErrObj{Msg: pubErr.Error()},
+					Additional: map[string]any{
+						"outbox_id":    msg.ID,
+						"event_type":   msg.EventType,
+						"routing_key":  msg.RoutingKey,
+						"retry_count":  msg.RetryCount + 1,
+						"next_attempt": nextAttempt.Format(time.RFC3339),
+					},
+				})
+
+				if err := w.outbox.MarkFailed(txCtx, msg.ID, nextAttempt, pubErr.Error()); err != nil {
+					return err
+				}
+				processed++
+				continue
+			}
+
+			if err := w.outbox.MarkSent(txCtx, msg.ID); err != nil {
+				return err
+			}
+			processed++
+
+			w.log.Debug(logger.Entry{
+				Action:  "outbox_message_sent",
+				Message: msg.EventType,
+				Additional: map[string]any{
+					"outbox_id":   msg.ID,
+					"routing_key": msg.RoutingKey,
+				},
+			})
+		}
+
+		return nil
+	})
+
+	return processed, err
+}
+
+// backoff возвращает задержку перед следующей попыткой: base * 2^retry, но не больше max
+func backoff(retryCount int) time.Duration {
+	d := time.Duration(float64(outboxBaseBackoff) * math.Pow(2, float64(retryCount)))
+	if d <= 0 || d > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return d
+}
@@ -1 +1,87 @@
 package out_amqp
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/out"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
+	"ridehail/internal/shared/logger"
+)
+
+// OutboxRidePublisher реализует out.EventPublisher через transactional outbox.
+//
+// Вместо прямой публикации в RabbitMQ событие сохраняется в таблицу outbox
+// в той же транзакции, что и изменение поездки. Доставку в ride_topic
+// выполняет OutboxWorker.
+type OutboxRidePublisher struct {
+	outbox out.OutboxRepository
+	log    *logger.Logger
+}
+
+// NewOutboxRidePublisher создает новый publisher
+func NewOutboxRidePublisher(outbox out.OutboxRepository, log *logger.Logger) *OutboxRidePublisher {
+	return &OutboxRidePublisher{
+		outbox: outbox,
+		log:    log,
+	}
+}
+
+// PublishRideEvent сохраняет событие поездки в outbox
+func (p *OutboxRidePublisher) PublishRideEvent(ctx context.Context, eventType string, data out.RideEventData) error {
+	payload, err := contract.Encode(statusChangedMessage(eventType, data))
+	if err != nil {
+		return fmt.Errorf("marshal event data: %w", err)
+	}
+
+	routingKey := contract.RideRoutingKey(eventType)
+
+	if err := p.outbox.Enqueue(ctx, eventType, routingKey, payload); err != nil {
+		return fmt.Errorf("enqueue ride event: %w", err)
+	}
+
+	p.log.Debug(logger.Entry{
+		Action:  "ride_event_enqueued",
+		Message: eventType,
+		RideID:  data.RideID,
+		Additional: map[string]any{
+			"routing_key": routingKey,
+		},
+	})
+
+	return nil
+}
+
+// PublishRideRequested сохраняет запрос на подбор водителя в outbox
+func (p *OutboxRidePublisher) PublishRideRequested(ctx context.Context, msg *contract.RideRequested) error {
+	payload, err := contract.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("marshal ride requested: %w", err)
+	}
+
+	if err := p.outbox.Enqueue(ctx, constants.EventRideRequested, contract.RoutingKeyRideRequested, payload); err != nil {
+		return fmt.Errorf("enqueue ride requested: %w", err)
+	}
+
+	p.log.Debug(logger.Entry{
+		Action:  "ride_requested_enqueued",
+		Message: msg.RideNumber,
+		RideID:  msg.RideID,
+	})
+
+	return nil
+}
+
+// statusChangedMessage переводит данные события поездки в сообщение контракта
+func statusChangedMessage(eventType string, data out.RideEventData) *contract.RideStatusChanged {
+	return &contract.RideStatusChanged{
+		EventType:      eventType,
+		RideID:         data.RideID,
+		PassengerID:    data.PassengerID,
+		DriverID:       data.DriverID,
+		Status:         data.Status,
+		VehicleType:    data.VehicleType,
+		AdditionalData: data.AdditionalData,
+	}
+}
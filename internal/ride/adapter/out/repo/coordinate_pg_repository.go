@@ -38,7 +38,7 @@ func (r *CoordinatePgRepository) Create(ctx context.Context, coord *domain.Coord
 		)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		coord.ID,
 		coord.EntityID,
 		coord.EntityType,
@@ -76,7 +76,7 @@ func (r *CoordinatePgRepository) FindByID(ctx context.Context, coordID string) (
 	`
 
 	coord := &domain.Coordinate{}
-	err := r.pool.QueryRow(ctx, query, coordID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, coordID).Scan(
 		&coord.ID,
 		&coord.EntityID,
 		&coord.EntityType,
@@ -114,7 +114,7 @@ func (r *CoordinatePgRepository) FindCurrentByEntity(ctx context.Context, entity
 	`
 
 	coord := &domain.Coordinate{}
-	err := r.pool.QueryRow(ctx, query, entityID, entityType).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, entityID, entityType).Scan(
 		&coord.ID,
 		&coord.EntityID,
 		&coord.EntityType,
@@ -146,7 +146,7 @@ func (r *CoordinatePgRepository) MarkAsNotCurrent(ctx context.Context, entityID,
 		WHERE entity_id = $1 AND entity_type = $2 AND is_current = true
 	`
 
-	_, err := r.pool.Exec(ctx, query, entityID, entityType)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, entityID, entityType)
 	if err != nil {
 		r.log.Error(logger.Entry{
 			Action:  "db_mark_coordinates_not_current_failed",
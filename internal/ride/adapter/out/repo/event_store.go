@@ -1 +1,97 @@
 package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RideEventPgStore — PostgreSQL реализация журнала событий (таблица ride_events)
+type RideEventPgStore struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewRideEventPgStore создает новый экземпляр журнала событий
+func NewRideEventPgStore(pool *pgxpool.Pool, log *logger.Logger) *RideEventPgStore {
+	return &RideEventPgStore{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Append добавляет событие в журнал. Журнал только дополняется — строки не изменяются.
+func (s *RideEventPgStore) Append(ctx context.Context, event *domain.RideEvent) error {
+	data, err := json.Marshal(event.EventData)
+	if err != nil {
+		return fmt.Errorf("marshal event data: %w", err)
+	}
+
+	query := `
+		INSERT INTO ride_events (ride_id, event_type, event_data, created_at)
+		VALUES ($1, $2, $3, COALESCE($4, now()))
+		RETURNING id, created_at
+	`
+
+	var createdAt any
+	if !event.CreatedAt.IsZero() {
+		createdAt = event.CreatedAt
+	}
+
+	err = conn(ctx, s.pool).QueryRow(ctx, query, event.RideID, event.EventType, data, createdAt).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "db_append_ride_event_failed",
+			Message: err.Error(),
+			RideID:  event.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"event_type": event.EventType,
+			},
+		})
+		return fmt.Errorf("insert ride event: %w", err)
+	}
+
+	return nil
+}
+
+// ListByRideID возвращает события поездки в хронологическом порядке
+func (s *RideEventPgStore) ListByRideID(ctx context.Context, rideID string) ([]*domain.RideEvent, error) {
+	query := `
+		SELECT id, ride_id, event_type, event_data, created_at
+		FROM ride_events
+		WHERE ride_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn(ctx, s.pool).Query(ctx, query, rideID)
+	if err != nil {
+		return nil, fmt.Errorf("query ride events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.RideEvent
+	for rows.Next() {
+		var (
+			e    domain.RideEvent
+			data []byte
+		)
+		if err := rows.Scan(&e.ID, &e.RideID, &e.EventType, &data, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ride event: %w", err)
+		}
+		e.EventData = json.RawMessage(data)
+		events = append(events, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate ride events: %w", err)
+	}
+
+	return events, nil
+}
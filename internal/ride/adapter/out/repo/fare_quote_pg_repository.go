@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FareQuotePgRepository — PostgreSQL репозиторий квот стоимости
+type FareQuotePgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewFareQuotePgRepository создает новый экземпляр репозитория
+func NewFareQuotePgRepository(pool *pgxpool.Pool, log *logger.Logger) *FareQuotePgRepository {
+	return &FareQuotePgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Create сохраняет новую квоту
+func (r *FareQuotePgRepository) Create(ctx context.Context, quote *domain.FareQuote) error {
+	query := `
+		INSERT INTO fare_quotes (
+			id, passenger_id,
+			pickup_lat, pickup_lng, pickup_address,
+			destination_lat, destination_lng, destination_address,
+			distance_km, duration_minutes, estimates, surge_multiplier, signature,
+			expires_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+		)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		quote.ID,
+		quote.PassengerID,
+		quote.PickupLat,
+		quote.PickupLng,
+		quote.PickupAddress,
+		quote.DestinationLat,
+		quote.DestinationLng,
+		quote.DestinationAddress,
+		quote.DistanceKm,
+		quote.DurationMinutes,
+		quote.Estimates,
+		quote.SurgeMultiplier,
+		quote.Signature,
+		quote.ExpiresAt,
+		quote.CreatedAt,
+	)
+	if err != nil {
+		r.log.Error(logger.Entry{
+			Action:  "db_create_fare_quote_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"quote_id": quote.ID,
+			},
+		})
+		return fmt.Errorf("insert fare quote: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID возвращает квоту по ID
+func (r *FareQuotePgRepository) FindByID(ctx context.Context, quoteID string) (*domain.FareQuote, error) {
+	query := `
+		SELECT
+			id, passenger_id,
+			pickup_lat, pickup_lng, pickup_address,
+			destination_lat, destination_lng, destination_address,
+			distance_km, duration_minutes, estimates, surge_multiplier, signature,
+			expires_at, created_at,
+			ride_id, vehicle_type, quoted_fare, used_at
+		FROM fare_quotes
+		WHERE id = $1
+	`
+
+	quote := &domain.FareQuote{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, quoteID).Scan(
+		&quote.ID,
+		&quote.PassengerID,
+		&quote.PickupLat,
+		&quote.PickupLng,
+		&quote.PickupAddress,
+		&quote.DestinationLat,
+		&quote.DestinationLng,
+		&quote.DestinationAddress,
+		&quote.DistanceKm,
+		&quote.DurationMinutes,
+		&quote.Estimates,
+		&quote.SurgeMultiplier,
+		&quote.Signature,
+		&quote.ExpiresAt,
+		&quote.CreatedAt,
+		&quote.RideID,
+		&quote.VehicleType,
+		&quote.QuotedFare,
+		&quote.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrQuoteNotFound
+		}
+		return nil, fmt.Errorf("query fare quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+// MarkUsed привязывает квоту к созданной поездке.
+// Условие used_at IS NULL защищает от двух поездок по одной квоте.
+func (r *FareQuotePgRepository) MarkUsed(ctx context.Context, quoteID, rideID, vehicleType string, fare float64) error {
+	query := `
+		UPDATE fare_quotes SET
+			ride_id = $2,
+			vehicle_type = $3,
+			quoted_fare = $4,
+			used_at = NOW()
+		WHERE id = $1
+		  AND used_at IS NULL
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, quoteID, rideID, vehicleType, fare)
+	if err != nil {
+		return fmt.Errorf("mark fare quote used: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrQuoteAlreadyUsed
+	}
+
+	return nil
+}
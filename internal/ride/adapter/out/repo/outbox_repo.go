@@ -1 +1,121 @@
 package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxPgRepository — PostgreSQL репозиторий transactional outbox
+type OutboxPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewOutboxPgRepository создает новый экземпляр репозитория
+func NewOutboxPgRepository(pool *pgxpool.Pool, log *logger.Logger) *OutboxPgRepository {
+	return &OutboxPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Enqueue сохраняет сообщение в outbox.
+// Внутри WithinTx запись попадает в ту же транзакцию, что и изменение поездки.
+func (r *OutboxPgRepository) Enqueue(ctx context.Context, eventType, routingKey string, payload []byte) error {
+	query := `
+		INSERT INTO outbox (event_type, routing_key, payload, status)
+		VALUES ($1, $2, $3, 'PENDING')
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, eventType, routingKey, payload); err != nil {
+		r.log.Error(logger.Entry{
+			Action:  "db_outbox_enqueue_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"event_type":  eventType,
+				"routing_key": routingKey,
+			},
+		})
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimBatch блокирует готовые к отправке сообщения.
+//
+// FOR UPDATE SKIP LOCKED: несколько реплик Ride Service могут запускать relay
+// одновременно — каждая получит свой непересекающийся набор строк.
+func (r *OutboxPgRepository) ClaimBatch(ctx context.Context, limit, maxRetries int) ([]out.OutboxMessage, error) {
+	query := `
+		SELECT id, event_type, routing_key, payload, retry_count, created_at
+		FROM outbox
+		WHERE status IN ('PENDING', 'FAILED')
+		  AND next_attempt_at <= now()
+		  AND retry_count < $2
+		ORDER BY created_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit, maxRetries)
+	if err != nil {
+		return nil, fmt.Errorf("query outbox batch: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []out.OutboxMessage
+	for rows.Next() {
+		var m out.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.EventType, &m.RoutingKey, &m.Payload, &m.RetryCount, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		messages = append(messages, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkSent помечает сообщение как отправленное
+func (r *OutboxPgRepository) MarkSent(ctx context.Context, id string) error {
+	query := `
+		UPDATE outbox
+		SET status = 'SENT', sent_at = now(), last_error = NULL
+		WHERE id = $1
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("mark outbox message sent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed увеличивает retry_count и откладывает следующую попытку
+func (r *OutboxPgRepository) MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr string) error {
+	query := `
+		UPDATE outbox
+		SET status = 'FAILED',
+		    retry_count = retry_count + 1,
+		    next_attempt_at = $2,
+		    last_error = $3
+		WHERE id = $1
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, id, nextAttemptAt, lastErr); err != nil {
+		return fmt.Errorf("mark outbox message failed: %w", err)
+	}
+
+	return nil
+}
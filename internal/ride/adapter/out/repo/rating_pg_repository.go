@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RatingPgRepository — PostgreSQL репозиторий оценок поездок
+type RatingPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewRatingPgRepository создает новый экземпляр репозитория
+func NewRatingPgRepository(pool *pgxpool.Pool, log *logger.Logger) *RatingPgRepository {
+	return &RatingPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Create сохраняет оценку; уникальный индекс (ride_id, rater_role) не дает оценить дважды
+func (r *RatingPgRepository) Create(ctx context.Context, rating *domain.Rating) error {
+	query := `
+		INSERT INTO ride_ratings (
+			id, ride_id, rater_id, ratee_id, rater_role, score, tags, comment, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		rating.ID,
+		rating.RideID,
+		rating.RaterID,
+		rating.RateeID,
+		rating.RaterRole,
+		rating.Score,
+		rating.Tags,
+		rating.Comment,
+		rating.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrAlreadyRated
+		}
+
+		r.log.Error(logger.Entry{
+			Action:  "db_create_rating_failed",
+			Message: err.Error(),
+			RideID:  rating.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return fmt.Errorf("insert ride rating: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyDriverScore пересчитывает рейтинг водителя по сумме и числу оценок.
+// В SET используются значения строки до обновления.
+func (r *RatingPgRepository) ApplyDriverScore(ctx context.Context, driverID string, score int) (float64, int, error) {
+	query := `
+		UPDATE drivers SET
+			rating = round((rating_sum + $2)::numeric / (rating_count + 1), 2),
+			rating_sum = rating_sum + $2,
+			rating_count = rating_count + 1,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING rating, rating_count
+	`
+
+	return r.applyScore(ctx, query, driverID, score, "driver")
+}
+
+// ApplyPassengerScore пересчитывает рейтинг пассажира по сумме и числу оценок
+func (r *RatingPgRepository) ApplyPassengerScore(ctx context.Context, passengerID string, score int) (float64, int, error) {
+	query := `
+		UPDATE users SET
+			rating = round((rating_sum + $2)::numeric / (rating_count + 1), 2),
+			rating_sum = rating_sum + $2,
+			rating_count = rating_count + 1,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING rating, rating_count
+	`
+
+	return r.applyScore(ctx, query, passengerID, score, "passenger")
+}
+
+func (r *RatingPgRepository) applyScore(ctx context.Context, query, userID string, score int, kind string) (float64, int, error) {
+	var (
+		rating float64
+		count  int
+	)
+
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID, score).Scan(&rating, &count)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, fmt.Errorf("%s %s not found", kind, userID)
+		}
+		return 0, 0, fmt.Errorf("update %s rating: %w", kind, err)
+	}
+
+	return rating, count, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"ridehail/internal/ride/domain"
 	"ridehail/internal/shared/logger"
@@ -26,21 +27,24 @@ func NewRidePgRepository(pool *pgxpool.Pool, log *logger.Logger) *RidePgReposito
 	}
 }
 
-// Create создает новую поездку
+// Create создает новую поездку. Пишет все колонки, которые восстанавливает
+// проекция: Rebuild создает строку заново по событиям.
+// Пустой город (события до появления городов) — город по умолчанию, как в миграции.
 func (r *RidePgRepository) Create(ctx context.Context, ride *domain.Ride) error {
 	query := `
 		INSERT INTO rides (
 			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
 			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
-			cancellation_reason, estimated_fare, final_fare,
-			pickup_coordinate_id, destination_coordinate_id,
-			created_at, updated_at
+			cancellation_reason, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
+			created_at, updated_at, cancellation_fee, fare_breakdown
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+			COALESCE(NULLIF($21, ''), 'default'), $22, $23, $24, $25
 		)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		ride.ID,
 		ride.RideNumber,
 		ride.PassengerID,
@@ -57,10 +61,15 @@ func (r *RidePgRepository) Create(ctx context.Context, ride *domain.Ride) error
 		ride.CancellationReason,
 		ride.EstimatedFare,
 		ride.FinalFare,
+		ride.SurgeMultiplier,
 		ride.PickupCoordinateID,
 		ride.DestinationCoordinateID,
+		ride.ScheduledFor,
+		ride.City,
 		ride.CreatedAt,
 		ride.UpdatedAt,
+		ride.CancellationFee,
+		ride.FareBreakdown,
 	)
 	if err != nil {
 		r.log.Error(logger.Entry{
@@ -81,15 +90,15 @@ func (r *RidePgRepository) FindByID(ctx context.Context, rideID string) (*domain
 		SELECT 
 			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
 			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
-			cancellation_reason, estimated_fare, final_fare,
-			pickup_coordinate_id, destination_coordinate_id,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
 			created_at, updated_at
 		FROM rides
 		WHERE id = $1
 	`
 
 	ride := &domain.Ride{}
-	err := r.pool.QueryRow(ctx, query, rideID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rideID).Scan(
 		&ride.ID,
 		&ride.RideNumber,
 		&ride.PassengerID,
@@ -104,10 +113,14 @@ func (r *RidePgRepository) FindByID(ctx context.Context, rideID string) (*domain
 		&ride.CompletedAt,
 		&ride.CancelledAt,
 		&ride.CancellationReason,
+		&ride.CancellationFee,
 		&ride.EstimatedFare,
 		&ride.FinalFare,
+		&ride.SurgeMultiplier,
 		&ride.PickupCoordinateID,
 		&ride.DestinationCoordinateID,
+		&ride.ScheduledFor,
+		&ride.City,
 		&ride.CreatedAt,
 		&ride.UpdatedAt,
 	)
@@ -133,15 +146,15 @@ func (r *RidePgRepository) FindByRideNumber(ctx context.Context, rideNumber stri
 		SELECT 
 			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
 			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
-			cancellation_reason, estimated_fare, final_fare,
-			pickup_coordinate_id, destination_coordinate_id,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
 			created_at, updated_at
 		FROM rides
 		WHERE ride_number = $1
 	`
 
 	ride := &domain.Ride{}
-	err := r.pool.QueryRow(ctx, query, rideNumber).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rideNumber).Scan(
 		&ride.ID,
 		&ride.RideNumber,
 		&ride.PassengerID,
@@ -156,10 +169,14 @@ func (r *RidePgRepository) FindByRideNumber(ctx context.Context, rideNumber stri
 		&ride.CompletedAt,
 		&ride.CancelledAt,
 		&ride.CancellationReason,
+		&ride.CancellationFee,
 		&ride.EstimatedFare,
 		&ride.FinalFare,
+		&ride.SurgeMultiplier,
 		&ride.PickupCoordinateID,
 		&ride.DestinationCoordinateID,
+		&ride.ScheduledFor,
+		&ride.City,
 		&ride.CreatedAt,
 		&ride.UpdatedAt,
 	)
@@ -173,8 +190,11 @@ func (r *RidePgRepository) FindByRideNumber(ctx context.Context, rideNumber stri
 	return ride, nil
 }
 
-// Update обновляет существующую поездку
-func (r *RidePgRepository) Update(ctx context.Context, ride *domain.Ride) error {
+// Update обновляет существующую поездку.
+// Conditional UPDATE: запись проходит, только если статус в БД равен expectedStatus,
+// иначе конкурентный переход (например, принятие водителем) был бы перезаписан.
+// fare_breakdown пишется только если задан: FindByID его не читает.
+func (r *RidePgRepository) Update(ctx context.Context, ride *domain.Ride, expectedStatus string) error {
 	query := `
 		UPDATE rides SET
 			driver_id = $2,
@@ -185,12 +205,15 @@ func (r *RidePgRepository) Update(ctx context.Context, ride *domain.Ride) error
 			completed_at = $7,
 			cancelled_at = $8,
 			cancellation_reason = $9,
-			final_fare = $10,
-			updated_at = $11
+			cancellation_fee = $10,
+			final_fare = $11,
+			updated_at = $12,
+			fare_breakdown = COALESCE($14, fare_breakdown)
 		WHERE id = $1
+		  AND status = $13
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		ride.ID,
 		ride.DriverID,
 		ride.Status,
@@ -200,8 +223,11 @@ func (r *RidePgRepository) Update(ctx context.Context, ride *domain.Ride) error
 		ride.CompletedAt,
 		ride.CancelledAt,
 		ride.CancellationReason,
+		ride.CancellationFee,
 		ride.FinalFare,
 		ride.UpdatedAt,
+		expectedStatus,
+		ride.FareBreakdown,
 	)
 	if err != nil {
 		r.log.Error(logger.Entry{
@@ -213,6 +239,10 @@ func (r *RidePgRepository) Update(ctx context.Context, ride *domain.Ride) error
 		return fmt.Errorf("update ride: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w (ride_id=%s, expected=%s)", domain.ErrStatusConflict, ride.ID, expectedStatus)
+	}
+
 	return nil
 }
 
@@ -222,8 +252,8 @@ func (r *RidePgRepository) FindActiveByPassengerID(ctx context.Context, passenge
 		SELECT 
 			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
 			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
-			cancellation_reason, estimated_fare, final_fare,
-			pickup_coordinate_id, destination_coordinate_id,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
 			created_at, updated_at
 		FROM rides
 		WHERE passenger_id = $1 
@@ -231,7 +261,7 @@ func (r *RidePgRepository) FindActiveByPassengerID(ctx context.Context, passenge
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, passengerID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, passengerID)
 	if err != nil {
 		return nil, fmt.Errorf("query active rides: %w", err)
 	}
@@ -255,10 +285,73 @@ func (r *RidePgRepository) FindActiveByPassengerID(ctx context.Context, passenge
 			&ride.CompletedAt,
 			&ride.CancelledAt,
 			&ride.CancellationReason,
+			&ride.CancellationFee,
+			&ride.EstimatedFare,
+			&ride.FinalFare,
+			&ride.SurgeMultiplier,
+			&ride.PickupCoordinateID,
+			&ride.DestinationCoordinateID,
+			&ride.ScheduledFor,
+			&ride.City,
+			&ride.CreatedAt,
+			&ride.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan ride: %w", err)
+		}
+		rides = append(rides, ride)
+	}
+
+	return rides, rows.Err()
+}
+
+// FindActiveByDriverID возвращает поездки, которые водитель сейчас выполняет
+func (r *RidePgRepository) FindActiveByDriverID(ctx context.Context, driverID string) ([]*domain.Ride, error) {
+	query := `
+		SELECT 
+			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
+			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
+			created_at, updated_at
+		FROM rides
+		WHERE driver_id = $1
+		  AND status IN ('MATCHED', 'EN_ROUTE', 'ARRIVED', 'IN_PROGRESS')
+		ORDER BY matched_at NULLS LAST, id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("query active rides by driver: %w", err)
+	}
+	defer rows.Close()
+
+	var rides []*domain.Ride
+	for rows.Next() {
+		ride := &domain.Ride{}
+		err := rows.Scan(
+			&ride.ID,
+			&ride.RideNumber,
+			&ride.PassengerID,
+			&ride.DriverID,
+			&ride.VehicleType,
+			&ride.Status,
+			&ride.Priority,
+			&ride.RequestedAt,
+			&ride.MatchedAt,
+			&ride.ArrivedAt,
+			&ride.StartedAt,
+			&ride.CompletedAt,
+			&ride.CancelledAt,
+			&ride.CancellationReason,
+			&ride.CancellationFee,
 			&ride.EstimatedFare,
 			&ride.FinalFare,
+			&ride.SurgeMultiplier,
 			&ride.PickupCoordinateID,
 			&ride.DestinationCoordinateID,
+			&ride.ScheduledFor,
+			&ride.City,
 			&ride.CreatedAt,
 			&ride.UpdatedAt,
 		)
@@ -277,8 +370,8 @@ func (r *RidePgRepository) FindByStatus(ctx context.Context, status string, limi
 		SELECT 
 			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
 			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
-			cancellation_reason, estimated_fare, final_fare,
-			pickup_coordinate_id, destination_coordinate_id,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
 			created_at, updated_at
 		FROM rides
 		WHERE status = $1
@@ -286,7 +379,7 @@ func (r *RidePgRepository) FindByStatus(ctx context.Context, status string, limi
 		LIMIT $2
 	`
 
-	rows, err := r.pool.Query(ctx, query, status, limit)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, status, limit)
 	if err != nil {
 		return nil, fmt.Errorf("query rides by status: %w", err)
 	}
@@ -310,10 +403,14 @@ func (r *RidePgRepository) FindByStatus(ctx context.Context, status string, limi
 			&ride.CompletedAt,
 			&ride.CancelledAt,
 			&ride.CancellationReason,
+			&ride.CancellationFee,
 			&ride.EstimatedFare,
 			&ride.FinalFare,
+			&ride.SurgeMultiplier,
 			&ride.PickupCoordinateID,
 			&ride.DestinationCoordinateID,
+			&ride.ScheduledFor,
+			&ride.City,
 			&ride.CreatedAt,
 			&ride.UpdatedAt,
 		)
@@ -326,7 +423,6 @@ func (r *RidePgRepository) FindByStatus(ctx context.Context, status string, limi
 	return rides, rows.Err()
 }
 
-// AssignDriver назначает водителя на поездку и обновляет статус на DRIVER_ASSIGNED
 // AssignDriver — атомарно назначает водителя на поездку.
 //
 // БИЗНЕС-ЛОГИКА:
@@ -368,7 +464,7 @@ func (r *RidePgRepository) AssignDriver(ctx context.Context, rideID string, driv
 
 	// Выполняем UPDATE через connection pool
 	// pgx автоматически переиспользует соединения
-	result, err := r.pool.Exec(ctx, query, driverID, rideID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, driverID, rideID)
 	if err != nil {
 		// SQL ошибка (constraint violation, connection timeout, etc.)
 		r.log.Error(logger.Entry{
@@ -385,7 +481,7 @@ func (r *RidePgRepository) AssignDriver(ctx context.Context, rideID string, driv
 	// 1. Поездка не найдена (неверный ride_id)
 	// 2. Поездка уже назначена другому водителю (status != REQUESTED)
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("ride not found or already assigned (ride_id=%s)", rideID)
+		return fmt.Errorf("%w: ride not found or already assigned (ride_id=%s)", domain.ErrStatusConflict, rideID)
 	}
 
 	// SUCCESS: Логируем для мониторинга
@@ -404,3 +500,115 @@ func (r *RidePgRepository) AssignDriver(ctx context.Context, rideID string, driv
 
 	return nil
 }
+
+// FindScheduledDue возвращает SCHEDULED поездки с подачей не позже before —
+// их пора передавать в подбор водителя. Ближайшие первыми.
+func (r *RidePgRepository) FindScheduledDue(ctx context.Context, before time.Time, limit int) ([]*domain.Ride, error) {
+	query := `
+		SELECT 
+			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
+			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
+			created_at, updated_at
+		FROM rides
+		WHERE status = 'SCHEDULED'
+		  AND scheduled_for <= $1
+		ORDER BY scheduled_for ASC
+		LIMIT $2
+	`
+
+	rides, err := r.queryRides(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due scheduled rides: %w", err)
+	}
+	return rides, nil
+}
+
+// FindScheduledForReminder возвращает SCHEDULED поездки с подачей не позже before,
+// по которым напоминание еще не отправлялось
+func (r *RidePgRepository) FindScheduledForReminder(ctx context.Context, before time.Time, limit int) ([]*domain.Ride, error) {
+	query := `
+		SELECT 
+			id, ride_number, passenger_id, driver_id, vehicle_type, status, priority,
+			requested_at, matched_at, arrived_at, started_at, completed_at, cancelled_at,
+			cancellation_reason, cancellation_fee, estimated_fare, final_fare, surge_multiplier,
+			pickup_coordinate_id, destination_coordinate_id, scheduled_for, city,
+			created_at, updated_at
+		FROM rides
+		WHERE status = 'SCHEDULED'
+		  AND reminder_sent_at IS NULL
+		  AND scheduled_for <= $1
+		ORDER BY scheduled_for ASC
+		LIMIT $2
+	`
+
+	rides, err := r.queryRides(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query scheduled rides for reminder: %w", err)
+	}
+	return rides, nil
+}
+
+// MarkReminderSent отмечает отправку напоминания.
+// Условный UPDATE: false — напоминание уже отметила другая реплика.
+func (r *RidePgRepository) MarkReminderSent(ctx context.Context, rideID string, at time.Time) (bool, error) {
+	query := `
+		UPDATE rides SET reminder_sent_at = $2
+		WHERE id = $1
+		  AND reminder_sent_at IS NULL
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, rideID, at)
+	if err != nil {
+		return false, fmt.Errorf("mark reminder sent: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// queryRides выполняет запрос с полным набором колонок rides и сканирует результат
+func (r *RidePgRepository) queryRides(ctx context.Context, query string, args ...any) ([]*domain.Ride, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rides []*domain.Ride
+	for rows.Next() {
+		ride := &domain.Ride{}
+		err := rows.Scan(
+			&ride.ID,
+			&ride.RideNumber,
+			&ride.PassengerID,
+			&ride.DriverID,
+			&ride.VehicleType,
+			&ride.Status,
+			&ride.Priority,
+			&ride.RequestedAt,
+			&ride.MatchedAt,
+			&ride.ArrivedAt,
+			&ride.StartedAt,
+			&ride.CompletedAt,
+			&ride.CancelledAt,
+			&ride.CancellationReason,
+			&ride.CancellationFee,
+			&ride.EstimatedFare,
+			&ride.FinalFare,
+			&ride.SurgeMultiplier,
+			&ride.PickupCoordinateID,
+			&ride.DestinationCoordinateID,
+			&ride.ScheduledFor,
+			&ride.City,
+			&ride.CreatedAt,
+			&ride.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan ride: %w", err)
+		}
+		rides = append(rides, ride)
+	}
+
+	return rides, rows.Err()
+}
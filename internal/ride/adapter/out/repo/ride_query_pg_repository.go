@@ -0,0 +1,269 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/pricing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rideDetailsSelect — общая часть запросов чтения: поездка + точки маршрута + водитель.
+// Точки и водитель через LEFT JOIN: у REQUESTED поездки водителя еще нет.
+const rideDetailsSelect = `
+	SELECT
+		r.id, r.ride_number, r.passenger_id, r.driver_id, r.vehicle_type, r.status, r.priority,
+		r.requested_at, r.matched_at, r.arrived_at, r.started_at, r.completed_at, r.cancelled_at,
+		r.cancellation_reason, r.estimated_fare, r.final_fare, r.surge_multiplier,
+		r.pickup_coordinate_id, r.destination_coordinate_id,
+		r.created_at, r.updated_at,
+		pc.id, pc.address, pc.latitude, pc.longitude,
+		dc.id, dc.address, dc.latitude, dc.longitude,
+		dc.fare_amount, dc.distance_km, dc.duration_minutes,
+		d.id, d.vehicle_type, d.vehicle_attrs, d.rating,
+		r.fare_breakdown, r.scheduled_for, r.cancellation_fee
+	FROM rides r
+	LEFT JOIN coordinates pc ON pc.id = r.pickup_coordinate_id
+	LEFT JOIN coordinates dc ON dc.id = r.destination_coordinate_id
+	LEFT JOIN drivers d ON d.id = r.driver_id
+`
+
+// RideQueryPgRepository — PostgreSQL реализация RideQueryRepository
+type RideQueryPgRepository struct {
+	pool  *pgxpool.Pool
+	stops *RideStopPgRepository
+	log   *logger.Logger
+}
+
+// NewRideQueryPgRepository создает новый репозиторий чтения поездок
+func NewRideQueryPgRepository(pool *pgxpool.Pool, log *logger.Logger) *RideQueryPgRepository {
+	return &RideQueryPgRepository{
+		pool:  pool,
+		stops: NewRideStopPgRepository(pool, log),
+		log:   log,
+	}
+}
+
+// FindDetailsByID возвращает поездку с точками маршрута и водителем по ID
+func (r *RideQueryPgRepository) FindDetailsByID(ctx context.Context, rideID string) (*domain.RideDetails, error) {
+	query := rideDetailsSelect + `WHERE r.id = $1`
+
+	details, err := scanRideDetails(conn(ctx, r.pool).QueryRow(ctx, query, rideID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRideNotFound
+		}
+		r.log.Error(logger.Entry{
+			Action:  "db_find_ride_details_failed",
+			Message: err.Error(),
+			RideID:  rideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, fmt.Errorf("query ride details by id: %w", err)
+	}
+
+	if err := r.attachStops(ctx, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// FindDetailsByRideNumber возвращает поездку с точками маршрута и водителем по номеру
+func (r *RideQueryPgRepository) FindDetailsByRideNumber(ctx context.Context, rideNumber string) (*domain.RideDetails, error) {
+	query := rideDetailsSelect + `WHERE r.ride_number = $1`
+
+	details, err := scanRideDetails(conn(ctx, r.pool).QueryRow(ctx, query, rideNumber))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRideNotFound
+		}
+		return nil, fmt.Errorf("query ride details by number: %w", err)
+	}
+
+	if err := r.attachStops(ctx, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// ListByPassenger возвращает страницу истории поездок пассажира.
+// Keyset-пагинация по (requested_at, id) — индекс idx_rides_passenger_requested.
+func (r *RideQueryPgRepository) ListByPassenger(ctx context.Context, filter domain.RideListFilter) ([]*domain.RideDetails, error) {
+	query := rideDetailsSelect + `
+		WHERE r.passenger_id = $1
+		  AND ($2::text = '' OR r.status = $2)
+		  AND ($3::timestamptz IS NULL OR r.requested_at >= $3)
+		  AND ($4::timestamptz IS NULL OR r.requested_at < $4)
+		  AND ($5::timestamptz IS NULL OR (r.requested_at, r.id) < ($5, $6::uuid))
+		ORDER BY r.requested_at DESC, r.id DESC
+		LIMIT $7
+	`
+
+	var afterAt, afterID any
+	if filter.After != nil {
+		afterAt, afterID = filter.After.RequestedAt, filter.After.ID
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query,
+		filter.PassengerID,
+		filter.Status,
+		filter.From,
+		filter.To,
+		afterAt,
+		afterID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query passenger rides: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*domain.RideDetails
+	for rows.Next() {
+		details, err := scanRideDetails(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan ride details: %w", err)
+		}
+		result = append(result, details)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachStops(ctx, result...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// attachStops загружает промежуточные остановки одним запросом на всю страницу
+func (r *RideQueryPgRepository) attachStops(ctx context.Context, details ...*domain.RideDetails) error {
+	rideIDs := make([]string, 0, len(details))
+	for _, d := range details {
+		rideIDs = append(rideIDs, d.Ride.ID)
+	}
+
+	byRide, err := r.stops.ListByRideIDs(ctx, rideIDs)
+	if err != nil {
+		return fmt.Errorf("load ride stops: %w", err)
+	}
+
+	for _, d := range details {
+		d.Stops = byRide[d.Ride.ID]
+	}
+	return nil
+}
+
+// scanRideDetails сканирует строку rideDetailsSelect (pgx.Row или pgx.Rows)
+func scanRideDetails(row pgx.Row) (*domain.RideDetails, error) {
+	ride := &domain.Ride{}
+
+	var (
+		pickupID, pickupAddress           *string
+		pickupLat, pickupLng              *float64
+		destID, destAddress               *string
+		destLat, destLng                  *float64
+		destFare, destDistance            *float64
+		destDuration                      *int
+		driverID, driverVehicleType       *string
+		driverVehicleAttrs                map[string]interface{}
+		driverRating                      *float64
+		fareBreakdown                     *pricing.Breakdown
+		pickupCoordinateID, destinationID *string
+	)
+
+	err := row.Scan(
+		&ride.ID,
+		&ride.RideNumber,
+		&ride.PassengerID,
+		&ride.DriverID,
+		&ride.VehicleType,
+		&ride.Status,
+		&ride.Priority,
+		&ride.RequestedAt,
+		&ride.MatchedAt,
+		&ride.ArrivedAt,
+		&ride.StartedAt,
+		&ride.CompletedAt,
+		&ride.CancelledAt,
+		&ride.CancellationReason,
+		&ride.EstimatedFare,
+		&ride.FinalFare,
+		&ride.SurgeMultiplier,
+		&pickupCoordinateID,
+		&destinationID,
+		&ride.CreatedAt,
+		&ride.UpdatedAt,
+		&pickupID, &pickupAddress, &pickupLat, &pickupLng,
+		&destID, &destAddress, &destLat, &destLng,
+		&destFare, &destDistance, &destDuration,
+		&driverID, &driverVehicleType, &driverVehicleAttrs, &driverRating,
+		&fareBreakdown, &ride.ScheduledFor, &ride.CancellationFee,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if pickupCoordinateID != nil {
+		ride.PickupCoordinateID = *pickupCoordinateID
+	}
+	if destinationID != nil {
+		ride.DestinationCoordinateID = *destinationID
+	}
+
+	details := &domain.RideDetails{Ride: ride, Fare: fareBreakdown}
+
+	if pickupID != nil {
+		details.Pickup = &domain.Coordinate{
+			ID:        *pickupID,
+			Address:   deref(pickupAddress),
+			Latitude:  derefFloat(pickupLat),
+			Longitude: derefFloat(pickupLng),
+		}
+	}
+
+	if destID != nil {
+		details.Destination = &domain.Coordinate{
+			ID:              *destID,
+			Address:         deref(destAddress),
+			Latitude:        derefFloat(destLat),
+			Longitude:       derefFloat(destLng),
+			FareAmount:      destFare,
+			DistanceKm:      destDistance,
+			DurationMinutes: destDuration,
+		}
+	}
+
+	if driverID != nil {
+		details.Driver = &domain.DriverInfo{
+			ID:           *driverID,
+			VehicleType:  deref(driverVehicleType),
+			VehicleAttrs: driverVehicleAttrs,
+			Rating:       derefFloat(driverRating),
+		}
+	}
+
+	return details, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rideStopSelect — остановка вместе с адресом и координатами из coordinates
+const rideStopSelect = `
+	SELECT
+		s.id, s.ride_id, s.stop_number, s.coordinate_id,
+		c.address, c.latitude, c.longitude,
+		s.arrived_at, s.created_at
+	FROM ride_stops s
+	JOIN coordinates c ON c.id = s.coordinate_id
+`
+
+// RideStopPgRepository — PostgreSQL реализация RideStopRepository
+type RideStopPgRepository struct {
+	pool *pgxpool.Pool
+	log  *logger.Logger
+}
+
+// NewRideStopPgRepository создает новый репозиторий остановок
+func NewRideStopPgRepository(pool *pgxpool.Pool, log *logger.Logger) *RideStopPgRepository {
+	return &RideStopPgRepository{
+		pool: pool,
+		log:  log,
+	}
+}
+
+// Create сохраняет остановку поездки
+func (r *RideStopPgRepository) Create(ctx context.Context, stop *domain.RideStop) error {
+	query := `
+		INSERT INTO ride_stops (id, ride_id, stop_number, coordinate_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		stop.ID,
+		stop.RideID,
+		stop.StopNumber,
+		stop.CoordinateID,
+		stop.CreatedAt,
+	)
+	if err != nil {
+		r.log.Error(logger.Entry{
+			Action:  "db_create_ride_stop_failed",
+			Message: err.Error(),
+			RideID:  stop.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return fmt.Errorf("insert ride stop: %w", err)
+	}
+
+	return nil
+}
+
+// ListByRideID возвращает остановки поездки по порядку
+func (r *RideStopPgRepository) ListByRideID(ctx context.Context, rideID string) ([]*domain.RideStop, error) {
+	byRide, err := r.ListByRideIDs(ctx, []string{rideID})
+	if err != nil {
+		return nil, err
+	}
+	return byRide[rideID], nil
+}
+
+// ListByRideIDs возвращает остановки нескольких поездок одним запросом
+func (r *RideStopPgRepository) ListByRideIDs(ctx context.Context, rideIDs []string) (map[string][]*domain.RideStop, error) {
+	result := make(map[string][]*domain.RideStop, len(rideIDs))
+	if len(rideIDs) == 0 {
+		return result, nil
+	}
+
+	query := rideStopSelect + `
+		WHERE s.ride_id = ANY($1::uuid[])
+		ORDER BY s.ride_id, s.stop_number
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, rideIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query ride stops: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stop := &domain.RideStop{}
+		if err := rows.Scan(
+			&stop.ID,
+			&stop.RideID,
+			&stop.StopNumber,
+			&stop.CoordinateID,
+			&stop.Address,
+			&stop.Latitude,
+			&stop.Longitude,
+			&stop.ArrivedAt,
+			&stop.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan ride stop: %w", err)
+		}
+		result[stop.RideID] = append(result[stop.RideID], stop)
+	}
+
+	return result, rows.Err()
+}
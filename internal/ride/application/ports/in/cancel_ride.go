@@ -1 +1,47 @@
 package in
+
+import (
+	"context"
+	"time"
+)
+
+// CancelRideInput — входные данные для отмены поездки.
+//
+// Источник: HTTP (POST /rides/{ride_id}/cancel) или WebSocket пассажира (cancel_ride).
+// RequesterID и RequesterRole берутся из JWT токена, а не из тела запроса.
+type CancelRideInput struct {
+	RideID        string // UUID поездки
+	RequesterID   string // UUID пассажира или назначенного водителя
+	RequesterRole string // DRIVER — отмена назначенным водителем, иначе — пассажиром
+	Reason        string // Причина отмены (опционально)
+}
+
+// CancelRideOutput — результат отмены поездки (формат ответа из регламента)
+type CancelRideOutput struct {
+	RideID      string    `json:"ride_id"`
+	Status      string    `json:"status"`
+	CancelledAt time.Time `json:"cancelled_at"`
+	Message     string    `json:"message"`
+	CancelledBy string    `json:"cancelled_by"` // PASSENGER | DRIVER
+
+	// LateCancellation — заказ заранее отменен ближе чем за free_cancel_minutes до подачи
+	LateCancellation bool `json:"late_cancellation,omitempty"`
+
+	// CancellationFee — штраф за отмену по тарифу; 0 — отмена бесплатная
+	CancellationFee float64 `json:"cancellation_fee"`
+	FeeReason       string  `json:"fee_reason,omitempty"` // driver_en_route, driver_arrived, late_scheduled
+}
+
+// CancelRideUseCase — интерфейс use-case для отмены поездки пассажиром или водителем
+type CancelRideUseCase interface {
+	// Execute отменяет поездку.
+	//
+	// Возвращает:
+	//   - domain.ErrRideNotFound — поездка не существует
+	//   - domain.ErrForbidden — поездка принадлежит другому пассажиру или назначена
+	//     другому водителю (либо роль не может отменять поездки)
+	//   - domain.ErrRideAlreadyCancelled / domain.ErrRideAlreadyCompleted
+	//   - domain.ErrInvalidTransition — поездка уже началась (IN_PROGRESS)
+	//   - domain.ErrStatusConflict — статус изменился во время отмены
+	Execute(ctx context.Context, input CancelRideInput) (*CancelRideOutput, error)
+}
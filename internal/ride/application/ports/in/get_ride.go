@@ -0,0 +1,74 @@
+package in
+
+import (
+	"context"
+	"time"
+
+	"ridehail/internal/shared/pricing"
+)
+
+// GetRideInput — входные данные для чтения поездки.
+// Задается ровно одно из RideID / RideNumber.
+type GetRideInput struct {
+	RideID        string // UUID поездки
+	RideNumber    string // Номер поездки (RIDE_YYYYMMDD_...)
+	RequesterID   string // UUID пользователя из JWT
+	RequesterRole string // PASSENGER | DRIVER | ADMIN
+}
+
+// RideLocationDTO — точка маршрута
+type RideLocationDTO struct {
+	Address string  `json:"address"`
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+}
+
+// RideDriverDTO — водитель поездки
+type RideDriverDTO struct {
+	DriverID     string                 `json:"driver_id"`
+	VehicleType  string                 `json:"vehicle_type,omitempty"`
+	VehicleAttrs map[string]interface{} `json:"vehicle_attrs,omitempty"`
+	Rating       float64                `json:"rating,omitempty"`
+}
+
+// RideStopDTO — промежуточная остановка в ответах API чтения
+type RideStopDTO struct {
+	StopNumber int        `json:"stop_number"`
+	Address    string     `json:"address"`
+	Lat        float64    `json:"lat"`
+	Lng        float64    `json:"lng"`
+	ArrivedAt  *time.Time `json:"arrived_at,omitempty"`
+}
+
+// RideDTO — поездка в ответах API чтения
+type RideDTO struct {
+	RideID              string             `json:"ride_id"`
+	RideNumber          string             `json:"ride_number"`
+	Status              string             `json:"status"`
+	VehicleType         string             `json:"vehicle_type"`
+	PickupLocation      *RideLocationDTO   `json:"pickup_location,omitempty"`
+	DestinationLocation *RideLocationDTO   `json:"destination_location,omitempty"`
+	Stops               []RideStopDTO      `json:"stops,omitempty"`
+	Driver              *RideDriverDTO     `json:"driver,omitempty"`
+	EstimatedFare       *float64           `json:"estimated_fare,omitempty"`
+	FinalFare           *float64           `json:"final_fare,omitempty"`
+	FareBreakdown       *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+	SurgeMultiplier     float64            `json:"surge_multiplier"`
+	DistanceKm          *float64           `json:"distance_km,omitempty"`
+	DurationMinutes     *int               `json:"duration_minutes,omitempty"`
+	CancellationReason  *string            `json:"cancellation_reason,omitempty"`
+	CancellationFee     *float64           `json:"cancellation_fee,omitempty"`
+	RequestedAt         time.Time          `json:"requested_at"`
+	ScheduledFor        *time.Time         `json:"scheduled_for,omitempty"`
+	MatchedAt           *time.Time         `json:"matched_at,omitempty"`
+	ArrivedAt           *time.Time         `json:"arrived_at,omitempty"`
+	StartedAt           *time.Time         `json:"started_at,omitempty"`
+	CompletedAt         *time.Time         `json:"completed_at,omitempty"`
+	CancelledAt         *time.Time         `json:"cancelled_at,omitempty"`
+}
+
+// GetRideUseCase — интерфейс use-case для чтения одной поездки.
+// Доступна пассажиру-владельцу, назначенному водителю и администраторам.
+type GetRideUseCase interface {
+	Execute(ctx context.Context, input GetRideInput) (*RideDTO, error)
+}
@@ -0,0 +1,34 @@
+package in
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// GetRideEventsInput — входные данные для получения хронологии поездки
+type GetRideEventsInput struct {
+	RideID        string // UUID поездки
+	RequesterID   string // UUID пользователя из JWT
+	RequesterRole string // PASSENGER | ADMIN
+}
+
+// RideEventDTO — одно событие хронологии
+type RideEventDTO struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	EventData json.RawMessage `json:"event_data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// GetRideEventsOutput — полная хронология поездки
+type GetRideEventsOutput struct {
+	RideID string         `json:"ride_id"`
+	Events []RideEventDTO `json:"events"`
+}
+
+// GetRideEventsUseCase — интерфейс use-case для чтения журнала событий поездки.
+// Доступен владельцу поездки и администраторам (поддержка).
+type GetRideEventsUseCase interface {
+	Execute(ctx context.Context, input GetRideEventsInput) (*GetRideEventsOutput, error)
+}
@@ -0,0 +1,24 @@
+package in
+
+import "context"
+
+// HandleDriverArrivedInput — прибытие водителя на точку подачи от Driver Service
+// (сообщение driver.arrived.{ride_id})
+type HandleDriverArrivedInput struct {
+	RideID                 string  // UUID поездки
+	DriverID               string  // UUID водителя
+	Lat                    float64 // Где водитель отметил прибытие
+	Lng                    float64
+	DistanceToPickupMeters float64 // Расстояние до точки подачи в момент отметки
+	FreeWaitingMinutes     int     // Бесплатное ожидание по тарифу
+	ArrivedAt              string  // RFC3339
+}
+
+// HandleDriverArrivedUseCase — интерфейс use-case для прибытия водителя.
+//
+// Статус ARRIVED и событие DRIVER_ARRIVED уже записаны Driver Service,
+// здесь пассажир получает уведомление "водитель на месте".
+type HandleDriverArrivedUseCase interface {
+	// Execute уведомляет пассажира. Для поездок не в статусе ARRIVED ничего не делает.
+	Execute(ctx context.Context, input HandleDriverArrivedInput) error
+}
@@ -0,0 +1,24 @@
+package in
+
+import "context"
+
+// HandleMatchingStatusInput — этап подбора водителя от Driver Service
+// (сообщение driver.matching.{ride_id})
+type HandleMatchingStatusInput struct {
+	RideID     string  // UUID поездки
+	Status     string  // SEARCHING | OFFERS_SENT | RETRYING | RADIUS_EXPANDED | NO_DRIVERS_AVAILABLE
+	Round      int     // Номер раунда офферов
+	RadiusKm   float64 // Текущий радиус поиска
+	OffersSent int     // Сколько офферов отправлено в раунде
+	Reason     string  // Причина (declined, offer_timeout, no_drivers_available)
+}
+
+// HandleMatchingStatusUseCase — интерфейс use-case для этапов подбора водителя.
+//
+// Промежуточные этапы пересылаются пассажиру через WebSocket,
+// NO_DRIVERS_AVAILABLE отменяет поездку с причиной no_drivers_available.
+type HandleMatchingStatusUseCase interface {
+	// Execute обрабатывает этап подбора.
+	// Для поездок, которые уже не в статусе REQUESTED, ничего не делает.
+	Execute(ctx context.Context, input HandleMatchingStatusInput) error
+}
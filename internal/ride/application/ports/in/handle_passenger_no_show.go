@@ -0,0 +1,22 @@
+package in
+
+import "context"
+
+// HandlePassengerNoShowInput — отмена поездки водителем за неявку пассажира от Driver Service
+// (сообщение driver.no_show.{ride_id})
+type HandlePassengerNoShowInput struct {
+	RideID          string  // UUID поездки
+	DriverID        string  // UUID водителя
+	WaitedMinutes   int     // Сколько водитель прождал на точке подачи
+	CancellationFee float64 // Штраф пассажиру
+	CancelledAt     string  // RFC3339
+}
+
+// HandlePassengerNoShowUseCase — интерфейс use-case для неявки пассажира.
+//
+// Отмену, штраф и событие RIDE_CANCELLED уже записал Driver Service,
+// здесь пассажир получает уведомление об отмене и штрафе.
+type HandlePassengerNoShowUseCase interface {
+	// Execute уведомляет пассажира. Для поездок не в статусе CANCELLED ничего не делает.
+	Execute(ctx context.Context, input HandlePassengerNoShowInput) error
+}
@@ -0,0 +1,25 @@
+package in
+
+import "context"
+
+// HandleStopArrivedInput — прибытие водителя на промежуточную остановку от Driver Service
+// (сообщение driver.stop_arrived.{ride_id})
+type HandleStopArrivedInput struct {
+	RideID     string  // UUID поездки
+	DriverID   string  // UUID водителя
+	StopNumber int     // Номер остановки (с 1)
+	TotalStops int     // Всего остановок в поездке
+	Address    string  // Адрес остановки
+	Lat        float64 // Где водитель отметил прибытие
+	Lng        float64
+	ArrivedAt  string // RFC3339
+}
+
+// HandleStopArrivedUseCase — интерфейс use-case для прибытия на остановку.
+//
+// Отметку в ride_stops и событие STOP_ARRIVED уже записал Driver Service,
+// здесь пассажир получает уведомление на каждой остановке.
+type HandleStopArrivedUseCase interface {
+	// Execute уведомляет пассажира. Для поездок не в статусе IN_PROGRESS ничего не делает.
+	Execute(ctx context.Context, input HandleStopArrivedInput) error
+}
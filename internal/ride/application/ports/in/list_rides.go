@@ -0,0 +1,30 @@
+package in
+
+import (
+	"context"
+	"time"
+)
+
+// ListRidesInput — входные данные для истории поездок пассажира
+type ListRidesInput struct {
+	PassengerID string     // UUID пассажира из JWT (история всегда своя)
+	Status      string     // Фильтр по статусу (опционально)
+	From        *time.Time // requested_at >= From (опционально)
+	To          *time.Time // requested_at < To (опционально)
+	Cursor      string     // next_cursor предыдущей страницы (опционально)
+	Limit       int        // Размер страницы (0 — по умолчанию)
+}
+
+// ListRidesOutput — страница истории поездок
+type ListRidesOutput struct {
+	Rides      []RideDTO `json:"rides"`
+	NextCursor string    `json:"next_cursor,omitempty"` // пусто — страниц больше нет
+}
+
+// ListRidesUseCase — интерфейс use-case для истории поездок пассажира.
+//
+// Пагинация по курсору (requested_at, id): в отличие от offset, новые поездки,
+// созданные между запросами, не сдвигают страницы.
+type ListRidesUseCase interface {
+	Execute(ctx context.Context, input ListRidesInput) (*ListRidesOutput, error)
+}
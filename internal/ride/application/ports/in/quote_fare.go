@@ -0,0 +1,41 @@
+package in
+
+import (
+	"context"
+	"time"
+)
+
+// QuoteFareInput — маршрут, для которого нужна предварительная цена
+type QuoteFareInput struct {
+	PassengerID   string
+	PickupLat     float64
+	PickupLng     float64
+	PickupAddress string
+	DestLat       float64
+	DestLng       float64
+	DestAddress   string
+}
+
+// FareEstimateDTO — цена для одного типа авто
+type FareEstimateDTO struct {
+	VehicleType   string  `json:"vehicle_type"`
+	EstimatedFare float64 `json:"estimated_fare"`
+}
+
+// QuoteFareOutput — квота: цены по всем типам авто, действительные до ExpiresAt
+type QuoteFareOutput struct {
+	QuoteID         string            `json:"quote_id"`
+	Estimates       []FareEstimateDTO `json:"estimates"`
+	DistanceKm      float64           `json:"distance_km"`
+	DurationMinutes int               `json:"duration_minutes"`
+	SurgeMultiplier float64           `json:"surge_multiplier"` // 1 — без surge; уже учтен в estimates
+	ExpiresAt       time.Time         `json:"expires_at"`
+	TTLSeconds      int               `json:"ttl_seconds"`
+	Signature       string            `json:"quote_signature"` // HMAC-SHA256 квоты, hex; клиент возвращает ее в POST /rides
+}
+
+// QuoteFareUseCase — интерфейс use-case для расчета цены до заказа.
+// Квота сохраняется; POST /rides с quote_id и quote_signature фиксирует цену из нее.
+type QuoteFareUseCase interface {
+	Execute(ctx context.Context, input QuoteFareInput) (*QuoteFareOutput, error)
+}
@@ -0,0 +1,37 @@
+package in
+
+import (
+	"context"
+	"time"
+)
+
+// RateRideInput — оценка завершенной поездки одним из ее участников
+type RateRideInput struct {
+	RideID    string   // UUID поездки
+	RaterID   string   // Кто оценивает (из токена)
+	RaterRole string   // PASSENGER — оценивает водителя, DRIVER — пассажира
+	Score     int      // 1–5
+	Tags      []string // Например: clean_car, polite, late
+	Comment   *string  // Необязательный комментарий
+}
+
+// RateRideOutput — сохраненная оценка
+type RateRideOutput struct {
+	RatingID  string    `json:"rating_id"`
+	RideID    string    `json:"ride_id"`
+	RaterRole string    `json:"rater_role"`
+	RateeID   string    `json:"ratee_id"`
+	Score     int       `json:"score"`
+	Tags      []string  `json:"tags"`
+	Comment   *string   `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RateRideUseCase — интерфейс use-case для оценок после поездки.
+//
+// Оценить можно только COMPLETED поездку, в течение окна после завершения
+// и не больше одного раза с каждой стороны. Средний рейтинг оцененного
+// участника пересчитывается в той же транзакции.
+type RateRideUseCase interface {
+	Execute(ctx context.Context, input RateRideInput) (*RateRideOutput, error)
+}
@@ -1,6 +1,9 @@
 package in
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // RequestRideInput — входные данные для создания поездки
 type RequestRideInput struct {
@@ -13,16 +16,39 @@ type RequestRideInput struct {
 	DestLng       float64 `json:"destination_lng"`
 	DestAddress   string  `json:"destination_address"`
 	Priority      int     `json:"priority"` // 1-10, по умолчанию 1
+	QuoteID       string  `json:"quote_id"` // Квота из POST /rides/quote (опционально)
+
+	// QuoteSignature — подпись квоты, которую клиент получил вместе с ней
+	QuoteSignature string `json:"quote_signature,omitempty"`
+
+	// ScheduledFor — время подачи для заказа заранее; nil — подбор водителя сразу
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+
+	// Stops — промежуточные остановки между подачей и назначением, по порядку
+	Stops []StopInput `json:"stops,omitempty"`
+}
+
+// StopInput — промежуточная остановка в заказе
+type StopInput struct {
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+	Address string  `json:"address"`
 }
 
 // RequestRideOutput — результат создания поездки
 type RequestRideOutput struct {
-	RideID        string  `json:"ride_id"`
-	RideNumber    string  `json:"ride_number"`
-	Status        string  `json:"status"`
-	EstimatedFare float64 `json:"estimated_fare"`
-	PickupAddress string  `json:"pickup_address"`
-	DestAddress   string  `json:"destination_address"`
+	RideID          string  `json:"ride_id"`
+	RideNumber      string  `json:"ride_number"`
+	Status          string  `json:"status"`
+	EstimatedFare   float64 `json:"estimated_fare"`
+	SurgeMultiplier float64 `json:"surge_multiplier"` // 1 — без surge; уже учтен в estimated_fare
+	PickupAddress   string  `json:"pickup_address"`
+	DestAddress     string  `json:"destination_address"`
+	QuoteID         string  `json:"quote_id,omitempty"` // Квота, по цене которой создана поездка
+
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"` // Только для заказа заранее (status SCHEDULED)
+	DistanceKm   float64    `json:"distance_km"`             // Сумма участков маршрута с учетом остановок
+	Stops        int        `json:"stops,omitempty"`         // Число промежуточных остановок
 }
 
 // RequestRideUseCase — интерфейс use-case для запроса поездки
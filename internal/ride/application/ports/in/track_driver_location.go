@@ -0,0 +1,44 @@
+package in
+
+import "context"
+
+// TrackDriverLocationInput — обновление локации водителя из location_fanout
+type TrackDriverLocationInput struct {
+	DriverID       string  // UUID водителя
+	RideID         string  // UUID поездки (опционально; без него — все активные поездки водителя)
+	Lat            float64 // Широта
+	Lng            float64 // Долгота
+	SpeedKmh       float64 // Скорость, км/ч
+	HeadingDegrees float64 // Направление движения, градусы
+	Timestamp      string  // Время замера (RFC3339)
+}
+
+// TrackDriverLocationOutput — обновление, которое нужно отправить пассажиру
+type TrackDriverLocationOutput struct {
+	RideID         string
+	PassengerID    string
+	RideStatus     string  // MATCHED | EN_ROUTE | ARRIVED | IN_PROGRESS
+	Lat            float64 // Текущая локация водителя
+	Lng            float64
+	SpeedKmh       float64
+	HeadingDegrees float64
+	ETATarget      string  // pickup — водитель едет к пассажиру, destination — поездка идет
+	DistanceKm     float64 // Расстояние до ETATarget по прямой
+	ETAMinutes     int
+	Timestamp      string
+}
+
+// TrackDriverLocationUseCase — интерфейс use-case для live-карты пассажира.
+//
+// Определяет активные поездки водителя и их пассажиров (с кэшем в памяти),
+// ограничивает частоту обновлений и считает ETA до точки подачи или назначения.
+type TrackDriverLocationUseCase interface {
+	// Execute возвращает обновления для пассажиров: по одному на поездку (RideID
+	// из входа — только для нее). Пустой список — у водителя нет активных поездок
+	// или сработал throttle.
+	Execute(ctx context.Context, input TrackDriverLocationInput) ([]*TrackDriverLocationOutput, error)
+
+	// InvalidateDriver сбрасывает закэшированные поездки водителя
+	// (вызывается на событиях смены статуса поездки или водителя)
+	InvalidateDriver(driverID string)
+}
@@ -2,6 +2,8 @@ package out
 
 import (
 	"context"
+
+	"ridehail/internal/shared/contract"
 )
 
 // RideEventData — данные события поездки
@@ -19,4 +21,7 @@ type EventPublisher interface {
 	// PublishRideEvent публикует событие поездки
 	// eventType: RIDE_REQUESTED | DRIVER_MATCHED | RIDE_STARTED | RIDE_COMPLETED | RIDE_CANCELLED
 	PublishRideEvent(ctx context.Context, eventType string, data RideEventData) error
+
+	// PublishRideRequested публикует запрос на подбор водителя (ride.requested → driver_matching)
+	PublishRideRequested(ctx context.Context, msg *contract.RideRequested) error
 }
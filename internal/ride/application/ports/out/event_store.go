@@ -0,0 +1,16 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/ride/domain"
+)
+
+// EventStore — интерфейс журнала событий поездки (event sourcing / аудит)
+type EventStore interface {
+	// Append добавляет событие (в транзакции вызывающего, если она открыта)
+	Append(ctx context.Context, event *domain.RideEvent) error
+
+	// ListByRideID возвращает все события поездки в хронологическом порядке
+	ListByRideID(ctx context.Context, rideID string) ([]*domain.RideEvent, error)
+}
@@ -0,0 +1,20 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/ride/domain"
+)
+
+// FareQuoteRepository — хранилище квот стоимости
+type FareQuoteRepository interface {
+	// Create сохраняет новую квоту
+	Create(ctx context.Context, quote *domain.FareQuote) error
+
+	// FindByID возвращает квоту. domain.ErrQuoteNotFound — такой нет.
+	FindByID(ctx context.Context, quoteID string) (*domain.FareQuote, error)
+
+	// MarkUsed привязывает квоту к поездке (в транзакции вызывающего, если она открыта).
+	// Conditional UPDATE: domain.ErrQuoteAlreadyUsed, если квота уже погашена.
+	MarkUsed(ctx context.Context, quoteID, rideID, vehicleType string, fare float64) error
+}
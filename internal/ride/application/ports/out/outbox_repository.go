@@ -0,0 +1,32 @@
+package out
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxMessage — сообщение, ожидающее отправки в RabbitMQ
+type OutboxMessage struct {
+	ID         string
+	EventType  string
+	RoutingKey string
+	Payload    []byte
+	RetryCount int
+	CreatedAt  time.Time
+}
+
+// OutboxRepository — интерфейс репозитория transactional outbox
+type OutboxRepository interface {
+	// Enqueue сохраняет сообщение в outbox (в транзакции вызывающего, если она открыта)
+	Enqueue(ctx context.Context, eventType, routingKey string, payload []byte) error
+
+	// ClaimBatch блокирует до limit готовых к отправке сообщений (FOR UPDATE SKIP LOCKED).
+	// Должен вызываться внутри TxManager.WithinTx — блокировки держатся до конца транзакции.
+	ClaimBatch(ctx context.Context, limit, maxRetries int) ([]OutboxMessage, error)
+
+	// MarkSent помечает сообщение как отправленное
+	MarkSent(ctx context.Context, id string) error
+
+	// MarkFailed помечает неудачную попытку и планирует следующую
+	MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr string) error
+}
@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/ride/domain"
+)
+
+// RatingRepository — оценки поездок и средние рейтинги участников.
+// Методы используют транзакцию вызывающего, если она открыта.
+type RatingRepository interface {
+	// Create сохраняет оценку. domain.ErrAlreadyRated — эта сторона уже оценила поездку.
+	Create(ctx context.Context, rating *domain.Rating) error
+
+	// ApplyDriverScore добавляет оценку к рейтингу водителя (drivers.rating)
+	// и возвращает новый средний рейтинг и число оценок
+	ApplyDriverScore(ctx context.Context, driverID string, score int) (float64, int, error)
+
+	// ApplyPassengerScore добавляет оценку к рейтингу пассажира (users.rating)
+	// и возвращает новый средний рейтинг и число оценок
+	ApplyPassengerScore(ctx context.Context, passengerID string, score int) (float64, int, error)
+}
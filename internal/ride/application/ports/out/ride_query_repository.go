@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/ride/domain"
+)
+
+// RideQueryRepository — чтение поездок для API (join с coordinates и drivers).
+// Отделен от RideRepository: write-путь работает с голой сущностью Ride.
+type RideQueryRepository interface {
+	// FindDetailsByID возвращает поездку по ID. domain.ErrRideNotFound — такой нет.
+	FindDetailsByID(ctx context.Context, rideID string) (*domain.RideDetails, error)
+
+	// FindDetailsByRideNumber возвращает поездку по номеру. domain.ErrRideNotFound — такой нет.
+	FindDetailsByRideNumber(ctx context.Context, rideNumber string) (*domain.RideDetails, error)
+
+	// ListByPassenger возвращает поездки пассажира, новые первыми,
+	// начиная строго после filter.After
+	ListByPassenger(ctx context.Context, filter domain.RideListFilter) ([]*domain.RideDetails, error)
+}
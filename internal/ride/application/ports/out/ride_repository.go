@@ -2,6 +2,7 @@ package out
 
 import (
 	"context"
+	"time"
 
 	"ridehail/internal/ride/domain"
 )
@@ -17,15 +18,31 @@ type RideRepository interface {
 	// FindByRideNumber возвращает поездку по номеру
 	FindByRideNumber(ctx context.Context, rideNumber string) (*domain.Ride, error)
 
-	// Update обновляет существующую поездку
-	Update(ctx context.Context, ride *domain.Ride) error
+	// Update обновляет поездку, только если ее статус в БД равен expectedStatus.
+	// Возвращает domain.ErrStatusConflict, если статус успел измениться.
+	Update(ctx context.Context, ride *domain.Ride, expectedStatus string) error
 
 	// FindActiveByPassengerID возвращает активные поездки пассажира
 	FindActiveByPassengerID(ctx context.Context, passengerID string) ([]*domain.Ride, error)
 
+	// FindActiveByDriverID возвращает поездки, которые водитель сейчас выполняет
+	// (MATCHED, EN_ROUTE, ARRIVED, IN_PROGRESS), в порядке назначения. У POOL их
+	// может быть несколько; пустой список — активных поездок нет.
+	FindActiveByDriverID(ctx context.Context, driverID string) ([]*domain.Ride, error)
+
 	// FindByStatus возвращает поездки с определенным статусом
 	FindByStatus(ctx context.Context, status string, limit int) ([]*domain.Ride, error)
 
-	// AssignDriver назначает водителя на поездку
+	// FindScheduledDue возвращает SCHEDULED поездки с scheduled_for <= before
+	FindScheduledDue(ctx context.Context, before time.Time, limit int) ([]*domain.Ride, error)
+
+	// FindScheduledForReminder возвращает SCHEDULED поездки с scheduled_for <= before без напоминания
+	FindScheduledForReminder(ctx context.Context, before time.Time, limit int) ([]*domain.Ride, error)
+
+	// MarkReminderSent отмечает напоминание; false — оно уже было отмечено
+	MarkReminderSent(ctx context.Context, rideID string, at time.Time) (bool, error)
+
+	// AssignDriver назначает водителя на поездку (REQUESTED → MATCHED).
+	// Возвращает domain.ErrStatusConflict, если поездка уже не в REQUESTED.
 	AssignDriver(ctx context.Context, rideID string, driverID string) error
 }
@@ -0,0 +1,20 @@
+package out
+
+import (
+	"context"
+
+	"ridehail/internal/ride/domain"
+)
+
+// RideStopRepository — промежуточные остановки поездки (ride_stops + coordinates).
+// Методы используют транзакцию вызывающего, если она открыта.
+type RideStopRepository interface {
+	// Create сохраняет остановку; координата остановки должна быть создана заранее
+	Create(ctx context.Context, stop *domain.RideStop) error
+
+	// ListByRideID возвращает остановки поездки по возрастанию stop_number
+	ListByRideID(ctx context.Context, rideID string) ([]*domain.RideStop, error)
+
+	// ListByRideIDs возвращает остановки нескольких поездок: ride_id → остановки по порядку
+	ListByRideIDs(ctx context.Context, rideIDs []string) (map[string][]*domain.RideStop, error)
+}
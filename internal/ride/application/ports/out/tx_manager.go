@@ -0,0 +1,11 @@
+package out
+
+import "context"
+
+// TxManager — интерфейс для выполнения нескольких операций репозиториев в одной транзакции.
+//
+// Репозитории, вызванные внутри fn с переданным ctx, автоматически используют
+// открытую транзакцию. Ошибка fn (или panic) откатывает транзакцию.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -1,25 +1,294 @@
 package usecase
 
-// import (
-// 	"ridehail/internal/ride/domain"
-// 	"ridehail/internal/shared/logger"
-// )
-
-// type Service struct {
-// 	repo   out.RideRepoInterface
-// 	logger *logger.Logger
-// }
-
-// type ServiceInterface interface {
-// 	CreateRide(passengerID string, req domain.RideRequest) (domain.Ride, error)
-// 	CancelRide(rideID string, userID string) error
-// }
-
-// func NewService(repo, logger *logger.Logger) *Service {
-// 	return &Service{repo: repo, logger: logger}
-// }
-
-// func (s *Service) CancelRide(rideID string, userID string) error {
-// 	// Логика отмены поездки
-// 	return nil
-// }
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/pricing"
+)
+
+// ============================================================================
+// БИЗНЕС-ЛОГИКА: Отмена поездки пассажиром или водителем
+// ============================================================================
+// Этот use case отвечает за:
+// 1. Проверку, что поездку отменяет ее пассажир или назначенный на нее водитель
+// 2. Проверку, что поездка находится в отменяемом статусе
+//    (заказ заранее отменяется и до передачи в подбор — из SCHEDULED)
+// 3. Применение политики отмены: бесплатно или штраф cancellation_fee тарифа.
+//    Отмена водителем для пассажира всегда бесплатна
+// 4. Сохранение cancelled_at / cancellation_reason / cancellation_fee
+// 5. Публикацию события ride.cancelled через outbox (в той же транзакции);
+//    Driver Service по этому событию освобождает водителя и начисляет ему долю штрафа
+// 6. Уведомление пассажира через WebSocket
+// ============================================================================
+
+// Причины по умолчанию, если отменяющий не указал свою
+const (
+	defaultCancellationReason       = "cancelled by passenger"
+	defaultDriverCancellationReason = "cancelled by driver"
+)
+
+// CancelRideService реализует CancelRideUseCase
+type CancelRideService struct {
+	txManager  out.TxManager
+	rideRepo   out.RideRepository
+	eventStore out.EventStore
+	publisher  out.EventPublisher
+	notifier   out.RideNotifier
+	pricing    *pricing.Engine
+	policy     domain.CancellationPolicy
+	log        *logger.Logger
+}
+
+// NewCancelRideService создает новый сервис отмены поездки
+func NewCancelRideService(
+	txManager out.TxManager,
+	rideRepo out.RideRepository,
+	eventStore out.EventStore,
+	publisher out.EventPublisher,
+	notifier out.RideNotifier,
+	pricingEngine *pricing.Engine,
+	schedule config.ScheduleConfig,
+	cancellation config.CancellationConfig,
+	log *logger.Logger,
+) *CancelRideService {
+	return &CancelRideService{
+		txManager:  txManager,
+		rideRepo:   rideRepo,
+		eventStore: eventStore,
+		publisher:  publisher,
+		notifier:   notifier,
+		pricing:    pricingEngine,
+		policy: domain.CancellationPolicy{
+			FreeWindow:          time.Duration(cancellation.FreeWindowSeconds) * time.Second,
+			EnRouteGrace:        time.Duration(cancellation.EnRouteGraceMinutes) * time.Minute,
+			ScheduledFreeWindow: time.Duration(schedule.FreeCancelMinutes) * time.Minute,
+		},
+		log: log,
+	}
+}
+
+// Execute выполняет отмену поездки.
+//
+// БИЗНЕС-ПРАВИЛА:
+//   - Пассажир отменяет только свою поездку, водитель — только назначенную ему
+//   - Допустимость отмены определяет state machine поездки (domain.Ride.Cancel)
+//   - Платность отмены пассажиром определяет domain.CancellationPolicy; сумма — cancellation_fee
+//     тарифа. Штраф не блокирует отмену: без тарифа отмена проходит бесплатно
+//   - Отмена водителем бесплатна для пассажира; неявку пассажира водитель фиксирует
+//     отдельно (Driver Service, POST /drivers/{id}/no-show)
+//   - Заказ заранее, отмененный позже free_cancel_minutes до подачи, помечается late_cancellation
+//   - Запись условная (WHERE status = previous) — конкурентный переход дает ErrStatusConflict
+//   - WebSocket уведомление отправляется после коммита, его ошибка не откатывает отмену
+func (s *CancelRideService) Execute(ctx context.Context, input in.CancelRideInput) (*in.CancelRideOutput, error) {
+	// ШАГ 1: Загружаем поездку
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if err != nil {
+		return nil, fmt.Errorf("find ride: %w", err)
+	}
+
+	// ШАГ 2: Проверяем, что отменяет сторона поездки
+	if !canCancelRide(ride, input.RequesterID, input.RequesterRole) {
+		s.log.Warn(logger.Entry{
+			Action:  "cancel_ride_forbidden",
+			Message: fmt.Sprintf("%s %s cannot cancel ride %s", input.RequesterRole, input.RequesterID, input.RideID),
+			RideID:  input.RideID,
+		})
+		return nil, domain.ErrForbidden
+	}
+	byDriver := input.RequesterRole == constants.RoleDriver
+	cancelledBy := constants.RolePassenger
+	if byDriver {
+		cancelledBy = constants.RoleDriver
+	}
+
+	reason := input.Reason
+	if reason == "" {
+		reason = defaultCancellationReason
+		if byDriver {
+			reason = defaultDriverCancellationReason
+		}
+	}
+
+	// ШАГ 3: Политика отмены — до смены статуса, решение зависит от текущего.
+	// Водитель отказался сам — пассажир не платит
+	previousStatus := ride.Status
+	now := time.Now().UTC()
+	decision := s.policy.Evaluate(ride, now)
+	if byDriver {
+		decision = domain.CancellationDecision{}
+	}
+
+	// Переход статуса через state machine (проставляет cancelled_at)
+	if err := ride.Cancel(reason, now); err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "cancel_ride_rejected",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Additional: map[string]any{
+				"status": previousStatus,
+			},
+		})
+		return nil, err
+	}
+
+	lateCancellation := decision.Late
+	fee := s.cancellationFee(ctx, ride, decision)
+	if fee > 0 {
+		ride.CancellationFee = &fee
+	}
+
+	// Событие ride.cancelled — Driver Service уведомит назначенного водителя
+	eventData := out.RideEventData{
+		RideID:      ride.ID,
+		PassengerID: ride.PassengerID,
+		DriverID:    ride.DriverID,
+		Status:      constants.RideStatusCancelled,
+		VehicleType: ride.VehicleType,
+		AdditionalData: map[string]interface{}{
+			"ride_number":     ride.RideNumber,
+			"previous_status": previousStatus,
+			"reason":          reason,
+			"cancelled_by":    cancelledBy,
+			"cancelled_at":    now.Format(time.RFC3339),
+		},
+	}
+	if ride.IsScheduled() {
+		eventData.AdditionalData["scheduled_for"] = ride.ScheduledFor.Format(time.RFC3339)
+		eventData.AdditionalData["late_cancellation"] = lateCancellation
+	}
+	if fee > 0 {
+		eventData.AdditionalData["cancellation_fee"] = fee
+		eventData.AdditionalData["fee_reason"] = decision.FeeReason
+	}
+
+	cancelledPayload := domain.RideEventPayload{
+		OldStatus:        previousStatus,
+		NewStatus:        constants.RideStatusCancelled,
+		OccurredAt:       now,
+		Reason:           reason,
+		LateCancellation: lateCancellation,
+		CancellationFee:  ride.CancellationFee,
+		FeeReason:        decision.FeeReason,
+	}
+	if byDriver {
+		cancelledPayload.DriverID = input.RequesterID
+	}
+	cancelledEvent := domain.NewRideEvent(ride.ID, constants.EventRideCancelled, cancelledPayload)
+
+	// ШАГ 4: Сохраняем отмену, штраф, событие аудита и outbox в одной транзакции
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.rideRepo.Update(ctx, ride, previousStatus); err != nil {
+			return fmt.Errorf("update ride: %w", err)
+		}
+		if err := s.eventStore.Append(ctx, cancelledEvent); err != nil {
+			return fmt.Errorf("append ride event: %w", err)
+		}
+		if err := s.publisher.PublishRideEvent(ctx, constants.EventRideCancelled, eventData); err != nil {
+			return fmt.Errorf("publish ride event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "cancel_ride_persist_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, err
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "ride_cancelled",
+		Message: ride.RideNumber,
+		RideID:  ride.ID,
+		Additional: map[string]any{
+			"passenger_id":    ride.PassengerID,
+			"cancelled_by":    cancelledBy,
+			"previous_status": previousStatus,
+			"reason":          reason,
+			"late":            lateCancellation,
+			"fee":             fee,
+			"fee_reason":      decision.FeeReason,
+		},
+	})
+
+	// ШАГ 5: Уведомляем пассажира (другие вкладки/устройства; при отмене водителем — его самого)
+	notification := out.RideNotification{
+		Type:    "ride_cancelled",
+		RideID:  ride.ID,
+		Message: "Your ride has been cancelled",
+		Data: map[string]interface{}{
+			"ride_number":  ride.RideNumber,
+			"status":       constants.RideStatusCancelled,
+			"reason":       reason,
+			"cancelled_by": cancelledBy,
+		},
+	}
+	if byDriver {
+		notification.Message = "Your driver has cancelled the ride"
+	}
+	if lateCancellation {
+		notification.Data["late_cancellation"] = true
+	}
+	if fee > 0 {
+		notification.Data["cancellation_fee"] = fee
+		notification.Data["fee_reason"] = decision.FeeReason
+	}
+
+	if err := s.notifier.NotifyPassenger(ctx, ride.PassengerID, notification); err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "notify_passenger_failed",
+			Message: err.Error(),
+			RideID:  ride.ID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+
+	return &in.CancelRideOutput{
+		RideID:           ride.ID,
+		Status:           constants.RideStatusCancelled,
+		CancelledAt:      now,
+		CancelledBy:      cancelledBy,
+		LateCancellation: lateCancellation,
+		CancellationFee:  fee,
+		FeeReason:        decision.FeeReason,
+		Message:          "Ride cancelled successfully",
+	}, nil
+}
+
+// canCancelRide проверяет, что отменяющий — назначенный на поездку водитель
+// или ее пассажир (заказать поездку может и ADMIN — он отменяет ее как пассажир)
+func canCancelRide(ride *domain.Ride, requesterID, requesterRole string) bool {
+	if requesterRole == constants.RoleDriver {
+		return ride.DriverID != nil && *ride.DriverID == requesterID
+	}
+	return ride.PassengerID == requesterID
+}
+
+// cancellationFee возвращает штраф по тарифу поездки, если политика требует оплаты
+func (s *CancelRideService) cancellationFee(ctx context.Context, ride *domain.Ride, decision domain.CancellationDecision) float64 {
+	if !decision.Chargeable {
+		return 0
+	}
+
+	fee, err := s.pricing.CancellationFee(ctx, ride.City, ride.VehicleType)
+	if err != nil {
+		s.log.Warn(logger.Entry{
+			Action:  "cancellation_fee_lookup_failed",
+			Message: err.Error(),
+			RideID:  ride.ID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return 0
+	}
+	return fee
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// GetRideService реализует GetRideUseCase
+type GetRideService struct {
+	queryRepo out.RideQueryRepository
+	log       *logger.Logger
+}
+
+// NewGetRideService создает новый сервис чтения поездки
+func NewGetRideService(queryRepo out.RideQueryRepository, log *logger.Logger) *GetRideService {
+	return &GetRideService{
+		queryRepo: queryRepo,
+		log:       log,
+	}
+}
+
+// Execute возвращает поездку по ID или номеру с проверкой доступа
+func (s *GetRideService) Execute(ctx context.Context, input in.GetRideInput) (*in.RideDTO, error) {
+	var (
+		details *domain.RideDetails
+		err     error
+	)
+
+	if input.RideID != "" {
+		details, err = s.queryRepo.FindDetailsByID(ctx, input.RideID)
+	} else {
+		details, err = s.queryRepo.FindDetailsByRideNumber(ctx, input.RideNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find ride: %w", err)
+	}
+
+	if !canViewRide(details.Ride, input.RequesterID, input.RequesterRole) {
+		s.log.Warn(logger.Entry{
+			Action:  "ride_access_denied",
+			Message: "requester is neither passenger nor driver of the ride",
+			RideID:  details.Ride.ID,
+			Additional: map[string]any{
+				"requester_id":   input.RequesterID,
+				"requester_role": input.RequesterRole,
+			},
+		})
+		return nil, domain.ErrForbidden
+	}
+
+	dto := toRideDTO(details)
+	return &dto, nil
+}
+
+// canViewRide — поездку видят ее пассажир, назначенный водитель и администраторы
+func canViewRide(ride *domain.Ride, requesterID, requesterRole string) bool {
+	switch {
+	case requesterRole == constants.RoleAdmin:
+		return true
+	case ride.PassengerID == requesterID:
+		return true
+	case ride.DriverID != nil && *ride.DriverID == requesterID:
+		return true
+	default:
+		return false
+	}
+}
+
+// toRideDTO маппит read model поездки в DTO ответа
+func toRideDTO(details *domain.RideDetails) in.RideDTO {
+	ride := details.Ride
+
+	dto := in.RideDTO{
+		RideID:             ride.ID,
+		RideNumber:         ride.RideNumber,
+		Status:             ride.Status,
+		VehicleType:        ride.VehicleType,
+		EstimatedFare:      ride.EstimatedFare,
+		FinalFare:          ride.FinalFare,
+		FareBreakdown:      details.Fare,
+		SurgeMultiplier:    ride.SurgeMultiplier,
+		CancellationReason: ride.CancellationReason,
+		CancellationFee:    ride.CancellationFee,
+		RequestedAt:        ride.RequestedAt,
+		ScheduledFor:       ride.ScheduledFor,
+		MatchedAt:          ride.MatchedAt,
+		ArrivedAt:          ride.ArrivedAt,
+		StartedAt:          ride.StartedAt,
+		CompletedAt:        ride.CompletedAt,
+		CancelledAt:        ride.CancelledAt,
+	}
+
+	if details.Pickup != nil {
+		dto.PickupLocation = &in.RideLocationDTO{
+			Address: details.Pickup.Address,
+			Lat:     details.Pickup.Latitude,
+			Lng:     details.Pickup.Longitude,
+		}
+	}
+
+	if details.Destination != nil {
+		dto.DestinationLocation = &in.RideLocationDTO{
+			Address: details.Destination.Address,
+			Lat:     details.Destination.Latitude,
+			Lng:     details.Destination.Longitude,
+		}
+		dto.DistanceKm = details.Destination.DistanceKm
+		dto.DurationMinutes = details.Destination.DurationMinutes
+	}
+
+	for _, stop := range details.Stops {
+		dto.Stops = append(dto.Stops, in.RideStopDTO{
+			StopNumber: stop.StopNumber,
+			Address:    stop.Address,
+			Lat:        stop.Latitude,
+			Lng:        stop.Longitude,
+			ArrivedAt:  stop.ArrivedAt,
+		})
+	}
+
+	if details.Driver != nil {
+		dto.Driver = &in.RideDriverDTO{
+			DriverID:     details.Driver.ID,
+			VehicleType:  details.Driver.VehicleType,
+			VehicleAttrs: details.Driver.VehicleAttrs,
+			Rating:       details.Driver.Rating,
+		}
+	}
+
+	return dto
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// GetRideEventsService реализует GetRideEventsUseCase
+type GetRideEventsService struct {
+	rideRepo   out.RideRepository
+	eventStore out.EventStore
+	log        *logger.Logger
+}
+
+// NewGetRideEventsService создает новый сервис чтения хронологии поездки
+func NewGetRideEventsService(
+	rideRepo out.RideRepository,
+	eventStore out.EventStore,
+	log *logger.Logger,
+) *GetRideEventsService {
+	return &GetRideEventsService{
+		rideRepo:   rideRepo,
+		eventStore: eventStore,
+		log:        log,
+	}
+}
+
+// Execute возвращает события поездки в хронологическом порядке
+func (s *GetRideEventsService) Execute(ctx context.Context, input in.GetRideEventsInput) (*in.GetRideEventsOutput, error) {
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if err != nil {
+		return nil, fmt.Errorf("find ride: %w", err)
+	}
+
+	// Пассажир видит только свои поездки, администратор — любые
+	if input.RequesterRole != constants.RoleAdmin && ride.PassengerID != input.RequesterID {
+		return nil, domain.ErrForbidden
+	}
+
+	events, err := s.eventStore.ListByRideID(ctx, input.RideID)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "list_ride_events_failed",
+			Message: err.Error(),
+			RideID:  input.RideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, fmt.Errorf("list ride events: %w", err)
+	}
+
+	output := &in.GetRideEventsOutput{
+		RideID: input.RideID,
+		Events: make([]in.RideEventDTO, 0, len(events)),
+	}
+
+	for _, e := range events {
+		data, err := json.Marshal(e.EventData)
+		if err != nil {
+			return nil, fmt.Errorf("marshal event data: %w", err)
+		}
+		output.Events = append(output.Events, in.RideEventDTO{
+			ID:        e.ID,
+			EventType: e.EventType,
+			EventData: data,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
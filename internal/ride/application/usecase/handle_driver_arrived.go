@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// HandleDriverArrivedService реализует HandleDriverArrivedUseCase
+type HandleDriverArrivedService struct {
+	rideRepo out.RideRepository
+	notifier out.RideNotifier
+	log      *logger.Logger
+}
+
+// NewHandleDriverArrivedService создает новый сервис прибытия водителя
+func NewHandleDriverArrivedService(
+	rideRepo out.RideRepository,
+	notifier out.RideNotifier,
+	log *logger.Logger,
+) *HandleDriverArrivedService {
+	return &HandleDriverArrivedService{
+		rideRepo: rideRepo,
+		notifier: notifier,
+		log:      log,
+	}
+}
+
+// Execute уведомляет пассажира о прибытии водителя
+func (s *HandleDriverArrivedService) Execute(ctx context.Context, input in.HandleDriverArrivedInput) error {
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if errors.Is(err, domain.ErrRideNotFound) {
+		s.log.Warn(logger.Entry{
+			Action:  "driver_arrived_ride_not_found",
+			Message: input.DriverID,
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find ride: %w", err)
+	}
+
+	// Сообщение устарело: поездка уже началась или отменена
+	if ride.Status != constants.RideStatusArrived {
+		s.log.Debug(logger.Entry{
+			Action:  "driver_arrived_ignored",
+			Message: fmt.Sprintf("ride status %s", ride.Status),
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+
+	notification := out.RideNotification{
+		Type:    "driver_arrived",
+		RideID:  ride.ID,
+		Message: "Your driver has arrived",
+		Data: map[string]interface{}{
+			"ride_number":          ride.RideNumber,
+			"status":               ride.Status,
+			"driver_id":            input.DriverID,
+			"location":             map[string]float64{"lat": input.Lat, "lng": input.Lng},
+			"arrived_at":           input.ArrivedAt,
+			"free_waiting_minutes": input.FreeWaitingMinutes,
+		},
+	}
+
+	// Пассажир может быть оффлайн — это не ошибка обработки сообщения
+	_ = s.notifier.NotifyPassenger(ctx, ride.PassengerID, notification)
+
+	s.log.Info(logger.Entry{
+		Action:  "passenger_notified_driver_arrived",
+		Message: ride.RideNumber,
+		RideID:  ride.ID,
+		Additional: map[string]any{
+			"driver_id":                 input.DriverID,
+			"distance_to_pickup_meters": input.DistanceToPickupMeters,
+		},
+	})
+
+	return nil
+}
@@ -3,9 +3,12 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"ridehail/internal/ride/application/ports/in"
 	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
 	"ridehail/internal/shared/logger"
 )
 
@@ -16,7 +19,9 @@ import (
 // 1. Получение ответа водителя (принял/отклонил) через RabbitMQ
 // 2. Валидацию статуса поездки (должна быть REQUESTED)
 // 3. Назначение водителя на поездку в базе данных
-// 4. Уведомление пассажира об успешном назначении
+// 4. Переводу поездки в EN_ROUTE — водитель сразу едет к точке подачи
+// 5. Публикации ride.matched (Driver Service переводит водителя в EN_ROUTE)
+// 6. Уведомлению пассажира об успешном назначении
 //
 // Архитектурный паттерн: Clean Architecture
 // - Не зависит от деталей реализации БД или транспорта
@@ -26,11 +31,17 @@ import (
 // HandleDriverResponseService реализует бизнес-логику обработки ответа водителя.
 //
 // Зависимости:
+//   - txManager: атомарность назначения водителя и записи события
 //   - rideRepo: доступ к данным поездок (чтение, обновление статуса)
+//   - eventStore: журнал событий поездки (DRIVER_MATCHED, STATUS_CHANGED)
+//   - publisher: ride.matched через outbox в той же транзакции
 //   - log: структурированное логирование для отладки и мониторинга
 type HandleDriverResponseService struct {
-	rideRepo out.RideRepository // Интерфейс для работы с БД (абстракция)
-	log      *logger.Logger     // Логгер для трейсинга операций
+	txManager  out.TxManager      // Транзакции поверх репозиториев
+	rideRepo   out.RideRepository // Интерфейс для работы с БД (абстракция)
+	eventStore out.EventStore     // Журнал событий поездки
+	publisher  out.EventPublisher // Публикация ride.matched
+	log        *logger.Logger     // Логгер для трейсинга операций
 }
 
 // NewHandleDriverResponseService — фабрика для создания сервиса.
@@ -38,12 +49,18 @@ type HandleDriverResponseService struct {
 // Dependency Injection: все зависимости передаются извне, что упрощает
 // тестирование и позволяет легко заменять реализации.
 func NewHandleDriverResponseService(
+	txManager out.TxManager,
 	rideRepo out.RideRepository,
+	eventStore out.EventStore,
+	publisher out.EventPublisher,
 	log *logger.Logger,
 ) *HandleDriverResponseService {
 	return &HandleDriverResponseService{
-		rideRepo: rideRepo,
-		log:      log,
+		txManager:  txManager,
+		rideRepo:   rideRepo,
+		eventStore: eventStore,
+		publisher:  publisher,
+		log:        log,
 	}
 }
 
@@ -56,9 +73,11 @@ func NewHandleDriverResponseService(
 // 4. WebSocket → RabbitMQ → этот метод
 //
 // БИЗНЕС-ПРАВИЛА:
-// - Поездка должна быть в статусе REQUESTED (не взята другим водителем)
-// - Если водитель отклонил — отправляем оффер следующему водителю (TODO)
-// - Если принял — атомарно обновляем статус и driver_id в БД
+//   - Поездка должна быть в статусе REQUESTED (не взята другим водителем)
+//   - Если водитель отклонил — следующий оффер отправит координатор подбора в Driver Service
+//   - Если принял — атомарно обновляем статус и driver_id в БД
+//   - Подтвержденное назначение сразу переводит поездку MATCHED → EN_ROUTE:
+//     отдельного шага "выехал" у водителя нет
 //
 // ВОЗВРАЩАЕМОЕ ЗНАЧЕНИЕ:
 // - Output содержит passenger_id для отправки уведомления через WebSocket
@@ -86,15 +105,12 @@ func (s *HandleDriverResponseService) Execute(
 			RideID:  input.RideID,
 		})
 
-		// TODO (Будущая реализация):
-		// 1. Получить список других доступных водителей поблизости
-		// 2. Отправить оффер следующему водителю из очереди
-		// 3. Если водителей нет — отменить поездку или увеличить радиус поиска
-		//
-		// Пока просто возвращаем статус REQUESTED (поездка ждет водителя)
+		// Повторные офферы, расширение радиуса и отмену по крайнему сроку
+		// ведет координатор подбора в Driver Service (этапы приходят в
+		// driver.matching.{ride_id}). Поездка продолжает ждать водителя.
 		return &in.HandleDriverResponseOutput{
 			RideID:         input.RideID,
-			Status:         "REQUESTED",
+			Status:         constants.RideStatusRequested,
 			DriverAssigned: false,
 		}, nil
 	}
@@ -114,23 +130,78 @@ func (s *HandleDriverResponseService) Execute(
 		return nil, fmt.Errorf("find ride: %w", err)
 	}
 
-	// ШАГ 2: Валидация бизнес-правил
+	// ШАГ 2: Валидация бизнес-правил через state machine
 	// КРИТИЧНО: проверяем, что поездка еще не взята другим водителем
 	// (race condition может произойти если несколько водителей одновременно приняли)
-	if ride.Status != "REQUESTED" {
+	previousStatus := ride.Status
+	if err := ride.AssignDriver(input.DriverID, time.Now().UTC()); err != nil {
 		s.log.Warn(logger.Entry{
 			Action:  "ride_not_in_requested_status",
-			Message: fmt.Sprintf("ride %s has status %s, expected REQUESTED", input.RideID, ride.Status),
+			Message: err.Error(),
 			RideID:  input.RideID,
 		})
-		return nil, fmt.Errorf("ride is not in REQUESTED status (current: %s)", ride.Status)
+		return nil, fmt.Errorf("assign driver: %w", err)
+	}
+
+	matchedAt := *ride.MatchedAt
+	if err := ride.TransitionTo(constants.RideStatusEnRoute, matchedAt); err != nil {
+		return nil, fmt.Errorf("mark en route: %w", err)
 	}
 
 	// ШАГ 3: Атомарное назначение водителя в БД
 	// SQL: UPDATE rides SET driver_id=$1, status='MATCHED', matched_at=NOW()
 	//      WHERE id=$2 AND status='REQUESTED'
-	// WHERE status='REQUESTED' защищает от race condition
-	if err := s.rideRepo.AssignDriver(ctx, input.RideID, input.DriverID); err != nil {
+	// WHERE status='REQUESTED' защищает от race condition.
+	// В той же транзакции: MATCHED → EN_ROUTE, события DRIVER_MATCHED и
+	// STATUS_CHANGED, ride.matched в outbox.
+	matchedEvent := domain.NewRideEvent(input.RideID, constants.EventDriverMatched, domain.RideEventPayload{
+		OldStatus:  previousStatus,
+		NewStatus:  constants.RideStatusMatched,
+		OccurredAt: matchedAt,
+		DriverID:   input.DriverID,
+		Location:   &domain.EventLocation{Lat: input.DriverLocationLat, Lng: input.DriverLocationLng},
+	})
+	enRouteEvent := domain.NewRideEvent(input.RideID, constants.EventStatusChanged, domain.RideEventPayload{
+		OldStatus:  constants.RideStatusMatched,
+		NewStatus:  constants.RideStatusEnRoute,
+		OccurredAt: matchedAt,
+		DriverID:   input.DriverID,
+	})
+
+	// Событие ride.matched — Driver Service переведет водителя в EN_ROUTE
+	eventData := out.RideEventData{
+		RideID:      ride.ID,
+		PassengerID: ride.PassengerID,
+		DriverID:    ride.DriverID,
+		Status:      constants.RideStatusEnRoute,
+		VehicleType: ride.VehicleType,
+		AdditionalData: map[string]interface{}{
+			"ride_number":     ride.RideNumber,
+			"matched_at":      matchedAt.Format(time.RFC3339),
+			"eta_minutes":     input.EstimatedArrivalMinutes,
+			"driver_location": map[string]float64{"lat": input.DriverLocationLat, "lng": input.DriverLocationLng},
+		},
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.rideRepo.AssignDriver(ctx, input.RideID, input.DriverID); err != nil {
+			return err
+		}
+		if err := s.rideRepo.Update(ctx, ride, constants.RideStatusMatched); err != nil {
+			return fmt.Errorf("update ride: %w", err)
+		}
+		if err := s.eventStore.Append(ctx, matchedEvent); err != nil {
+			return fmt.Errorf("append ride event: %w", err)
+		}
+		if err := s.eventStore.Append(ctx, enRouteEvent); err != nil {
+			return fmt.Errorf("append ride event: %w", err)
+		}
+		if err := s.publisher.PublishRideEvent(ctx, constants.EventDriverMatched, eventData); err != nil {
+			return fmt.Errorf("publish ride event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		s.log.Error(logger.Entry{
 			Action:  "assign_driver_failed",
 			Message: err.Error(),
@@ -156,7 +227,7 @@ func (s *HandleDriverResponseService) Execute(
 	// "Водитель найден! Прибудет через X минут"
 	return &in.HandleDriverResponseOutput{
 		RideID:         input.RideID,
-		Status:         "DRIVER_ASSIGNED", // Фронтенд показывает "Водитель едет к вам"
+		Status:         ride.Status, // EN_ROUTE — фронтенд показывает "Водитель едет к вам"
 		DriverAssigned: true,
 		PassengerID:    ride.PassengerID, // Кому отправить уведомление
 	}, nil
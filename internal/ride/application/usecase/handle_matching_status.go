@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// ============================================================================
+// БИЗНЕС-ЛОГИКА: Этапы подбора водителя
+// ============================================================================
+// Driver Service ведет раунды офферов и сообщает о каждом этапе.
+// Этот use case отвечает за:
+// 1. Уведомление пассажира о ходе поиска (WebSocket)
+// 2. Отмену поездки, если водитель не найден до крайнего срока
+// ============================================================================
+
+// matchingMessages — тексты для пассажира по этапам подбора
+var matchingMessages = map[string]string{
+	constants.MatchingStatusSearching:      "Looking for a driver nearby",
+	constants.MatchingStatusOffersSent:     "Ride offered to nearby drivers",
+	constants.MatchingStatusRetrying:       "Still looking for a driver",
+	constants.MatchingStatusRadiusExpanded: "Expanding search area",
+}
+
+// HandleMatchingStatusService реализует HandleMatchingStatusUseCase
+type HandleMatchingStatusService struct {
+	txManager  out.TxManager
+	rideRepo   out.RideRepository
+	eventStore out.EventStore
+	publisher  out.EventPublisher
+	notifier   out.RideNotifier
+	log        *logger.Logger
+}
+
+// NewHandleMatchingStatusService создает новый сервис этапов подбора
+func NewHandleMatchingStatusService(
+	txManager out.TxManager,
+	rideRepo out.RideRepository,
+	eventStore out.EventStore,
+	publisher out.EventPublisher,
+	notifier out.RideNotifier,
+	log *logger.Logger,
+) *HandleMatchingStatusService {
+	return &HandleMatchingStatusService{
+		txManager:  txManager,
+		rideRepo:   rideRepo,
+		eventStore: eventStore,
+		publisher:  publisher,
+		notifier:   notifier,
+		log:        log,
+	}
+}
+
+// Execute обрабатывает этап подбора водителя
+func (s *HandleMatchingStatusService) Execute(ctx context.Context, input in.HandleMatchingStatusInput) error {
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if errors.Is(err, domain.ErrRideNotFound) {
+		s.log.Warn(logger.Entry{
+			Action:  "matching_status_ride_not_found",
+			Message: input.Status,
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find ride: %w", err)
+	}
+
+	// Этап устарел: водитель уже назначен или поездка отменена
+	if ride.Status != constants.RideStatusRequested {
+		s.log.Debug(logger.Entry{
+			Action:  "matching_status_ignored",
+			Message: fmt.Sprintf("ride status %s, matching status %s", ride.Status, input.Status),
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+
+	if input.Status == constants.MatchingStatusNoDrivers {
+		return s.cancelNoDrivers(ctx, ride)
+	}
+
+	message, ok := matchingMessages[input.Status]
+	if !ok {
+		s.log.Warn(logger.Entry{
+			Action:  "matching_status_unknown",
+			Message: input.Status,
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+
+	notification := out.RideNotification{
+		Type:    "ride_matching_status",
+		RideID:  ride.ID,
+		Message: message,
+		Data: map[string]interface{}{
+			"ride_number":     ride.RideNumber,
+			"matching_status": input.Status,
+			"round":           input.Round,
+			"radius_km":       input.RadiusKm,
+			"offers_sent":     input.OffersSent,
+			"reason":          input.Reason,
+		},
+	}
+
+	// Пассажир может быть оффлайн — это не ошибка обработки этапа
+	_ = s.notifier.NotifyPassenger(ctx, ride.PassengerID, notification)
+
+	return nil
+}
+
+// cancelNoDrivers отменяет поездку, для которой не нашлось водителя
+func (s *HandleMatchingStatusService) cancelNoDrivers(ctx context.Context, ride *domain.Ride) error {
+	reason := constants.CancellationReasonNoDrivers
+	previousStatus := ride.Status
+	now := time.Now().UTC()
+
+	if err := ride.Cancel(reason, now); err != nil {
+		return fmt.Errorf("cancel ride: %w", err)
+	}
+
+	eventData := out.RideEventData{
+		RideID:      ride.ID,
+		PassengerID: ride.PassengerID,
+		DriverID:    ride.DriverID,
+		Status:      constants.RideStatusCancelled,
+		VehicleType: ride.VehicleType,
+		AdditionalData: map[string]interface{}{
+			"ride_number":     ride.RideNumber,
+			"previous_status": previousStatus,
+			"reason":          reason,
+			"cancelled_at":    now.Format(time.RFC3339),
+		},
+	}
+
+	cancelledEvent := domain.NewRideEvent(ride.ID, constants.EventRideCancelled, domain.RideEventPayload{
+		OldStatus:  previousStatus,
+		NewStatus:  constants.RideStatusCancelled,
+		OccurredAt: now,
+		Reason:     reason,
+	})
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.rideRepo.Update(ctx, ride, previousStatus); err != nil {
+			return fmt.Errorf("update ride: %w", err)
+		}
+		if err := s.eventStore.Append(ctx, cancelledEvent); err != nil {
+			return fmt.Errorf("append ride event: %w", err)
+		}
+		if err := s.publisher.PublishRideEvent(ctx, constants.EventRideCancelled, eventData); err != nil {
+			return fmt.Errorf("publish ride event: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrStatusConflict) {
+		// Водитель принял оффер одновременно с истечением срока — отмена не нужна
+		s.log.Info(logger.Entry{
+			Action:  "no_drivers_cancel_skipped",
+			Message: err.Error(),
+			RideID:  ride.ID,
+		})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "ride_cancelled_no_drivers",
+		Message: ride.RideNumber,
+		RideID:  ride.ID,
+		Additional: map[string]any{
+			"passenger_id": ride.PassengerID,
+		},
+	})
+
+	notification := out.RideNotification{
+		Type:    "ride_cancelled",
+		RideID:  ride.ID,
+		Message: "No drivers available, your ride has been cancelled",
+		Data: map[string]interface{}{
+			"ride_number": ride.RideNumber,
+			"status":      constants.RideStatusCancelled,
+			"reason":      reason,
+		},
+	}
+
+	// Отмена уже сохранена — ошибка доставки уведомления не критична
+	_ = s.notifier.NotifyPassenger(ctx, ride.PassengerID, notification)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// HandlePassengerNoShowService реализует HandlePassengerNoShowUseCase
+type HandlePassengerNoShowService struct {
+	rideRepo out.RideRepository
+	notifier out.RideNotifier
+	log      *logger.Logger
+}
+
+// NewHandlePassengerNoShowService создает новый сервис неявки пассажира
+func NewHandlePassengerNoShowService(
+	rideRepo out.RideRepository,
+	notifier out.RideNotifier,
+	log *logger.Logger,
+) *HandlePassengerNoShowService {
+	return &HandlePassengerNoShowService{
+		rideRepo: rideRepo,
+		notifier: notifier,
+		log:      log,
+	}
+}
+
+// Execute уведомляет пассажира об отмене поездки за неявку и о штрафе
+func (s *HandlePassengerNoShowService) Execute(ctx context.Context, input in.HandlePassengerNoShowInput) error {
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if errors.Is(err, domain.ErrRideNotFound) {
+		s.log.Warn(logger.Entry{
+			Action:  "passenger_no_show_ride_not_found",
+			Message: input.DriverID,
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find ride: %w", err)
+	}
+
+	if ride.Status != constants.RideStatusCancelled {
+		s.log.Warn(logger.Entry{
+			Action:  "passenger_no_show_ignored",
+			Message: fmt.Sprintf("ride status %s", ride.Status),
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+
+	notification := out.RideNotification{
+		Type:    "ride_cancelled",
+		RideID:  ride.ID,
+		Message: "Your ride was cancelled: the driver waited but you did not show up",
+		Data: map[string]interface{}{
+			"ride_number":      ride.RideNumber,
+			"status":           ride.Status,
+			"reason":           constants.CancellationReasonNoShow,
+			"driver_id":        input.DriverID,
+			"waited_minutes":   input.WaitedMinutes,
+			"cancellation_fee": input.CancellationFee,
+			"fee_reason":       constants.FeeReasonNoShow,
+			"cancelled_at":     input.CancelledAt,
+		},
+	}
+
+	// Пассажир может быть оффлайн — это не ошибка обработки сообщения
+	_ = s.notifier.NotifyPassenger(ctx, ride.PassengerID, notification)
+
+	s.log.Info(logger.Entry{
+		Action:  "passenger_notified_no_show",
+		Message: ride.RideNumber,
+		RideID:  ride.ID,
+		Additional: map[string]any{
+			"driver_id":        input.DriverID,
+			"waited_minutes":   input.WaitedMinutes,
+			"cancellation_fee": input.CancellationFee,
+		},
+	})
+
+	return nil
+}
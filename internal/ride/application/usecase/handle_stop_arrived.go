@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// HandleStopArrivedService реализует HandleStopArrivedUseCase
+type HandleStopArrivedService struct {
+	rideRepo out.RideRepository
+	notifier out.RideNotifier
+	log      *logger.Logger
+}
+
+// NewHandleStopArrivedService создает новый сервис прибытия на остановку
+func NewHandleStopArrivedService(
+	rideRepo out.RideRepository,
+	notifier out.RideNotifier,
+	log *logger.Logger,
+) *HandleStopArrivedService {
+	return &HandleStopArrivedService{
+		rideRepo: rideRepo,
+		notifier: notifier,
+		log:      log,
+	}
+}
+
+// Execute уведомляет пассажира о прибытии на промежуточную остановку
+func (s *HandleStopArrivedService) Execute(ctx context.Context, input in.HandleStopArrivedInput) error {
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if errors.Is(err, domain.ErrRideNotFound) {
+		s.log.Warn(logger.Entry{
+			Action:  "stop_arrived_ride_not_found",
+			Message: input.DriverID,
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find ride: %w", err)
+	}
+
+	// Остановки бывают только в пути; после завершения уведомление уже не нужно
+	if ride.Status != constants.RideStatusInProgress {
+		s.log.Debug(logger.Entry{
+			Action:  "stop_arrived_ignored",
+			Message: fmt.Sprintf("ride status %s", ride.Status),
+			RideID:  input.RideID,
+		})
+		return nil
+	}
+
+	notification := out.RideNotification{
+		Type:    "stop_arrived",
+		RideID:  ride.ID,
+		Message: fmt.Sprintf("Arrived at stop %d of %d", input.StopNumber, input.TotalStops),
+		Data: map[string]interface{}{
+			"ride_number": ride.RideNumber,
+			"status":      ride.Status,
+			"driver_id":   input.DriverID,
+			"stop_number": input.StopNumber,
+			"total_stops": input.TotalStops,
+			"address":     input.Address,
+			"location":    map[string]float64{"lat": input.Lat, "lng": input.Lng},
+			"arrived_at":  input.ArrivedAt,
+		},
+	}
+
+	// Пассажир может быть оффлайн — это не ошибка обработки сообщения
+	_ = s.notifier.NotifyPassenger(ctx, ride.PassengerID, notification)
+
+	s.log.Info(logger.Entry{
+		Action:  "passenger_notified_stop_arrived",
+		Message: ride.RideNumber,
+		RideID:  ride.ID,
+		Additional: map[string]any{
+			"driver_id":   input.DriverID,
+			"stop_number": input.StopNumber,
+			"total_stops": input.TotalStops,
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRidesPageSize = 20
+	maxRidesPageSize     = 100
+)
+
+// ListRidesService реализует ListRidesUseCase
+type ListRidesService struct {
+	queryRepo out.RideQueryRepository
+	log       *logger.Logger
+}
+
+// NewListRidesService создает новый сервис истории поездок
+func NewListRidesService(queryRepo out.RideQueryRepository, log *logger.Logger) *ListRidesService {
+	return &ListRidesService{
+		queryRepo: queryRepo,
+		log:       log,
+	}
+}
+
+// Execute возвращает страницу поездок пассажира, новые первыми
+func (s *ListRidesService) Execute(ctx context.Context, input in.ListRidesInput) (*in.ListRidesOutput, error) {
+	if input.Status != "" && !domain.IsValidStatus(input.Status) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, input.Status)
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultRidesPageSize
+	}
+	if limit > maxRidesPageSize {
+		limit = maxRidesPageSize
+	}
+
+	filter := domain.RideListFilter{
+		PassengerID: input.PassengerID,
+		Status:      input.Status,
+		From:        input.From,
+		To:          input.To,
+		Limit:       limit + 1, // +1 — чтобы понять, есть ли следующая страница
+	}
+
+	if input.Cursor != "" {
+		cursor, err := decodeRideCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	rides, err := s.queryRepo.ListByPassenger(ctx, filter)
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "list_passenger_rides_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"passenger_id": input.PassengerID,
+			},
+		})
+		return nil, fmt.Errorf("list passenger rides: %w", err)
+	}
+
+	output := &in.ListRidesOutput{Rides: make([]in.RideDTO, 0, limit)}
+
+	if len(rides) > limit {
+		rides = rides[:limit]
+		last := rides[limit-1].Ride
+		output.NextCursor = encodeRideCursor(domain.RideCursor{RequestedAt: last.RequestedAt, ID: last.ID})
+	}
+
+	for _, details := range rides {
+		output.Rides = append(output.Rides, toRideDTO(details))
+	}
+
+	return output, nil
+}
+
+// encodeRideCursor кодирует позицию как base64url("<requested_at RFC3339Nano>|<id>").
+// Курсор непрозрачен для клиента: формат может меняться.
+func encodeRideCursor(c domain.RideCursor) string {
+	raw := c.RequestedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeRideCursor разбирает курсор encodeRideCursor
+func decodeRideCursor(s string) (*domain.RideCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	requestedAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &domain.RideCursor{RequestedAt: requestedAt, ID: id}, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"ridehail/internal/ride/domain"
+)
+
+func TestRideCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor domain.RideCursor
+	}{
+		{
+			name:   "utc",
+			cursor: domain.RideCursor{RequestedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), ID: "3f1c2b4e-8d7a-4c5b-9e6f-1a2b3c4d5e6f"},
+		},
+		{
+			name:   "nanoseconds are kept",
+			cursor: domain.RideCursor{RequestedAt: time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC), ID: "3f1c2b4e-8d7a-4c5b-9e6f-1a2b3c4d5e6f"},
+		},
+		{
+			name:   "other time zone",
+			cursor: domain.RideCursor{RequestedAt: time.Date(2026, 3, 1, 18, 0, 0, 0, time.FixedZone("ALMT", 6*3600)), ID: "3f1c2b4e-8d7a-4c5b-9e6f-1a2b3c4d5e6f"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decodeRideCursor(encodeRideCursor(tc.cursor))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !got.RequestedAt.Equal(tc.cursor.RequestedAt) || got.ID != tc.cursor.ID {
+				t.Errorf("round trip = %+v, want %+v", *got, tc.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeRideCursorInvalid(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!!"},
+		{name: "no separator", cursor: encode("2026-03-01T12:00:00Z")},
+		{name: "bad time", cursor: encode("yesterday|3f1c2b4e-8d7a-4c5b-9e6f-1a2b3c4d5e6f")},
+		{name: "bad id", cursor: encode("2026-03-01T12:00:00Z|ride-1")},
+		{name: "empty", cursor: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := decodeRideCursor(tc.cursor); !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Errorf("err = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/pricing"
+
+	"github.com/google/uuid"
+)
+
+// quotedVehicleTypes — типы авто, для которых считается квота (порядок ответа)
+var quotedVehicleTypes = []string{
+	constants.VehicleEconomy,
+	constants.VehiclePremium,
+	constants.VehicleXL,
+	constants.VehiclePool,
+}
+
+// QuoteFareService реализует QuoteFareUseCase
+type QuoteFareService struct {
+	quoteRepo out.FareQuoteRepository
+	pricing   *pricing.Engine
+	cfg       config.QuoteConfig
+	log       *logger.Logger
+}
+
+// NewQuoteFareService создает сервис квот стоимости
+func NewQuoteFareService(
+	quoteRepo out.FareQuoteRepository,
+	pricingEngine *pricing.Engine,
+	cfg config.QuoteConfig,
+	log *logger.Logger,
+) *QuoteFareService {
+	return &QuoteFareService{
+		quoteRepo: quoteRepo,
+		pricing:   pricingEngine,
+		cfg:       cfg,
+		log:       log,
+	}
+}
+
+// Execute считает цены по всем типам авто и сохраняет подписанную квоту
+func (s *QuoteFareService) Execute(ctx context.Context, input in.QuoteFareInput) (*in.QuoteFareOutput, error) {
+	if err := domain.ValidateCoordinates(input.PickupLat, input.PickupLng); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateCoordinates(input.DestLat, input.DestLng); err != nil {
+		return nil, err
+	}
+
+	// Значения округляются до точности колонок fare_quotes,
+	// иначе подпись не совпадет после чтения из БД
+	pickupLat, pickupLng := roundTo(input.PickupLat, 6), roundTo(input.PickupLng, 6)
+	destLat, destLng := roundTo(input.DestLat, 6), roundTo(input.DestLng, 6)
+	distance := roundTo(calculateDistance(pickupLat, pickupLng, destLat, destLng), 2)
+
+	duration := s.pricing.EstimateDurationMinutes(distance)
+	surge := s.pricing.Surge(ctx, pickupLat, pickupLng)
+	city := s.pricing.City(ctx, pickupLat, pickupLng)
+
+	estimates := make(map[string]float64, len(quotedVehicleTypes))
+	for _, vt := range quotedVehicleTypes {
+		fare, err := s.pricing.Quote(ctx, pricing.Trip{
+			City:            city,
+			VehicleType:     vt,
+			DistanceKm:      distance,
+			DurationMinutes: duration,
+			SurgeMultiplier: surge.Multiplier,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("estimate fare: %w", err)
+		}
+		estimates[vt] = fare.Total
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	quote := &domain.FareQuote{
+		ID:                 uuid.New().String(),
+		PassengerID:        input.PassengerID,
+		PickupLat:          pickupLat,
+		PickupLng:          pickupLng,
+		PickupAddress:      input.PickupAddress,
+		DestinationLat:     destLat,
+		DestinationLng:     destLng,
+		DestinationAddress: input.DestAddress,
+		DistanceKm:         distance,
+		DurationMinutes:    duration,
+		Estimates:          estimates,
+		SurgeMultiplier:    surge.Multiplier,
+		ExpiresAt:          now.Add(time.Duration(s.cfg.TTLSeconds) * time.Second),
+		CreatedAt:          now,
+	}
+	quote.Signature = signQuote(s.cfg.SigningSecret, quote)
+
+	if err := s.quoteRepo.Create(ctx, quote); err != nil {
+		return nil, fmt.Errorf("create fare quote: %w", err)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "fare_quote_created",
+		Message: quote.ID,
+		Additional: map[string]any{
+			"passenger_id": input.PassengerID,
+			"city":         city,
+			"distance_km":  distance,
+			"estimates":    estimates,
+			"surge_cell":   surge.Cell,
+			"surge":        surge.Multiplier,
+		},
+	})
+
+	output := &in.QuoteFareOutput{
+		QuoteID:         quote.ID,
+		Estimates:       make([]in.FareEstimateDTO, 0, len(quotedVehicleTypes)),
+		DistanceKm:      quote.DistanceKm,
+		DurationMinutes: quote.DurationMinutes,
+		SurgeMultiplier: quote.SurgeMultiplier,
+		ExpiresAt:       quote.ExpiresAt,
+		TTLSeconds:      s.cfg.TTLSeconds,
+		Signature:       quote.Signature,
+	}
+	for _, vt := range quotedVehicleTypes {
+		output.Estimates = append(output.Estimates, in.FareEstimateDTO{
+			VehicleType:   vt,
+			EstimatedFare: estimates[vt],
+		})
+	}
+
+	return output, nil
+}
+
+// checkQuote проверяет, что по квоте можно создать поездку input,
+// и возвращает цену для выбранного типа авто
+func checkQuote(quote *domain.FareQuote, input in.RequestRideInput, cfg config.QuoteConfig, now time.Time) (float64, error) {
+	// Чужая квота неотличима от несуществующей
+	if quote.PassengerID != input.PassengerID {
+		return 0, domain.ErrQuoteNotFound
+	}
+	if quote.IsUsed() {
+		return 0, domain.ErrQuoteAlreadyUsed
+	}
+	if quote.IsExpired(now) {
+		return 0, domain.ErrQuoteExpired
+	}
+
+	// Подпись присылает клиент: она подтверждает, что он видел именно эти цены
+	// и маршрут. Ожидаемая считается заново от полей квоты в БД; сравнение — за
+	// постоянное время, чтобы подпись нельзя было подобрать по времени ответа.
+	expected := signQuote(cfg.SigningSecret, quote)
+	if !hmac.Equal([]byte(expected), []byte(input.QuoteSignature)) {
+		return 0, fmt.Errorf("%w: invalid signature", domain.ErrQuoteMismatch)
+	}
+
+	fare, ok := quote.Estimates[input.VehicleType]
+	if !ok {
+		return 0, fmt.Errorf("%w: vehicle type %s not quoted", domain.ErrQuoteMismatch, input.VehicleType)
+	}
+
+	// Квота считается по прямому маршруту: остановки меняют и расстояние, и цену
+	if len(input.Stops) > 0 {
+		return 0, fmt.Errorf("%w: quote does not cover intermediate stops", domain.ErrQuoteMismatch)
+	}
+
+	if calculateDistance(quote.PickupLat, quote.PickupLng, input.PickupLat, input.PickupLng) > cfg.RouteToleranceKm ||
+		calculateDistance(quote.DestinationLat, quote.DestinationLng, input.DestLat, input.DestLng) > cfg.RouteToleranceKm {
+		return 0, fmt.Errorf("%w: route differs from quote", domain.ErrQuoteMismatch)
+	}
+
+	return fare, nil
+}
+
+// signQuote считает HMAC-SHA256 от неизменяемых полей квоты.
+// Формат чисел совпадает с точностью колонок, поэтому подпись стабильна после БД.
+func signQuote(secret string, q *domain.FareQuote) string {
+	vehicleTypes := make([]string, 0, len(q.Estimates))
+	for vt := range q.Estimates {
+		vehicleTypes = append(vehicleTypes, vt)
+	}
+	sort.Strings(vehicleTypes)
+
+	estimates := make([]string, 0, len(vehicleTypes))
+	for _, vt := range vehicleTypes {
+		estimates = append(estimates, fmt.Sprintf("%s=%.2f", vt, q.Estimates[vt]))
+	}
+
+	payload := strings.Join([]string{
+		q.ID,
+		q.PassengerID,
+		fmt.Sprintf("%.6f,%.6f", q.PickupLat, q.PickupLng),
+		fmt.Sprintf("%.6f,%.6f", q.DestinationLat, q.DestinationLng),
+		fmt.Sprintf("%.2f", q.DistanceKm),
+		fmt.Sprintf("%d", q.DurationMinutes),
+		strings.Join(estimates, ","),
+		fmt.Sprintf("%.2f", q.SurgeMultiplier),
+		fmt.Sprintf("%d", q.ExpiresAt.Unix()),
+	}, "|")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// roundTo округляет до заданного числа знаков после запятой
+func roundTo(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
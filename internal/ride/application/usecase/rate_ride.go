@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+
+	"github.com/google/uuid"
+)
+
+// RateRideService реализует RateRideUseCase
+type RateRideService struct {
+	txManager  out.TxManager
+	rideRepo   out.RideRepository
+	ratingRepo out.RatingRepository
+	cfg        config.RatingConfig
+	log        *logger.Logger
+}
+
+// NewRateRideService создает сервис оценок поездок
+func NewRateRideService(
+	txManager out.TxManager,
+	rideRepo out.RideRepository,
+	ratingRepo out.RatingRepository,
+	cfg config.RatingConfig,
+	log *logger.Logger,
+) *RateRideService {
+	return &RateRideService{
+		txManager:  txManager,
+		rideRepo:   rideRepo,
+		ratingRepo: ratingRepo,
+		cfg:        cfg,
+		log:        log,
+	}
+}
+
+// Execute сохраняет оценку и обновляет средний рейтинг оцененного участника
+func (s *RateRideService) Execute(ctx context.Context, input in.RateRideInput) (*in.RateRideOutput, error) {
+	ride, err := s.rideRepo.FindByID(ctx, input.RideID)
+	if err != nil {
+		return nil, fmt.Errorf("find ride: %w", err)
+	}
+
+	// Оценивать могут только участники поездки: пассажир — водителя, водитель — пассажира
+	var rateeID string
+	switch input.RaterRole {
+	case domain.RaterPassenger:
+		if ride.PassengerID != input.RaterID {
+			return nil, domain.ErrForbidden
+		}
+		if ride.DriverID != nil {
+			rateeID = *ride.DriverID
+		}
+	case domain.RaterDriver:
+		if ride.DriverID == nil || *ride.DriverID != input.RaterID {
+			return nil, domain.ErrForbidden
+		}
+		rateeID = ride.PassengerID
+	default:
+		return nil, domain.ErrForbidden
+	}
+
+	if ride.Status != constants.RideStatusCompleted || ride.CompletedAt == nil || rateeID == "" {
+		return nil, fmt.Errorf("%w: status %s", domain.ErrRideNotCompleted, ride.Status)
+	}
+
+	now := time.Now().UTC()
+	window := time.Duration(s.cfg.WindowHours) * time.Hour
+	if now.After(ride.CompletedAt.Add(window)) {
+		return nil, fmt.Errorf("%w: ride completed at %s, window %dh",
+			domain.ErrRatingWindowClosed, ride.CompletedAt.Format(time.RFC3339), s.cfg.WindowHours)
+	}
+
+	rating := &domain.Rating{
+		ID:        uuid.New().String(),
+		RideID:    ride.ID,
+		RaterID:   input.RaterID,
+		RateeID:   rateeID,
+		RaterRole: input.RaterRole,
+		Score:     input.Score,
+		Tags:      input.Tags,
+		Comment:   input.Comment,
+		CreatedAt: now,
+	}
+	if err := domain.NormalizeRating(rating, domain.RatingLimits{
+		MaxTags:          s.cfg.MaxTags,
+		MaxCommentLength: s.cfg.MaxCommentLength,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Оценка и пересчет среднего — атомарно: повторная оценка откатит и пересчет
+	var (
+		average float64
+		count   int
+	)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.ratingRepo.Create(ctx, rating); err != nil {
+			return err
+		}
+
+		var err error
+		if rating.RaterRole == domain.RaterPassenger {
+			average, count, err = s.ratingRepo.ApplyDriverScore(ctx, rateeID, rating.Score)
+		} else {
+			average, count, err = s.ratingRepo.ApplyPassengerScore(ctx, rateeID, rating.Score)
+		}
+		return err
+	})
+	if errors.Is(err, domain.ErrAlreadyRated) {
+		return nil, err
+	}
+	if err != nil {
+		s.log.Error(logger.Entry{
+			Action:  "rate_ride_failed",
+			Message: err.Error(),
+			RideID:  ride.ID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, fmt.Errorf("save rating: %w", err)
+	}
+
+	s.log.Info(logger.Entry{
+		Action:  "ride_rated",
+		Message: fmt.Sprintf("%s rated %d", rating.RaterRole, rating.Score),
+		RideID:  ride.ID,
+		Additional: map[string]any{
+			"rater_id":     rating.RaterID,
+			"ratee_id":     rateeID,
+			"ratee_rating": average,
+			"rating_count": count,
+		},
+	})
+
+	return &in.RateRideOutput{
+		RatingID:  rating.ID,
+		RideID:    rating.RideID,
+		RaterRole: rating.RaterRole,
+		RateeID:   rating.RateeID,
+		Score:     rating.Score,
+		Tags:      rating.Tags,
+		Comment:   rating.Comment,
+		CreatedAt: rating.CreatedAt,
+	}, nil
+}
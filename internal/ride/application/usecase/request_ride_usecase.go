@@ -9,35 +9,59 @@ import (
 	"ridehail/internal/ride/application/ports/in"
 	"ridehail/internal/ride/application/ports/out"
 	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/config"
 	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
+	"ridehail/internal/shared/pricing"
 
 	"github.com/google/uuid"
 )
 
 // RequestRideService реализует RequestRideUseCase
 type RequestRideService struct {
-	rideRepo  out.RideRepository
-	coordRepo out.CoordinateRepository
-	publisher out.EventPublisher
-	notifier  out.RideNotifier
-	log       *logger.Logger
+	txManager  out.TxManager
+	rideRepo   out.RideRepository
+	coordRepo  out.CoordinateRepository
+	stopRepo   out.RideStopRepository
+	quoteRepo  out.FareQuoteRepository
+	quoteCfg   config.QuoteConfig
+	schedule   config.ScheduleConfig
+	pricing    *pricing.Engine
+	eventStore out.EventStore
+	publisher  out.EventPublisher
+	notifier   out.RideNotifier
+	log        *logger.Logger
 }
 
 // NewRequestRideService создает новый сервис для запроса поездки
 func NewRequestRideService(
+	txManager out.TxManager,
 	rideRepo out.RideRepository,
 	coordRepo out.CoordinateRepository,
+	stopRepo out.RideStopRepository,
+	quoteRepo out.FareQuoteRepository,
+	quoteCfg config.QuoteConfig,
+	schedule config.ScheduleConfig,
+	pricingEngine *pricing.Engine,
+	eventStore out.EventStore,
 	publisher out.EventPublisher,
 	notifier out.RideNotifier,
 	log *logger.Logger,
 ) *RequestRideService {
 	return &RequestRideService{
-		rideRepo:  rideRepo,
-		coordRepo: coordRepo,
-		publisher: publisher,
-		notifier:  notifier,
-		log:       log,
+		txManager:  txManager,
+		rideRepo:   rideRepo,
+		coordRepo:  coordRepo,
+		stopRepo:   stopRepo,
+		quoteRepo:  quoteRepo,
+		quoteCfg:   quoteCfg,
+		schedule:   schedule,
+		pricing:    pricingEngine,
+		eventStore: eventStore,
+		publisher:  publisher,
+		notifier:   notifier,
+		log:        log,
 	}
 }
 
@@ -51,10 +75,27 @@ func (s *RequestRideService) Execute(ctx context.Context, input in.RequestRideIn
 		return nil, err
 	}
 
+	// Промежуточные остановки: не больше MaxRideStops, у каждой адрес и валидные координаты
+	if err := domain.ValidateStopCount(len(input.Stops)); err != nil {
+		return nil, err
+	}
+	for i, stop := range input.Stops {
+		if err := domain.ValidateCoordinates(stop.Lat, stop.Lng); err != nil {
+			return nil, fmt.Errorf("stop %d: %w", i+1, err)
+		}
+		if stop.Address == "" {
+			return nil, fmt.Errorf("%w: stop %d has no address", domain.ErrInvalidStops, i+1)
+		}
+	}
+
 	// Валидация типа автомобиля
 	if !isValidVehicleType(input.VehicleType) {
 		return nil, domain.ErrInvalidVehicleType
 	}
+	// Маршрут POOL строится из посадок и высадок попутчиков — свои остановки в него не вписать
+	if input.VehicleType == constants.VehiclePool && len(input.Stops) > 0 {
+		return nil, fmt.Errorf("%w: %s rides cannot have stops", domain.ErrInvalidStops, constants.VehiclePool)
+	}
 
 	// Валидация приоритета
 	priority := input.Priority
@@ -62,6 +103,23 @@ func (s *RequestRideService) Execute(ctx context.Context, input in.RequestRideIn
 		priority = 1
 	}
 
+	// Заказ заранее: поездка ждет в SCHEDULED, в подбор ее переведет планировщик
+	now := time.Now().UTC()
+	status := constants.RideStatusRequested
+	var scheduledFor *time.Time
+	if input.ScheduledFor != nil {
+		at := input.ScheduledFor.UTC()
+		window := domain.ScheduleWindow{
+			MinAdvance: time.Duration(s.schedule.MinAdvanceMinutes) * time.Minute,
+			MaxAdvance: time.Duration(s.schedule.MaxAdvanceDays) * 24 * time.Hour,
+		}
+		if err := domain.ValidateSchedule(at, now, window); err != nil {
+			return nil, err
+		}
+		scheduledFor = &at
+		status = constants.RideStatusScheduled
+	}
+
 	// Создаем координаты pickup
 	pickupCoord := &domain.Coordinate{
 		ID:         uuid.New().String(),
@@ -75,20 +133,64 @@ func (s *RequestRideService) Execute(ctx context.Context, input in.RequestRideIn
 		UpdatedAt:  time.Now().UTC(),
 	}
 
-	if err := s.coordRepo.Create(ctx, pickupCoord); err != nil {
-		s.log.Error(logger.Entry{
-			Action:  "create_pickup_coordinate_failed",
-			Message: err.Error(),
-			Error:   &logger.ErrObj{Msg: err.Error()},
+	// Создаем координаты destination
+	distance := routeDistance(input)
+	estimatedDuration := s.pricing.EstimateDurationMinutes(distance)
+
+	// Город тарифа — по точке подачи; фиксируется на поездке, чтобы финальная
+	// стоимость, штраф за отмену и аналитика считались в том же городе
+	city := s.pricing.City(ctx, input.PickupLat, input.PickupLng)
+
+	var estimatedFare, surgeMultiplier float64
+
+	// Цена из квоты имеет приоритет над текущим расчетом: пассажир видел ее до заказа.
+	// Surge фиксируется на поездке — финальная стоимость считается с тем же коэффициентом.
+	// Заказ заранее surge не получает: текущий спрос ничего не говорит о времени подачи.
+	// Поэтому квота (цена с текущим surge) к заказу заранее не применяется.
+	if input.QuoteID != "" && scheduledFor != nil {
+		return nil, fmt.Errorf("%w: quotes are not accepted for scheduled rides", domain.ErrQuoteMismatch)
+	}
+	if input.QuoteID == "" {
+		surgeMultiplier = 1
+		if scheduledFor == nil {
+			surgeMultiplier = s.pricing.Surge(ctx, input.PickupLat, input.PickupLng).Multiplier
+		}
+		fare, err := s.pricing.Quote(ctx, pricing.Trip{
+			City:            city,
+			VehicleType:     input.VehicleType,
+			DistanceKm:      distance,
+			DurationMinutes: estimatedDuration,
+			SurgeMultiplier: surgeMultiplier,
 		})
-		return nil, fmt.Errorf("create pickup coordinate: %w", err)
+		if err != nil {
+			return nil, fmt.Errorf("estimate fare: %w", err)
+		}
+		estimatedFare = fare.Total
+	} else {
+		quote, err := s.quoteRepo.FindByID(ctx, input.QuoteID)
+		if err != nil {
+			return nil, fmt.Errorf("find fare quote: %w", err)
+		}
+
+		fare, err := checkQuote(quote, input, s.quoteCfg, now)
+		if err != nil {
+			s.log.Warn(logger.Entry{
+				Action:  "fare_quote_rejected",
+				Message: err.Error(),
+				Additional: map[string]any{
+					"quote_id":     input.QuoteID,
+					"passenger_id": input.PassengerID,
+				},
+			})
+			return nil, err
+		}
+
+		estimatedFare = fare
+		surgeMultiplier = quote.SurgeMultiplier
+		distance = quote.DistanceKm
+		estimatedDuration = quote.DurationMinutes
 	}
 
-	// Создаем координаты destination
-	distance := calculateDistance(input.PickupLat, input.PickupLng, input.DestLat, input.DestLng)
-	estimatedFare := calculateFare(distance, input.VehicleType)
-	estimatedDuration := calculateDuration(distance)
-
 	destCoord := &domain.Coordinate{
 		ID:              uuid.New().String(),
 		EntityID:        input.PassengerID,
@@ -104,47 +206,151 @@ func (s *RequestRideService) Execute(ctx context.Context, input in.RequestRideIn
 		UpdatedAt:       time.Now().UTC(),
 	}
 
-	if err := s.coordRepo.Create(ctx, destCoord); err != nil {
-		s.log.Error(logger.Entry{
-			Action:  "create_destination_coordinate_failed",
-			Message: err.Error(),
-			Error:   &logger.ErrObj{Msg: err.Error()},
-		})
-		return nil, fmt.Errorf("create destination coordinate: %w", err)
-	}
-
 	// Генерируем уникальный номер поездки
 	rideNumber := generateRideNumber()
+	rideID := uuid.New().String()
+
+	// Остановки: координата (адрес + точка) и строка ride_stops с порядковым номером
+	stopCoords := make([]*domain.Coordinate, 0, len(input.Stops))
+	stops := make([]*domain.RideStop, 0, len(input.Stops))
+	for i, stop := range input.Stops {
+		coord := &domain.Coordinate{
+			ID:         uuid.New().String(),
+			EntityID:   input.PassengerID,
+			EntityType: "passenger",
+			Address:    stop.Address,
+			Latitude:   stop.Lat,
+			Longitude:  stop.Lng,
+			IsCurrent:  false,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		stopCoords = append(stopCoords, coord)
+		stops = append(stops, &domain.RideStop{
+			ID:           uuid.New().String(),
+			RideID:       rideID,
+			StopNumber:   i + 1,
+			CoordinateID: coord.ID,
+			Address:      stop.Address,
+			Latitude:     stop.Lat,
+			Longitude:    stop.Lng,
+			CreatedAt:    now,
+		})
+	}
 
 	// Создаем поездку
-	now := time.Now().UTC()
 	ride := &domain.Ride{
-		ID:                      uuid.New().String(),
+		ID:                      rideID,
 		RideNumber:              rideNumber,
 		PassengerID:             input.PassengerID,
 		DriverID:                nil,
 		VehicleType:             input.VehicleType,
-		Status:                  constants.RideStatusRequested,
+		City:                    city,
+		Status:                  status,
 		Priority:                priority,
 		RequestedAt:             now,
+		ScheduledFor:            scheduledFor,
 		EstimatedFare:           &estimatedFare,
+		SurgeMultiplier:         surgeMultiplier,
 		PickupCoordinateID:      pickupCoord.ID,
 		DestinationCoordinateID: destCoord.ID,
 		CreatedAt:               now,
 		UpdatedAt:               now,
 	}
 
-	if err := s.rideRepo.Create(ctx, ride); err != nil {
-		s.log.Error(logger.Entry{
-			Action:  "create_ride_failed",
-			Message: err.Error(),
-			Error:   &logger.ErrObj{Msg: err.Error()},
-			Additional: map[string]any{
-				"ride_number":  rideNumber,
-				"passenger_id": input.PassengerID,
-			},
-		})
-		return nil, fmt.Errorf("create ride: %w", err)
+	// Полный снимок поездки в журнал событий (достаточен для проекции)
+	requestedEvent := domain.NewRideEvent(ride.ID, constants.EventRideRequested, domain.RideEventPayload{
+		NewStatus:               status,
+		OccurredAt:              now,
+		RideNumber:              rideNumber,
+		PassengerID:             input.PassengerID,
+		VehicleType:             input.VehicleType,
+		City:                    city,
+		Priority:                priority,
+		EstimatedFare:           &estimatedFare,
+		SurgeMultiplier:         surgeMultiplier,
+		PickupCoordinateID:      pickupCoord.ID,
+		DestinationCoordinateID: destCoord.ID,
+		Location:                &domain.EventLocation{Lat: input.PickupLat, Lng: input.PickupLng},
+		QuoteID:                 input.QuoteID,
+		ScheduledFor:            scheduledFor,
+	})
+
+	// Координаты, поездка, событие аудита и outbox сохраняются атомарно:
+	// либо поездка создана и ride.requested гарантированно будет доставлен,
+	// либо не создано ничего. Заказ заранее уходит в подбор позже —
+	// ride.requested для него публикует ScheduledRideWorker.
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.coordRepo.Create(ctx, pickupCoord); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "create_pickup_coordinate_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+			return fmt.Errorf("create pickup coordinate: %w", err)
+		}
+
+		if err := s.coordRepo.Create(ctx, destCoord); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "create_destination_coordinate_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+			return fmt.Errorf("create destination coordinate: %w", err)
+		}
+
+		if err := s.rideRepo.Create(ctx, ride); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "create_ride_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+				Additional: map[string]any{
+					"ride_number":  rideNumber,
+					"passenger_id": input.PassengerID,
+				},
+			})
+			return fmt.Errorf("create ride: %w", err)
+		}
+
+		for i, stop := range stops {
+			if err := s.coordRepo.Create(ctx, stopCoords[i]); err != nil {
+				return fmt.Errorf("create stop coordinate: %w", err)
+			}
+			if err := s.stopRepo.Create(ctx, stop); err != nil {
+				return fmt.Errorf("create ride stop: %w", err)
+			}
+		}
+
+		// Погашение квоты в той же транзакции: вторая поездка по квоте откатится целиком
+		if input.QuoteID != "" {
+			if err := s.quoteRepo.MarkUsed(ctx, input.QuoteID, ride.ID, input.VehicleType, estimatedFare); err != nil {
+				return fmt.Errorf("redeem fare quote: %w", err)
+			}
+		}
+
+		if err := s.eventStore.Append(ctx, requestedEvent); err != nil {
+			return fmt.Errorf("append ride event: %w", err)
+		}
+
+		if scheduledFor != nil {
+			return nil
+		}
+
+		// Запрос на подбор водителя (ride.requested → driver_matching)
+		if err := s.publisher.PublishRideRequested(ctx, newRideRequested(ride, pickupCoord, destCoord, stops, now)); err != nil {
+			s.log.Error(logger.Entry{
+				Action:  "publish_ride_event_failed",
+				Message: err.Error(),
+				RideID:  ride.ID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+			return fmt.Errorf("publish ride event: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.log.Info(logger.Entry{
@@ -154,47 +360,33 @@ func (s *RequestRideService) Execute(ctx context.Context, input in.RequestRideIn
 		Additional: map[string]any{
 			"passenger_id":   input.PassengerID,
 			"vehicle_type":   input.VehicleType,
+			"city":           city,
 			"estimated_fare": estimatedFare,
 			"distance_km":    distance,
+			"stops":          len(stops),
+			"surge":          surgeMultiplier,
+			"status":         status,
+			"scheduled_for":  scheduledFor,
 		},
 	})
 
-	// Публикуем событие в RabbitMQ
-	eventData := out.RideEventData{
-		RideID:      ride.ID,
-		PassengerID: input.PassengerID,
-		Status:      constants.RideStatusRequested,
-		VehicleType: input.VehicleType,
-		AdditionalData: map[string]interface{}{
-			"ride_number":    rideNumber,
-			"estimated_fare": estimatedFare,
-			"pickup_address": input.PickupAddress,
-			"dest_address":   input.DestAddress,
-			"distance_km":    distance,
-		},
-	}
-
-	if err := s.publisher.PublishRideEvent(ctx, constants.EventRideRequested, eventData); err != nil {
-		s.log.Error(logger.Entry{
-			Action:  "publish_ride_event_failed",
-			Message: err.Error(),
-			RideID:  ride.ID,
-			Error:   &logger.ErrObj{Msg: err.Error()},
-		})
-		// Не возвращаем ошибку, т.к. поездка уже создана
-	}
-
 	// Отправляем WebSocket уведомление пассажиру
 	notification := out.RideNotification{
 		Type:    "ride_requested",
 		RideID:  ride.ID,
 		Message: "Your ride has been requested",
 		Data: map[string]interface{}{
-			"ride_number":    rideNumber,
-			"estimated_fare": estimatedFare,
-			"status":         constants.RideStatusRequested,
+			"ride_number":      rideNumber,
+			"estimated_fare":   estimatedFare,
+			"surge_multiplier": surgeMultiplier,
+			"status":           status,
 		},
 	}
+	if scheduledFor != nil {
+		notification.Type = "ride_scheduled"
+		notification.Message = "Your ride has been scheduled"
+		notification.Data["scheduled_for"] = scheduledFor.Format(time.RFC3339)
+	}
 
 	if err := s.notifier.NotifyPassenger(ctx, input.PassengerID, notification); err != nil {
 		s.log.Error(logger.Entry{
@@ -208,25 +400,85 @@ func (s *RequestRideService) Execute(ctx context.Context, input in.RequestRideIn
 
 	// Формируем ответ
 	return &in.RequestRideOutput{
-		RideID:        ride.ID,
-		RideNumber:    rideNumber,
-		Status:        constants.RideStatusRequested,
-		EstimatedFare: estimatedFare,
-		PickupAddress: input.PickupAddress,
-		DestAddress:   input.DestAddress,
+		RideID:          ride.ID,
+		RideNumber:      rideNumber,
+		Status:          status,
+		EstimatedFare:   estimatedFare,
+		SurgeMultiplier: surgeMultiplier,
+		PickupAddress:   input.PickupAddress,
+		DestAddress:     input.DestAddress,
+		QuoteID:         input.QuoteID,
+		ScheduledFor:    scheduledFor,
+		DistanceKm:      math.Round(distance*100) / 100,
+		Stops:           len(stops),
 	}, nil
 }
 
+// newRideRequested собирает запрос на подбор водителя для поездки и ее точек маршрута
+func newRideRequested(ride *domain.Ride, pickup, dest *domain.Coordinate, stops []*domain.RideStop, requestedAt time.Time) *contract.RideRequested {
+	var estimatedFare float64
+	if ride.EstimatedFare != nil {
+		estimatedFare = *ride.EstimatedFare
+	}
+
+	// Оценка времени в пути хранится на точке назначения вместе с расстоянием маршрута
+	var estimatedDuration int
+	if dest.DurationMinutes != nil {
+		estimatedDuration = *dest.DurationMinutes
+	}
+
+	var stopLocations []contract.Location
+	for _, stop := range stops {
+		stopLocations = append(stopLocations, contract.Location{
+			Lat:     stop.Latitude,
+			Lng:     stop.Longitude,
+			Address: stop.Address,
+		})
+	}
+
+	return &contract.RideRequested{
+		RideID:      ride.ID,
+		RideNumber:  ride.RideNumber,
+		PassengerID: ride.PassengerID,
+		PickupLocation: contract.Location{
+			Lat:     pickup.Latitude,
+			Lng:     pickup.Longitude,
+			Address: pickup.Address,
+		},
+		DestinationLocation: contract.Location{
+			Lat:     dest.Latitude,
+			Lng:     dest.Longitude,
+			Address: dest.Address,
+		},
+		Stops:                    stopLocations,
+		RideType:                 ride.VehicleType,
+		EstimatedFare:            estimatedFare,
+		EstimatedDurationMinutes: estimatedDuration,
+		RequestedAt:              requestedAt.Format(time.RFC3339),
+	}
+}
+
 // isValidVehicleType проверяет корректность типа автомобиля
 func isValidVehicleType(vType string) bool {
 	switch vType {
-	case constants.VehicleEconomy, constants.VehiclePremium, constants.VehicleXL:
+	case constants.VehicleEconomy, constants.VehiclePremium, constants.VehicleXL, constants.VehiclePool:
 		return true
 	default:
 		return false
 	}
 }
 
+// routeDistance — длина маршрута подача → остановки → назначение (сумма участков)
+func routeDistance(input in.RequestRideInput) float64 {
+	lat, lng := input.PickupLat, input.PickupLng
+	total := 0.0
+	for _, stop := range input.Stops {
+		total += calculateDistance(lat, lng, stop.Lat, stop.Lng)
+		lat, lng = stop.Lat, stop.Lng
+	}
+	return total + calculateDistance(lat, lng, input.DestLat, input.DestLng)
+}
+
 // calculateDistance вычисляет расстояние между двумя точками (формула Haversine)
 func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	const earthRadius = 6371.0 // км
@@ -245,31 +497,6 @@ func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	return earthRadius * c
 }
 
-// calculateFare вычисляет примерную стоимость поездки
-func calculateFare(distanceKm float64, vehicleType string) float64 {
-	baseFare := 50.0 // базовый тариф
-	perKm := 15.0    // за км
-
-	switch vehicleType {
-	case constants.VehiclePremium:
-		baseFare = 100.0
-		perKm = 25.0
-	case constants.VehicleXL:
-		baseFare = 80.0
-		perKm = 20.0
-	}
-
-	fare := baseFare + (distanceKm * perKm)
-	return math.Round(fare*100) / 100 // округляем до 2 знаков
-}
-
-// calculateDuration вычисляет примерное время поездки (минуты)
-func calculateDuration(distanceKm float64) int {
-	avgSpeedKmh := 40.0 // средняя скорость в городе
-	durationHours := distanceKm / avgSpeedKmh
-	return int(math.Ceil(durationHours * 60))
-}
-
 // generateRideNumber генерирует уникальный номер поездки в формате RIDE_YYYYMMDD_HHMMSS_XXX
 // Формат согласно регламенту: RIDE_20241216_103000_001
 func generateRideNumber() string {
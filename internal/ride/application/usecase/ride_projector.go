@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/logger"
+)
+
+// RideProjector восстанавливает строку rides из журнала событий.
+//
+// Используется поддержкой для восстановления поврежденных или удаленных
+// записей и для сверки текущего состояния с хронологией (см. cmd/rebuild-ride).
+type RideProjector struct {
+	txManager  out.TxManager
+	rideRepo   out.RideRepository
+	eventStore out.EventStore
+	log        *logger.Logger
+}
+
+// NewRideProjector создает новый проектор
+func NewRideProjector(
+	txManager out.TxManager,
+	rideRepo out.RideRepository,
+	eventStore out.EventStore,
+	log *logger.Logger,
+) *RideProjector {
+	return &RideProjector{
+		txManager:  txManager,
+		rideRepo:   rideRepo,
+		eventStore: eventStore,
+		log:        log,
+	}
+}
+
+// Project вычисляет состояние поездки по событиям, ничего не записывая
+func (p *RideProjector) Project(ctx context.Context, rideID string) (*domain.Ride, error) {
+	events, err := p.eventStore.ListByRideID(ctx, rideID)
+	if err != nil {
+		return nil, fmt.Errorf("list ride events: %w", err)
+	}
+
+	return domain.ProjectRide(rideID, events)
+}
+
+// Rebuild вычисляет состояние поездки по событиям и сохраняет его в rides.
+// Если строки нет — она создается заново.
+func (p *RideProjector) Rebuild(ctx context.Context, rideID string) (*domain.Ride, error) {
+	var ride *domain.Ride
+
+	err := p.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		projected, err := p.Project(ctx, rideID)
+		if err != nil {
+			return err
+		}
+
+		current, err := p.rideRepo.FindByID(ctx, rideID)
+		switch {
+		case errors.Is(err, domain.ErrRideNotFound):
+			if err := p.rideRepo.Create(ctx, projected); err != nil {
+				return fmt.Errorf("create ride: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("find ride: %w", err)
+		default:
+			if err := p.rideRepo.Update(ctx, projected, current.Status); err != nil {
+				return fmt.Errorf("update ride: %w", err)
+			}
+		}
+
+		ride = projected
+		return nil
+	})
+	if err != nil {
+		p.log.Error(logger.Entry{
+			Action:  "ride_rebuild_failed",
+			Message: err.Error(),
+			RideID:  rideID,
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return nil, err
+	}
+
+	p.log.Info(logger.Entry{
+		Action:  "ride_rebuilt_from_events",
+		Message: ride.Status,
+		RideID:  rideID,
+	})
+
+	return ride, nil
+}
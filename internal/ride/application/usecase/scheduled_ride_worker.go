@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/ride/domain"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// ScheduledRideWorker — планировщик заказов заранее.
+//
+// ЦИКЛ (раз в interval):
+//  1. Напоминания: SCHEDULED поездки, до подачи которых осталось reminder_lead_minutes
+//  2. Передача в подбор: SCHEDULED поездки, до подачи которых осталось dispatch_lead_minutes,
+//     переходят в REQUESTED, и в той же транзакции в outbox пишется ride.requested
+//
+// Безопасно в каждой реплике: переход статуса — conditional UPDATE (WHERE status='SCHEDULED'),
+// напоминание — conditional UPDATE reminder_sent_at IS NULL. Проигравшая реплика
+// получает ErrStatusConflict / false и пропускает поездку.
+type ScheduledRideWorker struct {
+	txManager    out.TxManager
+	rideRepo     out.RideRepository
+	coordRepo    out.CoordinateRepository
+	stopRepo     out.RideStopRepository
+	eventStore   out.EventStore
+	publisher    out.EventPublisher
+	notifier     out.RideNotifier
+	interval     time.Duration
+	dispatchLead time.Duration
+	reminderLead time.Duration
+	batchSize    int
+	log          *logger.Logger
+}
+
+// NewScheduledRideWorker создает планировщик заказов заранее
+func NewScheduledRideWorker(
+	txManager out.TxManager,
+	rideRepo out.RideRepository,
+	coordRepo out.CoordinateRepository,
+	stopRepo out.RideStopRepository,
+	eventStore out.EventStore,
+	publisher out.EventPublisher,
+	notifier out.RideNotifier,
+	cfg config.ScheduleConfig,
+	log *logger.Logger,
+) *ScheduledRideWorker {
+	return &ScheduledRideWorker{
+		txManager:    txManager,
+		rideRepo:     rideRepo,
+		coordRepo:    coordRepo,
+		stopRepo:     stopRepo,
+		eventStore:   eventStore,
+		publisher:    publisher,
+		notifier:     notifier,
+		interval:     time.Duration(cfg.IntervalSeconds) * time.Second,
+		dispatchLead: time.Duration(cfg.DispatchLeadMinutes) * time.Minute,
+		reminderLead: time.Duration(cfg.ReminderLeadMinutes) * time.Minute,
+		batchSize:    cfg.BatchSize,
+		log:          log,
+	}
+}
+
+// Run запускает цикл планировщика до отмены контекста (блокирующий).
+// При interval = 0 планировщик выключен: SCHEDULED поездки не уходят в подбор.
+func (w *ScheduledRideWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		w.log.Info(logger.Entry{Action: "scheduled_ride_worker_disabled", Message: "interval_seconds = 0"})
+		return
+	}
+
+	w.log.Info(logger.Entry{
+		Action:  "scheduled_ride_worker_started",
+		Message: fmt.Sprintf("interval %s, dispatch lead %s, reminder lead %s", w.interval, w.dispatchLead, w.reminderLead),
+	})
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info(logger.Entry{Action: "scheduled_ride_worker_stopped", Message: "context cancelled"})
+			return
+		case <-ticker.C:
+			w.tick(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// tick выполняет один проход: сначала напоминания, затем передачу в подбор
+func (w *ScheduledRideWorker) tick(ctx context.Context, now time.Time) {
+	if w.reminderLead > 0 {
+		if err := w.sendReminders(ctx, now); err != nil {
+			w.log.Error(logger.Entry{
+				Action:  "scheduled_ride_reminders_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}
+
+	if err := w.dispatchDue(ctx, now); err != nil {
+		w.log.Error(logger.Entry{
+			Action:  "scheduled_ride_dispatch_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+	}
+}
+
+// sendReminders уведомляет пассажиров о скорой подаче (один раз на поездку)
+func (w *ScheduledRideWorker) sendReminders(ctx context.Context, now time.Time) error {
+	rides, err := w.rideRepo.FindScheduledForReminder(ctx, now.Add(w.reminderLead), w.batchSize)
+	if err != nil {
+		return fmt.Errorf("find rides for reminder: %w", err)
+	}
+
+	for _, ride := range rides {
+		marked, err := w.rideRepo.MarkReminderSent(ctx, ride.ID, now)
+		if err != nil {
+			return fmt.Errorf("mark reminder sent: %w", err)
+		}
+		if !marked {
+			continue
+		}
+
+		notification := out.RideNotification{
+			Type:    "ride_reminder",
+			RideID:  ride.ID,
+			Message: fmt.Sprintf("Your scheduled ride is in %d minutes", minutesUntil(*ride.ScheduledFor, now)),
+			Data: map[string]interface{}{
+				"ride_number":   ride.RideNumber,
+				"status":        ride.Status,
+				"scheduled_for": ride.ScheduledFor.Format(time.RFC3339),
+			},
+		}
+		if err := w.notifier.NotifyPassenger(ctx, ride.PassengerID, notification); err != nil {
+			w.log.Error(logger.Entry{
+				Action:  "notify_passenger_failed",
+				Message: err.Error(),
+				RideID:  ride.ID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}
+
+	return nil
+}
+
+// dispatchDue передает в подбор все поездки, чье время подачи минус lead уже наступило
+func (w *ScheduledRideWorker) dispatchDue(ctx context.Context, now time.Time) error {
+	rides, err := w.rideRepo.FindScheduledDue(ctx, now.Add(w.dispatchLead), w.batchSize)
+	if err != nil {
+		return fmt.Errorf("find due scheduled rides: %w", err)
+	}
+
+	for _, ride := range rides {
+		err := w.dispatch(ctx, ride, now)
+		switch {
+		case errors.Is(err, domain.ErrStatusConflict):
+			// Поездку отменили или ее уже передала другая реплика
+			continue
+		case err != nil:
+			w.log.Error(logger.Entry{
+				Action:  "scheduled_ride_dispatch_failed",
+				Message: err.Error(),
+				RideID:  ride.ID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+			continue
+		}
+
+		w.log.Info(logger.Entry{
+			Action:  "scheduled_ride_dispatched",
+			Message: ride.RideNumber,
+			RideID:  ride.ID,
+			Additional: map[string]any{
+				"scheduled_for": ride.ScheduledFor.Format(time.RFC3339),
+			},
+		})
+
+		notification := out.RideNotification{
+			Type:    "ride_requested",
+			RideID:  ride.ID,
+			Message: "Looking for a driver for your scheduled ride",
+			Data: map[string]interface{}{
+				"ride_number":   ride.RideNumber,
+				"status":        constants.RideStatusRequested,
+				"scheduled_for": ride.ScheduledFor.Format(time.RFC3339),
+			},
+		}
+		if err := w.notifier.NotifyPassenger(ctx, ride.PassengerID, notification); err != nil {
+			w.log.Error(logger.Entry{
+				Action:  "notify_passenger_failed",
+				Message: err.Error(),
+				RideID:  ride.ID,
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}
+
+	return nil
+}
+
+// dispatch переводит поездку SCHEDULED → REQUESTED и ставит ride.requested в outbox
+func (w *ScheduledRideWorker) dispatch(ctx context.Context, ride *domain.Ride, now time.Time) error {
+	pickup, err := w.coordRepo.FindByID(ctx, ride.PickupCoordinateID)
+	if err != nil {
+		return fmt.Errorf("find pickup coordinate: %w", err)
+	}
+	dest, err := w.coordRepo.FindByID(ctx, ride.DestinationCoordinateID)
+	if err != nil {
+		return fmt.Errorf("find destination coordinate: %w", err)
+	}
+	stops, err := w.stopRepo.ListByRideID(ctx, ride.ID)
+	if err != nil {
+		return fmt.Errorf("list ride stops: %w", err)
+	}
+
+	if err := ride.TransitionTo(constants.RideStatusRequested, now); err != nil {
+		return err
+	}
+
+	event := domain.NewRideEvent(ride.ID, constants.EventStatusChanged, domain.RideEventPayload{
+		OldStatus:    constants.RideStatusScheduled,
+		NewStatus:    constants.RideStatusRequested,
+		OccurredAt:   now,
+		ScheduledFor: ride.ScheduledFor,
+	})
+
+	return w.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := w.rideRepo.Update(ctx, ride, constants.RideStatusScheduled); err != nil {
+			return fmt.Errorf("update ride: %w", err)
+		}
+		if err := w.eventStore.Append(ctx, event); err != nil {
+			return fmt.Errorf("append ride event: %w", err)
+		}
+		if err := w.publisher.PublishRideRequested(ctx, newRideRequested(ride, pickup, dest, stops, now)); err != nil {
+			return fmt.Errorf("publish ride requested: %w", err)
+		}
+		return nil
+	})
+}
+
+// minutesUntil — целые минуты до момента at (не меньше 0)
+func minutesUntil(at, now time.Time) int {
+	d := at.Sub(now)
+	if d < 0 {
+		return 0
+	}
+	return int(d.Minutes())
+}
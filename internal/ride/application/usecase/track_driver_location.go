@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"ridehail/internal/ride/application/ports/in"
+	"ridehail/internal/ride/application/ports/out"
+	"ridehail/internal/shared/config"
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/logger"
+)
+
+// ============================================================================
+// БИЗНЕС-ЛОГИКА: Live-карта пассажира
+// ============================================================================
+// Driver Service публикует локации в location_fanout без passenger_id.
+// Этот use case:
+// 1. Находит активные поездки водителя и их пассажиров (кэш в памяти;
+//    у POOL поездок несколько, ride_id из сообщения выбирает одну)
+// 2. Ограничивает частоту обновлений для пассажира (throttle)
+// 3. Считает ETA: до точки подачи (MATCHED/EN_ROUTE/ARRIVED)
+//    или до точки назначения (IN_PROGRESS)
+//
+// Кэш сбрасывается событиями ride.* и driver.status.* (InvalidateDriver),
+// TTL — страховка на случай потерянного события.
+// ============================================================================
+
+const (
+	etaTargetPickup      = "pickup"
+	etaTargetDestination = "destination"
+
+	// minMovingSpeedKmh — ниже этой скорости считаем, что водитель стоит
+	// (светофор, пробка), и берем среднюю скорость из конфига
+	minMovingSpeedKmh = 5.0
+)
+
+// trackedDriver — закэшированные активные поездки водителя (у POOL их несколько).
+// Пустой rides — активных поездок нет (отрицательный кэш).
+type trackedDriver struct {
+	rides    []*trackedRide
+	loadedAt time.Time
+}
+
+// trackedRide — активная поездка водителя; lastSentAt — throttle ее пассажира
+type trackedRide struct {
+	rideID      string
+	passengerID string
+	status      string
+	pickup      latLng
+	destination latLng
+	lastSentAt  time.Time
+}
+
+type latLng struct {
+	lat, lng float64
+}
+
+// TrackDriverLocationService реализует TrackDriverLocationUseCase
+type TrackDriverLocationService struct {
+	rideRepo  out.RideRepository
+	coordRepo out.CoordinateRepository
+	cfg       config.TrackingConfig
+	log       *logger.Logger
+
+	mu      sync.Mutex
+	drivers map[string]*trackedDriver // driver_id → активные поездки
+	epoch   uint64                    // растет при каждой инвалидации
+}
+
+// NewTrackDriverLocationService создает сервис live-трекинга
+func NewTrackDriverLocationService(
+	rideRepo out.RideRepository,
+	coordRepo out.CoordinateRepository,
+	cfg config.TrackingConfig,
+	log *logger.Logger,
+) *TrackDriverLocationService {
+	return &TrackDriverLocationService{
+		rideRepo:  rideRepo,
+		coordRepo: coordRepo,
+		cfg:       cfg,
+		log:       log,
+		drivers:   make(map[string]*trackedDriver),
+	}
+}
+
+// Execute определяет получателей обновления и считает ETA для каждого
+func (s *TrackDriverLocationService) Execute(ctx context.Context, input in.TrackDriverLocationInput) ([]*in.TrackDriverLocationOutput, error) {
+	now := time.Now()
+
+	rides, err := s.resolve(ctx, input.DriverID, input.RideID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(s.cfg.MinIntervalSeconds) * time.Second
+	updates := make([]*in.TrackDriverLocationOutput, 0, len(rides))
+	for _, ride := range rides {
+		// Throttle: пассажиру не нужно обновление чаще MinIntervalSeconds
+		s.mu.Lock()
+		if !ride.lastSentAt.IsZero() && now.Sub(ride.lastSentAt) < interval {
+			s.mu.Unlock()
+			continue
+		}
+		ride.lastSentAt = now
+		s.mu.Unlock()
+
+		target, point := etaTargetPickup, ride.pickup
+		if ride.status == constants.RideStatusInProgress {
+			target, point = etaTargetDestination, ride.destination
+		}
+
+		distance := calculateDistance(input.Lat, input.Lng, point.lat, point.lng)
+
+		updates = append(updates, &in.TrackDriverLocationOutput{
+			RideID:         ride.rideID,
+			PassengerID:    ride.passengerID,
+			RideStatus:     ride.status,
+			Lat:            input.Lat,
+			Lng:            input.Lng,
+			SpeedKmh:       input.SpeedKmh,
+			HeadingDegrees: input.HeadingDegrees,
+			ETATarget:      target,
+			DistanceKm:     math.Round(distance*100) / 100,
+			ETAMinutes:     s.etaMinutes(distance, input.SpeedKmh),
+			Timestamp:      input.Timestamp,
+		})
+	}
+
+	return updates, nil
+}
+
+// InvalidateDriver сбрасывает закэшированные поездки водителя
+func (s *TrackDriverLocationService) InvalidateDriver(driverID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.drivers, driverID)
+	s.epoch++
+}
+
+// resolve возвращает активные поездки водителя, которым адресовано обновление:
+// rideID из сообщения сужает их до одной, пустой — все поездки водителя
+func (s *TrackDriverLocationService) resolve(ctx context.Context, driverID, rideID string, now time.Time) ([]*trackedRide, error) {
+	ttl := time.Duration(s.cfg.CacheTTLSeconds) * time.Second
+
+	s.mu.Lock()
+	cached, ok := s.drivers[driverID]
+	fresh := ok && now.Sub(cached.loadedAt) < ttl &&
+		// Driver Service знает поездки лучше кэша — незнакомая поездка значит, что кэш устарел
+		(rideID == "" || findTrackedRide(cached.rides, rideID) != nil)
+	epoch := s.epoch
+	s.mu.Unlock()
+
+	if fresh {
+		return selectTrackedRides(cached.rides, rideID), nil
+	}
+
+	loaded, err := s.load(ctx, driverID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	// Если пока мы читали БД пришла инвалидация, результат мог устареть — не кэшируем
+	if s.epoch == epoch {
+		if prev, ok := s.drivers[driverID]; ok {
+			for _, ride := range loaded.rides {
+				if old := findTrackedRide(prev.rides, ride.rideID); old != nil {
+					ride.lastSentAt = old.lastSentAt
+				}
+			}
+		}
+		s.drivers[driverID] = loaded
+	}
+	s.mu.Unlock()
+
+	return selectTrackedRides(loaded.rides, rideID), nil
+}
+
+// findTrackedRide ищет поездку по ID (nil — не найдена)
+func findTrackedRide(rides []*trackedRide, rideID string) *trackedRide {
+	for _, ride := range rides {
+		if ride.rideID == rideID {
+			return ride
+		}
+	}
+	return nil
+}
+
+// selectTrackedRides — все поездки или только rideID, если он задан
+func selectTrackedRides(rides []*trackedRide, rideID string) []*trackedRide {
+	if rideID == "" {
+		return rides
+	}
+	if ride := findTrackedRide(rides, rideID); ride != nil {
+		return []*trackedRide{ride}
+	}
+	return nil
+}
+
+// load читает активные поездки водителя и их точки маршрута из БД
+func (s *TrackDriverLocationService) load(ctx context.Context, driverID string, now time.Time) (*trackedDriver, error) {
+	rides, err := s.rideRepo.FindActiveByDriverID(ctx, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("find active rides: %w", err)
+	}
+
+	tracked := &trackedDriver{loadedAt: now}
+	for _, ride := range rides {
+		pickup, err := s.coordRepo.FindByID(ctx, ride.PickupCoordinateID)
+		if err != nil {
+			return nil, fmt.Errorf("find pickup coordinate: %w", err)
+		}
+		destination, err := s.coordRepo.FindByID(ctx, ride.DestinationCoordinateID)
+		if err != nil {
+			return nil, fmt.Errorf("find destination coordinate: %w", err)
+		}
+
+		s.log.Debug(logger.Entry{
+			Action:  "tracked_ride_loaded",
+			Message: ride.Status,
+			RideID:  ride.ID,
+			Additional: map[string]any{
+				"driver_id": driverID,
+			},
+		})
+
+		tracked.rides = append(tracked.rides, &trackedRide{
+			rideID:      ride.ID,
+			passengerID: ride.PassengerID,
+			status:      ride.Status,
+			pickup:      latLng{lat: pickup.Latitude, lng: pickup.Longitude},
+			destination: latLng{lat: destination.Latitude, lng: destination.Longitude},
+		})
+	}
+
+	return tracked, nil
+}
+
+// etaMinutes считает ETA по текущей скорости водителя (или средней, если он стоит)
+func (s *TrackDriverLocationService) etaMinutes(distanceKm, speedKmh float64) int {
+	if speedKmh < minMovingSpeedKmh {
+		speedKmh = s.cfg.DefaultSpeedKmh
+	}
+	if speedKmh <= 0 {
+		return 0
+	}
+	return int(math.Ceil(distanceKm / speedKmh * 60))
+}
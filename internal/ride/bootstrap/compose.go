@@ -54,6 +54,7 @@ import (
 	db_conn "ridehail/internal/shared/db"
 	"ridehail/internal/shared/logger"
 	"ridehail/internal/shared/mq"
+	"ridehail/internal/shared/pricing"
 	"ridehail/internal/shared/user"
 )
 
@@ -132,8 +133,10 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	// WebSocket позволяет отправлять уведомления пассажирам в реальном времени
 	// (например, "Водитель найден!", "Водитель прибыл на место").
 
-	// Создаем WebSocket handler для пассажиров
-	passengerWS := in_ws.NewPassengerWSHandler(jwtService, log)
+	// Создаем WebSocket handler для пассажиров.
+	// Репозиторий пользователей нужен уже здесь: статус и роль проверяются при подключении.
+	userRepo := user.NewPgRepository(dbPool, log)
+	passengerWS := in_ws.NewPassengerWSHandler(jwtService, userRepo, log)
 	wsHub := passengerWS.GetHub()
 
 	// Запускаем Hub в отдельной горутине
@@ -147,19 +150,43 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	// Repositories — это "переводчики" между бизнес-логикой и БД.
 	// Они реализуют интерфейсы, определенные в Use Cases.
 
-	rideRepo := repo.NewRidePgRepository(dbPool, log)        // CRUD для rides
-	coordRepo := repo.NewCoordinatePgRepository(dbPool, log) // CRUD для coordinates
-	userRepo := user.NewPgRepository(dbPool, log)            // CRUD для users
+	rideRepo := repo.NewRidePgRepository(dbPool, log)           // CRUD для rides
+	coordRepo := repo.NewCoordinatePgRepository(dbPool, log)    // CRUD для coordinates
+	outboxRepo := repo.NewOutboxPgRepository(dbPool, log)       // Transactional outbox
+	rideQueryRepo := repo.NewRideQueryPgRepository(dbPool, log) // Чтение поездок (join coordinates + drivers)
+	quoteRepo := repo.NewFareQuotePgRepository(dbPool, log)     // Квоты стоимости fare_quotes
+	ratingRepo := repo.NewRatingPgRepository(dbPool, log)       // Оценки ride_ratings + средние рейтинги
+	stopRepo := repo.NewRideStopPgRepository(dbPool, log)       // Промежуточные остановки ride_stops
+	txManager := repo.NewPgTxManager(dbPool, log)               // Транзакции поверх нескольких репозиториев
+	eventStore := repo.NewRideEventPgStore(dbPool, log)         // Журнал событий ride_events
+
+	// Тарифы: таблица tariffs (город × тип авто), при отсутствии строки — pricing.yaml
+	tariffSource := pricing.NewPgSource(dbPool, pricing.NewConfigSource(cfg.Pricing),
+		time.Duration(cfg.Pricing.TariffCacheTTLSeconds)*time.Second, log)
+	// Город поездки — по зоне из cities, в которую попадает точка подачи
+	pricingEngine := pricing.NewEngine(tariffSource, pricing.NewPgSurgeSource(dbPool),
+		pricing.NewPgCityResolver(dbPool), cfg.Pricing, log)
+
+	// Surge: пересчет коэффициентов по ячейкам geohash. Безопасно в каждой реплике —
+	// тик выполняет только взявшая advisory lock.
+	surgeWorker := pricing.NewSurgeWorker(dbPool, cfg.Pricing.Surge, log)
+	go surgeWorker.Run(ctx)
 
 	// ========================================================================
 	// СЛОЙ 4: PUBLISHERS / NOTIFIERS (Адаптеры для отправки данных)
 	// ========================================================================
 	// Эти компоненты отправляют события и уведомления наружу:
-	// - eventPublisher → отправляет события в RabbitMQ
+	// - eventPublisher → пишет события в outbox (в транзакции use case)
+	// - outboxWorker → доставляет outbox в RabbitMQ с publisher confirms
 	// - rideNotifier → отправляет уведомления через WebSocket
 
-	eventPublisher := out_amqp.NewRideEventPublisher(mqConn, log) // Publish в RabbitMQ
-	rideNotifier := out_ws.NewWsRideNotifier(wsHub, log)          // Send через WebSocket
+	eventPublisher := out_amqp.NewOutboxRidePublisher(outboxRepo, log) // Insert в outbox
+	rideNotifier := out_ws.NewWsRideNotifier(wsHub, log)               // Send через WebSocket
+
+	// Relay безопасно запускать в каждой реплике: строки захватываются
+	// через FOR UPDATE SKIP LOCKED, поэтому реплики не мешают друг другу.
+	outboxWorker := out_amqp.NewOutboxWorker(txManager, outboxRepo, mqConn, log)
+	go outboxWorker.Run(ctx)
 
 	// ========================================================================
 	// СЛОЙ 5: USE CASES (Бизнес-логика)
@@ -169,8 +196,15 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 
 	// Use Case 1: Создание новой поездки пассажиром
 	requestRideUC := usecase.NewRequestRideService(
+		txManager,      // Поездка + координаты + outbox в одной транзакции
 		rideRepo,       // Для сохранения поездки в БД
 		coordRepo,      // Для сохранения координат
+		stopRepo,       // Для промежуточных остановок
+		quoteRepo,      // Для цены из квоты (quote_id)
+		cfg.Quote,      // TTL, ключ подписи, допуск маршрута
+		cfg.Schedule,   // Окно заказа заранее (scheduled_for)
+		pricingEngine,  // Оценка стоимости по тарифу
+		eventStore,     // Для записи RIDE_REQUESTED в журнал
 		eventPublisher, // Для отправки события "ride_requested" водителям
 		rideNotifier,   // Для уведомления пассажира (опционально)
 		log,
@@ -178,9 +212,81 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 
 	// Use Case 2: Обработка ответа водителя (принял/отклонил поездку)
 	handleDriverResponseUC := usecase.NewHandleDriverResponseService(
-		rideRepo, // Для обновления поездки (назначение водителя)
+		txManager,      // Назначение + событие в одной транзакции
+		rideRepo,       // Для обновления поездки (назначение водителя)
+		eventStore,     // Для записи DRIVER_MATCHED и EN_ROUTE в журнал
+		eventPublisher, // ride.matched через outbox
+		log,
+	)
+
+	// Use Case 3: Отмена поездки пассажиром (HTTP + WebSocket)
+	cancelRideUC := usecase.NewCancelRideService(
+		txManager,        // Отмена + outbox в одной транзакции
+		rideRepo,         // Для проверки владельца и сохранения отмены
+		eventStore,       // Для записи RIDE_CANCELLED в журнал
+		eventPublisher,   // Для отправки события "ride.cancelled" водителю
+		rideNotifier,     // Для уведомления пассажира
+		pricingEngine,    // Штраф за отмену по тарифу
+		cfg.Schedule,     // Бесплатная отмена заказа заранее
+		cfg.Cancellation, // Когда отмена платная
+		log,
+	)
+	passengerWS.SetCancelRideUseCase(cancelRideUC)
+
+	// Use Case 4: Хронология поездки для пассажира и поддержки
+	getRideEventsUC := usecase.NewGetRideEventsService(rideRepo, eventStore, log)
+
+	// Use Case 5: Этапы подбора водителя (уведомления + отмена no_drivers_available)
+	handleMatchingStatusUC := usecase.NewHandleMatchingStatusService(
+		txManager,      // Отмена + outbox в одной транзакции
+		rideRepo,       // Для проверки статуса и сохранения отмены
+		eventStore,     // Для записи RIDE_CANCELLED в журнал
+		eventPublisher, // Для отправки события "ride.cancelled"
+		rideNotifier,   // Для уведомления пассажира о ходе поиска
+		log,
+	)
+
+	// Use Case 6: Live-карта пассажира (локация водителя + ETA)
+	trackDriverLocationUC := usecase.NewTrackDriverLocationService(
+		rideRepo,     // Для поиска активной поездки водителя
+		coordRepo,    // Для точек подачи и назначения (ETA)
+		cfg.Tracking, // Throttle, TTL кэша, средняя скорость
+		log,
+	)
+
+	// Use Case 7-8: Чтение поездки и история поездок пассажира (HTTP)
+	getRideUC := usecase.NewGetRideService(rideQueryRepo, log)
+	listRidesUC := usecase.NewListRidesService(rideQueryRepo, log)
+
+	// Use Case 9: Цена до заказа (подписанная квота)
+	quoteFareUC := usecase.NewQuoteFareService(quoteRepo, pricingEngine, cfg.Quote, log)
+
+	// Use Case 10: Прибытие водителя на точку подачи (уведомление пассажира)
+	handleDriverArrivedUC := usecase.NewHandleDriverArrivedService(rideRepo, rideNotifier, log)
+
+	// Use Case 11: Оценки после поездки (пассажир ↔ водитель)
+	rateRideUC := usecase.NewRateRideService(txManager, rideRepo, ratingRepo, cfg.Rating, log)
+
+	// Use Case 12: Планировщик заказов заранее (напоминания + передача в подбор).
+	// Безопасно в каждой реплике: переходы статуса и отметка напоминания условные.
+	scheduledRideWorker := usecase.NewScheduledRideWorker(
+		txManager,      // SCHEDULED → REQUESTED + outbox в одной транзакции
+		rideRepo,       // Поиск поездок к подаче и напоминанию
+		coordRepo,      // Точки маршрута для ride.requested
+		stopRepo,       // Остановки для ride.requested
+		eventStore,     // Для записи STATUS_CHANGED в журнал
+		eventPublisher, // ride.requested через outbox
+		rideNotifier,   // Напоминание и "ищем водителя"
+		cfg.Schedule,
 		log,
 	)
+	go scheduledRideWorker.Run(ctx)
+
+	// Use Case 13: Прибытие на промежуточную остановку (уведомление пассажира)
+	handleStopArrivedUC := usecase.NewHandleStopArrivedService(rideRepo, rideNotifier, log)
+
+	// Use Case 14: Отмена водителем за неявку пассажира (уведомление о штрафе)
+	handleNoShowUC := usecase.NewHandlePassengerNoShowService(rideRepo, rideNotifier, log)
 
 	// ========================================================================
 	// СЛОЙ 6: CONSUMERS (Входящие адаптеры для RabbitMQ)
@@ -188,8 +294,8 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	// Consumers "слушают" очереди RabbitMQ и вызывают Use Cases.
 
 	// Consumer 1: Получает обновления местоположения от водителей
-	// Маршрут: Driver App → Driver Service → RabbitMQ → Location Consumer → WebSocket Hub → Passenger App
-	locationConsumer := inamqp.NewLocationConsumer(mqConn, passengerWS, log)
+	// Маршрут: Driver App → Driver Service → RabbitMQ → Location Consumer → Use Case (ride → passenger, ETA) → WebSocket Hub → Passenger App
+	locationConsumer := inamqp.NewLocationConsumer(mqConn, trackDriverLocationUC, passengerWS, log)
 	go func() {
 		if err := locationConsumer.Start(ctx); err != nil {
 			log.Error(logger.Entry{
@@ -213,12 +319,90 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 		}
 	}()
 
+	// Consumer 3: Получает этапы подбора водителя
+	// Маршрут: Driver Service (координатор) → RabbitMQ → Matching Status Consumer → Use Case → WebSocket / PostgreSQL
+	matchingStatusConsumer := inamqp.NewMatchingStatusConsumer(mqConn, handleMatchingStatusUC, log)
+	go func() {
+		if err := matchingStatusConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "matching_status_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// Consumer 4: Сбрасывает кэш live-трекинга при смене статуса поездки или водителя
+	// Маршрут: ride_topic / driver_topic → Tracking Invalidation Consumer → Use Case (кэш в памяти реплики)
+	trackingInvalidationConsumer := inamqp.NewTrackingInvalidationConsumer(mqConn, trackDriverLocationUC, log)
+	go func() {
+		if err := trackingInvalidationConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "tracking_invalidation_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// Consumer 5: Получает прибытие водителя на точку подачи
+	// Маршрут: Driver App → Driver Service (ARRIVED) → RabbitMQ → Driver Arrived Consumer → Use Case → WebSocket
+	driverArrivedConsumer := inamqp.NewDriverArrivedConsumer(mqConn, handleDriverArrivedUC, log)
+	go func() {
+		if err := driverArrivedConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "driver_arrived_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// Consumer 6: Получает прибытие водителя на промежуточные остановки
+	// Маршрут: Driver App → Driver Service (ride_stops) → RabbitMQ → Stop Arrived Consumer → Use Case → WebSocket
+	stopArrivedConsumer := inamqp.NewStopArrivedConsumer(mqConn, handleStopArrivedUC, log)
+	go func() {
+		if err := stopArrivedConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "stop_arrived_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// Consumer 7: Получает отмены поездок водителями за неявку пассажира
+	// Маршрут: Driver App → Driver Service (отмена + штраф) → RabbitMQ → No Show Consumer → Use Case → WebSocket
+	noShowConsumer := inamqp.NewNoShowConsumer(mqConn, handleNoShowUC, log)
+	go func() {
+		if err := noShowConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "no_show_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
+	// Consumer 8: Закрывает WebSocket пользователей, заблокированных или удаленных администратором
+	// Маршрут: Admin Service → RabbitMQ (user_topic) → Sessions Revoked Consumer → Hub
+	sessionsRevokedConsumer := inamqp.NewSessionsRevokedConsumer(mqConn, passengerWS, log)
+	go func() {
+		if err := sessionsRevokedConsumer.Start(ctx); err != nil {
+			log.Error(logger.Entry{
+				Action:  "sessions_revoked_consumer_failed",
+				Message: err.Error(),
+				Error:   &logger.ErrObj{Msg: err.Error()},
+			})
+		}
+	}()
+
 	// ========================================================================
 	// СЛОЙ 7: HTTP HANDLER (Входящий адаптер для REST API)
 	// ========================================================================
 	// HTTP handler обрабатывает REST запросы и вызывает Use Cases.
 
-	httpHandler := transport.NewHTTPHandler(requestRideUC, log)
+	httpHandler := transport.NewHTTPHandler(requestRideUC, cancelRideUC, getRideEventsUC, getRideUC, listRidesUC, quoteFareUC, rateRideUC, log)
 
 	// ========================================================================
 	// СЛОЙ 8: HTTP СЕРВЕР (Настройка и запуск)
@@ -230,9 +414,17 @@ func Run(ctx context.Context, cfg config.Config, log *logger.Logger) {
 	// Без валидного токена запросы не пройдут дальше.
 	authMiddleware := transport.JWTMiddleware(jwtService, userRepo, log)
 
+	// Отменить поездку, оценить ее после завершения и прочитать ее карточку
+	// могут и пассажир, и назначенный водитель
+	participantMiddleware := transport.JWTMiddleware(jwtService, userRepo, log, "PASSENGER", "DRIVER", "ADMIN")
+
 	// Регистрируем маршруты REST API
-	// POST /api/v1/rides/request — создать поездку
-	httpHandler.RegisterRoutes(mux, authMiddleware)
+	// POST /rides — создать поездку
+	// POST /rides/{ride_id}/cancel — отменить поездку (пассажир или назначенный водитель)
+	// GET /rides/{ride_id}, GET /rides/by-number/{ride_number} — карточка поездки (пассажир, водитель, админ)
+	// GET /rides/{ride_id}/events — хронология поездки
+	// POST /rides/{ride_id}/rating — оценка после завершения
+	httpHandler.RegisterRoutes(mux, authMiddleware, participantMiddleware)
 
 	// WebSocket endpoint для пассажиров
 	// Пассажиры подключаются сюда для получения real-time уведомлений
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	constants "ridehail/internal/shared/const"
+)
+
+// CancellationPolicy — когда отмена поездки пассажиром платная
+type CancellationPolicy struct {
+	FreeWindow          time.Duration // Отмена в течение FreeWindow после заказа всегда бесплатна
+	EnRouteGrace        time.Duration // Бесплатно, пока водитель едет к пассажиру меньше EnRouteGrace
+	ScheduledFreeWindow time.Duration // Заказ заранее: бесплатно не позже чем за это время до подачи
+}
+
+// CancellationDecision — результат применения политики к отмене
+type CancellationDecision struct {
+	Chargeable bool   // Пассажир платит cancellation_fee тарифа
+	FeeReason  string // constants.FeeReason*; "" — отмена бесплатная
+	Late       bool   // Заказ заранее отменен ближе чем за ScheduledFreeWindow до подачи
+}
+
+// Evaluate решает, платная ли отмена поездки в момент at.
+// Вызывается до ride.Cancel: решение зависит от статуса, из которого отменяют.
+//
+// ПРАВИЛА (по порядку):
+//  1. Не прошло FreeWindow с момента заказа — бесплатно
+//  2. Водитель уже на точке подачи (ARRIVED) — штраф
+//  3. Водитель едет к пассажиру (MATCHED/EN_ROUTE) не меньше EnRouteGrace — штраф.
+//     EN_ROUTE ставится сразу при назначении, поэтому отсчет идет от matched_at
+//  4. Поздняя отмена заказа заранее — штраф
+//  5. Иначе бесплатно
+func (p CancellationPolicy) Evaluate(r *Ride, at time.Time) CancellationDecision {
+	decision := CancellationDecision{Late: r.IsLateCancellation(at, p.ScheduledFreeWindow)}
+
+	switch {
+	case at.Sub(r.RequestedAt) <= p.FreeWindow:
+		return decision
+	case r.Status == constants.RideStatusArrived:
+		decision.FeeReason = constants.FeeReasonDriverArrived
+	case (r.Status == constants.RideStatusMatched || r.Status == constants.RideStatusEnRoute) &&
+		r.MatchedAt != nil && at.Sub(*r.MatchedAt) >= p.EnRouteGrace:
+		decision.FeeReason = constants.FeeReasonDriverEnRoute
+	case decision.Late:
+		decision.FeeReason = constants.FeeReasonLateScheduled
+	default:
+		return decision
+	}
+
+	decision.Chargeable = true
+	return decision
+}
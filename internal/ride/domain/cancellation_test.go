@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	constants "ridehail/internal/shared/const"
+)
+
+func TestCancellationPolicyEvaluate(t *testing.T) {
+	policy := CancellationPolicy{
+		FreeWindow:          2 * time.Minute,
+		EnRouteGrace:        5 * time.Minute,
+		ScheduledFreeWindow: time.Hour,
+	}
+
+	requested := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return requested.Add(d) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name     string
+		ride     Ride
+		at       time.Time
+		wantFee  string
+		wantLate bool
+	}{
+		{
+			name: "within free window even if driver arrived",
+			ride: Ride{Status: constants.RideStatusArrived, MatchedAt: ptr(at(30 * time.Second))},
+			at:   at(2 * time.Minute),
+		},
+		{
+			name:    "driver arrived",
+			ride:    Ride{Status: constants.RideStatusArrived, MatchedAt: ptr(at(time.Minute))},
+			at:      at(3 * time.Minute),
+			wantFee: constants.FeeReasonDriverArrived,
+		},
+		{
+			name: "driver en route shorter than grace",
+			ride: Ride{Status: constants.RideStatusEnRoute, MatchedAt: ptr(at(time.Minute))},
+			at:   at(5*time.Minute + 59*time.Second),
+		},
+		{
+			name:    "driver en route for grace",
+			ride:    Ride{Status: constants.RideStatusEnRoute, MatchedAt: ptr(at(time.Minute))},
+			at:      at(6 * time.Minute),
+			wantFee: constants.FeeReasonDriverEnRoute,
+		},
+		{
+			name:    "matched counts as en route",
+			ride:    Ride{Status: constants.RideStatusMatched, MatchedAt: ptr(at(time.Minute))},
+			at:      at(10 * time.Minute),
+			wantFee: constants.FeeReasonDriverEnRoute,
+		},
+		{
+			name: "matched without matched_at",
+			ride: Ride{Status: constants.RideStatusMatched},
+			at:   at(10 * time.Minute),
+		},
+		{
+			name: "still searching for a driver",
+			ride: Ride{Status: constants.RideStatusRequested},
+			at:   at(10 * time.Minute),
+		},
+		{
+			name: "scheduled well before pickup",
+			ride: Ride{Status: constants.RideStatusScheduled, ScheduledFor: ptr(at(3 * time.Hour))},
+			at:   at(time.Hour),
+		},
+		{
+			name:     "scheduled late",
+			ride:     Ride{Status: constants.RideStatusScheduled, ScheduledFor: ptr(at(3 * time.Hour))},
+			at:       at(2*time.Hour + 30*time.Minute),
+			wantFee:  constants.FeeReasonLateScheduled,
+			wantLate: true,
+		},
+		{
+			name:     "scheduled late within free window after booking",
+			ride:     Ride{Status: constants.RideStatusScheduled, ScheduledFor: ptr(at(30 * time.Minute))},
+			at:       at(time.Minute),
+			wantLate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ride := tc.ride
+			ride.RequestedAt = requested
+
+			got := policy.Evaluate(&ride, tc.at)
+
+			if got.FeeReason != tc.wantFee {
+				t.Errorf("FeeReason = %q, want %q", got.FeeReason, tc.wantFee)
+			}
+			if got.Chargeable != (tc.wantFee != "") {
+				t.Errorf("Chargeable = %v, want %v", got.Chargeable, tc.wantFee != "")
+			}
+			if got.Late != tc.wantLate {
+				t.Errorf("Late = %v, want %v", got.Late, tc.wantLate)
+			}
+		})
+	}
+}
@@ -21,6 +21,53 @@ var (
 	// ErrUnauthorized возвращается при отсутствии прав доступа
 	ErrUnauthorized = errors.New("unauthorized")
 
+	// ErrForbidden возвращается при попытке изменить чужую поездку
+	ErrForbidden = errors.New("access to ride denied")
+
+	// ErrIncompleteEventStream возвращается, если по событиям нельзя восстановить поездку
+	ErrIncompleteEventStream = errors.New("incomplete ride event stream")
+
+	// ErrInvalidTransition возвращается при недопустимом переходе статуса поездки
+	ErrInvalidTransition = errors.New("invalid ride status transition")
+
+	// ErrStatusConflict возвращается, если статус поездки изменился конкурентно
+	// (conditional UPDATE ... WHERE status = $expected не затронул ни одной строки)
+	ErrStatusConflict = errors.New("ride status changed concurrently")
+
 	// ErrInvalidStatus возвращается при невалидном статусе поездки
 	ErrInvalidStatus = errors.New("invalid ride status")
+
+	// ErrInvalidCursor возвращается при поврежденном курсоре пагинации
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+	// ErrQuoteNotFound возвращается, когда квота не найдена или принадлежит другому пассажиру
+	ErrQuoteNotFound = errors.New("fare quote not found")
+
+	// ErrQuoteExpired возвращается при погашении квоты после ExpiresAt
+	ErrQuoteExpired = errors.New("fare quote expired")
+
+	// ErrQuoteAlreadyUsed возвращается при повторном погашении квоты
+	ErrQuoteAlreadyUsed = errors.New("fare quote already used")
+
+	// ErrInvalidRating возвращается при оценке вне 1–5, некорректных тегах или слишком длинном комментарии
+	ErrInvalidRating = errors.New("invalid rating")
+
+	// ErrRideNotCompleted возвращается при попытке оценить незавершенную поездку
+	ErrRideNotCompleted = errors.New("ride is not completed")
+
+	// ErrRatingWindowClosed возвращается, если с завершения поездки прошло больше окна оценки
+	ErrRatingWindowClosed = errors.New("rating window closed")
+
+	// ErrAlreadyRated возвращается при повторной оценке поездки той же стороной
+	ErrAlreadyRated = errors.New("ride already rated")
+
+	// ErrInvalidSchedule возвращается, если scheduled_for слишком близко или слишком далеко
+	ErrInvalidSchedule = errors.New("invalid scheduled_for")
+
+	// ErrInvalidStops возвращается при слишком большом числе остановок или остановке без адреса
+	ErrInvalidStops = errors.New("invalid ride stops")
+
+	// ErrQuoteMismatch возвращается, если поездка не совпадает с квотой
+	// (другой маршрут, тип авто вне квоты или неверная подпись)
+	ErrQuoteMismatch = errors.New("ride does not match fare quote")
 )
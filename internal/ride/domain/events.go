@@ -1,8 +1,14 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
 
-// RideEvent — событие, которое отправляется в RabbitMQ.
+	"ridehail/internal/shared/ridestate"
+)
+
+// RideEvent — событие жизненного цикла поездки (таблица ride_events).
 type RideEvent struct {
 	ID        string    `json:"id" db:"id"`
 	RideID    string    `json:"ride_id" db:"ride_id"`
@@ -10,3 +16,46 @@ type RideEvent struct {
 	EventData any       `json:"event_data" db:"event_data"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
+
+// RideEventPayload — содержимое event_data; формат общий с Driver Service
+type RideEventPayload = ridestate.EventPayload
+
+// EventLocation — координаты, связанные с событием
+type EventLocation = ridestate.EventLocation
+
+// NewRideEvent создает событие с типизированным payload
+func NewRideEvent(rideID, eventType string, payload RideEventPayload) *RideEvent {
+	if payload.OccurredAt.IsZero() {
+		payload.OccurredAt = time.Now().UTC()
+	}
+	return &RideEvent{
+		RideID:    rideID,
+		EventType: eventType,
+		EventData: payload,
+		CreatedAt: payload.OccurredAt,
+	}
+}
+
+// Payload декодирует event_data в RideEventPayload.
+// EventData может быть как RideEventPayload, так и сырым JSON из БД.
+func (e *RideEvent) Payload() (RideEventPayload, error) {
+	if p, ok := e.EventData.(RideEventPayload); ok {
+		return p, nil
+	}
+
+	raw, err := json.Marshal(e.EventData)
+	if err != nil {
+		return RideEventPayload{}, fmt.Errorf("marshal event data: %w", err)
+	}
+
+	var p RideEventPayload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return RideEventPayload{}, fmt.Errorf("unmarshal event data: %w", err)
+	}
+
+	if p.OccurredAt.IsZero() {
+		p.OccurredAt = e.CreatedAt
+	}
+
+	return p, nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// FareQuote — предварительный расчет стоимости поездки по всем типам авто.
+//
+// Квота выдается до создания поездки (POST /rides/quote) и фиксирует цену
+// на ExpiresAt. Signature — HMAC от неизменяемых полей квоты; при погашении
+// подпись пересчитывается, так что цену нельзя подменить в обход сервиса.
+type FareQuote struct {
+	ID                 string
+	PassengerID        string
+	PickupLat          float64
+	PickupLng          float64
+	PickupAddress      string
+	DestinationLat     float64
+	DestinationLng     float64
+	DestinationAddress string
+	DistanceKm         float64
+	DurationMinutes    int
+	Estimates          map[string]float64 // vehicle_type → estimated fare
+	SurgeMultiplier    float64            // Коэффициент ячейки подачи на момент квоты (уже учтен в Estimates)
+	Signature          string
+	ExpiresAt          time.Time
+	CreatedAt          time.Time
+
+	// Заполняются при погашении квоты (POST /rides с quote_id)
+	RideID      *string
+	VehicleType *string
+	QuotedFare  *float64
+	UsedAt      *time.Time
+}
+
+// IsExpired возвращает true, если цена квоты больше не действует
+func (q *FareQuote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// IsUsed возвращает true, если по квоте уже создана поездка
+func (q *FareQuote) IsUsed() bool {
+	return q.UsedAt != nil
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+
+	constants "ridehail/internal/shared/const"
+)
+
+// ProjectRide восстанавливает состояние поездки, последовательно применяя события.
+//
+// События должны быть упорядочены по времени создания. Первое событие обязано
+// быть RIDE_REQUESTED — оно содержит неизменяемые атрибуты поездки.
+// Заказ заранее начинается с RIDE_REQUESTED в статусе SCHEDULED, передача
+// в подбор — STATUS_CHANGED SCHEDULED → REQUESTED.
+func ProjectRide(rideID string, events []*RideEvent) (*Ride, error) {
+	if len(events) == 0 {
+		return nil, ErrRideNotFound
+	}
+
+	if events[0].EventType != constants.EventRideRequested {
+		return nil, fmt.Errorf("%w: first event is %s", ErrIncompleteEventStream, events[0].EventType)
+	}
+
+	ride := &Ride{ID: rideID}
+
+	for _, e := range events {
+		p, err := e.Payload()
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", e.ID, err)
+		}
+
+		at := p.OccurredAt
+
+		switch e.EventType {
+		case constants.EventRideRequested:
+			ride.RideNumber = p.RideNumber
+			ride.PassengerID = p.PassengerID
+			ride.VehicleType = p.VehicleType
+			ride.City = p.City
+			ride.Priority = p.Priority
+			ride.EstimatedFare = p.EstimatedFare
+			ride.SurgeMultiplier = p.SurgeMultiplier
+			if ride.SurgeMultiplier == 0 {
+				// События до появления surge
+				ride.SurgeMultiplier = 1
+			}
+			ride.PickupCoordinateID = p.PickupCoordinateID
+			ride.DestinationCoordinateID = p.DestinationCoordinateID
+			ride.Status = constants.RideStatusRequested
+			if p.NewStatus == constants.RideStatusScheduled {
+				ride.Status = constants.RideStatusScheduled
+			}
+			ride.ScheduledFor = p.ScheduledFor
+			ride.RequestedAt = at
+			ride.CreatedAt = at
+
+		case constants.EventDriverMatched:
+			driverID := p.DriverID
+			ride.DriverID = &driverID
+			ride.MatchedAt = &at
+			ride.Status = constants.RideStatusMatched
+
+		case constants.EventDriverArrived:
+			ride.ArrivedAt = &at
+			ride.Status = constants.RideStatusArrived
+
+		case constants.EventRideStarted:
+			ride.StartedAt = &at
+			ride.Status = constants.RideStatusInProgress
+
+		case constants.EventRideCompleted:
+			ride.CompletedAt = &at
+			ride.Status = constants.RideStatusCompleted
+			if p.FinalFare != nil {
+				ride.FinalFare = p.FinalFare
+			}
+			if p.FareBreakdown != nil {
+				ride.FareBreakdown = p.FareBreakdown
+			}
+
+		case constants.EventRideCancelled:
+			reason := p.Reason
+			ride.CancelledAt = &at
+			ride.CancellationReason = &reason
+			ride.CancellationFee = p.CancellationFee
+			ride.Status = constants.RideStatusCancelled
+
+		case constants.EventStatusChanged:
+			if p.NewStatus != "" {
+				ride.Status = p.NewStatus
+			}
+
+		case constants.EventFareAdjusted:
+			if p.EstimatedFare != nil {
+				ride.EstimatedFare = p.EstimatedFare
+			}
+			if p.FinalFare != nil {
+				ride.FinalFare = p.FinalFare
+			}
+			if p.FareBreakdown != nil {
+				ride.FareBreakdown = p.FareBreakdown
+			}
+
+		case constants.EventLocationUpdated, constants.EventStopArrived,
+			constants.EventPassengerPickedUp, constants.EventPassengerDroppedOff:
+			// Не меняет состояние поездки (отметки остановок — в ride_stops,
+			// план POOL-маршрута — в pool_waypoints)
+		}
+
+		ride.UpdatedAt = at
+	}
+
+	return ride, nil
+}
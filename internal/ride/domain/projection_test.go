@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/pricing"
+)
+
+// TestProjectRideRestoresPricing проверяет, что пересобранная по событиям поездка
+// сохраняет город тарифа и детализацию финальной стоимости
+func TestProjectRideRestoresPricing(t *testing.T) {
+	requested := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	estimated, final := 850.0, 910.0
+	breakdown := &pricing.Breakdown{City: "almaty", VehicleType: "ECONOMY", DistanceKm: 11, DurationMinutes: 21, Total: final}
+
+	events := []*RideEvent{
+		NewRideEvent("ride-1", constants.EventRideRequested, RideEventPayload{
+			OccurredAt:    requested,
+			RideNumber:    "RIDE_20260301_001",
+			PassengerID:   "passenger-1",
+			VehicleType:   "ECONOMY",
+			City:          "almaty",
+			EstimatedFare: &estimated,
+		}),
+		NewRideEvent("ride-1", constants.EventDriverMatched, RideEventPayload{OccurredAt: requested.Add(time.Minute), DriverID: "driver-1"}),
+		NewRideEvent("ride-1", constants.EventStatusChanged, RideEventPayload{OccurredAt: requested.Add(time.Minute), NewStatus: constants.RideStatusEnRoute}),
+		NewRideEvent("ride-1", constants.EventDriverArrived, RideEventPayload{OccurredAt: requested.Add(5 * time.Minute)}),
+		NewRideEvent("ride-1", constants.EventRideStarted, RideEventPayload{OccurredAt: requested.Add(6 * time.Minute)}),
+		NewRideEvent("ride-1", constants.EventRideCompleted, RideEventPayload{
+			OccurredAt:    requested.Add(27 * time.Minute),
+			FinalFare:     &final,
+			FareBreakdown: breakdown,
+		}),
+	}
+
+	ride, err := ProjectRide("ride-1", events)
+	if err != nil {
+		t.Fatalf("ProjectRide: %v", err)
+	}
+
+	if ride.City != "almaty" {
+		t.Errorf("City = %q, want almaty", ride.City)
+	}
+	if ride.Status != constants.RideStatusCompleted {
+		t.Errorf("Status = %s, want %s", ride.Status, constants.RideStatusCompleted)
+	}
+	if ride.FinalFare == nil || *ride.FinalFare != final {
+		t.Errorf("FinalFare = %v, want %v", ride.FinalFare, final)
+	}
+	if ride.FareBreakdown == nil || *ride.FareBreakdown != *breakdown {
+		t.Errorf("FareBreakdown = %+v, want %+v", ride.FareBreakdown, breakdown)
+	}
+}
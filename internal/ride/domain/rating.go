@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Стороны поездки, которые ставят оценку
+const (
+	RaterPassenger = "PASSENGER" // Пассажир оценивает водителя
+	RaterDriver    = "DRIVER"    // Водитель оценивает пассажира
+)
+
+// maxTagLength — длина одного тега в символах
+const maxTagLength = 32
+
+// Rating — оценка одной стороны поездки другой стороной.
+// На поездку не больше одной оценки от каждой стороны.
+type Rating struct {
+	ID        string
+	RideID    string
+	RaterID   string
+	RateeID   string
+	RaterRole string // PASSENGER | DRIVER
+	Score     int    // 1–5
+	Tags      []string
+	Comment   *string
+	CreatedAt time.Time
+}
+
+// RatingLimits — ограничения на содержимое оценки
+type RatingLimits struct {
+	MaxTags          int
+	MaxCommentLength int
+}
+
+// NormalizeRating проверяет оценку и приводит теги к виду snake_case без повторов.
+// Пустой комментарий превращается в nil.
+func NormalizeRating(r *Rating, limits RatingLimits) error {
+	if r.Score < 1 || r.Score > 5 {
+		return fmt.Errorf("%w: score must be between 1 and 5", ErrInvalidRating)
+	}
+
+	tags := make([]string, 0, len(r.Tags))
+	seen := make(map[string]struct{}, len(r.Tags))
+	for _, tag := range r.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if !isTag(tag) {
+			return fmt.Errorf("%w: tag %q must contain only a-z, 0-9 and _ (max %d chars)", ErrInvalidRating, tag, maxTagLength)
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	if len(tags) > limits.MaxTags {
+		return fmt.Errorf("%w: at most %d tags allowed", ErrInvalidRating, limits.MaxTags)
+	}
+	r.Tags = tags
+
+	if r.Comment != nil {
+		comment := strings.TrimSpace(*r.Comment)
+		if comment == "" {
+			r.Comment = nil
+		} else {
+			if utf8.RuneCountInString(comment) > limits.MaxCommentLength {
+				return fmt.Errorf("%w: comment longer than %d characters", ErrInvalidRating, limits.MaxCommentLength)
+			}
+			r.Comment = &comment
+		}
+	}
+
+	return nil
+}
+
+// isTag — тег из строчных латинских букв, цифр и подчеркиваний
+func isTag(s string) bool {
+	if len(s) > maxTagLength {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,27 +1,36 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"ridehail/internal/shared/pricing"
+)
 
 // Ride представляет основную сущность поездки.
 type Ride struct {
-	ID                      string     `json:"id" db:"id"`
-	RideNumber              string     `json:"ride_number" db:"ride_number"`
-	PassengerID             string     `json:"passenger_id" db:"passenger_id"`
-	DriverID                *string    `json:"driver_id,omitempty" db:"driver_id"`
-	VehicleType             string     `json:"vehicle_type" db:"vehicle_type"`
-	Status                  string     `json:"status" db:"status"`
-	Priority                int        `json:"priority" db:"priority"`
-	RequestedAt             time.Time  `json:"requested_at" db:"requested_at"`
-	MatchedAt               *time.Time `json:"matched_at,omitempty" db:"matched_at"`
-	ArrivedAt               *time.Time `json:"arrived_at,omitempty" db:"arrived_at"`
-	StartedAt               *time.Time `json:"started_at,omitempty" db:"started_at"`
-	CompletedAt             *time.Time `json:"completed_at,omitempty" db:"completed_at"`
-	CancelledAt             *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
-	CancellationReason      *string    `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
-	EstimatedFare           *float64   `json:"estimated_fare,omitempty" db:"estimated_fare"`
-	FinalFare               *float64   `json:"final_fare,omitempty" db:"final_fare"`
-	PickupCoordinateID      string     `json:"pickup_coordinate_id" db:"pickup_coordinate_id"`
-	DestinationCoordinateID string     `json:"destination_coordinate_id" db:"destination_coordinate_id"`
-	CreatedAt               time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt               time.Time  `json:"updated_at" db:"updated_at"`
+	ID                      string             `json:"id" db:"id"`
+	RideNumber              string             `json:"ride_number" db:"ride_number"`
+	PassengerID             string             `json:"passenger_id" db:"passenger_id"`
+	DriverID                *string            `json:"driver_id,omitempty" db:"driver_id"`
+	VehicleType             string             `json:"vehicle_type" db:"vehicle_type"`
+	City                    string             `json:"city" db:"city"` // Город тарифа по точке подачи, фиксируется при заказе
+	Status                  string             `json:"status" db:"status"`
+	Priority                int                `json:"priority" db:"priority"`
+	RequestedAt             time.Time          `json:"requested_at" db:"requested_at"`
+	ScheduledFor            *time.Time         `json:"scheduled_for,omitempty" db:"scheduled_for"` // Время подачи заказа заранее
+	MatchedAt               *time.Time         `json:"matched_at,omitempty" db:"matched_at"`
+	ArrivedAt               *time.Time         `json:"arrived_at,omitempty" db:"arrived_at"`
+	StartedAt               *time.Time         `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt             *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+	CancelledAt             *time.Time         `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CancellationReason      *string            `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+	CancellationFee         *float64           `json:"cancellation_fee,omitempty" db:"cancellation_fee"` // Штраф пассажиру за отмену
+	EstimatedFare           *float64           `json:"estimated_fare,omitempty" db:"estimated_fare"`
+	FinalFare               *float64           `json:"final_fare,omitempty" db:"final_fare"`
+	FareBreakdown           *pricing.Breakdown `json:"fare_breakdown,omitempty" db:"fare_breakdown"` // Детализация финальной стоимости
+	SurgeMultiplier         float64            `json:"surge_multiplier" db:"surge_multiplier"`       // Фиксируется при заказе
+	PickupCoordinateID      string             `json:"pickup_coordinate_id" db:"pickup_coordinate_id"`
+	DestinationCoordinateID string             `json:"destination_coordinate_id" db:"destination_coordinate_id"`
+	CreatedAt               time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time          `json:"updated_at" db:"updated_at"`
 }
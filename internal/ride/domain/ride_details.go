@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"ridehail/internal/shared/pricing"
+)
+
+// RideDetails — поездка вместе с точками маршрута и водителем (read model для API чтения)
+type RideDetails struct {
+	Ride        *Ride
+	Pickup      *Coordinate        // nil, если точка не найдена
+	Destination *Coordinate        // nil, если точка не найдена
+	Stops       []*RideStop        // Промежуточные остановки по порядку
+	Driver      *DriverInfo        // nil, пока водитель не назначен
+	Fare        *pricing.Breakdown // Детализация финальной стоимости (после завершения)
+}
+
+// DriverInfo — публичные данные водителя, которые видит пассажир
+type DriverInfo struct {
+	ID           string
+	VehicleType  string
+	VehicleAttrs map[string]interface{} // make, model, color, plate, ...
+	Rating       float64
+}
+
+// RideCursor — позиция в истории поездок.
+// Сортировка (requested_at DESC, id DESC); id разрешает равные requested_at.
+type RideCursor struct {
+	RequestedAt time.Time
+	ID          string
+}
+
+// RideListFilter — фильтр истории поездок пассажира
+type RideListFilter struct {
+	PassengerID string
+	Status      string      // "" — любой статус
+	From        *time.Time  // requested_at >= From
+	To          *time.Time  // requested_at < To
+	After       *RideCursor // nil — с начала (самые новые)
+	Limit       int
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleWindow — допустимое время подачи для заказа заранее
+type ScheduleWindow struct {
+	MinAdvance time.Duration // Не ближе, чем now + MinAdvance
+	MaxAdvance time.Duration // Не дальше, чем now + MaxAdvance
+}
+
+// ValidateSchedule проверяет, что время подачи попадает в окно заказа заранее
+func ValidateSchedule(scheduledFor, now time.Time, window ScheduleWindow) error {
+	if scheduledFor.Before(now.Add(window.MinAdvance)) {
+		return fmt.Errorf("%w: must be at least %s ahead", ErrInvalidSchedule, window.MinAdvance)
+	}
+	if scheduledFor.After(now.Add(window.MaxAdvance)) {
+		return fmt.Errorf("%w: must be at most %s ahead", ErrInvalidSchedule, window.MaxAdvance)
+	}
+	return nil
+}
+
+// IsScheduled возвращает true для поездки, заказанной заранее
+// (в том числе уже переданной в подбор водителя)
+func (r *Ride) IsScheduled() bool {
+	return r.ScheduledFor != nil
+}
+
+// IsLateCancellation — отмена запланированной поездки ближе чем за freeWindow до подачи.
+// Для обычных поездок всегда false: у них свои правила отмены.
+func (r *Ride) IsLateCancellation(at time.Time, freeWindow time.Duration) bool {
+	if r.ScheduledFor == nil {
+		return false
+	}
+	return at.After(r.ScheduledFor.Add(-freeWindow))
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	constants "ridehail/internal/shared/const"
+	"ridehail/internal/shared/ridestate"
+)
+
+// Таблица переходов статусов — в ridestate (общая с Driver Service).
+// Здесь — ошибки переходов и изменение самой поездки.
+
+// TransitionError описывает недопустимый переход статуса.
+// errors.Is(err, ErrInvalidTransition) == true для любого TransitionError.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+// Error реализует интерфейс error
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invalid ride status transition %s → %s", e.From, e.To)
+}
+
+// Unwrap позволяет сравнивать через errors.Is с ErrInvalidTransition,
+// а для терминальных статусов — с ErrRideAlreadyCancelled / ErrRideAlreadyCompleted
+func (e *TransitionError) Unwrap() []error {
+	switch e.From {
+	case constants.RideStatusCancelled:
+		return []error{ErrInvalidTransition, ErrRideAlreadyCancelled}
+	case constants.RideStatusCompleted:
+		return []error{ErrInvalidTransition, ErrRideAlreadyCompleted}
+	default:
+		return []error{ErrInvalidTransition}
+	}
+}
+
+// CanTransition проверяет, разрешен ли переход from → to
+func CanTransition(from, to string) bool {
+	return ridestate.CanTransition(from, to)
+}
+
+// IsValidStatus проверяет, что статус поездки известен
+func IsValidStatus(status string) bool {
+	return ridestate.IsValidStatus(status)
+}
+
+// IsTerminalStatus возвращает true для статусов, из которых нет переходов
+func IsTerminalStatus(status string) bool {
+	return ridestate.IsTerminalStatus(status)
+}
+
+// TransitionTo переводит поездку в новый статус и проставляет соответствующий timestamp.
+// Возвращает *TransitionError, если переход недопустим.
+func (r *Ride) TransitionTo(to string, at time.Time) error {
+	if !CanTransition(r.Status, to) {
+		return &TransitionError{From: r.Status, To: to}
+	}
+
+	switch to {
+	case constants.RideStatusMatched:
+		r.MatchedAt = &at
+	case constants.RideStatusArrived:
+		r.ArrivedAt = &at
+	case constants.RideStatusInProgress:
+		r.StartedAt = &at
+	case constants.RideStatusCompleted:
+		r.CompletedAt = &at
+	case constants.RideStatusCancelled:
+		r.CancelledAt = &at
+	}
+
+	r.Status = to
+	r.UpdatedAt = at
+	return nil
+}
+
+// AssignDriver назначает водителя и переводит поездку в MATCHED
+func (r *Ride) AssignDriver(driverID string, at time.Time) error {
+	if err := r.TransitionTo(constants.RideStatusMatched, at); err != nil {
+		return err
+	}
+	r.DriverID = &driverID
+	return nil
+}
+
+// Cancel отменяет поездку с указанной причиной
+func (r *Ride) Cancel(reason string, at time.Time) error {
+	if err := r.TransitionTo(constants.RideStatusCancelled, at); err != nil {
+		return err
+	}
+	r.CancellationReason = &reason
+	return nil
+}
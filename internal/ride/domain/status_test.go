@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	constants "ridehail/internal/shared/const"
+)
+
+func TestRideTransitionTo(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr []error // nil — переход разрешен
+		stamp   func(r *Ride) *time.Time
+	}{
+		{name: "requested to matched", from: constants.RideStatusRequested, to: constants.RideStatusMatched, stamp: func(r *Ride) *time.Time { return r.MatchedAt }},
+		{name: "en route to arrived", from: constants.RideStatusEnRoute, to: constants.RideStatusArrived, stamp: func(r *Ride) *time.Time { return r.ArrivedAt }},
+		{name: "arrived to in progress", from: constants.RideStatusArrived, to: constants.RideStatusInProgress, stamp: func(r *Ride) *time.Time { return r.StartedAt }},
+		{name: "in progress to completed", from: constants.RideStatusInProgress, to: constants.RideStatusCompleted, stamp: func(r *Ride) *time.Time { return r.CompletedAt }},
+		{name: "scheduled to cancelled", from: constants.RideStatusScheduled, to: constants.RideStatusCancelled, stamp: func(r *Ride) *time.Time { return r.CancelledAt }},
+		{name: "requested skips matched", from: constants.RideStatusRequested, to: constants.RideStatusArrived, wantErr: []error{ErrInvalidTransition}},
+		{name: "in progress cannot be cancelled", from: constants.RideStatusInProgress, to: constants.RideStatusCancelled, wantErr: []error{ErrInvalidTransition}},
+		{name: "cancelled is terminal", from: constants.RideStatusCancelled, to: constants.RideStatusCancelled, wantErr: []error{ErrInvalidTransition, ErrRideAlreadyCancelled}},
+		{name: "completed is terminal", from: constants.RideStatusCompleted, to: constants.RideStatusCancelled, wantErr: []error{ErrInvalidTransition, ErrRideAlreadyCompleted}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ride := &Ride{Status: tc.from}
+			err := ride.TransitionTo(tc.to, at)
+
+			if tc.wantErr != nil {
+				var transitionErr *TransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("err = %v, want *TransitionError", err)
+				}
+				for _, want := range tc.wantErr {
+					if !errors.Is(err, want) {
+						t.Errorf("errors.Is(err, %v) = false", want)
+					}
+				}
+				if ride.Status != tc.from {
+					t.Errorf("status = %s, want unchanged %s", ride.Status, tc.from)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ride.Status != tc.to {
+				t.Errorf("status = %s, want %s", ride.Status, tc.to)
+			}
+			if stamp := tc.stamp(ride); stamp == nil || !stamp.Equal(at) {
+				t.Errorf("status timestamp = %v, want %v", stamp, at)
+			}
+			if !ride.UpdatedAt.Equal(at) {
+				t.Errorf("updated_at = %v, want %v", ride.UpdatedAt, at)
+			}
+		})
+	}
+}
+
+func TestRideCompletedIsNotCancelled(t *testing.T) {
+	ride := &Ride{Status: constants.RideStatusCompleted}
+	err := ride.Cancel("changed my mind", time.Now())
+	if errors.Is(err, ErrRideAlreadyCancelled) {
+		t.Errorf("completed ride reported as already cancelled: %v", err)
+	}
+	if ride.CancellationReason != nil {
+		t.Errorf("cancellation reason set on failed cancel")
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxRideStops — сколько промежуточных остановок можно добавить к поездке
+const MaxRideStops = 5
+
+// RideStop — промежуточная остановка между подачей и назначением (таблица ride_stops).
+// Адрес и координаты хранятся в coordinates, здесь — порядок и отметка прибытия.
+type RideStop struct {
+	ID           string
+	RideID       string
+	StopNumber   int // С 1, в порядке маршрута
+	CoordinateID string
+	Address      string
+	Latitude     float64
+	Longitude    float64
+	ArrivedAt    *time.Time // nil — водитель еще не доехал
+	CreatedAt    time.Time
+}
+
+// ValidateStopCount проверяет число промежуточных остановок
+func ValidateStopCount(n int) error {
+	if n > MaxRideStops {
+		return fmt.Errorf("%w: at most %d stops allowed", ErrInvalidStops, MaxRideStops)
+	}
+	return nil
+}
@@ -19,18 +19,30 @@ type Claims struct {
 
 // JWTService работает с JWT токенами
 type JWTService struct {
-	secret        []byte
-	expiryMinutes int
+	secret            []byte
+	expiryMinutes     int
+	refreshExpiryDays int
 }
 
 // NewJWTService создает новый сервис для работы с JWT
 func NewJWTService(cfg config.JWTConfig) *JWTService {
 	return &JWTService{
-		secret:        []byte(cfg.Secret),
-		expiryMinutes: cfg.ExpiryMinutes,
+		secret:            []byte(cfg.Secret),
+		expiryMinutes:     cfg.ExpiryMinutes,
+		refreshExpiryDays: cfg.RefreshExpiryDays,
 	}
 }
 
+// AccessTokenTTL возвращает время жизни access-токена
+func (s *JWTService) AccessTokenTTL() time.Duration {
+	return time.Duration(s.expiryMinutes) * time.Minute
+}
+
+// RefreshTokenTTL возвращает время жизни refresh-токена
+func (s *JWTService) RefreshTokenTTL() time.Duration {
+	return time.Duration(s.refreshExpiryDays) * 24 * time.Hour
+}
+
 // GenerateToken создает новый JWT токен для пользователя
 func (s *JWTService) GenerateToken(userID, email, role string) (string, error) {
 	now := time.Now()
@@ -86,7 +98,9 @@ func (s *JWTService) ExtractUserID(tokenString string) (userID, role string, err
 	return claims.UserID, claims.Role, nil
 }
 
-// RefreshToken обновляет токен (генерирует новый с обновленным expiry)
+// RefreshToken обновляет токен (генерирует новый с обновленным expiry).
+// Не отзывается и продлевает любой живой access-токен — для клиентов есть
+// POST /auth/refresh с отдельным refresh-токеном (см. NewRefreshToken).
 func (s *JWTService) RefreshToken(tokenString string) (string, error) {
 	claims, err := s.ValidateToken(tokenString)
 	if err != nil {
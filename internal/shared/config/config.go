@@ -11,11 +11,22 @@ import (
 
 // Config — полная конфигурация проекта
 type Config struct {
-	Database  DBConfig
-	RabbitMQ  MQConfig
-	WebSocket WSConfig
-	Services  ServicesConfig
-	JWT       JWTConfig
+	Database     DBConfig
+	RabbitMQ     MQConfig
+	WebSocket    WSConfig
+	Services     ServicesConfig
+	JWT          JWTConfig
+	Matching     MatchingConfig
+	Tracking     TrackingConfig
+	Quote        QuoteConfig
+	Pricing      PricingConfig
+	Rating       RatingConfig
+	Schedule     ScheduleConfig
+	Pool         PoolConfig
+	Cancellation CancellationConfig
+	Verification VerificationConfig
+	Dashboard    DashboardConfig
+	Analytics    AnalyticsConfig
 }
 
 type DBConfig struct {
@@ -46,8 +57,125 @@ type ServicesConfig struct {
 }
 
 type JWTConfig struct {
-	Secret        string
-	ExpiryMinutes int
+	Secret            string
+	ExpiryMinutes     int // TTL access-токена
+	RefreshExpiryDays int // TTL refresh-токена (хранится в БД, может быть отозван)
+}
+
+// MatchingConfig — параметры подбора водителя (повторные офферы, расширение радиуса)
+type MatchingConfig struct {
+	OfferTimeoutSeconds int     // Сколько водитель думает над оффером
+	BatchSize           int     // Сколько водителей получают оффер за один раунд
+	InitialRadiusKm     float64 // Стартовый радиус поиска
+	RadiusStepKm        float64 // На сколько расширяем радиус, когда кандидаты закончились
+	MaxRadiusKm         float64 // Максимальный радиус поиска
+	DeadlineSeconds     int     // После этого срока поездка отменяется с no_drivers_available
+
+	// Веса ранжирования кандидатов
+	DistanceWeight float64
+	RatingWeight   float64
+	IdleWeight     float64
+	IdleCapMinutes int // Простой дольше этого срока не дает дополнительного преимущества
+}
+
+// TrackingConfig — параметры live-трекинга водителя для пассажира
+type TrackingConfig struct {
+	MinIntervalSeconds int     // Не чаще одного обновления пассажиру за этот интервал
+	CacheTTLSeconds    int     // Страховочный TTL кэша "водитель → активная поездка"
+	DefaultSpeedKmh    float64 // Скорость для ETA, если водитель стоит или скорость неизвестна
+
+	ArrivalRadiusMeters float64 // Насколько близко к точке подачи водитель может отметить прибытие
+}
+
+// QuoteConfig — параметры предварительных расчетов стоимости (POST /rides/quote)
+type QuoteConfig struct {
+	TTLSeconds       int     // Сколько действует цена из квоты
+	SigningSecret    string  // Ключ HMAC подписи квоты
+	RouteToleranceKm float64 // Допустимое смещение точек поездки относительно квоты
+}
+
+// RatingConfig — правила оценок после поездки (POST /rides/{id}/rating)
+type RatingConfig struct {
+	WindowHours      int // Сколько часов после завершения поездку можно оценить
+	MaxTags          int // Максимум тегов в одной оценке
+	MaxCommentLength int // Максимальная длина комментария в символах
+}
+
+// ScheduleConfig — заказы заранее (POST /rides с scheduled_for)
+type ScheduleConfig struct {
+	MinAdvanceMinutes   int // Минимум до подачи; ближе — обычный заказ
+	MaxAdvanceDays      int // Насколько далеко вперед можно заказать
+	DispatchLeadMinutes int // За сколько минут до подачи поездка уходит в подбор водителя
+	ReminderLeadMinutes int // За сколько минут до подачи пассажир получает напоминание
+	FreeCancelMinutes   int // Отмена позже чем за N минут до подачи считается поздней
+	IntervalSeconds     int // Период планировщика; 0 — планировщик выключен
+	BatchSize           int // Сколько поездок планировщик обрабатывает за тик
+}
+
+// PoolConfig — совместные поездки (тип авто POOL)
+type PoolConfig struct {
+	DefaultSeats   int     // Мест для пассажиров, если в vehicle_attrs нет vehicle_seats
+	MaxDetourRatio float64 // Насколько (доля) может вырасти оставшийся путь уже едущего пассажира
+	MaxDetourKm    float64 // Абсолютный потолок такого удлинения
+	SharedDiscount float64 // Скидка за поездку, целиком проведенную с попутчиками (0..1)
+}
+
+// CancellationConfig — политика отмены поездки (сумма штрафа — tariffs.cancellation_fee)
+type CancellationConfig struct {
+	FreeWindowSeconds   int // Отмена в течение N секунд после заказа всегда бесплатна
+	EnRouteGraceMinutes int // Сколько минут водитель может ехать к пассажиру, пока отмена бесплатна
+	NoShowWaitMinutes   int // Минимум ожидания на точке подачи, после которого водитель может отменить за неявку
+}
+
+// VerificationConfig — проверка документов водителей
+type VerificationConfig struct {
+	ExpiryCheckIntervalSeconds int // Как часто искать водителей с истекшими документами (0 — выключено)
+	BatchSize                  int // Сколько водителей снимать с проверки за один проход
+}
+
+// DashboardConfig — живой дашборд администратора (GET /ws в Admin Service)
+type DashboardConfig struct {
+	MetricsIntervalSeconds int // Как часто пересчитывать метрики и рассылать изменившиеся
+	LocationFlushMillis    int // Как часто рассылать накопленные локации водителей
+	DriverStaleSeconds     int // Водитель без обновлений локации дольше этого срока убирается с карты
+}
+
+// AnalyticsConfig — роллапы для GET /admin/analytics
+type AnalyticsConfig struct {
+	RollupIntervalSeconds int // Как часто пересчитывать роллапы (0 — выключено)
+	RollupOverlapSeconds  int // Насколько раньше прошлого прогона начинать поиск измененных поездок
+}
+
+// PricingConfig — тарифы по умолчанию (используются, если в таблице tariffs нет строки)
+type PricingConfig struct {
+	DefaultCity           string                  // Город, если он не определен для поездки
+	AvgSpeedKmh           float64                 // Средняя скорость для оценки длительности
+	TariffCacheTTLSeconds int                     // Как долго тариф из БД кэшируется в памяти
+	Tariffs               map[string]TariffConfig // vehicle_type → тариф
+	Surge                 SurgeConfig
+}
+
+// SurgeConfig — повышающий коэффициент по ячейкам карты (спрос / предложение)
+type SurgeConfig struct {
+	IntervalSeconds   int     // Период пересчета; 0 — surge выключен
+	GeohashPrecision  int     // Размер ячейки: 5 ≈ 4.9 км, 6 ≈ 1.2 км
+	DemandThreshold   float64 // Отношение заказов к свободным водителям, с которого начинается surge
+	Sensitivity       float64 // Прирост коэффициента на единицу отношения сверх порога
+	MaxMultiplier     float64 // Потолок коэффициента
+	Smoothing         float64 // Доля нового значения при сглаживании (0..1]
+	StaleAfterSeconds int     // Коэффициент старше этого срока не применяется
+}
+
+// TariffConfig — ставки одного тарифа
+type TariffConfig struct {
+	BaseFare           float64
+	PerKm              float64
+	PerMinute          float64
+	MinimumFare        float64
+	BookingFee         float64
+	CancellationFee    float64
+	FreeWaitingMinutes int     // Бесплатное ожидание пассажира после прибытия водителя
+	PerWaitingMinute   float64 // Ставка за минуту ожидания сверх бесплатной
 }
 
 // Load — загрузка из CONFIG_DIR (по умолчанию ./config) + ENV перекрывает
@@ -117,19 +245,177 @@ func Load() Config {
 		if sec, ok := jwtKV["jwt"]; ok {
 			cfg.JWT.Secret = getStrWithEnvNested("JWT_SECRET", sec, "secret", "dev_secret")
 			cfg.JWT.ExpiryMinutes = getIntWithEnvNested("JWT_EXPIRY_MINUTES", sec, "expiry_minutes", 60)
+			cfg.JWT.RefreshExpiryDays = getIntWithEnvNested("JWT_REFRESH_EXPIRY_DAYS", sec, "refresh_expiry_days", 30)
 		} else {
 			// плоская структура
 			cfg.JWT.Secret = getStrWithEnv("JWT_SECRET", jwtKV, "secret", "dev_secret")
 			cfg.JWT.ExpiryMinutes = getIntWithEnv("JWT_EXPIRY_MINUTES", jwtKV, "expiry_minutes", 60)
+			cfg.JWT.RefreshExpiryDays = getIntWithEnv("JWT_REFRESH_EXPIRY_DAYS", jwtKV, "refresh_expiry_days", 30)
 		}
 	} else {
 		cfg.JWT.Secret = getEnv("JWT_SECRET", "dev_secret")
 		cfg.JWT.ExpiryMinutes = getEnvInt("JWT_EXPIRY_MINUTES", 60)
+		cfg.JWT.RefreshExpiryDays = getEnvInt("JWT_REFRESH_EXPIRY_DAYS", 30)
+	}
+
+	// matching.yaml
+	matchingPath := filepath.Join(configDir, "matching.yaml")
+	matchingKV, err := parseYAML(matchingPath)
+	if err != nil {
+		matchingKV = map[string]map[string]string{}
+	}
+	cfg.Matching.OfferTimeoutSeconds = getIntWithEnv("MATCHING_OFFER_TIMEOUT_SECONDS", matchingKV, "offer_timeout_seconds", 30)
+	cfg.Matching.BatchSize = getIntWithEnv("MATCHING_BATCH_SIZE", matchingKV, "batch_size", 5)
+	cfg.Matching.InitialRadiusKm = getFloatWithEnv("MATCHING_INITIAL_RADIUS_KM", matchingKV, "initial_radius_km", 5)
+	cfg.Matching.RadiusStepKm = getFloatWithEnv("MATCHING_RADIUS_STEP_KM", matchingKV, "radius_step_km", 5)
+	cfg.Matching.MaxRadiusKm = getFloatWithEnv("MATCHING_MAX_RADIUS_KM", matchingKV, "max_radius_km", 15)
+	cfg.Matching.DeadlineSeconds = getIntWithEnv("MATCHING_DEADLINE_SECONDS", matchingKV, "deadline_seconds", 180)
+	cfg.Matching.DistanceWeight = getFloatWithEnv("MATCHING_DISTANCE_WEIGHT", matchingKV, "distance_weight", 0.6)
+	cfg.Matching.RatingWeight = getFloatWithEnv("MATCHING_RATING_WEIGHT", matchingKV, "rating_weight", 0.25)
+	cfg.Matching.IdleWeight = getFloatWithEnv("MATCHING_IDLE_WEIGHT", matchingKV, "idle_weight", 0.15)
+	cfg.Matching.IdleCapMinutes = getIntWithEnv("MATCHING_IDLE_CAP_MINUTES", matchingKV, "idle_cap_minutes", 30)
+
+	// tracking.yaml
+	trackingPath := filepath.Join(configDir, "tracking.yaml")
+	trackingKV, err := parseYAML(trackingPath)
+	if err != nil {
+		trackingKV = map[string]map[string]string{}
+	}
+	cfg.Tracking.MinIntervalSeconds = getIntWithEnv("TRACKING_MIN_INTERVAL_SECONDS", trackingKV, "min_interval_seconds", 3)
+	cfg.Tracking.CacheTTLSeconds = getIntWithEnv("TRACKING_CACHE_TTL_SECONDS", trackingKV, "cache_ttl_seconds", 60)
+	cfg.Tracking.DefaultSpeedKmh = getFloatWithEnv("TRACKING_DEFAULT_SPEED_KMH", trackingKV, "default_speed_kmh", 30)
+	cfg.Tracking.ArrivalRadiusMeters = getFloatWithEnv("TRACKING_ARRIVAL_RADIUS_METERS", trackingKV, "arrival_radius_meters", 150)
+
+	// quote.yaml
+	quotePath := filepath.Join(configDir, "quote.yaml")
+	quoteKV, err := parseYAML(quotePath)
+	if err != nil {
+		quoteKV = map[string]map[string]string{}
+	}
+	cfg.Quote.TTLSeconds = getIntWithEnv("QUOTE_TTL_SECONDS", quoteKV, "ttl_seconds", 300)
+	cfg.Quote.SigningSecret = getStrWithEnv("QUOTE_SIGNING_SECRET", quoteKV, "signing_secret", "dev_quote_secret")
+	cfg.Quote.RouteToleranceKm = getFloatWithEnv("QUOTE_ROUTE_TOLERANCE_KM", quoteKV, "route_tolerance_km", 0.2)
+
+	// rating.yaml
+	ratingPath := filepath.Join(configDir, "rating.yaml")
+	ratingKV, err := parseYAML(ratingPath)
+	if err != nil {
+		ratingKV = map[string]map[string]string{}
+	}
+	cfg.Rating.WindowHours = getIntWithEnv("RATING_WINDOW_HOURS", ratingKV, "window_hours", 72)
+	cfg.Rating.MaxTags = getIntWithEnv("RATING_MAX_TAGS", ratingKV, "max_tags", 5)
+	cfg.Rating.MaxCommentLength = getIntWithEnv("RATING_MAX_COMMENT_LENGTH", ratingKV, "max_comment_length", 500)
+
+	// schedule.yaml
+	schedulePath := filepath.Join(configDir, "schedule.yaml")
+	scheduleKV, err := parseYAML(schedulePath)
+	if err != nil {
+		scheduleKV = map[string]map[string]string{}
+	}
+	cfg.Schedule.MinAdvanceMinutes = getIntWithEnv("SCHEDULE_MIN_ADVANCE_MINUTES", scheduleKV, "min_advance_minutes", 30)
+	cfg.Schedule.MaxAdvanceDays = getIntWithEnv("SCHEDULE_MAX_ADVANCE_DAYS", scheduleKV, "max_advance_days", 7)
+	cfg.Schedule.DispatchLeadMinutes = getIntWithEnv("SCHEDULE_DISPATCH_LEAD_MINUTES", scheduleKV, "dispatch_lead_minutes", 15)
+	cfg.Schedule.ReminderLeadMinutes = getIntWithEnv("SCHEDULE_REMINDER_LEAD_MINUTES", scheduleKV, "reminder_lead_minutes", 60)
+	cfg.Schedule.FreeCancelMinutes = getIntWithEnv("SCHEDULE_FREE_CANCEL_MINUTES", scheduleKV, "free_cancel_minutes", 60)
+	cfg.Schedule.IntervalSeconds = getIntWithEnv("SCHEDULE_INTERVAL_SECONDS", scheduleKV, "interval_seconds", 30)
+	cfg.Schedule.BatchSize = getIntWithEnv("SCHEDULE_BATCH_SIZE", scheduleKV, "batch_size", 100)
+
+	// pool.yaml
+	poolPath := filepath.Join(configDir, "pool.yaml")
+	poolKV, err := parseYAML(poolPath)
+	if err != nil {
+		poolKV = map[string]map[string]string{}
+	}
+	cfg.Pool.DefaultSeats = getIntWithEnv("POOL_DEFAULT_SEATS", poolKV, "default_seats", 3)
+	cfg.Pool.MaxDetourRatio = getFloatWithEnv("POOL_MAX_DETOUR_RATIO", poolKV, "max_detour_ratio", 0.5)
+	cfg.Pool.MaxDetourKm = getFloatWithEnv("POOL_MAX_DETOUR_KM", poolKV, "max_detour_km", 5)
+	cfg.Pool.SharedDiscount = getFloatWithEnv("POOL_SHARED_DISCOUNT", poolKV, "shared_discount", 0.25)
+
+	// cancellation.yaml
+	cancelPath := filepath.Join(configDir, "cancellation.yaml")
+	cancelKV, err := parseYAML(cancelPath)
+	if err != nil {
+		cancelKV = map[string]map[string]string{}
+	}
+	cfg.Cancellation.FreeWindowSeconds = getIntWithEnv("CANCEL_FREE_WINDOW_SECONDS", cancelKV, "free_window_seconds", 120)
+	cfg.Cancellation.EnRouteGraceMinutes = getIntWithEnv("CANCEL_EN_ROUTE_GRACE_MINUTES", cancelKV, "en_route_grace_minutes", 3)
+	cfg.Cancellation.NoShowWaitMinutes = getIntWithEnv("CANCEL_NO_SHOW_WAIT_MINUTES", cancelKV, "no_show_wait_minutes", 5)
+
+	// verification.yaml
+	verificationPath := filepath.Join(configDir, "verification.yaml")
+	verificationKV, err := parseYAML(verificationPath)
+	if err != nil {
+		verificationKV = map[string]map[string]string{}
+	}
+	cfg.Verification.ExpiryCheckIntervalSeconds = getIntWithEnv("VERIFICATION_EXPIRY_CHECK_INTERVAL_SECONDS", verificationKV, "expiry_check_interval_seconds", 3600)
+	cfg.Verification.BatchSize = getIntWithEnv("VERIFICATION_BATCH_SIZE", verificationKV, "batch_size", 100)
+
+	// dashboard.yaml
+	dashboardPath := filepath.Join(configDir, "dashboard.yaml")
+	dashboardKV, err := parseYAML(dashboardPath)
+	if err != nil {
+		dashboardKV = map[string]map[string]string{}
+	}
+	cfg.Dashboard.MetricsIntervalSeconds = getIntWithEnv("DASHBOARD_METRICS_INTERVAL_SECONDS", dashboardKV, "metrics_interval_seconds", 5)
+	cfg.Dashboard.LocationFlushMillis = getIntWithEnv("DASHBOARD_LOCATION_FLUSH_MILLIS", dashboardKV, "location_flush_millis", 1000)
+	cfg.Dashboard.DriverStaleSeconds = getIntWithEnv("DASHBOARD_DRIVER_STALE_SECONDS", dashboardKV, "driver_stale_seconds", 300)
+
+	// analytics.yaml
+	analyticsPath := filepath.Join(configDir, "analytics.yaml")
+	analyticsKV, err := parseYAML(analyticsPath)
+	if err != nil {
+		analyticsKV = map[string]map[string]string{}
+	}
+	cfg.Analytics.RollupIntervalSeconds = getIntWithEnv("ANALYTICS_ROLLUP_INTERVAL_SECONDS", analyticsKV, "rollup_interval_seconds", 300)
+	cfg.Analytics.RollupOverlapSeconds = getIntWithEnv("ANALYTICS_ROLLUP_OVERLAP_SECONDS", analyticsKV, "rollup_overlap_seconds", 300)
+
+	// pricing.yaml: корневые ключи + секция на каждый тип авто (economy, premium, xl, pool)
+	pricingPath := filepath.Join(configDir, "pricing.yaml")
+	pricingKV, err := parseYAML(pricingPath)
+	if err != nil {
+		pricingKV = map[string]map[string]string{}
+	}
+	cfg.Pricing.DefaultCity = getStrWithEnv("PRICING_DEFAULT_CITY", pricingKV, "default_city", "default")
+	cfg.Pricing.AvgSpeedKmh = getFloatWithEnv("PRICING_AVG_SPEED_KMH", pricingKV, "avg_speed_kmh", 40)
+	cfg.Pricing.TariffCacheTTLSeconds = getIntWithEnv("PRICING_TARIFF_CACHE_TTL_SECONDS", pricingKV, "tariff_cache_ttl_seconds", 60)
+	cfg.Pricing.Tariffs = map[string]TariffConfig{}
+	for vehicleType, def := range defaultTariffs {
+		section := pricingKV[strings.ToLower(vehicleType)]
+		env := "PRICING_" + vehicleType + "_"
+		cfg.Pricing.Tariffs[vehicleType] = TariffConfig{
+			BaseFare:           getFloatWithEnvNested(env+"BASE_FARE", section, "base_fare", def.BaseFare),
+			PerKm:              getFloatWithEnvNested(env+"PER_KM", section, "per_km", def.PerKm),
+			PerMinute:          getFloatWithEnvNested(env+"PER_MINUTE", section, "per_minute", def.PerMinute),
+			MinimumFare:        getFloatWithEnvNested(env+"MINIMUM_FARE", section, "minimum_fare", def.MinimumFare),
+			BookingFee:         getFloatWithEnvNested(env+"BOOKING_FEE", section, "booking_fee", def.BookingFee),
+			CancellationFee:    getFloatWithEnvNested(env+"CANCELLATION_FEE", section, "cancellation_fee", def.CancellationFee),
+			FreeWaitingMinutes: getIntWithEnvNested(env+"FREE_WAITING_MINUTES", section, "free_waiting_minutes", def.FreeWaitingMinutes),
+			PerWaitingMinute:   getFloatWithEnvNested(env+"PER_WAITING_MINUTE", section, "per_waiting_minute", def.PerWaitingMinute),
+		}
+	}
+
+	surgeKV := pricingKV["surge"]
+	cfg.Pricing.Surge = SurgeConfig{
+		IntervalSeconds:   getIntWithEnvNested("SURGE_INTERVAL_SECONDS", surgeKV, "interval_seconds", 30),
+		GeohashPrecision:  getIntWithEnvNested("SURGE_GEOHASH_PRECISION", surgeKV, "geohash_precision", 5),
+		DemandThreshold:   getFloatWithEnvNested("SURGE_DEMAND_THRESHOLD", surgeKV, "demand_threshold", 1.0),
+		Sensitivity:       getFloatWithEnvNested("SURGE_SENSITIVITY", surgeKV, "sensitivity", 0.5),
+		MaxMultiplier:     getFloatWithEnvNested("SURGE_MAX_MULTIPLIER", surgeKV, "max_multiplier", 2.5),
+		Smoothing:         getFloatWithEnvNested("SURGE_SMOOTHING", surgeKV, "smoothing", 0.3),
+		StaleAfterSeconds: getIntWithEnvNested("SURGE_STALE_AFTER_SECONDS", surgeKV, "stale_after_seconds", 300),
 	}
 
 	return cfg
 }
 
+// defaultTariffs — тарифы, если нет ни pricing.yaml, ни строки в tariffs
+var defaultTariffs = map[string]TariffConfig{
+	"ECONOMY": {BaseFare: 50, PerKm: 15, PerMinute: 3, MinimumFare: 100, BookingFee: 10, CancellationFee: 50, FreeWaitingMinutes: 3, PerWaitingMinute: 5},
+	"PREMIUM": {BaseFare: 100, PerKm: 25, PerMinute: 5, MinimumFare: 200, BookingFee: 15, CancellationFee: 100, FreeWaitingMinutes: 5, PerWaitingMinute: 8},
+	"XL":      {BaseFare: 80, PerKm: 20, PerMinute: 4, MinimumFare: 150, BookingFee: 15, CancellationFee: 75, FreeWaitingMinutes: 3, PerWaitingMinute: 6},
+	"POOL":    {BaseFare: 40, PerKm: 11, PerMinute: 2, MinimumFare: 80, BookingFee: 10, CancellationFee: 40, FreeWaitingMinutes: 2, PerWaitingMinute: 5},
+}
+
 // parseYAML — парсит простые YAML файлы без глубокой вложенности
 // Формат: key: value (плоский) либо section: \n  key: value
 func parseYAML(path string) (map[string]map[string]string, error) {
@@ -225,6 +511,20 @@ func getIntWithEnv(envKey string, yaml map[string]map[string]string, key string,
 	return def
 }
 
+func getFloatWithEnv(envKey string, yaml map[string]map[string]string, key string, def float64) float64 {
+	if v := strings.TrimSpace(os.Getenv(envKey)); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	if val, ok := yaml[""][key]; ok && val != "" {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
 func getStrWithEnvNested(envKey string, section map[string]string, key, def string) string {
 	if v := strings.TrimSpace(os.Getenv(envKey)); v != "" {
 		return v
@@ -249,6 +549,20 @@ func getIntWithEnvNested(envKey string, section map[string]string, key string, d
 	return def
 }
 
+func getFloatWithEnvNested(envKey string, section map[string]string, key string, def float64) float64 {
+	if v := strings.TrimSpace(os.Getenv(envKey)); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	if val, ok := section[key]; ok && val != "" {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
 // DSN возвращает строку подключения к БД
 func (c DBConfig) DSN() string {
 	return fmt.Sprintf(
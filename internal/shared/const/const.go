@@ -16,6 +16,7 @@ const (
 
 // ==== Ride Status ====
 const (
+	RideStatusScheduled  = "SCHEDULED"
 	RideStatusRequested  = "REQUESTED"
 	RideStatusMatched    = "MATCHED"
 	RideStatusEnRoute    = "EN_ROUTE"
@@ -30,6 +31,7 @@ const (
 	VehicleEconomy = "ECONOMY"
 	VehiclePremium = "PREMIUM"
 	VehicleXL      = "XL"
+	VehiclePool    = "POOL" // Совместная поездка: водитель везет нескольких пассажиров по пути
 )
 
 // ==== Ride Event Type ====
@@ -43,4 +45,34 @@ const (
 	EventStatusChanged   = "STATUS_CHANGED"
 	EventLocationUpdated = "LOCATION_UPDATED"
 	EventFareAdjusted    = "FARE_ADJUSTED"
+	EventStopArrived     = "STOP_ARRIVED"
+
+	// POOL: посадка и высадка конкретного пассажира совместной поездки
+	EventPassengerPickedUp   = "PASSENGER_PICKED_UP"
+	EventPassengerDroppedOff = "PASSENGER_DROPPED_OFF"
+)
+
+// ==== Matching Status ====
+// Этапы подбора водителя, которые Driver Service сообщает Ride Service
+const (
+	MatchingStatusSearching      = "SEARCHING"
+	MatchingStatusOffersSent     = "OFFERS_SENT"
+	MatchingStatusRetrying       = "RETRYING"
+	MatchingStatusRadiusExpanded = "RADIUS_EXPANDED"
+	MatchingStatusNoDrivers      = "NO_DRIVERS_AVAILABLE"
+)
+
+// ==== Cancellation Reason ====
+const (
+	CancellationReasonNoDrivers = "no_drivers_available"
+	CancellationReasonNoShow    = "passenger_no_show"
+)
+
+// ==== Cancellation Fee Reason ====
+// Почему отмена платная (fee_reason); бесплатная отмена причины не имеет
+const (
+	FeeReasonDriverEnRoute = "driver_en_route" // Водитель едет к пассажиру дольше en_route_grace_minutes
+	FeeReasonDriverArrived = "driver_arrived"  // Водитель уже на точке подачи
+	FeeReasonLateScheduled = "late_scheduled"  // Заказ заранее отменен позже free_cancel_minutes до подачи
+	FeeReasonNoShow        = "no_show"         // Пассажир не вышел, водитель отменил после ожидания
 )
@@ -0,0 +1,152 @@
+// Package contract описывает сообщения RabbitMQ, которыми обмениваются
+// Ride Service, Driver Service и Admin Service.
+//
+// Каждое сообщение несет поле "version". Изменение, ломающее совместимость
+// (удаление/переименование поля, смена типа), требует увеличения Version
+// и обновления golden-фикстур (testdata/, проверяются в contract_test.go).
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	constants "ridehail/internal/shared/const"
+)
+
+// Version — текущая версия контракта сообщений
+const Version = 1
+
+// Exchanges
+const (
+	ExchangeRide     = "ride_topic"
+	ExchangeDriver   = "driver_topic"
+	ExchangeLocation = "location_fanout"
+	ExchangeUser     = "user_topic"
+)
+
+// Очереди и routing keys
+const (
+	QueueDriverMatching = "driver_matching" // ride.requested → координатор подбора в Driver Service
+
+	RoutingKeyRideRequested = "ride.requested"
+	RoutingKeyRideMatched   = "ride.matched"
+	RoutingKeyRideStarted   = "ride.started"
+	RoutingKeyRideCompleted = "ride.completed"
+	RoutingKeyRideCancelled = "ride.cancelled"
+	RoutingKeyRideEvent     = "ride.event"
+	RoutingPatternRideAll   = "ride.*" // Все события поездок (дашборд Admin Service)
+
+	RoutingPatternDriverResponse = "driver.response.*"
+	RoutingPatternMatchingStatus = "driver.matching.*"
+	RoutingPatternDriverStatus   = "driver.status.*"
+	RoutingPatternDriverArrived  = "driver.arrived.*"
+	RoutingPatternStopArrived    = "driver.stop_arrived.*"
+	RoutingPatternNoShow         = "driver.no_show.*"
+	RoutingPatternVerification   = "driver.verification.*"
+
+	RoutingPatternSessionsRevoked = "user.sessions_revoked.*"
+)
+
+// ErrUnsupportedVersion возвращается при декодировании сообщения другой версии контракта
+var ErrUnsupportedVersion = errors.New("unsupported message contract version")
+
+// Header — общая часть всех сообщений
+type Header struct {
+	Version int `json:"version"`
+}
+
+// ContractVersion возвращает версию контракта, с которой сообщение было закодировано
+func (h Header) ContractVersion() int {
+	return h.Version
+}
+
+func (h *Header) stamp() {
+	h.Version = Version
+}
+
+// Message — сообщение контракта (указатель на структуру со встроенным Header)
+type Message interface {
+	ContractVersion() int
+	stamp()
+}
+
+// Encode проставляет текущую версию контракта и кодирует сообщение в JSON
+func Encode(msg Message) ([]byte, error) {
+	msg.stamp()
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+	return body, nil
+}
+
+// Decode декодирует сообщение и проверяет версию контракта
+func Decode(body []byte, msg Message) error {
+	if err := json.Unmarshal(body, msg); err != nil {
+		return fmt.Errorf("decode message: %w", err)
+	}
+	if v := msg.ContractVersion(); v != Version {
+		return fmt.Errorf("%w: got %d, expected %d", ErrUnsupportedVersion, v, Version)
+	}
+	return nil
+}
+
+// RideRoutingKey возвращает routing key ride_topic для типа события поездки
+func RideRoutingKey(eventType string) string {
+	switch eventType {
+	case constants.EventRideRequested:
+		return RoutingKeyRideRequested
+	case constants.EventDriverMatched:
+		return RoutingKeyRideMatched
+	case constants.EventRideStarted:
+		return RoutingKeyRideStarted
+	case constants.EventRideCompleted:
+		return RoutingKeyRideCompleted
+	case constants.EventRideCancelled:
+		return RoutingKeyRideCancelled
+	default:
+		return RoutingKeyRideEvent
+	}
+}
+
+// DriverResponseKey — routing key ответа водителя: driver.response.{ride_id}
+func DriverResponseKey(rideID string) string {
+	return "driver.response." + rideID
+}
+
+// MatchingStatusKey — routing key этапа подбора: driver.matching.{ride_id}
+func MatchingStatusKey(rideID string) string {
+	return "driver.matching." + rideID
+}
+
+// DriverStatusKey — routing key статуса водителя: driver.status.{driver_id}
+func DriverStatusKey(driverID string) string {
+	return "driver.status." + driverID
+}
+
+// DriverArrivedKey — routing key прибытия водителя на точку подачи: driver.arrived.{ride_id}
+func DriverArrivedKey(rideID string) string {
+	return "driver.arrived." + rideID
+}
+
+// StopArrivedKey — routing key прибытия на промежуточную остановку: driver.stop_arrived.{ride_id}
+func StopArrivedKey(rideID string) string {
+	return "driver.stop_arrived." + rideID
+}
+
+// NoShowKey — routing key отмены за неявку пассажира: driver.no_show.{ride_id}
+func NoShowKey(rideID string) string {
+	return "driver.no_show." + rideID
+}
+
+// VerificationKey — routing key решения по заявке водителя: driver.verification.{driver_id}
+func VerificationKey(driverID string) string {
+	return "driver.verification." + driverID
+}
+
+// SessionsRevokedKey — routing key отзыва сессий пользователя: user.sessions_revoked.{user_id}
+func SessionsRevokedKey(userID string) string {
+	return "user.sessions_revoked." + userID
+}
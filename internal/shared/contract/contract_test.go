@@ -0,0 +1,127 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	constants "ridehail/internal/shared/const"
+)
+
+// goldenCases — по одной фикстуре на каждый тип сообщения контракта (testdata/*.v1.json)
+var goldenCases = []struct {
+	name    string
+	fixture string
+	new     func() Message
+}{
+	{name: "ride.requested", fixture: "ride_requested.v1.json", new: func() Message { return &RideRequested{} }},
+	{name: "ride.cancelled", fixture: "ride_status_changed.v1.json", new: func() Message { return &RideStatusChanged{} }},
+	{name: "driver.response", fixture: "driver_response.v1.json", new: func() Message { return &DriverResponse{} }},
+	{name: "driver.matching", fixture: "matching_status.v1.json", new: func() Message { return &MatchingStatus{} }},
+	{name: "driver.status", fixture: "driver_status_changed.v1.json", new: func() Message { return &DriverStatusChanged{} }},
+	{name: "driver.arrived", fixture: "driver_arrived.v1.json", new: func() Message { return &DriverArrived{} }},
+	{name: "driver.stop_arrived", fixture: "stop_arrived.v1.json", new: func() Message { return &StopArrived{} }},
+	{name: "driver.no_show", fixture: "passenger_no_show.v1.json", new: func() Message { return &PassengerNoShow{} }},
+	{name: "driver.verification", fixture: "driver_verification.v1.json", new: func() Message { return &DriverVerification{} }},
+	{name: "user.sessions_revoked", fixture: "user_sessions_revoked.v1.json", new: func() Message { return &UserSessionsRevoked{} }},
+	{name: "location_fanout", fixture: "location_update.v1.json", new: func() Message { return &LocationUpdate{} }},
+}
+
+// TestGoldenFixtures проверяет контракт по golden-фикстурам:
+// строгое декодирование (неизвестное поле — ошибка) и версия, затем Encode
+// и сравнение с фикстурой после нормализации JSON (порядок ключей, пробелы).
+func TestGoldenFixtures(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fixture, err := os.ReadFile(filepath.Join("testdata", tc.fixture))
+			if err != nil {
+				t.Fatalf("read fixture: %v", err)
+			}
+
+			msg := tc.new()
+			dec := json.NewDecoder(bytes.NewReader(fixture))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(msg); err != nil {
+				t.Fatalf("strict decode: %v", err)
+			}
+			if v := msg.ContractVersion(); v != Version {
+				t.Fatalf("version = %d, want %d", v, Version)
+			}
+
+			encoded, err := Encode(msg)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			want, got := normalizeJSON(t, fixture), normalizeJSON(t, encoded)
+			if !bytes.Equal(want, got) {
+				t.Errorf("round trip mismatch:\n  want %s\n  got  %s", want, got)
+			}
+		})
+	}
+}
+
+// TestGoldenFixturesCovered — каждая фикстура в testdata проверяется
+func TestGoldenFixturesCovered(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	covered := make(map[string]bool, len(goldenCases))
+	for _, tc := range goldenCases {
+		covered[tc.fixture] = true
+	}
+	for _, file := range files {
+		if !covered[filepath.Base(file)] {
+			t.Errorf("fixture %s has no golden case", filepath.Base(file))
+		}
+	}
+}
+
+func TestDecodeRejectsOtherVersion(t *testing.T) {
+	var msg RideStatusChanged
+	err := Decode([]byte(`{"version": 2, "ride_id": "r1"}`), &msg)
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("Decode() error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestRideRoutingKey(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      string
+	}{
+		{constants.EventRideRequested, RoutingKeyRideRequested},
+		{constants.EventDriverMatched, RoutingKeyRideMatched},
+		{constants.EventRideStarted, RoutingKeyRideStarted},
+		{constants.EventRideCompleted, RoutingKeyRideCompleted},
+		{constants.EventRideCancelled, RoutingKeyRideCancelled},
+		{constants.EventDriverArrived, RoutingKeyRideEvent},
+		{"UNKNOWN", RoutingKeyRideEvent},
+	}
+
+	for _, tt := range tests {
+		if got := RideRoutingKey(tt.eventType); got != tt.want {
+			t.Errorf("RideRoutingKey(%q) = %q, want %q", tt.eventType, got, tt.want)
+		}
+	}
+}
+
+// normalizeJSON приводит JSON к каноническому виду (ключи объектов отсортированы)
+func normalizeJSON(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	return out
+}
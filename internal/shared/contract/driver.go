@@ -0,0 +1,129 @@
+package contract
+
+// LatLng — координаты без адреса
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Vehicle — данные автомобиля для пассажира
+type Vehicle struct {
+	Make  string `json:"make"`
+	Model string `json:"model"`
+	Color string `json:"color"`
+	Plate string `json:"plate"`
+}
+
+// DriverInfo — данные водителя для пассажира
+type DriverInfo struct {
+	Name    string   `json:"name"`
+	Rating  float64  `json:"rating"`
+	Vehicle *Vehicle `json:"vehicle,omitempty"`
+}
+
+// DriverResponse — ответ водителя на оффер.
+//
+// Exchange: driver_topic, routing key: driver.response.{ride_id}.
+type DriverResponse struct {
+	Header
+	RideID                  string      `json:"ride_id"`
+	DriverID                string      `json:"driver_id"`
+	Accepted                bool        `json:"accepted"`
+	EstimatedArrivalMinutes int         `json:"estimated_arrival_minutes,omitempty"`
+	DriverLocation          *LatLng     `json:"driver_location,omitempty"`
+	DriverInfo              *DriverInfo `json:"driver_info,omitempty"`
+	CorrelationID           string      `json:"correlation_id,omitempty"`
+}
+
+// MatchingStatus — этап подбора водителя (SEARCHING, OFFERS_SENT, ..., NO_DRIVERS_AVAILABLE).
+//
+// Exchange: driver_topic, routing key: driver.matching.{ride_id}.
+type MatchingStatus struct {
+	Header
+	RideID     string  `json:"ride_id"`
+	Status     string  `json:"status"`
+	Round      int     `json:"round"`
+	RadiusKm   float64 `json:"radius_km"`
+	OffersSent int     `json:"offers_sent,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
+	Timestamp  string  `json:"timestamp"`
+}
+
+// DriverStatusChanged — изменение статуса водителя.
+//
+// Exchange: driver_topic, routing key: driver.status.{driver_id}.
+type DriverStatusChanged struct {
+	Header
+	DriverID  string `json:"driver_id"`
+	Status    string `json:"status"`
+	RideID    string `json:"ride_id,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// DriverArrived — водитель прибыл на точку подачи, начался отсчет ожидания.
+//
+// Exchange: driver_topic, routing key: driver.arrived.{ride_id}.
+type DriverArrived struct {
+	Header
+	RideID                 string  `json:"ride_id"`
+	DriverID               string  `json:"driver_id"`
+	Location               LatLng  `json:"location"`
+	DistanceToPickupMeters float64 `json:"distance_to_pickup_meters"`
+	FreeWaitingMinutes     int     `json:"free_waiting_minutes"`
+	ArrivedAt              string  `json:"arrived_at"`
+}
+
+// StopArrived — водитель прибыл на промежуточную остановку поездки.
+//
+// Exchange: driver_topic, routing key: driver.stop_arrived.{ride_id}.
+type StopArrived struct {
+	Header
+	RideID               string  `json:"ride_id"`
+	DriverID             string  `json:"driver_id"`
+	StopNumber           int     `json:"stop_number"` // С 1, в порядке маршрута
+	TotalStops           int     `json:"total_stops"`
+	Address              string  `json:"address,omitempty"`
+	Location             LatLng  `json:"location"`
+	DistanceToStopMeters float64 `json:"distance_to_stop_meters"`
+	ArrivedAt            string  `json:"arrived_at"`
+}
+
+// PassengerNoShow — водитель отменил поездку: пассажир не вышел к машине.
+// Поездка уже CANCELLED, штраф записан Driver Service.
+//
+// Exchange: driver_topic, routing key: driver.no_show.{ride_id}.
+type PassengerNoShow struct {
+	Header
+	RideID          string  `json:"ride_id"`
+	DriverID        string  `json:"driver_id"`
+	WaitedMinutes   int     `json:"waited_minutes"`
+	CancellationFee float64 `json:"cancellation_fee"`
+	CancelledAt     string  `json:"cancelled_at"`
+}
+
+// DriverVerification — решение администратора по заявке водителя на проверку.
+// При APPROVED водитель уже отмечен is_verified и может выйти на линию.
+//
+// Exchange: driver_topic, routing key: driver.verification.{driver_id}.
+type DriverVerification struct {
+	Header
+	DriverID      string `json:"driver_id"`
+	ApplicationID string `json:"application_id"`
+	Status        string `json:"status"` // APPROVED | REJECTED
+	Reason        string `json:"reason,omitempty"`
+	VerifiedUntil string `json:"verified_until,omitempty"` // YYYY-MM-DD, только для APPROVED
+	DecidedAt     string `json:"decided_at"`
+}
+
+// LocationUpdate — обновление локации водителя.
+//
+// Exchange: location_fanout (routing key не используется).
+type LocationUpdate struct {
+	Header
+	DriverID       string  `json:"driver_id"`
+	RideID         string  `json:"ride_id,omitempty"`
+	Location       LatLng  `json:"location"`
+	SpeedKmh       float64 `json:"speed_kmh,omitempty"`
+	HeadingDegrees float64 `json:"heading_degrees,omitempty"`
+	Timestamp      string  `json:"timestamp"`
+}
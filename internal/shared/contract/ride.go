@@ -0,0 +1,43 @@
+package contract
+
+// Location — точка маршрута
+type Location struct {
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+	Address string  `json:"address,omitempty"`
+}
+
+// RideRequested — новая поездка ждет водителя.
+//
+// Exchange: ride_topic, routing key: ride.requested, очередь: driver_matching.
+// Публикует Ride Service (через outbox), потребляет координатор подбора.
+type RideRequested struct {
+	Header
+	RideID                   string     `json:"ride_id"`
+	RideNumber               string     `json:"ride_number"`
+	PassengerID              string     `json:"passenger_id"`
+	PickupLocation           Location   `json:"pickup_location"`
+	DestinationLocation      Location   `json:"destination_location"`
+	Stops                    []Location `json:"stops,omitempty"` // Промежуточные остановки по порядку
+	RideType                 string     `json:"ride_type"`       // ECONOMY, PREMIUM, XL
+	EstimatedFare            float64    `json:"estimated_fare"`
+	EstimatedDurationMinutes int        `json:"estimated_duration_minutes,omitempty"` // Оценка времени в пути по маршруту, мин
+	MaxDistanceKm            float64    `json:"max_distance_km,omitempty"`            // 0 — радиус матчера по умолчанию
+	TimeoutSeconds           int        `json:"timeout_seconds,omitempty"`            // 0 — таймаут оффера по умолчанию
+	CorrelationID            string     `json:"correlation_id,omitempty"`
+	RequestedAt              string     `json:"requested_at"`
+}
+
+// RideStatusChanged — изменение статуса поездки (ride.matched, ride.completed, ride.cancelled, ...).
+//
+// Exchange: ride_topic, routing key: RideRoutingKey(event_type).
+type RideStatusChanged struct {
+	Header
+	EventType      string                 `json:"event_type"`
+	RideID         string                 `json:"ride_id"`
+	PassengerID    string                 `json:"passenger_id"`
+	DriverID       *string                `json:"driver_id,omitempty"`
+	Status         string                 `json:"status"`
+	VehicleType    string                 `json:"vehicle_type"`
+	AdditionalData map[string]interface{} `json:"additional_data,omitempty"`
+}
@@ -0,0 +1,16 @@
+package contract
+
+// UserSessionsRevoked — администратор заблокировал, деактивировал, удалил
+// пользователя или сменил ему роль. Изменение уже записано Admin Service;
+// сервисы с WebSocket закрывают соединения пользователя, новые запросы
+// отсекают auth middleware по статусу в БД.
+//
+// Exchange: user_topic, routing key: user.sessions_revoked.{user_id}.
+type UserSessionsRevoked struct {
+	Header
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`   // роль, под которой открыты сессии: PASSENGER | DRIVER | ADMIN
+	Status    string `json:"status"` // ACTIVE (смена роли) | INACTIVE | BANNED
+	Reason    string `json:"reason"` // banned | suspended | deleted | role_changed
+	RevokedAt string `json:"revoked_at"`
+}
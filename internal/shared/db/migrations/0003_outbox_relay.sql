@@ -0,0 +1,8 @@
+-- Outbox relay: backoff and delivery bookkeeping. Idempotent, no BEGIN/COMMIT.
+
+alter table outbox add column if not exists next_attempt_at timestamptz not null default now();
+alter table outbox add column if not exists sent_at timestamptz;
+alter table outbox add column if not exists last_error text;
+
+-- Relay выбирает PENDING/FAILED строки, у которых наступило время повтора
+create index if not exists idx_outbox_pending on outbox(next_attempt_at) where status in ('PENDING', 'FAILED');
@@ -0,0 +1,3 @@
+-- Ride event store: timeline lookups by ride. Idempotent, no BEGIN/COMMIT.
+
+create index if not exists idx_ride_events_ride on ride_events(ride_id, created_at);
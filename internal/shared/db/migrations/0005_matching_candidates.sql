@@ -0,0 +1,5 @@
+-- Driver matching: candidate filter and idle-time lookups. Idempotent, no BEGIN/COMMIT.
+
+create index if not exists idx_drivers_matching on drivers(status, vehicle_type) where is_verified = true;
+create index if not exists idx_rides_driver_completed on rides(driver_id, completed_at desc);
+create index if not exists idx_driver_sessions_open on driver_sessions(driver_id) where ended_at is null;
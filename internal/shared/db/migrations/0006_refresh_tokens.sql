@@ -0,0 +1,13 @@
+-- Refresh-токены (хранится только SHA-256 хеш). Idempotent, no BEGIN/COMMIT.
+
+create table if not exists refresh_tokens (
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references users(id) on delete cascade,
+    token_hash text not null unique,
+    expires_at timestamptz not null,
+    revoked_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+-- Отзыв всех активных токенов пользователя (повторное использование, бан)
+create index if not exists idx_refresh_tokens_user_active on refresh_tokens(user_id) where revoked_at is null;
@@ -0,0 +1,4 @@
+-- Ride history: keyset pagination by passenger on (requested_at, id). Idempotent, no BEGIN/COMMIT.
+
+create index if not exists idx_rides_passenger_requested
+    on rides(passenger_id, requested_at desc, id desc);
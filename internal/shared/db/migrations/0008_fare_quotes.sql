@@ -0,0 +1,27 @@
+-- Fare quotes: signed price estimates issued before a ride is requested.
+-- ride_id / quoted_fare are filled when a quote is redeemed, so quoted vs final
+-- fare can be compared with a join on rides. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists fare_quotes (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    passenger_id uuid not null references users(id),
+    pickup_lat decimal(10,8) not null check (pickup_lat between -90 and 90),
+    pickup_lng decimal(11,8) not null check (pickup_lng between -180 and 180),
+    pickup_address text not null,
+    destination_lat decimal(10,8) not null check (destination_lat between -90 and 90),
+    destination_lng decimal(11,8) not null check (destination_lng between -180 and 180),
+    destination_address text not null,
+    distance_km decimal(8,2) not null check (distance_km >= 0),
+    duration_minutes integer not null check (duration_minutes >= 0),
+    estimates jsonb not null, -- {"ECONOMY": 123.45, "PREMIUM": ..., "XL": ...}
+    signature text not null,
+    expires_at timestamptz not null,
+    ride_id uuid references rides(id),
+    vehicle_type text references vehicle_type(value),
+    quoted_fare decimal(10,2),
+    used_at timestamptz
+);
+
+create index if not exists idx_fare_quotes_ride on fare_quotes(ride_id) where ride_id is not null;
+create index if not exists idx_fare_quotes_passenger on fare_quotes(passenger_id, created_at desc);
@@ -0,0 +1,24 @@
+-- Tariffs per city and vehicle type + itemised fare breakdown on rides.
+-- city = 'default' applies wherever a city has no own row. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists tariffs (
+    city text not null,
+    vehicle_type text not null references vehicle_type(value),
+    base_fare decimal(10,2) not null check (base_fare >= 0),
+    per_km decimal(10,2) not null check (per_km >= 0),
+    per_minute decimal(10,2) not null check (per_minute >= 0),
+    minimum_fare decimal(10,2) not null default 0 check (minimum_fare >= 0),
+    booking_fee decimal(10,2) not null default 0 check (booking_fee >= 0),
+    cancellation_fee decimal(10,2) not null default 0 check (cancellation_fee >= 0),
+    updated_at timestamptz not null default now(),
+    primary key (city, vehicle_type)
+);
+
+insert into tariffs (city, vehicle_type, base_fare, per_km, per_minute, minimum_fare, booking_fee, cancellation_fee)
+values
+    ('default', 'ECONOMY', 50, 15, 3, 100, 10, 50),
+    ('default', 'PREMIUM', 100, 25, 5, 200, 15, 100),
+    ('default', 'XL', 80, 20, 4, 150, 15, 75)
+on conflict (city, vehicle_type) do nothing;
+
+alter table rides add column if not exists fare_breakdown jsonb;
@@ -0,0 +1,31 @@
+-- Surge pricing per geohash cell.
+-- surge_cells holds the current state written by the ride service surge worker,
+-- surge_history keeps every recomputation for auditing. The multiplier applied
+-- to a ride or quote is stored with it. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists surge_cells (
+    cell text primary key,
+    demand integer not null check (demand >= 0),         -- open REQUESTED rides
+    supply integer not null check (supply >= 0),         -- AVAILABLE drivers
+    smoothed decimal(8,4) not null check (smoothed >= 1), -- state for the next tick
+    multiplier decimal(4,2) not null check (multiplier >= 1),
+    computed_at timestamptz not null default now()
+);
+
+create table if not exists surge_history (
+    id bigserial primary key,
+    cell text not null,
+    demand integer not null,
+    supply integer not null,
+    ratio decimal(10,4) not null,
+    target decimal(8,4) not null,
+    smoothed decimal(8,4) not null,
+    multiplier decimal(4,2) not null,
+    computed_at timestamptz not null default now()
+);
+
+create index if not exists idx_surge_history_cell_time on surge_history(cell, computed_at desc);
+create index if not exists idx_surge_history_time on surge_history(computed_at);
+
+alter table rides add column if not exists surge_multiplier decimal(4,2) not null default 1.00;
+alter table fare_quotes add column if not exists surge_multiplier decimal(4,2) not null default 1.00;
@@ -0,0 +1,11 @@
+-- Paid waiting at pickup: the driver marks arrival (rides.arrived_at), the clock
+-- stops at rides.started_at. Minutes beyond free_waiting_minutes are billed at
+-- per_waiting_minute. Idempotent, no BEGIN/COMMIT.
+
+alter table tariffs add column if not exists free_waiting_minutes integer not null default 3 check (free_waiting_minutes >= 0);
+alter table tariffs add column if not exists per_waiting_minute decimal(10,2) not null default 0 check (per_waiting_minute >= 0);
+
+update tariffs set free_waiting_minutes = 5 where city = 'default' and vehicle_type = 'PREMIUM' and free_waiting_minutes = 3 and per_waiting_minute = 0;
+update tariffs set per_waiting_minute = 5 where city = 'default' and vehicle_type = 'ECONOMY' and per_waiting_minute = 0;
+update tariffs set per_waiting_minute = 8 where city = 'default' and vehicle_type = 'PREMIUM' and per_waiting_minute = 0;
+update tariffs set per_waiting_minute = 6 where city = 'default' and vehicle_type = 'XL' and per_waiting_minute = 0;
@@ -0,0 +1,26 @@
+-- Mutual ratings after a completed ride: the passenger rates the driver and the
+-- driver rates the passenger, at most once per side. Averages are maintained
+-- incrementally from rating_sum / rating_count: drivers.rating for drivers,
+-- users.rating for passengers. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists ride_ratings (
+    id uuid primary key default gen_random_uuid(),
+    ride_id uuid not null references rides(id),
+    rater_id uuid not null references users(id),
+    ratee_id uuid not null references users(id),
+    rater_role text not null check (rater_role in ('PASSENGER', 'DRIVER')),
+    score smallint not null check (score between 1 and 5),
+    tags text[] not null default '{}',
+    comment text,
+    created_at timestamptz not null default now(),
+    unique (ride_id, rater_role)
+);
+
+create index if not exists idx_ride_ratings_ratee on ride_ratings(ratee_id, created_at desc);
+
+alter table drivers add column if not exists rating_sum integer not null default 0 check (rating_sum >= 0);
+alter table drivers add column if not exists rating_count integer not null default 0 check (rating_count >= 0);
+
+alter table users add column if not exists rating decimal(3,2) check (rating between 1.0 and 5.0);
+alter table users add column if not exists rating_sum integer not null default 0 check (rating_sum >= 0);
+alter table users add column if not exists rating_count integer not null default 0 check (rating_count >= 0);
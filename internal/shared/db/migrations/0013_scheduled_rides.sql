@@ -0,0 +1,11 @@
+-- Scheduled (advance-booking) rides: a ride is created in SCHEDULED with
+-- rides.scheduled_for and enters matching (SCHEDULED -> REQUESTED) lead minutes
+-- before pickup. reminder_sent_at makes the reminder one-shot across replicas.
+-- Idempotent, no BEGIN/COMMIT.
+
+insert into ride_status(value) values ('SCHEDULED') on conflict do nothing;
+
+alter table rides add column if not exists scheduled_for timestamptz;
+alter table rides add column if not exists reminder_sent_at timestamptz;
+
+create index if not exists idx_rides_scheduled_due on rides(scheduled_for) where status = 'SCHEDULED';
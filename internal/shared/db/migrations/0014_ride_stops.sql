@@ -0,0 +1,16 @@
+-- Multi-stop rides: ordered intermediate stops between pickup and destination.
+-- Each stop points at a coordinates row (address + lat/lng); the driver marks
+-- arrival stop by stop (arrived_at), strictly in stop_number order.
+-- Idempotent, no BEGIN/COMMIT.
+
+create table if not exists ride_stops (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    ride_id uuid not null references rides(id),
+    stop_number integer not null check (stop_number >= 1),
+    coordinate_id uuid not null references coordinates(id),
+    arrived_at timestamptz,
+    unique (ride_id, stop_number)
+);
+
+insert into ride_event_type(value) values ('STOP_ARRIVED') on conflict do nothing;
@@ -0,0 +1,27 @@
+-- Pooled rides: POOL vehicle product. A POOL driver keeps an ordered plan of
+-- open waypoints (pickup / drop-off of every rider on the trip); the matcher
+-- inserts new riders into it while seats and detour limits allow.
+-- Seats per vehicle live in drivers.vehicle_attrs->>'vehicle_seats'.
+-- Idempotent, no BEGIN/COMMIT.
+
+insert into vehicle_type(value) values ('POOL') on conflict do nothing;
+
+insert into tariffs (city, vehicle_type, base_fare, per_km, per_minute, minimum_fare, booking_fee, cancellation_fee)
+values ('default', 'POOL', 40, 11, 2, 80, 10, 40)
+on conflict (city, vehicle_type) do nothing;
+
+create table if not exists pool_waypoints (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    driver_id uuid not null references drivers(id),
+    ride_id uuid not null references rides(id),
+    kind text not null check (kind in ('PICKUP', 'DROPOFF')),
+    sequence integer not null check (sequence >= 1),
+    coordinate_id uuid not null references coordinates(id),
+    done_at timestamptz,
+    unique (ride_id, kind)
+);
+
+create index if not exists idx_pool_waypoints_open on pool_waypoints(driver_id, sequence) where done_at is null;
+
+insert into ride_event_type(value) values ('PASSENGER_PICKED_UP'), ('PASSENGER_DROPPED_OFF') on conflict do nothing;
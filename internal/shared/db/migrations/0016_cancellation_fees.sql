@@ -0,0 +1,7 @@
+-- Cancellation fees: the charged amount is stored on the ride, the driver's share
+-- is credited to drivers.total_earnings exactly once (compensation_paid_at marks it).
+-- Idempotent, no BEGIN/COMMIT.
+
+alter table rides add column if not exists cancellation_fee decimal(10,2) check (cancellation_fee >= 0);
+alter table rides add column if not exists driver_compensation decimal(10,2) check (driver_compensation >= 0);
+alter table rides add column if not exists compensation_paid_at timestamptz;
@@ -0,0 +1,35 @@
+-- Driver onboarding: a DRIVER user submits license, vehicle and documents as an
+-- application; an admin approves (drivers row is created/updated, is_verified = true)
+-- or rejects it with a reason. drivers.verified_until is the earliest document
+-- expiry of the approved application: after it the driver is un-verified.
+-- At most one PENDING application per driver. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists driver_applications (
+    id uuid primary key default gen_random_uuid(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    driver_id uuid not null references users(id),
+    status text not null default 'PENDING' check (status in ('PENDING', 'APPROVED', 'REJECTED', 'EXPIRED')),
+    license_number varchar(50) not null,
+    vehicle_type text not null references vehicle_type(value),
+    vehicle_attrs jsonb,
+    reviewed_by uuid references users(id),
+    reviewed_at timestamptz,
+    review_reason text
+);
+
+create unique index if not exists idx_driver_applications_pending on driver_applications(driver_id) where status = 'PENDING';
+create index if not exists idx_driver_applications_driver on driver_applications(driver_id, created_at desc);
+
+create table if not exists driver_documents (
+    id uuid primary key default gen_random_uuid(),
+    application_id uuid not null references driver_applications(id),
+    kind text not null check (kind in ('DRIVER_LICENSE', 'VEHICLE_REGISTRATION', 'INSURANCE')),
+    number varchar(100) not null,
+    expires_on date not null,
+    unique (application_id, kind)
+);
+
+alter table drivers add column if not exists verified_until date;
+
+create index if not exists idx_drivers_verified_until on drivers(verified_until) where is_verified;
@@ -0,0 +1,8 @@
+-- Admin user management: DELETE /admin/users/{id} is a soft delete. The row stays
+-- (rides, ratings and applications reference it), status becomes INACTIVE and
+-- deleted_at is set; deleted users are hidden from lists and cannot log in.
+-- Idempotent, no BEGIN/COMMIT.
+
+alter table users add column if not exists deleted_at timestamptz;
+
+create index if not exists idx_users_not_deleted on users(created_at desc) where deleted_at is null;
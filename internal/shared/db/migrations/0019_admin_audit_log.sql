@@ -0,0 +1,39 @@
+-- Admin audit log: one row per successful privileged admin action (user create /
+-- update / delete, driver approve / reject). before/after are JSON snapshots of the
+-- target, diff holds only the changed top-level fields. Append-only: UPDATE, DELETE
+-- and TRUNCATE are rejected by triggers. actor_id has no FK so the log survives any
+-- cleanup of users. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists admin_audit_log (
+    id bigserial primary key,
+    created_at timestamptz not null default now(),
+    actor_id uuid not null,
+    action text not null,
+    target_type text not null,
+    target_id text not null,
+    before jsonb,
+    after jsonb,
+    diff jsonb not null default '{}'::jsonb,
+    ip text,
+    request_id text
+);
+
+create index if not exists idx_admin_audit_log_created_at on admin_audit_log(created_at desc);
+create index if not exists idx_admin_audit_log_actor on admin_audit_log(actor_id, created_at desc);
+create index if not exists idx_admin_audit_log_target on admin_audit_log(target_id, created_at desc);
+
+create or replace function admin_audit_log_append_only() returns trigger as $$
+begin
+    raise exception 'admin_audit_log is append-only';
+end;
+$$ language plpgsql;
+
+drop trigger if exists admin_audit_log_no_modify on admin_audit_log;
+create trigger admin_audit_log_no_modify
+    before update or delete on admin_audit_log
+    for each row execute function admin_audit_log_append_only();
+
+drop trigger if exists admin_audit_log_no_truncate on admin_audit_log;
+create trigger admin_audit_log_no_truncate
+    before truncate on admin_audit_log
+    for each statement execute function admin_audit_log_append_only();
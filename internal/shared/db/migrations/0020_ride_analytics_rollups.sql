@@ -0,0 +1,51 @@
+-- Ride analytics rollups for GET /admin/analytics: rides pre-aggregated per UTC hour
+-- and per UTC day by (vehicle_type, status, city), so analytics never scan rides.
+-- A ride is bucketed by requested_at and counted under its current status; averages
+-- are stored as sum + count so day and week buckets re-aggregate exactly.
+-- city comes from fare_breakdown (rides have no own city column); rides without it
+-- are counted under the pricing default city. The Admin Service job rebuilds the
+-- buckets of rides whose updated_at moved since the last run and records its
+-- progress in analytics_rollup_state. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists ride_stats_hourly (
+    bucket timestamptz not null,
+    vehicle_type text not null,
+    status text not null,
+    city text not null,
+    rides integer not null default 0,
+    completed integer not null default 0,
+    cancelled integer not null default 0,
+    revenue decimal(14,2) not null default 0,
+    wait_seconds_sum double precision not null default 0,
+    wait_count integer not null default 0,
+    duration_seconds_sum double precision not null default 0,
+    duration_count integer not null default 0,
+    primary key (bucket, vehicle_type, status, city)
+);
+
+create table if not exists ride_stats_daily (
+    bucket timestamptz not null,
+    vehicle_type text not null,
+    status text not null,
+    city text not null,
+    rides integer not null default 0,
+    completed integer not null default 0,
+    cancelled integer not null default 0,
+    revenue decimal(14,2) not null default 0,
+    wait_seconds_sum double precision not null default 0,
+    wait_count integer not null default 0,
+    duration_seconds_sum double precision not null default 0,
+    duration_count integer not null default 0,
+    primary key (bucket, vehicle_type, status, city)
+);
+
+create table if not exists analytics_rollup_state (
+    name text primary key,
+    refreshed_until timestamptz not null,
+    updated_at timestamptz not null default now()
+);
+
+-- The refresh job looks up rides changed since its last run, then re-reads
+-- the affected buckets by request time
+create index if not exists idx_rides_updated_at on rides(updated_at);
+create index if not exists idx_rides_requested_bucket on rides((coalesce(requested_at, created_at)));
@@ -0,0 +1,36 @@
+-- Driver matching state: one session per ride waiting for a driver and one row per
+-- offer sent. Any Driver Service replica can continue a session: offer responses
+-- lock the session row, the coordinator claims sessions whose due_at has passed
+-- (next round, offer timeout, deadline) with FOR UPDATE SKIP LOCKED. state EXPIRED
+-- also marks REQUESTED rides timed out without a session, so they are reported once.
+-- Idempotent, no BEGIN/COMMIT.
+
+create table if not exists matching_sessions (
+    ride_id uuid primary key references rides(id) on delete cascade,
+    state text not null default 'ACTIVE' check (state in ('ACTIVE', 'CLOSED', 'EXPIRED')),
+    request jsonb not null,
+    offer_timeout_seconds integer not null,
+    radius_km numeric(6,2) not null,
+    round integer not null default 0,
+    started_at timestamptz not null,
+    deadline timestamptz not null,
+    next_search_at timestamptz,
+    due_at timestamptz not null,
+    updated_at timestamptz not null default now()
+);
+
+create index if not exists idx_matching_sessions_due on matching_sessions(due_at) where state = 'ACTIVE';
+
+create table if not exists matching_offers (
+    offer_id text primary key,
+    ride_id uuid not null references matching_sessions(ride_id) on delete cascade,
+    driver_id uuid not null,
+    distance_km numeric(8,3) not null,
+    sent_at timestamptz not null,
+    expires_at timestamptz not null,
+    state text not null check (state in ('PENDING', 'DECLINED', 'EXPIRED', 'ACCEPTED')),
+    pool jsonb,
+    unique (ride_id, driver_id)
+);
+
+create index if not exists idx_rides_requested_stale on rides(updated_at) where status = 'REQUESTED';
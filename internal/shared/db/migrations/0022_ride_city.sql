@@ -0,0 +1,23 @@
+-- Cities for tariffs and analytics: the Ride Service resolves the city of the pickup
+-- point against cities.area at quote and request time and stores it on the ride, so
+-- the estimate, the final fare, cancellation fees and analytics use the same city.
+-- A pickup outside every area gets the pricing default city ('default', like tariffs);
+-- rides created before this migration get it too. Idempotent, no BEGIN/COMMIT.
+
+create table if not exists cities (
+    city text primary key,
+    area geography(polygon, 4326) not null,
+    updated_at timestamptz not null default now()
+);
+
+create index if not exists idx_cities_area on cities using gist(area);
+
+insert into cities (city, area)
+values
+    ('almaty', st_geogfromtext('SRID=4326;POLYGON((76.70 43.10, 77.15 43.10, 77.15 43.42, 76.70 43.42, 76.70 43.10))')),
+    ('moscow', st_geogfromtext('SRID=4326;POLYGON((37.32 55.49, 37.97 55.49, 37.97 55.96, 37.32 55.96, 37.32 55.49))'))
+on conflict (city) do nothing;
+
+alter table rides add column if not exists city text not null default 'default';
+
+create index if not exists idx_rides_city on rides(city);
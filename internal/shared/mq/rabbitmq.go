@@ -20,6 +20,11 @@ type RabbitMQ struct {
 	log    *logger.Logger
 	mu     sync.RWMutex
 	closed bool
+
+	// confirmCh — отдельный канал в confirm mode для PublishConfirmed.
+	// Публикации через него сериализуются confirmMu.
+	confirmCh *amqp.Channel
+	confirmMu sync.Mutex
 }
 
 // NewRabbitMQ создает подключение к RabbitMQ с retry
@@ -150,6 +155,81 @@ func (mq *RabbitMQ) Publish(ctx context.Context, exchange, routingKey string, bo
 	)
 }
 
+// PublishConfirmed публикует сообщение и ждет подтверждения (publisher confirm) от брокера.
+// Возвращает ошибку, если брокер ответил nack или подтверждение не пришло вовремя.
+func (mq *RabbitMQ) PublishConfirmed(ctx context.Context, exchange, routingKey string, body []byte) error {
+	mq.confirmMu.Lock()
+	defer mq.confirmMu.Unlock()
+
+	ch, err := mq.confirmChannel()
+	if err != nil {
+		return err
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		publishCtx,
+		exchange,
+		routingKey,
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         body,
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now(),
+		},
+	)
+	if err != nil {
+		mq.confirmCh = nil
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(publishCtx)
+	if err != nil {
+		// Канал в неизвестном состоянии — откроем новый при следующей публикации
+		_ = ch.Close()
+		mq.confirmCh = nil
+		return fmt.Errorf("wait confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("broker nacked message to %s/%s", exchange, routingKey)
+	}
+
+	return nil
+}
+
+// confirmChannel возвращает (лениво открывает) канал в confirm mode.
+// Вызывается под confirmMu.
+func (mq *RabbitMQ) confirmChannel() (*amqp.Channel, error) {
+	if mq.confirmCh != nil && !mq.confirmCh.IsClosed() {
+		return mq.confirmCh, nil
+	}
+
+	mq.mu.RLock()
+	conn := mq.conn
+	mq.mu.RUnlock()
+
+	if conn == nil || conn.IsClosed() {
+		return nil, fmt.Errorf("rabbitmq connection not available")
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("open confirm channel: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("enable confirm mode: %w", err)
+	}
+
+	mq.confirmCh = ch
+	return ch, nil
+}
+
 // Consume начинает чтение сообщений из очереди
 func (mq *RabbitMQ) Consume(ctx context.Context, queue, consumer string, handler func(amqp.Delivery)) error {
 	mq.mu.RLock()
@@ -210,6 +290,13 @@ func (mq *RabbitMQ) Close() {
 
 	mq.closed = true
 
+	mq.confirmMu.Lock()
+	if mq.confirmCh != nil {
+		_ = mq.confirmCh.Close()
+		mq.confirmCh = nil
+	}
+	mq.confirmMu.Unlock()
+
 	if mq.ch != nil {
 		_ = mq.ch.Close()
 	}
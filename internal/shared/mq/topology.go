@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"ridehail/internal/shared/contract"
 	"ridehail/internal/shared/logger"
 )
 
@@ -53,9 +54,30 @@ func SetupTopology(ctx context.Context, mq *RabbitMQ, log *logger.Logger) error
 		return fmt.Errorf("declare location_fanout: %w", err)
 	}
 
-	// 4. Очереди для ride_topic
+	// 3.1. Exchange: user_topic (topic) — события учетных записей от Admin Service
+	if err := ch.ExchangeDeclare(
+		contract.ExchangeUser,
+		"topic",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	); err != nil {
+		return fmt.Errorf("declare %s: %w", contract.ExchangeUser, err)
+	}
+
+	// 4. Очередь подбора водителя: ride.requested → driver_matching (Driver Service).
+	// Отдельной очереди "ride.requested" нет — сообщения без потребителя копились бы в ней.
+	if _, err := ch.QueueDeclare(contract.QueueDriverMatching, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare queue %s: %w", contract.QueueDriverMatching, err)
+	}
+	if err := ch.QueueBind(contract.QueueDriverMatching, contract.RoutingKeyRideRequested, contract.ExchangeRide, false, nil); err != nil {
+		return fmt.Errorf("bind queue %s: %w", contract.QueueDriverMatching, err)
+	}
+
+	// 5. Очереди для ride_topic
 	rideQueues := []string{
-		"ride.requested",
 		"ride.matched",
 		"ride.completed",
 		"ride.cancelled",
@@ -71,7 +93,7 @@ func SetupTopology(ctx context.Context, mq *RabbitMQ, log *logger.Logger) error
 		}
 	}
 
-	// 5. Очереди для driver_topic
+	// 6. Очереди для driver_topic
 	driverQueues := []string{
 		"driver.status_changed",
 		"driver.location_updated",
@@ -86,7 +108,7 @@ func SetupTopology(ctx context.Context, mq *RabbitMQ, log *logger.Logger) error
 		}
 	}
 
-	// 6. Очередь для location_fanout (каждый сервис создаст свою эксклюзивную очередь при consume)
+	// 7. Очередь для location_fanout (каждый сервис создаст свою эксклюзивную очередь при consume)
 	// Здесь создаём общую очередь для примера, но в реальности fanout используется с auto-delete очередями
 	if _, err := ch.QueueDeclare("location.broadcast", true, false, false, false, nil); err != nil {
 		return fmt.Errorf("declare location.broadcast: %w", err)
@@ -0,0 +1,49 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CityResolver определяет город тарифа по точке подачи
+type CityResolver interface {
+	// City возвращает город, в зону которого попадает точка; "" — ни в одну
+	City(ctx context.Context, lat, lng float64) (string, error)
+}
+
+// PgCityResolver — города из таблицы cities (зона города — полигон)
+type PgCityResolver struct {
+	pool *pgxpool.Pool
+}
+
+// NewPgCityResolver создает определитель города из БД
+func NewPgCityResolver(pool *pgxpool.Pool) *PgCityResolver {
+	return &PgCityResolver{pool: pool}
+}
+
+// City возвращает город, зона которого покрывает точку. При пересечении зон
+// берется меньшая — город внутри агломерации точнее нее самой.
+func (r *PgCityResolver) City(ctx context.Context, lat, lng float64) (string, error) {
+	query := `
+		SELECT city
+		FROM cities
+		WHERE ST_Covers(area, ST_SetSRID(ST_MakePoint($2, $1), 4326)::geography)
+		ORDER BY ST_Area(area)
+		LIMIT 1
+	`
+
+	var city string
+	err := r.pool.QueryRow(ctx, query, lat, lng).Scan(&city)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("query city: %w", err)
+	}
+
+	return city, nil
+}
@@ -0,0 +1,132 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"ridehail/internal/shared/config"
+	"ridehail/internal/shared/logger"
+)
+
+// Engine — точка входа расчета стоимости для use case'ов
+type Engine struct {
+	source      TariffSource
+	surge       SurgeSource  // nil — surge не применяется
+	cities      CityResolver // nil — все поездки в городе по умолчанию
+	defaultCity string
+	avgSpeedKmh float64
+	surgeCfg    config.SurgeConfig
+	log         *logger.Logger
+}
+
+// NewEngine создает движок расчета стоимости.
+// surge и cities = nil для сервисов, которые только пересчитывают уже зафиксированную
+// цену: surge и город к этому моменту сохранены на поездке.
+func NewEngine(source TariffSource, surge SurgeSource, cities CityResolver, cfg config.PricingConfig, log *logger.Logger) *Engine {
+	return &Engine{
+		source:      source,
+		surge:       surge,
+		cities:      cities,
+		defaultCity: cfg.DefaultCity,
+		avgSpeedKmh: cfg.AvgSpeedKmh,
+		surgeCfg:    cfg.Surge,
+		log:         log,
+	}
+}
+
+// Quote считает стоимость поездки по тарифу ее города и типа авто.
+// Используется и для оценки (плановые км/мин), и для финальной стоимости (фактические).
+func (e *Engine) Quote(ctx context.Context, trip Trip) (Breakdown, error) {
+	tariff, err := e.tariff(ctx, trip.City, trip.VehicleType)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	return Calculate(tariff, trip), nil
+}
+
+// CancellationFee возвращает штраф за отмену для города и типа авто
+func (e *Engine) CancellationFee(ctx context.Context, city, vehicleType string) (float64, error) {
+	tariff, err := e.tariff(ctx, city, vehicleType)
+	if err != nil {
+		return 0, err
+	}
+	return round2(tariff.CancellationFee), nil
+}
+
+// FreeWaitingMinutes возвращает бесплатное время ожидания пассажира для города и типа авто
+func (e *Engine) FreeWaitingMinutes(ctx context.Context, city, vehicleType string) (int, error) {
+	tariff, err := e.tariff(ctx, city, vehicleType)
+	if err != nil {
+		return 0, err
+	}
+	return tariff.FreeWaitingMinutes, nil
+}
+
+// Surge возвращает текущий коэффициент ячейки точки подачи.
+// Ошибка чтения не мешает заказу: поездка считается без surge.
+func (e *Engine) Surge(ctx context.Context, lat, lng float64) Surge {
+	cell := Geohash(lat, lng, e.surgeCfg.GeohashPrecision)
+	if e.surge == nil || e.surgeCfg.IntervalSeconds <= 0 {
+		return NoSurge(cell)
+	}
+
+	notBefore := time.Now().UTC().Add(-time.Duration(e.surgeCfg.StaleAfterSeconds) * time.Second)
+	multiplier, err := e.surge.Multiplier(ctx, cell, notBefore)
+	if err != nil {
+		e.log.Warn(logger.Entry{
+			Action:  "surge_lookup_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+			Additional: map[string]any{
+				"cell": cell,
+			},
+		})
+		return NoSurge(cell)
+	}
+
+	return Surge{Cell: cell, Multiplier: multiplier}
+}
+
+// City возвращает город тарифа для точки подачи. Точка вне известных городов
+// и ошибка чтения не мешают заказу: поездка считается в городе по умолчанию.
+func (e *Engine) City(ctx context.Context, lat, lng float64) string {
+	if e.cities == nil {
+		return e.defaultCity
+	}
+
+	city, err := e.cities.City(ctx, lat, lng)
+	if err != nil {
+		e.log.Warn(logger.Entry{
+			Action:  "city_lookup_failed",
+			Message: err.Error(),
+			Error:   &logger.ErrObj{Msg: err.Error()},
+		})
+		return e.defaultCity
+	}
+	if city == "" {
+		return e.defaultCity
+	}
+	return city
+}
+
+// EstimateDurationMinutes оценивает время в пути по расстоянию и средней скорости
+func (e *Engine) EstimateDurationMinutes(distanceKm float64) int {
+	if e.avgSpeedKmh <= 0 {
+		return 0
+	}
+	return int(math.Ceil(distanceKm / e.avgSpeedKmh * 60))
+}
+
+func (e *Engine) tariff(ctx context.Context, city, vehicleType string) (Tariff, error) {
+	if city == "" {
+		city = e.defaultCity
+	}
+
+	tariff, err := e.source.Tariff(ctx, city, vehicleType)
+	if err != nil {
+		return Tariff{}, fmt.Errorf("tariff %s/%s: %w", city, vehicleType, err)
+	}
+	return tariff, nil
+}
@@ -0,0 +1,52 @@
+package pricing
+
+// geohashAlphabet — base32 алфавит geohash (без a, i, l, o)
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash кодирует точку в ячейку geohash заданной точности.
+//
+// Ячейка — прямоугольник сетки; у соседних точек общий префикс.
+// Точность 5 ≈ 4.9×4.9 км, 6 ≈ 1.2×0.6 км.
+func Geohash(lat, lng float64, precision int) string {
+	if precision <= 0 {
+		precision = 1
+	}
+
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	hash := make([]byte, 0, precision)
+	bit, ch := 0, 0
+	even := true // Четные биты делят долготу, нечетные — широту
+
+	for len(hash) < precision {
+		if even {
+			mid := (lngRange[0] + lngRange[1]) / 2
+			if lng >= mid {
+				ch = ch<<1 | 1
+				lngRange[0] = mid
+			} else {
+				ch <<= 1
+				lngRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if lat >= mid {
+				ch = ch<<1 | 1
+				latRange[0] = mid
+			} else {
+				ch <<= 1
+				latRange[1] = mid
+			}
+		}
+		even = !even
+
+		bit++
+		if bit == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return string(hash)
+}
@@ -0,0 +1,39 @@
+package pricing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lng  float64
+		precision int
+		want      string
+	}{
+		{name: "reference point", lat: 57.64911, lng: 10.40744, precision: 11, want: "u4pruydqqvj"},
+		{name: "reference cell", lat: 42.605, lng: -5.603, precision: 5, want: "ezs42"},
+		{name: "origin", lat: 0, lng: 0, precision: 1, want: "s"},
+		{name: "south-west corner", lat: -90, lng: -180, precision: 3, want: "000"},
+		{name: "non-positive precision means one char", lat: 57.64911, lng: 10.40744, precision: 0, want: "u"},
+	}
+
+	for _, tc := range tests {
+		if got := Geohash(tc.lat, tc.lng, tc.precision); got != tc.want {
+			t.Errorf("%s: Geohash(%v, %v, %d) = %q, want %q", tc.name, tc.lat, tc.lng, tc.precision, got, tc.want)
+		}
+	}
+}
+
+// Ячейка меньшей точности — префикс ячейки большей для той же точки
+func TestGeohashPrefix(t *testing.T) {
+	lat, lng := 43.238949, 76.889709
+	full := Geohash(lat, lng, 9)
+
+	for precision := 1; precision < 9; precision++ {
+		if cell := Geohash(lat, lng, precision); !strings.HasPrefix(full, cell) {
+			t.Errorf("Geohash precision %d = %q is not a prefix of %q", precision, cell, full)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultCity — строка tariffs, которая действует во всех городах без своего тарифа
+const defaultCity = "default"
+
+type cachedTariff struct {
+	tariff   Tariff
+	found    bool
+	loadedAt time.Time
+}
+
+// PgSource — тарифы из таблицы tariffs с кэшем в памяти.
+//
+// Поиск: (city, vehicle_type) → ('default', vehicle_type) → fallback (обычно конфиг).
+// Тарифы меняются редко, поэтому строки кэшируются на ttl, включая отсутствие строки.
+type PgSource struct {
+	pool     *pgxpool.Pool
+	fallback TariffSource
+	ttl      time.Duration
+	log      *logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedTariff // "city|vehicle_type" → тариф
+}
+
+// NewPgSource создает источник тарифов из БД
+func NewPgSource(pool *pgxpool.Pool, fallback TariffSource, ttl time.Duration, log *logger.Logger) *PgSource {
+	return &PgSource{
+		pool:     pool,
+		fallback: fallback,
+		ttl:      ttl,
+		log:      log,
+		cache:    make(map[string]cachedTariff),
+	}
+}
+
+// Tariff возвращает тариф города, тариф 'default' или тариф из fallback
+func (s *PgSource) Tariff(ctx context.Context, city, vehicleType string) (Tariff, error) {
+	key := city + "|" + vehicleType
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+
+	if !ok || now.Sub(cached.loadedAt) >= s.ttl {
+		t, found, err := s.load(ctx, city, vehicleType)
+		if err != nil {
+			// БД недоступна: лучше устаревший тариф, чем отказ в заказе
+			if ok {
+				s.log.Warn(logger.Entry{
+					Action:  "tariff_reload_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+				return s.resolve(ctx, cached, city, vehicleType)
+			}
+			return Tariff{}, err
+		}
+
+		cached = cachedTariff{tariff: t, found: found, loadedAt: now}
+		s.mu.Lock()
+		s.cache[key] = cached
+		s.mu.Unlock()
+	}
+
+	return s.resolve(ctx, cached, city, vehicleType)
+}
+
+func (s *PgSource) resolve(ctx context.Context, cached cachedTariff, city, vehicleType string) (Tariff, error) {
+	if cached.found {
+		return cached.tariff, nil
+	}
+	if s.fallback == nil {
+		return Tariff{}, ErrTariffNotFound
+	}
+	return s.fallback.Tariff(ctx, city, vehicleType)
+}
+
+// load читает тариф города, а если его нет — тариф 'default'
+func (s *PgSource) load(ctx context.Context, city, vehicleType string) (Tariff, bool, error) {
+	query := `
+		SELECT city, vehicle_type, base_fare, per_km, per_minute,
+		       minimum_fare, booking_fee, cancellation_fee,
+		       free_waiting_minutes, per_waiting_minute
+		FROM tariffs
+		WHERE vehicle_type = $2
+		  AND city IN ($1, $3)
+		ORDER BY (city = $1) DESC
+		LIMIT 1
+	`
+
+	var t Tariff
+	err := s.pool.QueryRow(ctx, query, city, vehicleType, defaultCity).Scan(
+		&t.City,
+		&t.VehicleType,
+		&t.BaseFare,
+		&t.PerKm,
+		&t.PerMinute,
+		&t.MinimumFare,
+		&t.BookingFee,
+		&t.CancellationFee,
+		&t.FreeWaitingMinutes,
+		&t.PerWaitingMinute,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Tariff{}, false, nil
+		}
+		return Tariff{}, false, fmt.Errorf("query tariff: %w", err)
+	}
+
+	return t, true, nil
+}
@@ -0,0 +1,50 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"ridehail/internal/shared/config"
+)
+
+// ErrTariffNotFound возвращается, если для типа авто нет тарифа ни в одном источнике
+var ErrTariffNotFound = errors.New("tariff not found")
+
+// TariffSource — откуда берутся тарифы (БД, конфиг)
+type TariffSource interface {
+	// Tariff возвращает тариф для города и типа авто. ErrTariffNotFound — тарифа нет.
+	Tariff(ctx context.Context, city, vehicleType string) (Tariff, error)
+}
+
+// ConfigSource — тарифы из pricing.yaml, одинаковые для всех городов
+type ConfigSource struct {
+	tariffs map[string]config.TariffConfig
+}
+
+// NewConfigSource создает источник тарифов из конфига
+func NewConfigSource(cfg config.PricingConfig) *ConfigSource {
+	return &ConfigSource{tariffs: cfg.Tariffs}
+}
+
+// Tariff возвращает тариф типа авто (город не учитывается)
+func (s *ConfigSource) Tariff(_ context.Context, city, vehicleType string) (Tariff, error) {
+	t, ok := s.tariffs[strings.ToUpper(vehicleType)]
+	if !ok {
+		return Tariff{}, ErrTariffNotFound
+	}
+
+	return Tariff{
+		City:            city,
+		VehicleType:     vehicleType,
+		BaseFare:        t.BaseFare,
+		PerKm:           t.PerKm,
+		PerMinute:       t.PerMinute,
+		MinimumFare:     t.MinimumFare,
+		BookingFee:      t.BookingFee,
+		CancellationFee: t.CancellationFee,
+
+		FreeWaitingMinutes: t.FreeWaitingMinutes,
+		PerWaitingMinute:   t.PerWaitingMinute,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"ridehail/internal/shared/config"
+)
+
+// Surge — повышающий коэффициент, действующий в ячейке карты
+type Surge struct {
+	Cell       string  `json:"cell"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// NoSurge — коэффициент 1.0 (цена по тарифу)
+func NoSurge(cell string) Surge {
+	return Surge{Cell: cell, Multiplier: 1}
+}
+
+// SurgeSource — откуда движок берет текущий коэффициент ячейки
+type SurgeSource interface {
+	// Multiplier возвращает коэффициент ячейки, посчитанный не раньше notBefore.
+	// Нет строки или она устарела — 1.0.
+	Multiplier(ctx context.Context, cell string, notBefore time.Time) (float64, error)
+}
+
+// SurgePolicy — правило пересчета коэффициента по спросу и предложению в ячейке
+type SurgePolicy struct {
+	DemandThreshold float64
+	Sensitivity     float64
+	MaxMultiplier   float64
+	Smoothing       float64
+}
+
+// NewSurgePolicy создает правило пересчета из конфига
+func NewSurgePolicy(cfg config.SurgeConfig) SurgePolicy {
+	return SurgePolicy{
+		DemandThreshold: cfg.DemandThreshold,
+		Sensitivity:     cfg.Sensitivity,
+		MaxMultiplier:   cfg.MaxMultiplier,
+		Smoothing:       cfg.Smoothing,
+	}
+}
+
+// Ratio — отношение открытых заказов к свободным водителям.
+// Без водителей знаменатель считается равным 1, иначе один заказ давал бы бесконечность.
+func (p SurgePolicy) Ratio(demand, supply int) float64 {
+	return float64(demand) / math.Max(float64(supply), 1)
+}
+
+// Target — коэффициент, который соответствует отношению без сглаживания:
+//
+//	clamp(1 + sensitivity·(ratio − threshold), 1, max)
+func (p SurgePolicy) Target(ratio float64) float64 {
+	target := 1 + p.Sensitivity*(ratio-p.DemandThreshold)
+	return math.Min(math.Max(target, 1), p.MaxMultiplier)
+}
+
+// Next сглаживает переход от предыдущего состояния к целевому коэффициенту
+// (экспоненциальное среднее), чтобы цена не прыгала от одного заказа.
+// Состояние хранится без округления, иначе затухание застревало бы на шаге сетки.
+func (p SurgePolicy) Next(prev, target float64) float64 {
+	if prev < 1 {
+		prev = 1
+	}
+
+	alpha := p.Smoothing
+	if alpha <= 0 || alpha > 1 {
+		alpha = 1
+	}
+
+	next := prev + alpha*(target-prev)
+	if next < 1.01 {
+		return 1
+	}
+	return math.Min(next, p.MaxMultiplier)
+}
+
+// Applied — коэффициент, который видит пассажир: сглаженное значение,
+// округленное до 0.05. Меньше 1.05 считается отсутствием surge.
+func (p SurgePolicy) Applied(smoothed float64) float64 {
+	m := math.Round(smoothed*20) / 20
+	if m < 1.05 {
+		return 1
+	}
+	return math.Min(m, p.MaxMultiplier)
+}
@@ -0,0 +1,42 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgSurgeSource — текущие коэффициенты из surge_cells (их пишет SurgeWorker)
+type PgSurgeSource struct {
+	pool *pgxpool.Pool
+}
+
+// NewPgSurgeSource создает источник коэффициентов из БД
+func NewPgSurgeSource(pool *pgxpool.Pool) *PgSurgeSource {
+	return &PgSurgeSource{pool: pool}
+}
+
+// Multiplier возвращает коэффициент ячейки или 1.0, если его нет или он устарел
+func (s *PgSurgeSource) Multiplier(ctx context.Context, cell string, notBefore time.Time) (float64, error) {
+	query := `
+		SELECT multiplier
+		FROM surge_cells
+		WHERE cell = $1
+		  AND computed_at >= $2
+	`
+
+	var multiplier float64
+	err := s.pool.QueryRow(ctx, query, cell, notBefore).Scan(&multiplier)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("query surge multiplier: %w", err)
+	}
+
+	return multiplier, nil
+}
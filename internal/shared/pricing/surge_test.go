@@ -0,0 +1,43 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSurgePolicy(t *testing.T) {
+	policy := SurgePolicy{DemandThreshold: 1, Sensitivity: 0.5, MaxMultiplier: 3, Smoothing: 0.5}
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "ratio", got: policy.Ratio(6, 3), want: 2},
+		{name: "ratio without drivers", got: policy.Ratio(3, 0), want: 3},
+		{name: "ratio without demand", got: policy.Ratio(0, 5), want: 0},
+
+		{name: "target below threshold", got: policy.Target(0.5), want: 1},
+		{name: "target at threshold", got: policy.Target(1), want: 1},
+		{name: "target above threshold", got: policy.Target(3), want: 2},
+		{name: "target capped", got: policy.Target(10), want: 3},
+
+		{name: "next moves halfway", got: policy.Next(1, 2), want: 1.5},
+		{name: "next decays halfway", got: policy.Next(2, 1), want: 1.5},
+		{name: "next treats missing state as 1", got: policy.Next(0, 2), want: 1.5},
+		{name: "next snaps to 1 near the floor", got: policy.Next(1.015, 1), want: 1},
+		{name: "next capped", got: policy.Next(3, 5), want: 3},
+		{name: "next without smoothing jumps to target", got: SurgePolicy{MaxMultiplier: 3}.Next(1, 2.2), want: 2.2},
+
+		{name: "applied below 1.05 is no surge", got: policy.Applied(1.02), want: 1},
+		{name: "applied rounds to 0.05", got: policy.Applied(1.234), want: 1.25},
+		{name: "applied smallest step", got: policy.Applied(1.06), want: 1.05},
+		{name: "applied capped", got: policy.Applied(3.3), want: 3},
+	}
+
+	for _, tc := range tests {
+		if math.Abs(tc.got-tc.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ridehail/internal/shared/config"
+	"ridehail/internal/shared/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// surgeLockKey — ключ pg_try_advisory_xact_lock: пересчет выполняет одна реплика за тик
+const surgeLockKey int64 = 0x5375726765 // "Surge"
+
+// cellStats — спрос и предложение в ячейке за один пересчет
+type cellStats struct {
+	demand int
+	supply int
+}
+
+// SurgeWorker периодически пересчитывает коэффициенты по ячейкам geohash.
+//
+// ЦИКЛ (раз в interval):
+// 1. Берет advisory lock транзакции — остальные реплики пропускают тик
+// 2. Считает открытые REQUESTED заказы (точка подачи) и AVAILABLE водителей по ячейкам
+// 3. Для каждой активной ячейки: отношение → целевой коэффициент → сглаживание
+// 4. Обновляет surge_cells (текущее состояние) и пишет surge_history (аудит)
+//
+// Ячейки без спроса затухают к 1.0 и удаляются из surge_cells.
+type SurgeWorker struct {
+	pool      *pgxpool.Pool
+	policy    SurgePolicy
+	precision int
+	interval  time.Duration
+	log       *logger.Logger
+}
+
+// NewSurgeWorker создает воркер пересчета surge
+func NewSurgeWorker(pool *pgxpool.Pool, cfg config.SurgeConfig, log *logger.Logger) *SurgeWorker {
+	return &SurgeWorker{
+		pool:      pool,
+		policy:    NewSurgePolicy(cfg),
+		precision: cfg.GeohashPrecision,
+		interval:  time.Duration(cfg.IntervalSeconds) * time.Second,
+		log:       log,
+	}
+}
+
+// Run запускает цикл пересчета до отмены контекста (блокирующий).
+// При interval = 0 surge выключен и воркер сразу завершается.
+func (w *SurgeWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		w.log.Info(logger.Entry{Action: "surge_worker_disabled", Message: "interval_seconds = 0"})
+		return
+	}
+
+	w.log.Info(logger.Entry{
+		Action:  "surge_worker_started",
+		Message: fmt.Sprintf("interval %s, geohash precision %d", w.interval, w.precision),
+	})
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info(logger.Entry{Action: "surge_worker_stopped", Message: "context cancelled"})
+			return
+		case <-ticker.C:
+			if err := w.recompute(ctx); err != nil {
+				w.log.Error(logger.Entry{
+					Action:  "surge_recompute_failed",
+					Message: err.Error(),
+					Error:   &logger.ErrObj{Msg: err.Error()},
+				})
+			}
+		}
+	}
+}
+
+// recompute выполняет один пересчет в транзакции под advisory lock
+func (w *SurgeWorker) recompute(ctx context.Context) error {
+	tx, err := w.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin surge tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, surgeLockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("acquire surge lock: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+
+	stats := map[string]*cellStats{}
+	if err := w.countPoints(ctx, tx, demandPointsQuery, stats, func(c *cellStats) { c.demand++ }); err != nil {
+		return fmt.Errorf("count demand: %w", err)
+	}
+	if err := w.countPoints(ctx, tx, supplyPointsQuery, stats, func(c *cellStats) { c.supply++ }); err != nil {
+		return fmt.Errorf("count supply: %w", err)
+	}
+
+	previous, err := w.loadState(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	surged := 0
+
+	// Активные ячейки: есть спрос сейчас или коэффициент еще не затух
+	cells := make(map[string]struct{}, len(stats)+len(previous))
+	for cell, s := range stats {
+		if s.demand > 0 {
+			cells[cell] = struct{}{}
+		}
+	}
+	for cell := range previous {
+		cells[cell] = struct{}{}
+	}
+
+	for cell := range cells {
+		s := stats[cell]
+		if s == nil {
+			s = &cellStats{}
+		}
+
+		ratio := w.policy.Ratio(s.demand, s.supply)
+		target := w.policy.Target(ratio)
+		smoothed := w.policy.Next(previous[cell], target)
+		multiplier := w.policy.Applied(smoothed)
+
+		if err := w.saveCell(ctx, tx, cell, s, ratio, target, smoothed, multiplier, now); err != nil {
+			return err
+		}
+		if multiplier > 1 {
+			surged++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit surge tx: %w", err)
+	}
+
+	if surged > 0 {
+		w.log.Debug(logger.Entry{
+			Action:  "surge_recomputed",
+			Message: fmt.Sprintf("%d active cells, %d with surge", len(cells), surged),
+		})
+	}
+
+	return nil
+}
+
+// demandPointsQuery — точки подачи открытых заказов
+const demandPointsQuery = `
+	SELECT c.latitude, c.longitude
+	FROM rides r
+	JOIN coordinates c ON c.id = r.pickup_coordinate_id
+	WHERE r.status = 'REQUESTED'
+`
+
+// supplyPointsQuery — текущие позиции свободных водителей
+const supplyPointsQuery = `
+	SELECT c.latitude, c.longitude
+	FROM drivers d
+	JOIN coordinates c ON c.entity_id = d.id
+	 AND c.entity_type = 'driver'
+	 AND c.is_current = true
+	WHERE d.status = 'AVAILABLE'
+`
+
+// countPoints раскладывает точки запроса по ячейкам и вызывает inc для каждой
+func (w *SurgeWorker) countPoints(ctx context.Context, tx pgx.Tx, query string, stats map[string]*cellStats, inc func(*cellStats)) error {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lat, lng float64
+		if err := rows.Scan(&lat, &lng); err != nil {
+			return err
+		}
+
+		cell := Geohash(lat, lng, w.precision)
+		s, ok := stats[cell]
+		if !ok {
+			s = &cellStats{}
+			stats[cell] = s
+		}
+		inc(s)
+	}
+
+	return rows.Err()
+}
+
+// loadState читает сглаженные значения прошлого пересчета
+func (w *SurgeWorker) loadState(ctx context.Context, tx pgx.Tx) (map[string]float64, error) {
+	rows, err := tx.Query(ctx, `SELECT cell, smoothed FROM surge_cells`)
+	if err != nil {
+		return nil, fmt.Errorf("query surge cells: %w", err)
+	}
+	defer rows.Close()
+
+	state := map[string]float64{}
+	for rows.Next() {
+		var cell string
+		var smoothed float64
+		if err := rows.Scan(&cell, &smoothed); err != nil {
+			return nil, fmt.Errorf("scan surge cell: %w", err)
+		}
+		state[cell] = smoothed
+	}
+
+	return state, rows.Err()
+}
+
+// saveCell пишет историю ячейки и обновляет ее текущее состояние.
+// Затухшая ячейка без спроса удаляется, чтобы surge_cells не рос бесконечно.
+func (w *SurgeWorker) saveCell(
+	ctx context.Context,
+	tx pgx.Tx,
+	cell string,
+	s *cellStats,
+	ratio, target, smoothed, multiplier float64,
+	now time.Time,
+) error {
+	history := `
+		INSERT INTO surge_history (cell, demand, supply, ratio, target, smoothed, multiplier, computed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	if _, err := tx.Exec(ctx, history, cell, s.demand, s.supply, ratio, target, smoothed, multiplier, now); err != nil {
+		return fmt.Errorf("insert surge history: %w", err)
+	}
+
+	if s.demand == 0 && smoothed <= 1 {
+		if _, err := tx.Exec(ctx, `DELETE FROM surge_cells WHERE cell = $1`, cell); err != nil {
+			return fmt.Errorf("delete surge cell: %w", err)
+		}
+		return nil
+	}
+
+	upsert := `
+		INSERT INTO surge_cells (cell, demand, supply, smoothed, multiplier, computed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (cell) DO UPDATE SET
+			demand = EXCLUDED.demand,
+			supply = EXCLUDED.supply,
+			smoothed = EXCLUDED.smoothed,
+			multiplier = EXCLUDED.multiplier,
+			computed_at = EXCLUDED.computed_at
+	`
+	if _, err := tx.Exec(ctx, upsert, cell, s.demand, s.supply, smoothed, multiplier, now); err != nil {
+		return fmt.Errorf("upsert surge cell: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Package pricing — единый расчет стоимости поездки для Ride и Driver Service.
+//
+// Оценка (при заказе и в квоте) и финальная стоимость (при завершении)
+// считаются одной формулой по одному тарифу, поэтому расходятся только
+// на разницу между оценочным и фактическим километражем/временем.
+package pricing
+
+import (
+	"math"
+	"time"
+)
+
+// Tariff — ставки для пары (город, тип авто)
+type Tariff struct {
+	City            string  `json:"city"`
+	VehicleType     string  `json:"vehicle_type"`
+	BaseFare        float64 `json:"base_fare"`        // Посадка
+	PerKm           float64 `json:"per_km"`           // За километр
+	PerMinute       float64 `json:"per_minute"`       // За минуту в пути
+	MinimumFare     float64 `json:"minimum_fare"`     // Нижняя граница стоимости поездки (без сервисного сбора)
+	BookingFee      float64 `json:"booking_fee"`      // Сервисный сбор, добавляется поверх минимума
+	CancellationFee float64 `json:"cancellation_fee"` // Штраф за отмену после назначения водителя
+
+	FreeWaitingMinutes int     `json:"free_waiting_minutes"` // Бесплатное ожидание пассажира на точке подачи
+	PerWaitingMinute   float64 `json:"per_waiting_minute"`   // За минуту ожидания сверх бесплатной
+}
+
+// Trip — параметры поездки, от которых зависит цена
+type Trip struct {
+	City            string // "" — город по умолчанию
+	VehicleType     string
+	DistanceKm      float64
+	DurationMinutes int
+	SurgeMultiplier float64 // 0 или 1 — без surge
+	WaitingMinutes  int     // Ожидание пассажира от прибытия водителя до начала поездки
+	PoolDiscount    float64 // POOL: доля скидки (0..1) за поездку с попутчиками
+}
+
+// Breakdown — детализация стоимости. Хранится вместе с поездкой (rides.fare_breakdown).
+type Breakdown struct {
+	City                  string  `json:"city"`
+	VehicleType           string  `json:"vehicle_type"`
+	DistanceKm            float64 `json:"distance_km"`
+	DurationMinutes       int     `json:"duration_minutes"`
+	BaseFare              float64 `json:"base_fare"`
+	DistanceFare          float64 `json:"distance_fare"`
+	TimeFare              float64 `json:"time_fare"`
+	MinimumFareAdjustment float64 `json:"minimum_fare_adjustment"` // Доплата до минимальной стоимости
+	SurgeMultiplier       float64 `json:"surge_multiplier"`
+	SurgeAmount           float64 `json:"surge_amount"`            // Надбавка за surge (сбор не умножается)
+	PoolDiscount          float64 `json:"pool_discount,omitempty"` // Скидка POOL за попутчиков (от суммы с surge)
+	WaitingMinutes        int     `json:"waiting_minutes"`
+	WaitingFare           float64 `json:"waiting_fare"` // Платное ожидание (без surge)
+	BookingFee            float64 `json:"booking_fee"`
+	Total                 float64 `json:"total"`
+}
+
+// Calculate считает стоимость поездки по тарифу:
+//
+//	max(base + km·per_km + min·per_minute, minimum_fare) · surge · (1 − pool_discount)
+//	  + max(waiting − free_waiting, 0)·per_waiting_minute + booking_fee
+func Calculate(t Tariff, trip Trip) Breakdown {
+	b := Breakdown{
+		City:            t.City,
+		VehicleType:     t.VehicleType,
+		DistanceKm:      round2(trip.DistanceKm),
+		DurationMinutes: trip.DurationMinutes,
+		BaseFare:        round2(t.BaseFare),
+		DistanceFare:    round2(trip.DistanceKm * t.PerKm),
+		TimeFare:        round2(float64(trip.DurationMinutes) * t.PerMinute),
+		SurgeMultiplier: 1,
+		WaitingMinutes:  trip.WaitingMinutes,
+		BookingFee:      round2(t.BookingFee),
+	}
+
+	subtotal := b.BaseFare + b.DistanceFare + b.TimeFare
+	if subtotal < t.MinimumFare {
+		b.MinimumFareAdjustment = round2(t.MinimumFare - subtotal)
+	}
+
+	fare := subtotal + b.MinimumFareAdjustment
+	if trip.SurgeMultiplier > 1 {
+		b.SurgeMultiplier = trip.SurgeMultiplier
+		b.SurgeAmount = round2(fare * (trip.SurgeMultiplier - 1))
+	}
+
+	if trip.PoolDiscount > 0 {
+		b.PoolDiscount = round2((fare + b.SurgeAmount) * math.Min(trip.PoolDiscount, 1))
+	}
+
+	if paid := trip.WaitingMinutes - t.FreeWaitingMinutes; paid > 0 {
+		b.WaitingFare = round2(float64(paid) * t.PerWaitingMinute)
+	}
+
+	b.Total = round2(fare + b.SurgeAmount - b.PoolDiscount + b.WaitingFare + b.BookingFee)
+	return b
+}
+
+// WaitingMinutes — полные минуты ожидания между прибытием водителя и началом поездки.
+// Неполная минута не оплачивается.
+func WaitingMinutes(arrivedAt, startedAt time.Time) int {
+	if !startedAt.After(arrivedAt) {
+		return 0
+	}
+	return int(startedAt.Sub(arrivedAt) / time.Minute)
+}
+
+// round2 округляет сумму до копеек
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
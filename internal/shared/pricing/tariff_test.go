@@ -0,0 +1,109 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculate(t *testing.T) {
+	tariff := Tariff{
+		City:               "almaty",
+		VehicleType:        "ECONOMY",
+		BaseFare:           100,
+		PerKm:              50,
+		PerMinute:          10,
+		MinimumFare:        300,
+		BookingFee:         50,
+		FreeWaitingMinutes: 3,
+		PerWaitingMinute:   20,
+	}
+
+	tests := []struct {
+		name string
+		trip Trip
+		want Breakdown
+	}{
+		{
+			name: "by tariff",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 1, Total: 850},
+		},
+		{
+			name: "raised to minimum fare, booking fee on top",
+			trip: Trip{DistanceKm: 1, DurationMinutes: 2},
+			want: Breakdown{DistanceFare: 50, TimeFare: 20, MinimumFareAdjustment: 130, SurgeMultiplier: 1, Total: 350},
+		},
+		{
+			name: "surge does not multiply booking fee",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20, SurgeMultiplier: 1.5},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 1.5, SurgeAmount: 400, Total: 1250},
+		},
+		{
+			name: "surge below one is ignored",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20, SurgeMultiplier: 0.5},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 1, Total: 850},
+		},
+		{
+			name: "pool discount applies after surge",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20, SurgeMultiplier: 1.5, PoolDiscount: 0.2},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 1.5, SurgeAmount: 400, PoolDiscount: 240, Total: 1010},
+		},
+		{
+			name: "pool discount is capped at the whole fare",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20, PoolDiscount: 1.5},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 1, PoolDiscount: 800, Total: 50},
+		},
+		{
+			name: "free waiting",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20, WaitingMinutes: 3},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 1, WaitingMinutes: 3, Total: 850},
+		},
+		{
+			name: "paid waiting is not surged",
+			trip: Trip{DistanceKm: 10, DurationMinutes: 20, SurgeMultiplier: 2, WaitingMinutes: 5},
+			want: Breakdown{DistanceFare: 500, TimeFare: 200, SurgeMultiplier: 2, SurgeAmount: 800, WaitingMinutes: 5, WaitingFare: 40, Total: 1690},
+		},
+		{
+			name: "amounts are rounded to kopecks",
+			trip: Trip{DistanceKm: 7.3333, DurationMinutes: 11},
+			want: Breakdown{DistanceFare: 366.67, TimeFare: 110, SurgeMultiplier: 1, Total: 626.67},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.want
+			want.City = tariff.City
+			want.VehicleType = tariff.VehicleType
+			want.DistanceKm = round2(tc.trip.DistanceKm)
+			want.DurationMinutes = tc.trip.DurationMinutes
+			want.BaseFare = tariff.BaseFare
+			want.BookingFee = tariff.BookingFee
+
+			if got := Calculate(tariff, tc.trip); got != want {
+				t.Errorf("Calculate =\n  %+v\nwant\n  %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWaitingMinutes(t *testing.T) {
+	arrived := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		started time.Time
+		want    int
+	}{
+		{name: "started before arrival", started: arrived.Add(-time.Minute), want: 0},
+		{name: "started on arrival", started: arrived, want: 0},
+		{name: "partial minute is free", started: arrived.Add(59 * time.Second), want: 0},
+		{name: "full minutes only", started: arrived.Add(4*time.Minute + 59*time.Second), want: 4},
+	}
+
+	for _, tc := range tests {
+		if got := WaitingMinutes(arrived, tc.started); got != tc.want {
+			t.Errorf("%s: WaitingMinutes = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ridestate
+
+import (
+	"time"
+
+	"ridehail/internal/shared/pricing"
+)
+
+// EventPayload — содержимое ride_events.event_data.
+//
+// Журнал поездки общий: в него пишут и Ride Service, и Driver Service, поэтому
+// формат один на оба сервиса. Поля заполняются в зависимости от типа события.
+// RIDE_REQUESTED содержит полный снимок поездки — этого достаточно, чтобы
+// проекция восстановила строку rides без обращения к другим таблицам.
+type EventPayload struct {
+	OldStatus  string    `json:"old_status,omitempty"`
+	NewStatus  string    `json:"new_status,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	RideNumber              string             `json:"ride_number,omitempty"`
+	PassengerID             string             `json:"passenger_id,omitempty"`
+	DriverID                string             `json:"driver_id,omitempty"`
+	VehicleType             string             `json:"vehicle_type,omitempty"`
+	City                    string             `json:"city,omitempty"`
+	Priority                int                `json:"priority,omitempty"`
+	EstimatedFare           *float64           `json:"estimated_fare,omitempty"`
+	FinalFare               *float64           `json:"final_fare,omitempty"`
+	FareBreakdown           *pricing.Breakdown `json:"fare_breakdown,omitempty"`
+	SurgeMultiplier         float64            `json:"surge_multiplier,omitempty"`
+	PickupCoordinateID      string             `json:"pickup_coordinate_id,omitempty"`
+	DestinationCoordinateID string             `json:"destination_coordinate_id,omitempty"`
+	Reason                  string             `json:"reason,omitempty"`
+	QuoteID                 string             `json:"quote_id,omitempty"`
+	ScheduledFor            *time.Time         `json:"scheduled_for,omitempty"`
+	LateCancellation        bool               `json:"late_cancellation,omitempty"`
+	CancellationFee         *float64           `json:"cancellation_fee,omitempty"`
+	FeeReason               string             `json:"fee_reason,omitempty"`
+	StopNumber              int                `json:"stop_number,omitempty"`
+
+	Location *EventLocation `json:"location,omitempty"`
+	Pool     *PoolEvent     `json:"pool,omitempty"`
+}
+
+// EventLocation — координаты, связанные с событием
+type EventLocation struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// PoolEvent — состояние совместной поездки на момент посадки/высадки пассажира
+type PoolEvent struct {
+	Onboard        int     `json:"onboard"`                   // Пассажиров в машине после события
+	SharedFraction float64 `json:"shared_fraction,omitempty"` // Доля поездки с попутчиками (при высадке)
+}
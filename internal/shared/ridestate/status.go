@@ -0,0 +1,61 @@
+package ridestate
+
+import constants "ridehail/internal/shared/const"
+
+// ============================================================================
+// STATE MACHINE: статусы поездки
+// ============================================================================
+//
+//	SCHEDULED → REQUESTED → MATCHED → EN_ROUTE → ARRIVED → IN_PROGRESS → COMPLETED
+//	    ↓           ↓          ↓          ↓          ↓
+//	    └───────────┴──────────┴──────────┴──────────┴──────→ CANCELLED
+//
+// SCHEDULED — заказ заранее; в подбор (REQUESTED) его переводит планировщик.
+// COMPLETED и CANCELLED — терминальные статусы.
+// Начатую поездку (IN_PROGRESS) отменить нельзя — только завершить.
+//
+// Статус поездки меняют оба сервиса (Ride — заказ и отмена, Driver — прибытие,
+// начало и завершение), поэтому таблица переходов одна на оба.
+// ============================================================================
+
+// transitions — допустимые переходы: текущий статус → возможные следующие
+var transitions = map[string][]string{
+	constants.RideStatusScheduled:  {constants.RideStatusRequested, constants.RideStatusCancelled},
+	constants.RideStatusRequested:  {constants.RideStatusMatched, constants.RideStatusCancelled},
+	constants.RideStatusMatched:    {constants.RideStatusEnRoute, constants.RideStatusCancelled},
+	constants.RideStatusEnRoute:    {constants.RideStatusArrived, constants.RideStatusCancelled},
+	constants.RideStatusArrived:    {constants.RideStatusInProgress, constants.RideStatusCancelled},
+	constants.RideStatusInProgress: {constants.RideStatusCompleted},
+}
+
+// CanTransition проверяет, разрешен ли переход from → to
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidStatus проверяет, что статус поездки известен
+func IsValidStatus(status string) bool {
+	switch status {
+	case constants.RideStatusScheduled,
+		constants.RideStatusRequested,
+		constants.RideStatusMatched,
+		constants.RideStatusEnRoute,
+		constants.RideStatusArrived,
+		constants.RideStatusInProgress,
+		constants.RideStatusCompleted,
+		constants.RideStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTerminalStatus возвращает true для статусов, из которых нет переходов
+func IsTerminalStatus(status string) bool {
+	return status == constants.RideStatusCompleted || status == constants.RideStatusCancelled
+}
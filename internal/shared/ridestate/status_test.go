@@ -0,0 +1,72 @@
+package ridestate
+
+import (
+	"testing"
+
+	constants "ridehail/internal/shared/const"
+)
+
+var allStatuses = []string{
+	constants.RideStatusScheduled,
+	constants.RideStatusRequested,
+	constants.RideStatusMatched,
+	constants.RideStatusEnRoute,
+	constants.RideStatusArrived,
+	constants.RideStatusInProgress,
+	constants.RideStatusCompleted,
+	constants.RideStatusCancelled,
+}
+
+// TestCanTransition проходит все пары статусов: разрешены только переходы из схемы
+func TestCanTransition(t *testing.T) {
+	allowed := map[[2]string]bool{
+		{constants.RideStatusScheduled, constants.RideStatusRequested}:  true,
+		{constants.RideStatusScheduled, constants.RideStatusCancelled}:  true,
+		{constants.RideStatusRequested, constants.RideStatusMatched}:    true,
+		{constants.RideStatusRequested, constants.RideStatusCancelled}:  true,
+		{constants.RideStatusMatched, constants.RideStatusEnRoute}:      true,
+		{constants.RideStatusMatched, constants.RideStatusCancelled}:    true,
+		{constants.RideStatusEnRoute, constants.RideStatusArrived}:      true,
+		{constants.RideStatusEnRoute, constants.RideStatusCancelled}:    true,
+		{constants.RideStatusArrived, constants.RideStatusInProgress}:   true,
+		{constants.RideStatusArrived, constants.RideStatusCancelled}:    true,
+		{constants.RideStatusInProgress, constants.RideStatusCompleted}: true,
+	}
+
+	for _, from := range allStatuses {
+		for _, to := range allStatuses {
+			want := allowed[[2]string{from, to}]
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestStatusPredicates(t *testing.T) {
+	tests := []struct {
+		status   string
+		valid    bool
+		terminal bool
+	}{
+		{status: constants.RideStatusScheduled, valid: true},
+		{status: constants.RideStatusRequested, valid: true},
+		{status: constants.RideStatusMatched, valid: true},
+		{status: constants.RideStatusEnRoute, valid: true},
+		{status: constants.RideStatusArrived, valid: true},
+		{status: constants.RideStatusInProgress, valid: true},
+		{status: constants.RideStatusCompleted, valid: true, terminal: true},
+		{status: constants.RideStatusCancelled, valid: true, terminal: true},
+		{status: "UNKNOWN"},
+		{status: ""},
+	}
+
+	for _, tc := range tests {
+		if got := IsValidStatus(tc.status); got != tc.valid {
+			t.Errorf("IsValidStatus(%q) = %v, want %v", tc.status, got, tc.valid)
+		}
+		if got := IsTerminalStatus(tc.status); got != tc.terminal {
+			t.Errorf("IsTerminalStatus(%q) = %v, want %v", tc.status, got, tc.terminal)
+		}
+	}
+}
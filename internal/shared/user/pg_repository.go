@@ -26,12 +26,13 @@ func NewPgRepository(pool *pgxpool.Pool, log *logger.Logger) *PgRepository {
 	}
 }
 
-// FindByID находит пользователя по ID
+// FindByID находит пользователя по ID. Удаленный администратором пользователь
+// считается несуществующим.
 func (r *PgRepository) FindByID(ctx context.Context, userID string) (*User, error) {
 	query := `
 		SELECT id, email, role, status, created_at, updated_at
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var user User
@@ -156,6 +156,11 @@ type AuthFunc func(token string) (userID, role string, err error)
 //	}
 type MessageHandler func(client *Client, messageType string, data json.RawMessage) error
 
+// ConnectionHandler — функция, вызываемая после регистрации или отключения клиента.
+// Вызывается из горутины Run вне мьютекса, поэтому может отправлять сообщения через хаб.
+// Не должна блокироваться надолго: пока она работает, хаб не обрабатывает подключения.
+type ConnectionHandler func(client *Client)
+
 // ============================================================================
 // CLIENT - Одно WebSocket соединение
 // ============================================================================
@@ -209,6 +214,8 @@ type Hub struct {
 	broadcast      chan []byte        // Канал broadcast сообщений
 	authFunc       AuthFunc           // Функция аутентификации
 	messageHandler MessageHandler     // Обработчик сообщений
+	onConnect      ConnectionHandler  // Хук после регистрации клиента
+	onDisconnect   ConnectionHandler  // Хук после отключения клиента
 	log            *logger.Logger     // Logger
 }
 
@@ -261,6 +268,15 @@ func (h *Hub) SetMessageHandler(handler MessageHandler) {
 	h.messageHandler = handler
 }
 
+// SetConnectionHandlers устанавливает хуки подключения и отключения клиентов.
+// Нужны обработчикам, которые держат состояние на соединение (например, подписки):
+// onConnect вызывается после регистрации, onDisconnect — после снятия с регистрации.
+// Любой из хуков может быть nil. Устанавливать до запуска Run.
+func (h *Hub) SetConnectionHandlers(onConnect, onDisconnect ConnectionHandler) {
+	h.onConnect = onConnect
+	h.onDisconnect = onDisconnect
+}
+
 // Run запускает главный цикл хаба
 func (h *Hub) Run(ctx context.Context) {
 	for {
@@ -281,10 +297,14 @@ func (h *Hub) Run(ctx context.Context) {
 					"role":    client.Role,
 				},
 			})
+			if h.onConnect != nil {
+				h.onConnect(client)
+			}
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.ID]; ok {
+			_, ok := h.clients[client.ID]
+			if ok {
 				delete(h.clients, client.ID)
 				close(client.send)
 			}
@@ -293,6 +313,9 @@ func (h *Hub) Run(ctx context.Context) {
 				Action:  "client_unregistered",
 				Message: client.ID,
 			})
+			if ok && h.onDisconnect != nil {
+				h.onDisconnect(client)
+			}
 
 		case message := <-h.broadcast:
 			h.mu.RLock()
@@ -341,6 +364,31 @@ func (h *Hub) SendToUser(userID string, message []byte) {
 	}
 }
 
+// SendToClient отправляет сообщение одному соединению по его ID.
+// Возвращает false, если соединения уже нет или его буфер переполнен.
+func (h *Hub) SendToClient(clientID string, message []byte) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	client, ok := h.clients[clientID]
+	if !ok {
+		return false
+	}
+	select {
+	case client.send <- message:
+		return true
+	default:
+		h.log.Error(logger.Entry{
+			Action:  "send_to_client_failed",
+			Message: clientID,
+			Additional: map[string]any{
+				"user_id": client.UserID,
+			},
+		})
+		return false
+	}
+}
+
 // SendToRole отправляет сообщение всем пользователям с определенной ролью
 func (h *Hub) SendToRole(role string, message []byte) {
 	h.mu.RLock()
@@ -395,6 +443,40 @@ func (h *Hub) IsUserConnected(userID string) bool {
 	return h.GetClient(userID) != nil
 }
 
+// DisconnectUser закрывает все соединения пользователя (например, после бана).
+// Клиент получает close frame с причиной; readPump видит закрытое соединение
+// и снимает клиента с регистрации обычным путем. Возвращает число закрытых соединений.
+func (h *Hub) DisconnectUser(userID, reason string) int {
+	h.mu.RLock()
+	var clients []*Client
+	for _, client := range h.clients {
+		if client.UserID == userID {
+			clients = append(clients, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range clients {
+		// WriteControl и Close безопасны параллельно с writePump
+		_ = client.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason),
+			time.Now().Add(writeWait))
+		_ = client.conn.Close()
+	}
+
+	if len(clients) > 0 {
+		h.log.Info(logger.Entry{
+			Action:  "user_disconnected",
+			Message: userID,
+			Additional: map[string]any{
+				"connections": len(clients),
+				"reason":      reason,
+			},
+		})
+	}
+	return len(clients)
+}
+
 // ServeWS обрабатывает HTTP запрос на WebSocket соединение
 func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)